@@ -5,16 +5,16 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
-
 	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/api/config"
+	"github.com/romshark/dgraph_graphql_go/api/eventbus"
 	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
 	"github.com/romshark/dgraph_graphql_go/api/graph"
 	"github.com/romshark/dgraph_graphql_go/api/transport"
 	"github.com/romshark/dgraph_graphql_go/api/validator"
 	"github.com/romshark/dgraph_graphql_go/store"
 	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	"github.com/romshark/dgraph_graphql_go/store/memory"
 )
 
 // Server interfaces an API server implementation
@@ -38,6 +38,9 @@ type server struct {
 	debugSessionKey      []byte
 	transports           []transport.Server
 	shutdownAwaitBlocker *sync.WaitGroup
+	shieldPersistency    gqlshield.PersistencyManager
+	stopReaper           chan struct{}
+	reaper               *sync.WaitGroup
 }
 
 // NewServer creates a new API server instance
@@ -59,6 +62,8 @@ func NewServer(conf *config.ServerConfig) (Server, error) {
 			PostTitleLenMax:       64,
 			ReactionMessageLenMin: 1,
 			ReactionMessageLenMax: 256,
+			ReportReasonLenMin:    1,
+			ReportReasonLenMax:    256,
 			UserDisplayNameLenMin: 2,
 			UserDisplayNameLenMax: 64,
 		},
@@ -67,18 +72,33 @@ func NewServer(conf *config.ServerConfig) (Server, error) {
 		return nil, fmt.Errorf("validator init: %s", err)
 	}
 
-	// Initialize store instance
-	store := dgraph.NewStore(
-		conf.DBHost,
-
-		// Compare password
-		func(hash, password string) bool {
-			return conf.PasswordHasher.Compare([]byte(hash), []byte(password))
-		},
+	// Compare password
+	comparePassword := func(password, hash string) bool {
+		return conf.PasswordHasher.Compare([]byte(password), []byte(hash))
+	}
 
-		conf.DebugLog,
-		conf.ErrorLog,
-	)
+	// Initialize store instance
+	var store store.Store
+	switch conf.DBDriver {
+	case config.DBDriverMemory:
+		store = memory.NewStore(
+			comparePassword,
+			conf.DebugLog,
+			conf.ErrorLog,
+		)
+	default:
+		store = dgraph.NewStore(
+			conf.DBHost,
+			dgraph.RetryConfig{
+				MaxAttempts: conf.DBTxnRetry.MaxAttempts,
+				MinBackoff:  conf.DBTxnRetry.MinBackoff,
+				MaxBackoff:  conf.DBTxnRetry.MaxBackoff,
+			},
+			comparePassword,
+			conf.DebugLog,
+			conf.ErrorLog,
+		)
+	}
 
 	// Initialize the GraphQL shield persistency manager
 	var shieldPersistencyManager gqlshield.PersistencyManager
@@ -98,58 +118,78 @@ func NewServer(conf *config.ServerConfig) (Server, error) {
 		queryWhitelistingEnabled = gqlshield.WhitelistEnabled
 	}
 
+	shieldClientRoles := make(
+		[]gqlshield.ClientRole,
+		len(conf.Shield.ClientRoles),
+	)
+	for i, clientRole := range conf.Shield.ClientRoles {
+		shieldClientRoles[i] = gqlshield.ClientRole{
+			ID:   clientRole.ID,
+			Name: clientRole.Name,
+		}
+	}
+
 	graphShield, err := gqlshield.NewGraphQLShield(
 		gqlshield.Config{
 			WhitelistOption:    queryWhitelistingEnabled,
 			PersistencyManager: shieldPersistencyManager,
 		},
-		gqlshield.ClientRole{
-			ID:   int(auth.GQLShieldClientDebug),
-			Name: "debug",
-		},
-		gqlshield.ClientRole{
-			ID:   int(auth.GQLShieldClientGuest),
-			Name: "guest",
-		},
-		gqlshield.ClientRole{
-			ID:   int(auth.GQLShieldClientRegular),
-			Name: "regular",
-		},
+		shieldClientRoles...,
 	)
 	if err != nil {
+		if shieldPersistencyManager != nil {
+			shieldPersistencyManager.Close()
+		}
 		return nil, errors.Wrap(err, "graph shield init")
 	}
 
-	graph, err := graph.New(
+	// Initialize API server instance
+	newSrv := &server{
+		store:                store,
+		conf:                 conf,
+		transports:           conf.Transport,
+		shutdownAwaitBlocker: &sync.WaitGroup{},
+		shieldPersistency:    shieldPersistencyManager,
+		stopReaper:           make(chan struct{}),
+		reaper:               &sync.WaitGroup{},
+	}
+
+	newSrv.graph, err = graph.New(
 		store,
 		validator,
 		conf.SessionKeyGenerator,
 		conf.PasswordHasher,
+		conf.Session.TTL(),
+		eventbus.New(),
+		conf.Mailer,
+		conf.Token.TTL(),
+		conf.Clock,
 		graphShield,
+		conf.Shield.Assignments(),
+		newSrv.handleUnexpectedError,
 	)
 	if err != nil {
+		if shieldPersistencyManager != nil {
+			shieldPersistencyManager.Close()
+		}
 		return nil, errors.Wrap(err, "graph init")
 	}
 
-	// Initialize API server instance
-	newSrv := &server{
-		store:                store,
-		conf:                 conf,
-		graph:                graph,
-		transports:           conf.Transport,
-		shutdownAwaitBlocker: &sync.WaitGroup{},
-	}
-
 	// Initialize transports
 	for _, transport := range conf.Transport {
 		if err := transport.Init(
 			newSrv.onGraphQuery,
+			newSrv.onGraphSubscription,
 			newSrv.onAuth,
 			newSrv.onDebugAuth,
 			newSrv.onDebugSess,
+			graphShield,
 			conf.DebugLog,
 			conf.ErrorLog,
 		); err != nil {
+			if shieldPersistencyManager != nil {
+				shieldPersistencyManager.Close()
+			}
 			return nil, err
 		}
 	}
@@ -174,6 +214,10 @@ func (srv *server) Launch() error {
 		return errors.Wrap(err, "store preparation")
 	}
 
+	// Launch the expired sessions reaper
+	srv.reaper.Add(1)
+	go srv.runSessionReaper()
+
 	// Launch all transports
 	srv.shutdownAwaitBlocker.Add(len(srv.transports))
 	for _, transport := range srv.transports {
@@ -196,23 +240,46 @@ func (srv *server) AwaitShutdown() {
 
 // Shutdown implements the Server interface
 func (srv *server) Shutdown(ctx context.Context) error {
+	// Stop the expired sessions reaper
+	close(srv.stopReaper)
+	srv.reaper.Wait()
+
 	wg := &sync.WaitGroup{}
 	wg.Add(len(srv.transports))
 
+	errsLock := &sync.Mutex{}
 	shutdownErrs := make([]error, 0)
 	for _, transport := range srv.transports {
 		t := transport
 		go func() {
 			if err := t.Shutdown(ctx); err != nil {
+				errsLock.Lock()
 				shutdownErrs = append(
 					shutdownErrs,
 					errors.Wrap(err, "transport shutdown"),
 				)
+				errsLock.Unlock()
 				srv.logErrf("transport shutdown: %s", err)
 			}
 			wg.Done()
 		}()
 	}
+	wg.Wait()
+
+	// Wait for the background tasks of the served requests
+	srv.graph.AwaitBackgroundTasks()
+
+	// Release the GraphQL shield persistency file
+	if srv.shieldPersistency != nil {
+		if err := srv.shieldPersistency.Close(); err != nil {
+			shutdownErrs = append(
+				shutdownErrs,
+				errors.Wrap(err, "shield persistency shutdown"),
+			)
+			srv.logErrf("shield persistency shutdown: %s", err)
+		}
+	}
+
 	if len(shutdownErrs) < 1 {
 		return nil
 	}
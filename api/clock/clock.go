@@ -0,0 +1,9 @@
+package clock
+
+import "time"
+
+// Clock defines the clock interface
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
@@ -0,0 +1,31 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Mock implements the Clock interface using a manually advanced time
+type Mock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+// NewMock creates a new mock clock starting at the given time
+func NewMock(now time.Time) *Mock {
+	return &Mock{now: now}
+}
+
+// Now returns the current time of the mock clock
+func (c *Mock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// Add advances the mock clock by the given duration
+func (c *Mock) Add(duration time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(duration)
+}
@@ -0,0 +1,11 @@
+package clock
+
+import "time"
+
+// System implements the Clock interface using the system clock
+type System struct{}
+
+// Now returns the current system time
+func (System) Now() time.Time {
+	return time.Now()
+}
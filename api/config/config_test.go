@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/romshark/dgraph_graphql_go/api/config"
 	"github.com/romshark/dgraph_graphql_go/api/transport"
@@ -64,4 +65,118 @@ func TestConfigInvalid(t *testing.T) {
 			Transport: []transport.Server{serverHTTP},
 		})
 	})
+	t.Run("production/memoryDBDriver", func(t *testing.T) {
+		serverHTTP, err := thttp.NewServer(thttp.ServerConfig{
+			Host: "localhost:80",
+			TLS: &thttp.ServerTLS{
+				CertificateFilePath: "certfile",
+				PrivateKeyFilePath:  "privkeyfile",
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, serverHTTP)
+
+		assumeErr(t, config.ServerConfig{
+			Mode:      config.ModeProduction,
+			DBDriver:  config.DBDriverMemory,
+			Transport: []transport.Server{serverHTTP},
+		})
+	})
+
+	t.Run("unknownDBDriver", func(t *testing.T) {
+		serverHTTP, err := thttp.NewServer(thttp.ServerConfig{
+			Host: "localhost:80",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, serverHTTP)
+
+		assumeErr(t, config.ServerConfig{
+			Mode:      config.ModeDebug,
+			DBDriver:  "unknown",
+			Transport: []transport.Server{serverHTTP},
+		})
+	})
+
+	t.Run("negativeSessionTTL", func(t *testing.T) {
+		serverHTTP, err := thttp.NewServer(thttp.ServerConfig{
+			Host: "localhost:80",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, serverHTTP)
+
+		assumeErr(t, config.ServerConfig{
+			Mode:      config.ModeDebug,
+			Transport: []transport.Server{serverHTTP},
+			Session: config.SessionConfig{
+				IdleTTL: -time.Hour,
+			},
+		})
+	})
+
+	t.Run("invalidTxnRetryBackoff", func(t *testing.T) {
+		serverHTTP, err := thttp.NewServer(thttp.ServerConfig{
+			Host: "localhost:80",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, serverHTTP)
+
+		assumeErr(t, config.ServerConfig{
+			Mode:      config.ModeDebug,
+			Transport: []transport.Server{serverHTTP},
+			DBTxnRetry: config.DBTxnRetryConfig{
+				MinBackoff: time.Second,
+				MaxBackoff: time.Millisecond,
+			},
+		})
+	})
+
+	t.Run("invalidShieldClientRoles", func(t *testing.T) {
+		serverHTTP, err := thttp.NewServer(thttp.ServerConfig{
+			Host: "localhost:80",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, serverHTTP)
+
+		guest := config.ShieldClientRole{
+			ID:       1,
+			Name:     "guest",
+			Assignee: config.ShieldRoleAssigneeGuest,
+		}
+		debug := config.ShieldClientRole{
+			ID:       2,
+			Name:     "debug",
+			Assignee: config.ShieldRoleAssigneeDebug,
+		}
+		regular := config.ShieldClientRole{
+			ID:       3,
+			Name:     "regular",
+			Assignee: "user",
+		}
+
+		for name, clientRoles := range map[string][]config.ShieldClientRole{
+			"invalidAssignee": {guest, debug, regular, {
+				ID:       4,
+				Name:     "unknown",
+				Assignee: "unknown",
+			}},
+			"duplicateAssignee": {guest, debug, regular, {
+				ID:       4,
+				Name:     "other",
+				Assignee: "user",
+			}},
+			"unassignedGuest":   {debug, regular},
+			"unassignedDebug":   {guest, regular},
+			"unassignedRegular": {guest, debug},
+		} {
+			t.Run(name, func(t *testing.T) {
+				assumeErr(t, config.ServerConfig{
+					Mode:      config.ModeDebug,
+					Transport: []transport.Server{serverHTTP},
+					Shield: config.ShieldConfig{
+						ClientRoles: clientRoles,
+					},
+				})
+			})
+		}
+	})
 }
@@ -0,0 +1,26 @@
+package config
+
+import "fmt"
+
+// DBDriver represents a database driver
+type DBDriver string
+
+const (
+	// DBDriverDgraph represents the Dgraph database driver
+	DBDriverDgraph DBDriver = "dgraph"
+
+	// DBDriverMemory represents the in-memory database driver
+	// intended for testing and local development
+	DBDriverMemory DBDriver = "memory"
+)
+
+// Validate returns an error if the driver is unknown
+func (drv DBDriver) Validate() error {
+	switch drv {
+	case DBDriverDgraph:
+		fallthrough
+	case DBDriverMemory:
+		return nil
+	}
+	return fmt.Errorf("unknown database driver: '%s'", drv)
+}
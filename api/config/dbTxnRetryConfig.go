@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// DBTxnRetryConfig defines the retry budget of database transactions
+// aborted due to conflicts. Zero values select the store defaults
+type DBTxnRetryConfig struct {
+	// MaxAttempts defines the maximum number of attempts
+	// to run a transaction
+	MaxAttempts int
+
+	// MinBackoff defines the wait before the first retry
+	MinBackoff time.Duration
+
+	// MaxBackoff defines the maximum wait between retries
+	MaxBackoff time.Duration
+}
+
+// Prepare validates the configurations
+func (conf *DBTxnRetryConfig) Prepare() error {
+	if conf.MaxAttempts < 0 {
+		return errors.New("negative transaction retry attempts")
+	}
+	if conf.MinBackoff < 0 {
+		return errors.New("negative minimum transaction retry backoff")
+	}
+	if conf.MaxBackoff < 0 {
+		return errors.New("negative maximum transaction retry backoff")
+	}
+	if conf.MaxBackoff > 0 && conf.MaxBackoff < conf.MinBackoff {
+		return errors.New(
+			"maximum transaction retry backoff " +
+				"is smaller than the minimum backoff",
+		)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
 	"github.com/romshark/dgraph_graphql_go/api/passhash"
 	"github.com/romshark/dgraph_graphql_go/api/sesskeygen"
 	thttp "github.com/romshark/dgraph_graphql_go/api/transport/http"
@@ -22,8 +23,43 @@ type File struct {
 	PasswordHasher      PasswordHasher      `toml:"password-hasher"`
 	SessionKeyGenerator SessionKeyGenerator `toml:"session-key-generator"`
 	DB                  struct {
-		Host string `toml:"host"`
+		Driver   DBDriver `toml:"driver"`
+		Host     string   `toml:"host"`
+		TxnRetry struct {
+			MaxAttempts int      `toml:"max-attempts"`
+			MinBackoff  Duration `toml:"min-backoff"`
+			MaxBackoff  Duration `toml:"max-backoff"`
+		} `toml:"txn-retry"`
 	} `toml:"db"`
+	Passhash struct {
+		Bcrypt struct {
+			Cost int `toml:"cost"`
+		} `toml:"bcrypt"`
+		Argon2id struct {
+			Memory      uint32 `toml:"memory"`
+			Time        uint32 `toml:"time"`
+			Parallelism uint8  `toml:"parallelism"`
+		} `toml:"argon2id"`
+	} `toml:"passhash"`
+	Session struct {
+		AbsoluteTTL    Duration `toml:"absolute-ttl"`
+		IdleTTL        Duration `toml:"idle-ttl"`
+		ReaperInterval Duration `toml:"reaper-interval"`
+	} `toml:"session"`
+	Token struct {
+		PasswordResetTTL     Duration `toml:"password-reset-ttl"`
+		EmailVerificationTTL Duration `toml:"email-verification-ttl"`
+	} `toml:"token"`
+	Mailer struct {
+		Driver string `toml:"driver"`
+		Log    string `toml:"log"`
+		SMTP   struct {
+			Host     string `toml:"host"`
+			Username string `toml:"username"`
+			Password string `toml:"password"`
+			From     string `toml:"from"`
+		} `toml:"smtp"`
+	} `toml:"mailer"`
 	Log struct {
 		Debug string `toml:"debug"`
 		Error string `toml:"error"`
@@ -34,8 +70,13 @@ type File struct {
 		Password string `toml:"password"`
 	} `toml:"debug"`
 	Shield struct {
-		Whitelist bool   `toml:"whitelist"`
-		PersistTo string `toml:"persist-to"`
+		Whitelist   bool   `toml:"whitelist"`
+		PersistTo   string `toml:"persist-to"`
+		ClientRoles []struct {
+			ID       int    `toml:"id"`
+			Name     string `toml:"name"`
+			Assignee string `toml:"assignee"`
+		} `toml:"client-roles"`
 	} `toml:"shield"`
 	TransportHTTP struct {
 		Host              string   `toml:"host"`
@@ -60,15 +101,52 @@ func (f *File) mode(conf *ServerConfig) error {
 	return nil
 }
 
+func (f *File) dbDriver(conf *ServerConfig) error {
+	if f.DB.Driver == "" {
+		return nil
+	}
+	if err := f.DB.Driver.Validate(); err != nil {
+		return err
+	}
+	conf.DBDriver = f.DB.Driver
+	return nil
+}
+
 func (f *File) dbHost(conf *ServerConfig) error {
 	conf.DBHost = f.DB.Host
 	return nil
 }
 
+func (f *File) dbTxnRetry(conf *ServerConfig) error {
+	conf.DBTxnRetry = DBTxnRetryConfig{
+		MaxAttempts: f.DB.TxnRetry.MaxAttempts,
+		MinBackoff:  time.Duration(f.DB.TxnRetry.MinBackoff),
+		MaxBackoff:  time.Duration(f.DB.TxnRetry.MaxBackoff),
+	}
+	return nil
+}
+
 func (f *File) passwordHasher(conf *ServerConfig) error {
 	switch f.PasswordHasher {
 	case "bcrypt":
-		conf.PasswordHasher = passhash.Bcrypt{}
+		hasher := passhash.Bcrypt{
+			Cost: f.Passhash.Bcrypt.Cost,
+		}
+		if err := hasher.Validate(); err != nil {
+			return err
+		}
+		conf.PasswordHasher = hasher
+		return nil
+	case "argon2id":
+		hasher := passhash.Argon2id{
+			Memory:      f.Passhash.Argon2id.Memory,
+			Time:        f.Passhash.Argon2id.Time,
+			Parallelism: f.Passhash.Argon2id.Parallelism,
+		}
+		if err := hasher.Validate(); err != nil {
+			return err
+		}
+		conf.PasswordHasher = hasher
 		return nil
 	}
 	return fmt.Errorf("unsupported password hasher: '%s'", f.PasswordHasher)
@@ -155,9 +233,82 @@ func (f *File) shield(conf *ServerConfig) error {
 		WhitelistEnabled:    f.Shield.Whitelist,
 		PersistencyFilePath: f.Shield.PersistTo,
 	}
+	for _, clientRole := range f.Shield.ClientRoles {
+		assignee := ShieldRoleAssignee(clientRole.Assignee)
+		if err := assignee.Validate(); err != nil {
+			return errors.Wrapf(err, "client role %d", clientRole.ID)
+		}
+		conf.Shield.ClientRoles = append(
+			conf.Shield.ClientRoles,
+			ShieldClientRole{
+				ID:       clientRole.ID,
+				Name:     clientRole.Name,
+				Assignee: assignee,
+			},
+		)
+	}
+	return nil
+}
+
+func (f *File) session(conf *ServerConfig) error {
+	conf.Session = SessionConfig{
+		AbsoluteTTL:    time.Duration(f.Session.AbsoluteTTL),
+		IdleTTL:        time.Duration(f.Session.IdleTTL),
+		ReaperInterval: time.Duration(f.Session.ReaperInterval),
+	}
+	return nil
+}
+
+func (f *File) token(conf *ServerConfig) error {
+	conf.Token = TokenConfig{
+		PasswordResetTTL:     time.Duration(f.Token.PasswordResetTTL),
+		EmailVerificationTTL: time.Duration(f.Token.EmailVerificationTTL),
+	}
 	return nil
 }
 
+func (f *File) mailer(conf *ServerConfig) error {
+	switch f.Mailer.Driver {
+	case "":
+		// Use the default mailer
+		return nil
+	case "log":
+		var writer io.Writer
+		if f.Mailer.Log == "" || strings.HasPrefix(f.Mailer.Log, "stdout") {
+			writer = os.Stdout
+		} else if strings.HasPrefix(f.Mailer.Log, "file:") &&
+			len(f.Mailer.Log) > 5 {
+			// Mail log to file
+			var err error
+			writer, err = os.OpenFile(
+				f.Mailer.Log[5:],
+				os.O_WRONLY|os.O_APPEND|os.O_CREATE,
+				0660,
+			)
+			if err != nil {
+				return errors.Wrap(err, "mail log file")
+			}
+		} else {
+			return fmt.Errorf("invalid log: '%s'", f.Mailer.Log)
+		}
+		conf.Mailer = mailer.NewLog(writer)
+		return nil
+	case "smtp":
+		smtpMailer, err := mailer.NewSMTP(mailer.SMTPConfig{
+			Host:     f.Mailer.SMTP.Host,
+			Username: f.Mailer.SMTP.Username,
+			Password: f.Mailer.SMTP.Password,
+			From:     f.Mailer.SMTP.From,
+		})
+		if err != nil {
+			return errors.Wrap(err, "SMTP mailer init")
+		}
+		conf.Mailer = smtpMailer
+		return nil
+	}
+	return fmt.Errorf("unsupported mailer driver: '%s'", f.Mailer.Driver)
+}
+
 func (f *File) transportHTTP(conf *ServerConfig) error {
 	srvConf := thttp.ServerConfig{}
 
@@ -225,8 +376,13 @@ func FromFile(path string) (*ServerConfig, error) {
 
 	for setterName, setter := range map[string]func(*ServerConfig) error{
 		"mode":                  file.mode,
+		"db.driver":             file.dbDriver,
 		"db.host":               file.dbHost,
+		"db.txn-retry":          file.dbTxnRetry,
 		"shield":                file.shield,
+		"session":               file.session,
+		"token":                 file.token,
+		"mailer":                file.mailer,
 		"password-hasher":       file.passwordHasher,
 		"session-key-generator": file.sessionKeyGenerator,
 		"log.debug":             file.debugLog,
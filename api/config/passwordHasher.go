@@ -13,6 +13,8 @@ func (v *PasswordHasher) UnmarshalTOML(val interface{}) error {
 	if str, isString := val.(string); isString {
 		switch str {
 		case "bcrypt":
+			fallthrough
+		case "argon2id":
 			*v = PasswordHasher(str)
 		default:
 			return fmt.Errorf("unknown password hasher: '%s'", val)
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 
+	"github.com/romshark/dgraph_graphql_go/api/clock"
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
 	"github.com/romshark/dgraph_graphql_go/api/passhash"
 	"github.com/romshark/dgraph_graphql_go/api/sesskeygen"
 	"github.com/romshark/dgraph_graphql_go/api/transport"
@@ -14,10 +16,16 @@ import (
 // ServerConfig defines the API server configurations
 type ServerConfig struct {
 	Mode                Mode
+	DBDriver            DBDriver
 	DBHost              string
+	DBTxnRetry          DBTxnRetryConfig
 	Shield              ShieldConfig
+	Session             SessionConfig
+	Token               TokenConfig
 	SessionKeyGenerator sesskeygen.SessionKeyGenerator
 	PasswordHasher      passhash.PasswordHasher
+	Mailer              mailer.Mailer
+	Clock               clock.Clock
 	DebugUser           DebugUserConfig
 	Transport           []transport.Server
 	DebugLog            *log.Logger
@@ -31,6 +39,11 @@ func (conf *ServerConfig) Prepare() error {
 		conf.Mode = ModeProduction
 	}
 
+	// Use Dgraph by default
+	if conf.DBDriver == "" {
+		conf.DBDriver = DBDriverDgraph
+	}
+
 	// Set default database host address
 	if conf.DBHost == "" {
 		switch conf.Mode {
@@ -51,6 +64,16 @@ func (conf *ServerConfig) Prepare() error {
 		conf.PasswordHasher = passhash.Bcrypt{}
 	}
 
+	// Write mails to stdout by default
+	if conf.Mailer == nil {
+		conf.Mailer = mailer.NewLog(os.Stdout)
+	}
+
+	// Use the system clock by default
+	if conf.Clock == nil {
+		conf.Clock = clock.System{}
+	}
+
 	// Use default debug logger to stdout
 	if conf.DebugLog == nil {
 		conf.DebugLog = log.New(
@@ -71,12 +94,39 @@ func (conf *ServerConfig) Prepare() error {
 
 	// VALIDATE
 
+	if err := conf.DBDriver.Validate(); err != nil {
+		return err
+	}
+
+	if err := conf.DBTxnRetry.Prepare(); err != nil {
+		return err
+	}
+
+	if err := conf.Session.Prepare(); err != nil {
+		return err
+	}
+
+	if err := conf.Shield.Prepare(); err != nil {
+		return err
+	}
+
+	if err := conf.Token.Prepare(); err != nil {
+		return err
+	}
+
 	// Ensure at least one transport adapter is specified
 	if len(conf.Transport) < 1 {
 		return errors.New("no transport adapter")
 	}
 
 	if conf.Mode == ModeProduction {
+		// Ensure the in-memory database isn't used in production
+		if conf.DBDriver == DBDriverMemory {
+			return errors.New(
+				"in-memory database must not be used in production mode",
+			)
+		}
+
 		// Validate transport adapters
 		for _, trn := range conf.Transport {
 			if httpAdapter, ok := trn.(*thttp.Server); ok {
@@ -107,10 +157,21 @@ func (conf *ServerConfig) Prepare() error {
 			)
 		}
 
-		// Ensure bcrypt password hasher is used in production
-		if _, ok := conf.PasswordHasher.(passhash.Bcrypt); !ok {
+		// Ensure a secure password hasher is used in production
+		switch conf.PasswordHasher.(type) {
+		case passhash.Bcrypt:
+		case passhash.Argon2id:
+		default:
+			return errors.New(
+				"bcrypt or argon2id password hasher " +
+					"must be used in production mode",
+			)
+		}
+
+		// Ensure mails are delivered in production
+		if _, ok := conf.Mailer.(*mailer.Log); ok {
 			return errors.New(
-				"bcrypt password hasher must be used in production mode",
+				"mail log must not be used in production mode",
 			)
 		}
 	}
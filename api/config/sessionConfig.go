@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+)
+
+// SessionConfig defines the user session configurations
+type SessionConfig struct {
+	// AbsoluteTTL defines the maximum lifetime of a session
+	// since its creation
+	AbsoluteTTL time.Duration
+
+	// IdleTTL defines the maximum lifetime of a session
+	// since its last access
+	IdleTTL time.Duration
+
+	// ReaperInterval defines how often expired sessions are deleted
+	ReaperInterval time.Duration
+}
+
+// Prepare sets defaults and validates the configurations
+func (conf *SessionConfig) Prepare() error {
+	// Expire sessions after 30 days by default
+	if conf.AbsoluteTTL == 0 {
+		conf.AbsoluteTTL = 30 * 24 * time.Hour
+	}
+
+	// Expire sessions after 7 days of inactivity by default
+	if conf.IdleTTL == 0 {
+		conf.IdleTTL = 7 * 24 * time.Hour
+	}
+
+	// Delete expired sessions every 10 minutes by default
+	if conf.ReaperInterval == 0 {
+		conf.ReaperInterval = 10 * time.Minute
+	}
+
+	// VALIDATE
+
+	if conf.AbsoluteTTL < 0 {
+		return errors.New("negative absolute session TTL")
+	}
+	if conf.IdleTTL < 0 {
+		return errors.New("negative idle session TTL")
+	}
+	if conf.ReaperInterval < 0 {
+		return errors.New("negative session reaper interval")
+	}
+	return nil
+}
+
+// TTL returns the session lifetime definition
+func (conf *SessionConfig) TTL() auth.SessionTTL {
+	return auth.SessionTTL{
+		Absolute: conf.AbsoluteTTL,
+		Idle:     conf.IdleTTL,
+	}
+}
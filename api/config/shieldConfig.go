@@ -1,7 +1,93 @@
 package config
 
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
 // ShieldConfig represents the GraphQL shield configuration
 type ShieldConfig struct {
 	WhitelistEnabled    bool
 	PersistencyFilePath string
+
+	// ClientRoles defines the client roles queries can be whitelisted for
+	ClientRoles []ShieldClientRole
+}
+
+// ShieldClientRole represents a GraphQL shield client role
+type ShieldClientRole struct {
+	ID       int
+	Name     string
+	Assignee ShieldRoleAssignee
+}
+
+// DefaultShieldClientRoles returns the client roles
+// used when none are configured
+func DefaultShieldClientRoles() []ShieldClientRole {
+	return []ShieldClientRole{
+		{ID: 1, Name: "guest", Assignee: ShieldRoleAssigneeGuest},
+		{ID: 2, Name: "debug", Assignee: ShieldRoleAssigneeDebug},
+		{ID: 3, Name: "regular", Assignee: ShieldRoleAssignee(role.User)},
+		{ID: 4, Name: "moderator", Assignee: ShieldRoleAssignee(role.Moderator)},
+		{ID: 5, Name: "admin", Assignee: ShieldRoleAssignee(role.Admin)},
+	}
+}
+
+// Prepare sets defaults and validates the configurations
+func (conf *ShieldConfig) Prepare() error {
+	// Use the default client roles if none are defined
+	if len(conf.ClientRoles) < 1 {
+		conf.ClientRoles = DefaultShieldClientRoles()
+	}
+
+	// VALIDATE
+
+	assigned := make(map[ShieldRoleAssignee]struct{}, len(conf.ClientRoles))
+	for _, clientRole := range conf.ClientRoles {
+		if err := clientRole.Assignee.Validate(); err != nil {
+			return errors.Wrapf(err, "shield client role %d", clientRole.ID)
+		}
+		// Ensure every client is assigned a single client role
+		if _, isAssigned := assigned[clientRole.Assignee]; isAssigned {
+			return fmt.Errorf(
+				"multiple shield client roles assigned to '%s'",
+				clientRole.Assignee,
+			)
+		}
+		assigned[clientRole.Assignee] = struct{}{}
+	}
+
+	// Ensure guests, the debug user and regular users are assigned a role
+	for _, assignee := range []ShieldRoleAssignee{
+		ShieldRoleAssigneeGuest,
+		ShieldRoleAssigneeDebug,
+		ShieldRoleAssignee(role.User),
+	} {
+		if _, isAssigned := assigned[assignee]; !isAssigned {
+			return fmt.Errorf("no shield client role assigned to '%s'", assignee)
+		}
+	}
+	return nil
+}
+
+// Assignments returns the mapping of the clients to their client roles
+func (conf *ShieldConfig) Assignments() auth.GQLShieldClientRoles {
+	assignments := auth.GQLShieldClientRoles{
+		Users: make(map[role.Role]auth.GQLShieldClientRole),
+	}
+	for _, clientRole := range conf.ClientRoles {
+		id := auth.GQLShieldClientRole(clientRole.ID)
+		switch clientRole.Assignee {
+		case ShieldRoleAssigneeGuest:
+			assignments.Guest = id
+		case ShieldRoleAssigneeDebug:
+			assignments.Debug = id
+		default:
+			assignments.Users[role.Role(clientRole.Assignee)] = id
+		}
+	}
+	return assignments
 }
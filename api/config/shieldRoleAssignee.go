@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// ShieldRoleAssignee represents the clients a GraphQL shield client role
+// is assigned to, which is either the guests, the debug user
+// or the users of a persisted user role
+type ShieldRoleAssignee string
+
+const (
+	// ShieldRoleAssigneeGuest represents unauthenticated clients
+	ShieldRoleAssigneeGuest ShieldRoleAssignee = "guest"
+
+	// ShieldRoleAssigneeDebug represents the debug user
+	ShieldRoleAssigneeDebug ShieldRoleAssignee = "debug"
+)
+
+// Validate returns an error if the value is invalid
+func (as ShieldRoleAssignee) Validate() error {
+	switch as {
+	case ShieldRoleAssigneeGuest:
+		fallthrough
+	case ShieldRoleAssigneeDebug:
+		return nil
+	}
+	if err := role.Validate(role.Role(as)); err != nil {
+		return fmt.Errorf("invalid shield role assignee: '%s'", as)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+)
+
+// TokenConfig defines the configurations of the tokens issued to users
+type TokenConfig struct {
+	// PasswordResetTTL defines the lifetime of a password reset token
+	PasswordResetTTL time.Duration
+
+	// EmailVerificationTTL defines the lifetime
+	// of an email verification token
+	EmailVerificationTTL time.Duration
+}
+
+// Prepare sets defaults and validates the configurations
+func (conf *TokenConfig) Prepare() error {
+	// Expire password reset tokens after an hour by default
+	if conf.PasswordResetTTL == 0 {
+		conf.PasswordResetTTL = time.Hour
+	}
+
+	// Expire email verification tokens after 2 days by default
+	if conf.EmailVerificationTTL == 0 {
+		conf.EmailVerificationTTL = 48 * time.Hour
+	}
+
+	// VALIDATE
+
+	if conf.PasswordResetTTL < 0 {
+		return errors.New("negative password reset token TTL")
+	}
+	if conf.EmailVerificationTTL < 0 {
+		return errors.New("negative email verification token TTL")
+	}
+	return nil
+}
+
+// TTL returns the token lifetime definition
+func (conf *TokenConfig) TTL() auth.TokenTTL {
+	return auth.TokenTTL{
+		PasswordReset:     conf.PasswordResetTTL,
+		EmailVerification: conf.EmailVerificationTTL,
+	}
+}
@@ -0,0 +1,83 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBufferSize defines the number of events buffered per subscriber.
+// Events published to a subscriber with a full buffer are dropped
+const subscriberBufferSize = 64
+
+// Topic represents an event topic
+type Topic string
+
+// EventBus interfaces an in-process publish-subscribe event bus
+type EventBus interface {
+	// Publish delivers the event to all current subscribers of the topic
+	// without blocking
+	Publish(topic Topic, event interface{})
+
+	// Subscribe returns a channel receiving all events published
+	// to the topic. The channel is closed when the context is canceled
+	Subscribe(ctx context.Context, topic Topic) <-chan interface{}
+}
+
+type subscriber chan interface{}
+
+type eventBus struct {
+	lock        *sync.RWMutex
+	subscribers map[Topic]map[subscriber]struct{}
+}
+
+// New creates a new in-process event bus instance
+func New() EventBus {
+	return &eventBus{
+		lock:        &sync.RWMutex{},
+		subscribers: make(map[Topic]map[subscriber]struct{}),
+	}
+}
+
+// Publish implements the EventBus interface
+func (bus *eventBus) Publish(topic Topic, event interface{}) {
+	bus.lock.RLock()
+	defer bus.lock.RUnlock()
+	for sub := range bus.subscribers[topic] {
+		select {
+		case sub <- event:
+		default:
+			// Drop the event for slow subscribers
+		}
+	}
+}
+
+// Subscribe implements the EventBus interface
+func (bus *eventBus) Subscribe(
+	ctx context.Context,
+	topic Topic,
+) <-chan interface{} {
+	sub := make(subscriber, subscriberBufferSize)
+
+	bus.lock.Lock()
+	subs, ok := bus.subscribers[topic]
+	if !ok {
+		subs = make(map[subscriber]struct{})
+		bus.subscribers[topic] = subs
+	}
+	subs[sub] = struct{}{}
+	bus.lock.Unlock()
+
+	// Unsubscribe when the context is canceled
+	go func() {
+		<-ctx.Done()
+		bus.lock.Lock()
+		delete(bus.subscribers[topic], sub)
+		if len(bus.subscribers[topic]) < 1 {
+			delete(bus.subscribers, topic)
+		}
+		bus.lock.Unlock()
+		close(sub)
+	}()
+
+	return sub
+}
@@ -0,0 +1,33 @@
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEventBus tests publishing and subscribing
+func TestEventBus(t *testing.T) {
+	bus := eventbus.New()
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+
+	subA := bus.Subscribe(ctxA, "a")
+	subB := bus.Subscribe(ctxB, "b")
+
+	bus.Publish("a", 1)
+	bus.Publish("b", 2)
+	bus.Publish("c", 3)
+
+	require.Equal(t, 1, <-subA)
+	require.Equal(t, 2, <-subB)
+
+	// Ensure the channel is closed after unsubscribing
+	cancelA()
+	_, open := <-subA
+	require.False(t, open)
+}
@@ -1,10 +1,15 @@
 package gqlshield
 
+import "sort"
+
 func (shld *shield) captureState() *State {
 	roles := make([]ClientRole, 0, len(shld.clientRoles))
 	for _, role := range shld.clientRoles {
 		roles = append(roles, role)
 	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].ID < roles[j].ID
+	})
 
 	queries := make(map[string]QueryModel, len(shld.queriesByName))
 	for _, query := range shld.queriesByName {
@@ -20,6 +25,7 @@ func (shld *shield) captureState() *State {
 		for role := range query.whitelistedFor {
 			whitelistedFor = append(whitelistedFor, role)
 		}
+		sort.Ints(whitelistedFor)
 
 		queries[string(query.id)] = QueryModel{
 			Query:          string(query.query),
@@ -5,7 +5,7 @@ import "fmt"
 func (shld *shield) Check(
 	clientRoleID int,
 	queryString []byte,
-	arguments map[string]*string,
+	arguments map[string]interface{},
 ) ([]byte, error) {
 	if len(queryString) < 1 {
 		return queryString, Error{
@@ -27,11 +27,6 @@ func (shld *shield) Check(
 	shld.lock.RLock()
 	defer shld.lock.RUnlock()
 
-	// Find role
-	if _, roleDefined := shld.clientRoles[clientRoleID]; !roleDefined {
-		return normalized, fmt.Errorf("role %d is undefined", clientRoleID)
-	}
-
 	// Lookup query
 	qrObj, found := shld.index.Search(normalized)
 	if !found {
@@ -40,11 +35,51 @@ func (shld *shield) Check(
 			Message: "query not whitelisted",
 		}
 	}
-	qr := qrObj.(*query)
+
+	return normalized, shld.checkQuery(clientRoleID, qrObj.(*query), arguments)
+}
+
+func (shld *shield) CheckPersisted(
+	clientRoleID int,
+	queryHash string,
+	arguments map[string]interface{},
+) ([]byte, error) {
+	shld.lock.RLock()
+	defer shld.lock.RUnlock()
+
+	// Lookup query
+	qr, found := shld.queriesByHash[queryHash]
+	if !found {
+		return nil, Error{
+			Code:    ErrPersistedQueryNotFound,
+			Message: "persisted query not found",
+		}
+	}
+
+	if shld.conf.WhitelistOption != WhitelistEnabled {
+		// Don't check the query if query whitelisting is disabled
+		return qr.Query(), nil
+	}
+
+	return qr.Query(), shld.checkQuery(clientRoleID, qr, arguments)
+}
+
+// checkQuery returns an error if the given whitelisted query isn't allowed
+// for the given client role to be executed or if the provided arguments
+// are unacceptable. The shield must be read-locked during the check
+func (shld *shield) checkQuery(
+	clientRoleID int,
+	qr *query,
+	arguments map[string]interface{},
+) error {
+	// Find role
+	if _, roleDefined := shld.clientRoles[clientRoleID]; !roleDefined {
+		return fmt.Errorf("role %d is undefined", clientRoleID)
+	}
 
 	// Ensure the client is allowed to execute this query
 	if _, roleAllowed := qr.whitelistedFor[clientRoleID]; !roleAllowed {
-		return normalized, Error{
+		return Error{
 			Code: ErrUnauthorized,
 			Message: fmt.Sprintf(
 				"role %d is not allowed to execute this query",
@@ -55,7 +90,7 @@ func (shld *shield) Check(
 
 	// Check arguments
 	if len(arguments) != len(qr.parameters) {
-		return normalized, Error{
+		return Error{
 			Code: ErrUnauthorized,
 			Message: fmt.Sprintf(
 				"unexpected number of arguments: (%d/%d)",
@@ -67,26 +102,15 @@ func (shld *shield) Check(
 	for name, expectedParam := range qr.parameters {
 		actual, hasArg := arguments[name]
 		if !hasArg {
-			return normalized, Error{
+			return Error{
 				Code:    ErrUnauthorized,
 				Message: fmt.Sprintf("missing argument '%s'", name),
 			}
 		}
-		if actual == nil {
-			continue
-		}
-		if uint32(len(*actual)) > expectedParam.MaxValueLength {
-			return normalized, Error{
-				Code: ErrUnauthorized,
-				Message: fmt.Sprintf(
-					"argument '%s' exceeds max length (%d/%d)",
-					name,
-					len(*actual),
-					expectedParam.MaxValueLength,
-				),
-			}
+		if err := checkArgument(name, expectedParam, actual); err != nil {
+			return err
 		}
 	}
 
-	return normalized, nil
+	return nil
 }
@@ -0,0 +1,133 @@
+package gqlshield
+
+import (
+	"fmt"
+	"math"
+)
+
+// checkArgument returns an error if the given argument value
+// doesn't match the type of the parameter or exceeds its limits.
+// Values are expected to be decoded from JSON, null values are accepted
+func checkArgument(name string, param Parameter, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	if param.Type == "" {
+		param.Type = ParamString
+	}
+
+	mismatch := func() error {
+		return Error{
+			Code: ErrUnauthorized,
+			Message: fmt.Sprintf(
+				"argument '%s' is not of type %s",
+				name,
+				param.Type,
+			),
+		}
+	}
+
+	switch param.Type {
+	case ParamString:
+		actual, ok := value.(string)
+		if !ok {
+			return mismatch()
+		}
+		if uint32(len(actual)) > param.MaxValueLength {
+			return Error{
+				Code: ErrUnauthorized,
+				Message: fmt.Sprintf(
+					"argument '%s' exceeds max length (%d/%d)",
+					name,
+					len(actual),
+					param.MaxValueLength,
+				),
+			}
+		}
+
+	case ParamInt:
+		switch actual := value.(type) {
+		case int, int32:
+		case int64:
+			if actual < math.MinInt32 || actual > math.MaxInt32 {
+				return mismatch()
+			}
+		case float64:
+			if actual != math.Trunc(actual) ||
+				actual < math.MinInt32 ||
+				actual > math.MaxInt32 {
+				return mismatch()
+			}
+		default:
+			return mismatch()
+		}
+
+	case ParamFloat:
+		switch value.(type) {
+		case float64, float32, int, int32, int64:
+		default:
+			return mismatch()
+		}
+
+	case ParamBoolean:
+		if _, ok := value.(bool); !ok {
+			return mismatch()
+		}
+
+	case ParamList:
+		actual, ok := value.([]interface{})
+		if !ok {
+			return mismatch()
+		}
+		if uint32(len(actual)) > param.MaxItems {
+			return Error{
+				Code: ErrUnauthorized,
+				Message: fmt.Sprintf(
+					"argument '%s' exceeds max number of items (%d/%d)",
+					name,
+					len(actual),
+					param.MaxItems,
+				),
+			}
+		}
+		for i, item := range actual {
+			if err := checkArgument(
+				fmt.Sprintf("%s[%d]", name, i),
+				*param.Items,
+				item,
+			); err != nil {
+				return err
+			}
+		}
+
+	case ParamObject:
+		actual, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+		for fieldName, fieldValue := range actual {
+			field, isDefined := param.Fields[fieldName]
+			if !isDefined {
+				return Error{
+					Code: ErrUnauthorized,
+					Message: fmt.Sprintf(
+						"argument '%s' has unexpected field '%s'",
+						name,
+						fieldName,
+					),
+				}
+			}
+			if err := checkArgument(
+				name+"."+fieldName,
+				field,
+				fieldValue,
+			); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown parameter type: %s", param.Type)
+	}
+	return nil
+}
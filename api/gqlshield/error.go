@@ -11,6 +11,10 @@ const (
 
 	// ErrWrongInput is returned when Check fails due to a client error
 	ErrWrongInput ErrorCode = "WrongInput"
+
+	// ErrPersistedQueryNotFound is returned when CheckPersisted
+	// doesn't find the query identified by the given hash
+	ErrPersistedQueryNotFound ErrorCode = "PersistedQueryNotFound"
 )
 
 // Error represents a typed GraphQL shield error
@@ -0,0 +1,50 @@
+//go:build !windows
+// +build !windows
+
+package gqlshield
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// lockFile opens the lock file at the given path and locks it exclusively.
+// Returns an error if the file is already locked
+func lockFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0660)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(
+		int(file.Fd()),
+		syscall.LOCK_EX|syscall.LOCK_NB,
+	); err != nil {
+		file.Close()
+		if err == syscall.EWOULDBLOCK {
+			return nil, errors.Errorf("%s is locked by another process", path)
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+// unlockFile releases the lock and closes the lock file
+func unlockFile(file *os.File) error {
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_UN); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir flushes the directory entries to disk
+func syncDir(path string) {
+	dir, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	dir.Sync()
+	dir.Close()
+}
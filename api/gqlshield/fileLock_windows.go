@@ -0,0 +1,46 @@
+//go:build windows
+// +build windows
+
+package gqlshield
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// errSharingViolation is the ERROR_SHARING_VIOLATION Windows error code
+const errSharingViolation syscall.Errno = 32
+
+// lockFile opens the lock file at the given path without sharing it.
+// Returns an error if the file is already opened by another process
+func lockFile(path string) (*os.File, error) {
+	name, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	handle, err := syscall.CreateFile(
+		name,
+		syscall.GENERIC_READ|syscall.GENERIC_WRITE,
+		0, // Share nothing
+		nil,
+		syscall.OPEN_ALWAYS,
+		syscall.FILE_ATTRIBUTE_NORMAL,
+		0,
+	)
+	if err == errSharingViolation {
+		return nil, errors.Errorf("%s is locked by another process", path)
+	} else if err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(handle), path), nil
+}
+
+// unlockFile closes the lock file releasing the lock
+func unlockFile(file *os.File) error {
+	return file.Close()
+}
+
+// syncDir is a no-op since directories can't be synced on Windows
+func syncDir(path string) {}
@@ -31,6 +31,10 @@ type GraphQLShield interface {
 	// if any query was removed as well as the actual removed query.
 	RemoveQuery(query Query) error
 
+	// UpdateQueryRoles replaces the roles the given query is whitelisted for
+	// and returns the updated query.
+	UpdateQueryRoles(query Query, whitelistedFor []int) (Query, error)
+
 	// Check returns an error if the given query isn't allowed for the given
 	// client role to be executed or if the provided arguments are unacceptable.
 	//
@@ -39,7 +43,16 @@ type GraphQLShield interface {
 	Check(
 		clientRole int,
 		query []byte,
-		arguments map[string]*string,
+		arguments map[string]interface{},
+	) ([]byte, error)
+
+	// CheckPersisted is similar to Check but looks up the whitelisted query
+	// by its hash instead of the query string. Returns an error with the
+	// ErrPersistedQueryNotFound code if no query is identified by the hash.
+	CheckPersisted(
+		clientRole int,
+		queryHash string,
+		arguments map[string]interface{},
 	) ([]byte, error)
 
 	// ListQueries returns all whitelisted queries.
@@ -63,6 +76,7 @@ func NewGraphQLShield(
 		lock:          &sync.RWMutex{},
 		index:         art.New(),
 		queriesByName: make(map[string]*query),
+		queriesByHash: make(map[string]*query),
 		longest:       0,
 		clientRoles:   roles,
 	}
@@ -93,6 +107,9 @@ type shield struct {
 	// queriesByName references all whitelisted query objects by their name
 	queriesByName map[string]*query
 
+	// queriesByHash references all whitelisted query objects by their hash
+	queriesByHash map[string]*query
+
 	// index holds a radix-tree lookup index
 	index art.Tree
 
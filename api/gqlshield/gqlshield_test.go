@@ -56,7 +56,7 @@ func TestWhitelisting(t *testing.T) {
 	_, err = shield.Check(
 		0,
 		query1[0].Query(),
-		map[string]*string{"var1": &var1},
+		map[string]interface{}{"var1": var1},
 	)
 	require.NoError(t, err)
 
@@ -210,6 +210,55 @@ func TestWhitelistingErr(t *testing.T) {
 	})
 }
 
+// TestWhitelistingTypedParamErr tests the validation of typed parameters
+func TestWhitelistingTypedParamErr(t *testing.T) {
+	for name, param := range map[string]gqlshield.Parameter{
+		"unknownType": gqlshield.Parameter{Type: "Unknown"},
+		"listWithoutItems": gqlshield.Parameter{
+			Type:     gqlshield.ParamList,
+			MaxItems: 8,
+		},
+		"listWithoutMaxItems": gqlshield.Parameter{
+			Type:  gqlshield.ParamList,
+			Items: &gqlshield.Parameter{Type: gqlshield.ParamInt},
+		},
+		"listWithInvalidItems": gqlshield.Parameter{
+			Type:     gqlshield.ParamList,
+			MaxItems: 8,
+			Items:    &gqlshield.Parameter{MaxValueLength: 0},
+		},
+		"objectWithoutFields": gqlshield.Parameter{
+			Type: gqlshield.ParamObject,
+		},
+		"objectWithInvalidField": gqlshield.Parameter{
+			Type: gqlshield.ParamObject,
+			Fields: map[string]gqlshield.Parameter{
+				"f": gqlshield.Parameter{Type: "Unknown"},
+			},
+		},
+	} {
+		param := param
+		t.Run(name, func(t *testing.T) {
+			shield, err := gqlshield.NewGraphQLShield(
+				gqlshield.Config{},
+				gqlshield.ClientRole{ID: 0, Name: "default"},
+			)
+			require.NoError(t, err)
+
+			query, err := shield.WhitelistQueries(gqlshield.Entry{
+				Query: `query { users { id } }`,
+				Name:  "query one",
+				Parameters: map[string]gqlshield.Parameter{
+					"var1": param,
+				},
+				WhitelistedFor: []int{0},
+			})
+			require.Error(t, err)
+			require.Nil(t, query)
+		})
+	}
+}
+
 // TestRoleErr tests shield.WhitelistQueries
 func TestRoleErr(t *testing.T) {
 	// Create a new shield instance
@@ -277,7 +326,7 @@ func TestRoleErr(t *testing.T) {
 	type Expect map[int]bool
 	check := func(
 		query gqlshield.Query,
-		args map[string]*string,
+		args map[string]interface{},
 		expectancy Expect,
 	) {
 		for role, expectAuth := range expectancy {
@@ -300,7 +349,7 @@ func TestRoleErr(t *testing.T) {
 	}
 
 	var1 := "v"
-	check(queries[0], map[string]*string{"var1": &var1}, Expect{
+	check(queries[0], map[string]interface{}{"var1": var1}, Expect{
 		1: true,
 		2: false,
 		3: false,
@@ -314,9 +363,9 @@ func TestRoleErr(t *testing.T) {
 	})
 	userID := "12345678901234567890123456789012"
 	postListLimit := "50"
-	check(queries[2], map[string]*string{
-		"userID":        &userID,
-		"postListLimit": &postListLimit,
+	check(queries[2], map[string]interface{}{
+		"userID":        userID,
+		"postListLimit": postListLimit,
 	}, Expect{
 		1: false,
 		2: false,
@@ -375,7 +424,7 @@ func TestRemove(t *testing.T) {
 	_, err = shield.Check(
 		0,
 		queries[0].Query(),
-		map[string]*string{"var1": &var1},
+		map[string]interface{}{"var1": var1},
 	)
 	require.Error(t, err)
 	require.Equal(t, gqlshield.ErrUnauthorized, gqlshield.ErrCode(err))
@@ -409,6 +458,124 @@ func TestRemove(t *testing.T) {
 	require.Len(t, listedQueries, 0)
 }
 
+// TestUpdateQueryRoles tests UpdateQueryRoles
+func TestUpdateQueryRoles(t *testing.T) {
+	// Create a new shield instance
+	shield, err := gqlshield.NewGraphQLShield(
+		gqlshield.Config{},
+		gqlshield.ClientRole{ID: 0, Name: "first"},
+		gqlshield.ClientRole{ID: 1, Name: "second"},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, shield)
+
+	queries, err := shield.WhitelistQueries(gqlshield.Entry{
+		Query:          `query { posts { id title } }`,
+		Name:           "query one",
+		WhitelistedFor: []int{0},
+	})
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+
+	_, err = shield.Check(1, queries[0].Query(), nil)
+	require.Error(t, err)
+	require.Equal(t, gqlshield.ErrUnauthorized, gqlshield.ErrCode(err))
+
+	// Whitelist the query for the second role only
+	updated, err := shield.UpdateQueryRoles(queries[0], []int{1})
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, updated.WhitelistedFor())
+	require.Equal(t, queries[0].ID(), updated.ID())
+
+	_, err = shield.Check(1, queries[0].Query(), nil)
+	require.NoError(t, err)
+
+	_, err = shield.Check(0, queries[0].Query(), nil)
+	require.Error(t, err)
+	require.Equal(t, gqlshield.ErrUnauthorized, gqlshield.ErrCode(err))
+
+	// Expect errors for invalid roles
+	_, err = shield.UpdateQueryRoles(updated, nil)
+	require.Error(t, err)
+	_, err = shield.UpdateQueryRoles(updated, []int{0, 0})
+	require.Error(t, err)
+	_, err = shield.UpdateQueryRoles(updated, []int{2})
+	require.Error(t, err)
+
+	// Expect an error for removed queries
+	require.NoError(t, shield.RemoveQuery(updated))
+	_, err = shield.UpdateQueryRoles(updated, []int{0})
+	require.Error(t, err)
+}
+
+// TestCheckPersisted tests CheckPersisted
+func TestCheckPersisted(t *testing.T) {
+	// Create a new shield instance
+	shield, err := gqlshield.NewGraphQLShield(
+		gqlshield.Config{},
+		gqlshield.ClientRole{ID: 0, Name: "first"},
+		gqlshield.ClientRole{ID: 1, Name: "second"},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, shield)
+
+	queries, err := shield.WhitelistQueries(gqlshield.Entry{
+		Query: `query( $id: ID! ) {
+			user( id: $id ) { name }
+		}`,
+		Name: "query one",
+		Parameters: map[string]gqlshield.Parameter{
+			"id": gqlshield.Parameter{MaxValueLength: 4},
+		},
+		WhitelistedFor: []int{0},
+	})
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+
+	hash := queries[0].Hash()
+	require.Len(t, hash, 64)
+	require.Equal(t, gqlshield.HashQuery(queries[0].Query()), hash)
+
+	// Expect the normalized query to be returned
+	id := "1"
+	query, err := shield.CheckPersisted(0, hash, map[string]interface{}{"id": id})
+	require.NoError(t, err)
+	require.Equal(t, queries[0].Query(), query)
+
+	// Expect the same checks as Check
+	_, err = shield.CheckPersisted(1, hash, map[string]interface{}{"id": id})
+	require.Error(t, err)
+	require.Equal(t, gqlshield.ErrUnauthorized, gqlshield.ErrCode(err))
+
+	tooLong := "12345"
+	_, err = shield.CheckPersisted(
+		0,
+		hash,
+		map[string]interface{}{"id": tooLong},
+	)
+	require.Error(t, err)
+	require.Equal(t, gqlshield.ErrUnauthorized, gqlshield.ErrCode(err))
+
+	// Expect unknown hashes to be reported
+	_, err = shield.CheckPersisted(0, gqlshield.HashQuery([]byte("x")), nil)
+	require.Error(t, err)
+	require.Equal(
+		t,
+		gqlshield.ErrPersistedQueryNotFound,
+		gqlshield.ErrCode(err),
+	)
+
+	// Expect removed queries to be unknown
+	require.NoError(t, shield.RemoveQuery(queries[0]))
+	_, err = shield.CheckPersisted(0, hash, map[string]interface{}{"id": id})
+	require.Error(t, err)
+	require.Equal(
+		t,
+		gqlshield.ErrPersistedQueryNotFound,
+		gqlshield.ErrCode(err),
+	)
+}
+
 // TestWrongArg tests argument validation
 func TestWrongArg(t *testing.T) {
 	setup := func() (shield gqlshield.GraphQLShield, query gqlshield.Query) {
@@ -448,7 +615,7 @@ func TestWrongArg(t *testing.T) {
 		_, err := shield.Check(
 			0,
 			qr.Query(),
-			map[string]*string{"wrongName": &wrongName},
+			map[string]interface{}{"wrongName": wrongName},
 		)
 		require.Error(t, err)
 		require.Equal(t, gqlshield.ErrUnauthorized, gqlshield.ErrCode(err))
@@ -460,13 +627,131 @@ func TestWrongArg(t *testing.T) {
 		_, err := shield.Check(
 			0,
 			qr.Query(),
-			map[string]*string{"wrongName": &wrongName},
+			map[string]interface{}{"wrongName": wrongName},
 		)
 		require.Error(t, err)
 		require.Equal(t, gqlshield.ErrUnauthorized, gqlshield.ErrCode(err))
 	})
 }
 
+// TestTypedArg tests the validation of typed arguments
+func TestTypedArg(t *testing.T) {
+	shield, err := gqlshield.NewGraphQLShield(
+		gqlshield.Config{},
+		gqlshield.ClientRole{ID: 0, Name: "default"},
+	)
+	require.NoError(t, err)
+
+	queries, err := shield.WhitelistQueries(gqlshield.Entry{
+		Query: `query(
+			$id: String!
+			$first: Int
+			$ratio: Float
+			$flag: Boolean
+			$ids: [String!]
+			$filter: Filter
+		) {
+			users(
+				id: $id
+				first: $first
+				ratio: $ratio
+				flag: $flag
+				ids: $ids
+				filter: $filter
+			) {
+				name
+			}
+		}`,
+		Name: "typed",
+		Parameters: map[string]gqlshield.Parameter{
+			"id":    gqlshield.Parameter{MaxValueLength: 4},
+			"first": gqlshield.Parameter{Type: gqlshield.ParamInt},
+			"ratio": gqlshield.Parameter{Type: gqlshield.ParamFloat},
+			"flag":  gqlshield.Parameter{Type: gqlshield.ParamBoolean},
+			"ids": gqlshield.Parameter{
+				Type:     gqlshield.ParamList,
+				MaxItems: 2,
+				Items:    &gqlshield.Parameter{MaxValueLength: 4},
+			},
+			"filter": gqlshield.Parameter{
+				Type: gqlshield.ParamObject,
+				Fields: map[string]gqlshield.Parameter{
+					"name":  gqlshield.Parameter{MaxValueLength: 4},
+					"limit": gqlshield.Parameter{Type: gqlshield.ParamInt},
+				},
+			},
+		},
+		WhitelistedFor: []int{0},
+	})
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+
+	// args returns valid arguments with the given ones replaced
+	args := func(replace map[string]interface{}) map[string]interface{} {
+		args := map[string]interface{}{
+			"id":    "abcd",
+			"first": float64(10),
+			"ratio": 0.5,
+			"flag":  true,
+			"ids":   []interface{}{"a", "b"},
+			"filter": map[string]interface{}{
+				"name":  "abc",
+				"limit": float64(2),
+			},
+		}
+		for name, value := range replace {
+			args[name] = value
+		}
+		return args
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		for _, arguments := range []map[string]interface{}{
+			args(nil),
+			args(map[string]interface{}{
+				"first":  nil,
+				"ids":    nil,
+				"filter": nil,
+			}),
+			args(map[string]interface{}{
+				"ratio":  float64(2),
+				"ids":    []interface{}{},
+				"filter": map[string]interface{}{},
+			}),
+		} {
+			_, err := shield.Check(0, queries[0].Query(), arguments)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, replace := range map[string]map[string]interface{}{
+			"stringType":      {"id": float64(1)},
+			"stringLength":    {"id": "abcde"},
+			"intType":         {"first": "10"},
+			"intFraction":     {"first": 1.5},
+			"intRange":        {"first": float64(1 << 32)},
+			"floatType":       {"ratio": "0.5"},
+			"booleanType":     {"flag": "true"},
+			"listType":        {"ids": "a"},
+			"listLength":      {"ids": []interface{}{"a", "b", "c"}},
+			"listItemType":    {"ids": []interface{}{"a", float64(1)}},
+			"objectType":      {"filter": "name"},
+			"objectField":     {"filter": map[string]interface{}{"x": "a"}},
+			"objectFieldType": {"filter": map[string]interface{}{"name": true}},
+		} {
+			_, err := shield.Check(0, queries[0].Query(), args(replace))
+			require.Error(t, err, name)
+			require.Equal(
+				t,
+				gqlshield.ErrUnauthorized,
+				gqlshield.ErrCode(err),
+				name,
+			)
+		}
+	})
+}
+
 // TestNewGraphQLShield tests the NewGraphQLShield constructor function
 func TestNewGraphQLShield(t *testing.T) {
 	shield, err := gqlshield.NewGraphQLShield(
@@ -653,6 +938,10 @@ func (m *persistencyManagerMock) Save(state *gqlshield.State) error {
 	return nil
 }
 
+func (m *persistencyManagerMock) Close() error {
+	return nil
+}
+
 // TestPersistency tests the persistency manager option
 func TestPersistency(t *testing.T) {
 	// Create a new persistency manager mock instance
@@ -1,66 +1,122 @@
 package gqlshield
 
 import (
+	"bytes"
 	"encoding/json"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 )
 
 // PersistencyManager represents a persistency manager
 type PersistencyManager interface {
-	// Load loads the GraphQL shield configuration
+	// Load loads the GraphQL shield configuration.
+	// Returns nil if there's no persisted configuration
 	Load() (*State, error)
 
 	// Save persists the GraphQL shield configuration
 	Save(*State) error
+
+	// Close releases all resources held by the persistency manager
+	Close() error
 }
 
 // NewPepersistencyManagerFileJSON creates a new JSON file based
-// persistency manager
+// persistency manager. The file is locked exclusively until the manager
+// is closed to prevent other processes from overwriting it concurrently.
+// The file is written atomically and synced to disk if syncWrite is true
 func NewPepersistencyManagerFileJSON(
 	path string,
 	syncWrite bool,
 ) (PersistencyManager, error) {
+	lock, err := lockFile(path + ".lock")
+	if err != nil {
+		return nil, errors.Wrap(err, "locking file")
+	}
 	return &persistencyManagerFileJSON{
 		path:      path,
 		syncWrite: syncWrite,
+		lock:      lock,
 	}, nil
 }
 
 type persistencyManagerFileJSON struct {
 	path      string
 	syncWrite bool
+	lock      *os.File
 }
 
-func (man *persistencyManagerFileJSON) onFile(f func(*os.File) error) error {
-	// Open file
-	flags := os.O_CREATE | os.O_RDWR
-	if man.syncWrite {
-		flags = os.O_CREATE | os.O_RDWR | os.O_SYNC
+func (man *persistencyManagerFileJSON) Load() (*State, error) {
+	contents, err := ioutil.ReadFile(man.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "reading file")
 	}
-	file, err := os.OpenFile(man.path, flags, 0660)
-	if err != nil {
-		return errors.Wrap(err, "opening file")
+	if len(bytes.TrimSpace(contents)) < 1 {
+		return nil, nil
 	}
-	defer file.Close()
 
-	return f(file)
+	state := &State{}
+	if err := json.Unmarshal(contents, state); err != nil {
+		return nil, errors.Wrap(err, "decoding JSON")
+	}
+	return state, nil
 }
 
-func (man *persistencyManagerFileJSON) Load() (state *State, err error) {
-	err = man.onFile(func(file *os.File) error {
-		jsonDecoder := json.NewDecoder(file)
-		state = &State{}
-		return jsonDecoder.Decode(state)
-	})
-	return
+// Save writes the state to a temporary file first and then replaces
+// the actual file by it so that the file is never left half-written
+func (man *persistencyManagerFileJSON) Save(state *State) error {
+	encoded, err := json.MarshalIndent(state, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "encoding JSON")
+	}
+
+	dir, name := filepath.Split(man.path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := ioutil.TempFile(dir, name+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary file")
+	}
+	tmpPath := tmp.Name()
+	fail := func(err error, msg string) error {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return errors.Wrap(err, msg)
+	}
+
+	if err := tmp.Chmod(0660); err != nil {
+		return fail(err, "setting temporary file permissions")
+	}
+	if _, err := tmp.Write(encoded); err != nil {
+		return fail(err, "writing temporary file")
+	}
+	if man.syncWrite {
+		if err := tmp.Sync(); err != nil {
+			return fail(err, "syncing temporary file")
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return errors.Wrap(err, "closing temporary file")
+	}
+
+	if err := os.Rename(tmpPath, man.path); err != nil {
+		os.Remove(tmpPath)
+		return errors.Wrap(err, "replacing file")
+	}
+
+	if man.syncWrite {
+		// Persist the rename
+		syncDir(dir)
+	}
+	return nil
 }
 
-func (man *persistencyManagerFileJSON) Save(state *State) error {
-	return man.onFile(func(file *os.File) error {
-		jsonEncoder := json.NewEncoder(file)
-		state = &State{}
-		return jsonEncoder.Encode(state)
-	})
+func (man *persistencyManagerFileJSON) Close() error {
+	return unlockFile(man.lock)
 }
@@ -0,0 +1,181 @@
+package gqlshield_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPersistencyFileJSON tests whether a shield restarted
+// from the same JSON file restores the same whitelist
+func TestPersistencyFileJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlshield")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "shield.json")
+
+	newShield := func() (
+		gqlshield.GraphQLShield,
+		gqlshield.PersistencyManager,
+	) {
+		manager, err := gqlshield.NewPepersistencyManagerFileJSON(path, true)
+		require.NoError(t, err)
+		shield, err := gqlshield.NewGraphQLShield(
+			gqlshield.Config{PersistencyManager: manager},
+			gqlshield.ClientRole{ID: 1, Name: "first"},
+			gqlshield.ClientRole{ID: 2, Name: "second"},
+		)
+		require.NoError(t, err)
+		return shield, manager
+	}
+
+	// Start with an inexistent file
+	shield, manager := newShield()
+	queries, err := shield.ListQueries()
+	require.NoError(t, err)
+	require.Len(t, queries, 0)
+
+	added, err := shield.WhitelistQueries(
+		gqlshield.Entry{
+			Query: `query( $id: ID! ) {
+				user( id: $id ) { name email }
+			}`,
+			Name: "query one",
+			Parameters: map[string]gqlshield.Parameter{
+				"id": gqlshield.Parameter{MaxValueLength: 32},
+			},
+			WhitelistedFor: []int{1},
+		},
+		gqlshield.Entry{
+			Query:          `query { posts { id title } }`,
+			Name:           "query two",
+			WhitelistedFor: []int{1},
+		},
+		gqlshield.Entry{
+			Query:          `query { users { id } }`,
+			Name:           "query three",
+			WhitelistedFor: []int{1, 2},
+		},
+	)
+	require.NoError(t, err)
+	_, err = shield.UpdateQueryRoles(added[1], []int{2})
+	require.NoError(t, err)
+	require.NoError(t, shield.RemoveQuery(added[2]))
+
+	before, err := shield.ListQueries()
+	require.NoError(t, err)
+	require.Len(t, before, 2)
+	require.NoError(t, manager.Close())
+
+	// Ensure no temporary files were left behind
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Name()
+	}
+	require.ElementsMatch(t, []string{"shield.json", "shield.json.lock"}, names)
+
+	// Restart the shield
+	shield, manager = newShield()
+	defer manager.Close()
+
+	after, err := shield.ListQueries()
+	require.NoError(t, err)
+	require.Len(t, after, len(before))
+	for name, expected := range before {
+		actual, ok := after[name]
+		require.True(t, ok)
+		require.Equal(t, expected.ID(), actual.ID())
+		require.Equal(t, expected.Query(), actual.Query())
+		require.Equal(t, expected.Parameters(), actual.Parameters())
+		require.Equal(t, expected.WhitelistedFor(), actual.WhitelistedFor())
+		require.True(t, expected.Creation().Equal(actual.Creation()))
+	}
+
+	// Ensure the restored queries are checked and removed correctly
+	id := "1"
+	_, err = shield.Check(
+		1,
+		after["query one"].Query(),
+		map[string]interface{}{"id": id},
+	)
+	require.NoError(t, err)
+	_, err = shield.Check(2, after["query two"].Query(), nil)
+	require.NoError(t, err)
+	_, err = shield.Check(1, after["query two"].Query(), nil)
+	require.Error(t, err)
+
+	require.NoError(t, shield.RemoveQuery(after["query one"]))
+	queries, err = shield.ListQueries()
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+}
+
+// TestPersistencyFileJSONLock tests whether the JSON file is locked
+// against concurrent use
+func TestPersistencyFileJSONLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlshield")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "shield.json")
+
+	first, err := gqlshield.NewPepersistencyManagerFileJSON(path, false)
+	require.NoError(t, err)
+
+	second, err := gqlshield.NewPepersistencyManagerFileJSON(path, false)
+	require.Error(t, err)
+	require.Nil(t, second)
+
+	// Expect the file to be unlocked after closing the first manager
+	require.NoError(t, first.Close())
+	second, err = gqlshield.NewPepersistencyManagerFileJSON(path, false)
+	require.NoError(t, err)
+	require.NoError(t, second.Close())
+}
+
+// TestPersistencyFileJSONUnregisteredRole tests whether restoring a state
+// declaring a role the shield isn't registered with fails
+func TestPersistencyFileJSONUnregisteredRole(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlshield")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "shield.json")
+
+	manager, err := gqlshield.NewPepersistencyManagerFileJSON(path, true)
+	require.NoError(t, err)
+	shield, err := gqlshield.NewGraphQLShield(
+		gqlshield.Config{PersistencyManager: manager},
+		gqlshield.ClientRole{ID: 1, Name: "first"},
+		gqlshield.ClientRole{ID: 2, Name: "second"},
+	)
+	require.NoError(t, err)
+	_, err = shield.WhitelistQueries(gqlshield.Entry{
+		Query:          `query { posts { id title } }`,
+		Name:           "query one",
+		WhitelistedFor: []int{2},
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.Close())
+
+	for _, roles := range [][]gqlshield.ClientRole{
+		// Missing role
+		{{ID: 1, Name: "first"}},
+		// Renamed role
+		{{ID: 1, Name: "first"}, {ID: 2, Name: "renamed"}},
+	} {
+		manager, err := gqlshield.NewPepersistencyManagerFileJSON(path, true)
+		require.NoError(t, err)
+		shield, err := gqlshield.NewGraphQLShield(
+			gqlshield.Config{PersistencyManager: manager},
+			roles...,
+		)
+		require.Error(t, err)
+		require.Nil(t, shield)
+		require.NoError(t, manager.Close())
+	}
+}
@@ -1,6 +1,8 @@
 package gqlshield
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"sort"
 	"time"
 )
@@ -13,6 +15,10 @@ type Query interface {
 	// ID returns the unique identifier of the query
 	ID() ID
 
+	// Hash returns the hex encoded SHA-256 hash of the normalized query string
+	// identifying the query as a persisted query
+	Hash() string
+
 	// Creation returns the time of creation
 	Creation() time.Time
 
@@ -26,14 +32,68 @@ type Query interface {
 	WhitelistedFor() []int
 }
 
+// ParameterType represents the type of a query parameter value
+type ParameterType string
+
+const (
+	// ParamString accepts strings not exceeding the max value length.
+	// Parameters of unspecified type are string parameters
+	ParamString ParameterType = "String"
+
+	// ParamInt accepts 32-bit integers
+	ParamInt ParameterType = "Int"
+
+	// ParamFloat accepts floating point numbers
+	ParamFloat ParameterType = "Float"
+
+	// ParamBoolean accepts booleans
+	ParamBoolean ParameterType = "Boolean"
+
+	// ParamList accepts lists of items not exceeding the max number of items
+	ParamList ParameterType = "List"
+
+	// ParamObject accepts input objects with the defined fields only
+	ParamObject ParameterType = "Object"
+)
+
 // Parameter represents a query parameter
 type Parameter struct {
-	MaxValueLength uint32 `json:"max-value-length"`
+	Type ParameterType `json:"type,omitempty"`
+
+	// MaxValueLength is the max length of string values
+	MaxValueLength uint32 `json:"max-value-length,omitempty"`
+
+	// MaxItems is the max number of items of list values
+	MaxItems uint32 `json:"max-items,omitempty"`
+
+	// Items is the parameter the items of list values are checked against
+	Items *Parameter `json:"items,omitempty"`
+
+	// Fields are the parameters the fields of object values
+	// are checked against
+	Fields map[string]Parameter `json:"fields,omitempty"`
+}
+
+// clone returns a deep copy of the parameter
+func (param Parameter) clone() Parameter {
+	if param.Items != nil {
+		items := param.Items.clone()
+		param.Items = &items
+	}
+	if param.Fields != nil {
+		fields := make(map[string]Parameter, len(param.Fields))
+		for name, field := range param.Fields {
+			fields[name] = field.clone()
+		}
+		param.Fields = fields
+	}
+	return param
 }
 
 // query represents a whitelisted query
 type query struct {
 	id             ID
+	hash           string
 	query          []byte
 	creation       time.Time
 	name           string
@@ -45,6 +105,10 @@ func (q *query) ID() ID {
 	return q.id
 }
 
+func (q *query) Hash() string {
+	return q.hash
+}
+
 func (q *query) Creation() time.Time {
 	return q.creation
 }
@@ -62,7 +126,7 @@ func (q *query) Query() []byte {
 func (q *query) Parameters() map[string]Parameter {
 	params := make(map[string]Parameter, len(q.parameters))
 	for name, param := range q.parameters {
-		params[name] = param
+		params[name] = param.clone()
 	}
 	return params
 }
@@ -77,3 +141,9 @@ func (q *query) WhitelistedFor() []int {
 	sort.Ints(clientRoleIDs)
 	return clientRoleIDs
 }
+
+// HashQuery returns the persisted query hash of the normalized query string
+func HashQuery(normalized []byte) string {
+	hash := sha256.Sum256(normalized)
+	return hex.EncodeToString(hash[:])
+}
@@ -25,6 +25,7 @@ func (shld *shield) RemoveQuery(queryObject Query) error {
 
 	deletedQuery := shld.queriesByName[qr.name]
 	delete(shld.queriesByName, qr.name)
+	delete(shld.queriesByHash, qr.hash)
 
 	if len(qr.query) == shld.longest {
 		if err := shld.recalculateLongest(); err != nil {
@@ -39,6 +40,7 @@ func (shld *shield) RemoveQuery(queryObject Query) error {
 		); err != nil {
 			// Rollback changes
 			shld.queriesByName[qr.name] = deletedQuery
+			shld.queriesByHash[qr.hash] = deletedQuery
 			shld.index.Insert(qr.query, deletedQuery)
 			if err := shld.recalculateLongest(); err != nil {
 				rollbackErr := errors.Wrap(
@@ -29,12 +29,22 @@ func (shld *shield) restoreState(state *State) error {
 				foundID,
 			)
 		}
+		// Ensure the role is registered under the same name
+		registered, isRegistered := shld.clientRoles[role.ID]
+		if !isRegistered || registered.Name != role.Name {
+			return errors.Errorf(
+				"unregistered role (%d:'%s')",
+				role.ID,
+				role.Name,
+			)
+		}
 		clientRoles[role.ID] = role
 	}
 
 	// Restore queries
 	index := art.New()
 	queriesByName := make(map[string]*query)
+	queriesByHash := make(map[string]*query)
 
 	for id, queryModel := range state.WhitelistedQueries {
 		// Ensure the query ID is valid
@@ -65,7 +75,7 @@ func (shld *shield) restoreState(state *State) error {
 		}
 
 		// Verify query string validity
-		if err := validateQueryString(queryModel.Name); err != nil {
+		if err := validateQueryString(queryModel.Query); err != nil {
 			return errors.Wrapf(err, "query %s has invalid query string", id)
 		}
 
@@ -83,13 +93,12 @@ func (shld *shield) restoreState(state *State) error {
 					id,
 				)
 			}
-			if param.MaxValueLength < 1 {
-				return errors.Errorf(
-					"query %s has parameter ('%s') with invalid "+
-						"property MaxValueLength (%d)",
+			if err := validateParameter(param); err != nil {
+				return errors.Wrapf(
+					err,
+					"query %s has invalid parameter ('%s')",
 					id,
 					paramName,
-					param.MaxValueLength,
 				)
 			}
 		}
@@ -119,7 +128,8 @@ func (shld *shield) restoreState(state *State) error {
 
 		query := &query{
 			id:             ID(id),
-			query:          []byte(queryModel.Query),
+			hash:           HashQuery(queryString),
+			query:          queryString,
 			creation:       queryModel.Creation,
 			name:           queryModel.Name,
 			parameters:     queryModel.Parameters,
@@ -127,6 +137,7 @@ func (shld *shield) restoreState(state *State) error {
 		}
 
 		queriesByName[queryModel.Name] = query
+		queriesByHash[query.hash] = query
 		index.Insert(queryString, query)
 	}
 
@@ -134,14 +145,17 @@ func (shld *shield) restoreState(state *State) error {
 	defer shld.lock.Unlock()
 
 	oldQueriesByName := shld.queriesByName
+	oldQueriesByHash := shld.queriesByHash
 	oldIndex := shld.index
 
 	shld.queriesByName = queriesByName
+	shld.queriesByHash = queriesByHash
 	shld.index = index
 
 	if err := shld.recalculateLongest(); err != nil {
 		// Rollback changes
 		shld.queriesByName = oldQueriesByName
+		shld.queriesByHash = oldQueriesByHash
 		shld.index = oldIndex
 		return errors.Wrap(err, "recalculating longest")
 	}
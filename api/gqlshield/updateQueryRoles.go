@@ -0,0 +1,83 @@
+package gqlshield
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+func (shld *shield) UpdateQueryRoles(
+	queryObject Query,
+	whitelistedFor []int,
+) (Query, error) {
+	qr, isExpectedType := queryObject.(*query)
+	if !isExpectedType {
+		return nil, fmt.Errorf(
+			"unexpected query type: %s",
+			reflect.TypeOf(queryObject),
+		)
+	}
+
+	// Ensure whitelistedFor validity
+	if len(whitelistedFor) < 1 {
+		return nil, fmt.Errorf("query '%s' has no roles associated", qr.name)
+	}
+	roles := make(map[int]struct{}, len(whitelistedFor))
+	for _, roleID := range whitelistedFor {
+		// Ensure whitelistedFor role ID uniqueness
+		if _, isDefined := roles[roleID]; isDefined {
+			return nil, fmt.Errorf(
+				"query '%s' has duplicate role IDs (%d) in whitelistedFor",
+				qr.name,
+				roleID,
+			)
+		}
+		roles[roleID] = struct{}{}
+	}
+
+	shld.lock.Lock()
+	defer shld.lock.Unlock()
+
+	// Ensure referenced roles exist
+	for role := range roles {
+		if _, roleDefined := shld.clientRoles[role]; !roleDefined {
+			return nil, fmt.Errorf("undefined role: %d", role)
+		}
+	}
+
+	current, exists := shld.queriesByName[qr.name]
+	if !exists {
+		return nil, fmt.Errorf("query '%s' isn't whitelisted", qr.name)
+	}
+
+	// Replace the query object instead of mutating it
+	// since it might still be referenced by readers
+	updated := &query{
+		id:             current.id,
+		hash:           current.hash,
+		query:          current.query,
+		creation:       current.creation,
+		name:           current.name,
+		parameters:     current.parameters,
+		whitelistedFor: roles,
+	}
+	shld.queriesByName[updated.name] = updated
+	shld.queriesByHash[updated.hash] = updated
+	shld.index.Insert(updated.query, updated)
+
+	// Persist state changes
+	if shld.conf.PersistencyManager != nil {
+		if err := shld.conf.PersistencyManager.Save(
+			shld.captureState(),
+		); err != nil {
+			// Rollback changes
+			shld.queriesByName[current.name] = current
+			shld.queriesByHash[current.hash] = current
+			shld.index.Insert(current.query, current)
+			return nil, errors.Wrap(err, "persisting state after role update")
+		}
+	}
+
+	return updated, nil
+}
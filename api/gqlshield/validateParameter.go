@@ -0,0 +1,48 @@
+package gqlshield
+
+import "github.com/pkg/errors"
+
+func validateParameter(param Parameter) error {
+	switch param.Type {
+	case "", ParamString:
+		if param.MaxValueLength < 1 {
+			return errors.Errorf(
+				"invalid property MaxValueLength (%d)",
+				param.MaxValueLength,
+			)
+		}
+	case ParamInt, ParamFloat, ParamBoolean:
+	case ParamList:
+		if param.MaxItems < 1 {
+			return errors.Errorf(
+				"invalid property MaxItems (%d)",
+				param.MaxItems,
+			)
+		}
+		if param.Items == nil {
+			return errors.New("missing list item parameter")
+		}
+		if err := validateParameter(*param.Items); err != nil {
+			return errors.Wrap(err, "invalid list item parameter")
+		}
+	case ParamObject:
+		if len(param.Fields) < 1 {
+			return errors.New("missing object field parameters")
+		}
+		for fieldName, field := range param.Fields {
+			if err := validateParameterName(fieldName); err != nil {
+				return errors.Wrap(err, "invalid object field parameter")
+			}
+			if err := validateParameter(field); err != nil {
+				return errors.Wrapf(
+					err,
+					"invalid object field parameter ('%s')",
+					fieldName,
+				)
+			}
+		}
+	default:
+		return errors.Errorf("unknown parameter type: %s", param.Type)
+	}
+	return nil
+}
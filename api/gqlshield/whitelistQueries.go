@@ -36,6 +36,7 @@ func (shld *shield) WhitelistQueries(newEntries ...Entry) ([]Query, error) {
 			return nil, err
 		}
 		newQuery.query = normalized
+		newQuery.hash = HashQuery(normalized)
 
 		// Ensure whitelistedFor validity
 		if len(newEntry.WhitelistedFor) < 1 {
@@ -80,13 +81,12 @@ func (shld *shield) WhitelistQueries(newEntries ...Entry) ([]Query, error) {
 				}
 
 				// Ensure parameter properties validity
-				if param.MaxValueLength < 1 {
-					return nil, fmt.Errorf(
-						"query '%s' has parameter ('%s') with invalid "+
-							"property MaxValueLength (%d)",
+				if err := validateParameter(param); err != nil {
+					return nil, errors.Wrapf(
+						err,
+						"query '%s' has invalid parameter ('%s')",
 						newEntry.Name,
 						paramName,
-						param.MaxValueLength,
 					)
 				}
 
@@ -99,7 +99,7 @@ func (shld *shield) WhitelistQueries(newEntries ...Entry) ([]Query, error) {
 					)
 				}
 
-				newQuery.parameters[paramName] = param
+				newQuery.parameters[paramName] = param.clone()
 			}
 		}
 
@@ -147,34 +147,38 @@ func (shld *shield) WhitelistQueries(newEntries ...Entry) ([]Query, error) {
 	for _, newQuery := range newQueries {
 		// Store the original query
 		shld.queriesByName[newQuery.name] = newQuery
+		shld.queriesByHash[newQuery.hash] = newQuery
 
 		// Update index
 		shld.index.Insert(newQuery.query, newQuery)
 		if len(newQuery.query) > shld.longest {
 			shld.longest = len(newQuery.query)
 		}
+	}
 
-		// Persist state changes
-		if shld.conf.PersistencyManager != nil {
-			if err := shld.conf.PersistencyManager.Save(
-				shld.captureState(),
-			); err != nil {
-				// Rollback changes
+	// Persist state changes
+	if shld.conf.PersistencyManager != nil {
+		if err := shld.conf.PersistencyManager.Save(
+			shld.captureState(),
+		); err != nil {
+			// Rollback changes
+			for _, newQuery := range newQueries {
 				delete(shld.queriesByName, newQuery.name)
+				delete(shld.queriesByHash, newQuery.hash)
 				shld.index.Delete(newQuery.query)
-				if err := shld.recalculateLongest(); err != nil {
-					rollbackErr := errors.Wrap(
-						err,
-						"persisting state after insertion",
-					)
-					return nil, errors.Wrap(
-						rollbackErr,
-						"recalculating longest after rollback",
-					)
-				}
-
-				return nil, errors.Wrap(err, "persisting state after insertion")
 			}
+			if err := shld.recalculateLongest(); err != nil {
+				rollbackErr := errors.Wrap(
+					err,
+					"persisting state after insertion",
+				)
+				return nil, errors.Wrap(
+					rollbackErr,
+					"recalculating longest after rollback",
+				)
+			}
+
+			return nil, errors.Wrap(err, "persisting state after insertion")
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 	"github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
@@ -17,25 +18,82 @@ const CtxSession CtxKey = 1
 // GQLShieldClientRole represents a GraphQL shield client role identifier
 type GQLShieldClientRole int
 
-const (
-	_ GQLShieldClientRole = iota
+// GQLShieldClientRoles maps API clients to GraphQL shield client roles
+type GQLShieldClientRoles struct {
+	// Guest is the client role of unauthenticated clients
+	Guest GQLShieldClientRole
+
+	// Debug is the client role of the debug user
+	Debug GQLShieldClientRole
 
-	// GQLShieldClientGuest represents a guest API user
-	GQLShieldClientGuest
+	// Users maps persisted user roles to client roles,
+	// user roles without a client role are skipped
+	Users map[role.Role]GQLShieldClientRole
+}
+
+// Resolve returns the client roles of the given session ordered by privilege
+// starting with the most privileged one. A user is assigned the client roles
+// of all the user roles its roles include
+func (roles GQLShieldClientRoles) Resolve(
+	session *RequestSession,
+) []GQLShieldClientRole {
+	switch {
+	case session == nil:
+		return []GQLShieldClientRole{roles.Guest}
+	case session.IsDebug:
+		return []GQLShieldClientRole{roles.Debug}
+	case session.UserID == "":
+		return []GQLShieldClientRole{roles.Guest}
+	}
 
-	// GQLShieldClientDebug represents the debug API user
-	GQLShieldClientDebug
+	userRoles := role.Values()
+	resolved := make([]GQLShieldClientRole, 0, len(userRoles))
+	for i := len(userRoles) - 1; i >= 0; i-- {
+		userRole := userRoles[i]
+		clientRole, isDefined := roles.Users[userRole]
+		if !isDefined {
+			continue
+		}
+		// Every user is a regular user
+		if userRole == role.User || session.HasRole(userRole) {
+			resolved = append(resolved, clientRole)
+		}
+	}
+	return resolved
+}
 
-	// GQLShieldClientRegular represents the regular API user
-	GQLShieldClientRegular
+// DebugMode represents the access mode of a debug session
+type DebugMode int
+
+const (
+	_ DebugMode = iota
+
+	// DebugModeReadOnly permits the debug user to perform queries only
+	DebugModeReadOnly
+
+	// DebugModeReadWrite permits the debug user to perform
+	// both queries and mutations
+	DebugModeReadWrite
 )
 
 // RequestSession represents a client session
 type RequestSession struct {
-	IsDebug          bool
-	UserID           store.ID
-	Creation         time.Time
-	ShieldClientRole GQLShieldClientRole
+	IsDebug   bool
+	DebugMode DebugMode
+	UserID    store.ID
+	Roles     []role.Role
+	Creation  time.Time
+}
+
+// HasRole returns true if any of the roles of the session user
+// grants the privileges of the given role
+func (session *RequestSession) HasRole(r role.Role) bool {
+	for _, userRole := range session.Roles {
+		if userRole.Includes(r) {
+			return true
+		}
+	}
+	return false
 }
 
 // Requirement defines the authorization requirement implementation interface
@@ -52,7 +110,8 @@ func Authorize(
 	// Extract the session
 	session, isSession := ctx.Value(CtxSession).(*RequestSession)
 
-	// Pass debug clients without further authorization
+	// Pass debug clients without further authorization,
+	// mutations of read-only debug clients are rejected before execution
 	if isSession && session.IsDebug {
 		return nil
 	}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// HasRole indicates that the client is required to be an authenticated user
+// having either the given role or a role including it
+type HasRole struct {
+	Role role.Role
+}
+
+func (rule HasRole) check(session *RequestSession) string {
+	if session.UserID == "" || !session.HasRole(rule.Role) {
+		return fmt.Sprintf("the user is required to have the %s role", rule.Role)
+	}
+	return ""
+}
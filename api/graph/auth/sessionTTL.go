@@ -0,0 +1,35 @@
+package auth
+
+import "time"
+
+// SessionTTL defines the lifetime of user sessions
+type SessionTTL struct {
+	// Absolute defines the maximum lifetime of a session since its creation
+	Absolute time.Duration
+
+	// Idle defines the maximum lifetime of a session since its last access
+	Idle time.Duration
+}
+
+// ExpiresAt returns the time the session expires at
+func (ttl SessionTTL) ExpiresAt(creation, lastAccess time.Time) time.Time {
+	if lastAccess.Before(creation) {
+		// Sessions that were never accessed are idle since creation
+		lastAccess = creation
+	}
+	absolute := creation.Add(ttl.Absolute)
+	idle := lastAccess.Add(ttl.Idle)
+	if idle.Before(absolute) {
+		return idle
+	}
+	return absolute
+}
+
+// IsExpired returns true if the session is expired at the given time
+func (ttl SessionTTL) IsExpired(
+	creation time.Time,
+	lastAccess time.Time,
+	now time.Time,
+) bool {
+	return !now.Before(ttl.ExpiresAt(creation, lastAccess))
+}
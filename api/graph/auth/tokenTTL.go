@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// TokenTTL defines the lifetimes of the tokens issued to users
+type TokenTTL struct {
+	// PasswordReset defines the lifetime of a password reset token
+	PasswordReset time.Duration
+
+	// EmailVerification defines the lifetime of an email verification token
+	EmailVerification time.Duration
+}
+
+// Of returns the lifetime of tokens of the given kind
+func (ttl TokenTTL) Of(kind token.Kind) time.Duration {
+	switch kind {
+	case token.PasswordReset:
+		return ttl.PasswordReset
+	case token.EmailVerification:
+		return ttl.EmailVerification
+	}
+	return 0
+}
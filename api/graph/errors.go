@@ -3,9 +3,14 @@ package graph
 import (
 	"strconv"
 
+	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/errors"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
+// msgInternalError defines the message unexpected errors are masked with
+const msgInternalError = "internal server error"
+
 // GQLError represents a GraphQL error
 type GQLError struct {
 	errs []*errors.QueryError
@@ -19,3 +24,87 @@ func (gqlerr GQLError) Error() string {
 	msg += "]"
 	return msg
 }
+
+// internalError reports an unexpected error
+// and returns a response error masking it
+func (graph *Graph) internalError(err error) *ResponseError {
+	graph.onUnexpectedErr(err)
+	return &ResponseError{
+		Code:    string(strerr.ErrInternal),
+		Message: msgInternalError,
+	}
+}
+
+// requestErrors converts an error preventing the execution of a request
+// to response errors
+func (graph *Graph) requestErrors(err error) []*ResponseError {
+	if errCode := strerr.ErrorCode(err); errCode != "" {
+		// Expected user error
+		return []*ResponseError{{
+			Code:    errCode,
+			Message: err.Error(),
+		}}
+	}
+
+	if gqlErr, isGQLErr := err.(GQLError); isGQLErr {
+		// Expected GraphQL error
+		return graph.responseErrors(false, gqlErr.errs)
+	}
+
+	// Unexpected internal server error
+	return []*ResponseError{graph.internalError(err)}
+}
+
+// response converts a GraphQL response
+func (graph *Graph) response(rep *graphql.Response) Response {
+	return Response{
+		Data:   rep.Data,
+		Errors: graph.responseErrors(rep.Data != nil, rep.Errors),
+	}
+}
+
+// responseErrors converts GraphQL errors to response errors.
+// Resolver errors without an error code and errors that occurred
+// during the execution without being caused by a resolver are unexpected
+func (graph *Graph) responseErrors(
+	executed bool,
+	errs []*errors.QueryError,
+) []*ResponseError {
+	if len(errs) < 1 {
+		return nil
+	}
+
+	respErrs := make([]*ResponseError, len(errs))
+	for i, err := range errs {
+		var respErr *ResponseError
+		switch {
+		case err.ResolverError != nil &&
+			strerr.ErrorCode(err.ResolverError) != "":
+			// Expected user error
+			respErr = &ResponseError{
+				Code:    strerr.ErrorCode(err.ResolverError),
+				Message: err.ResolverError.Error(),
+			}
+		case err.ResolverError != nil:
+			respErr = graph.internalError(err.ResolverError)
+		case executed:
+			respErr = graph.internalError(err)
+		default:
+			// Expected GraphQL error
+			respErr = &ResponseError{Message: err.Message}
+		}
+
+		respErr.Path = err.Path
+		if len(err.Locations) > 0 {
+			respErr.Locations = make([]Location, len(err.Locations))
+			for i, loc := range err.Locations {
+				respErr.Locations[i] = Location{
+					Line:   loc.Line,
+					Column: loc.Column,
+				}
+			}
+		}
+		respErrs[i] = respErr
+	}
+	return respErrs
+}
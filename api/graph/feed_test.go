@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeedTies tests paginating the feed over posts
+// sharing the same creation time
+func TestFeedTies(t *testing.T) {
+	graph, str, _ := newTestGraph(t, testSetup{})
+
+	ctx := context.Background()
+	now := time.Now()
+	createUser := func(name, email string) store.ID {
+		usr, err := str.CreateUser(ctx, now, email, name, "testpass")
+		require.NoError(t, err)
+		return usr.ID
+	}
+	reader := createUser("reader", "1@test.test")
+	authors := []store.ID{
+		createUser("first", "2@test.test"),
+		createUser("second", "3@test.test"),
+	}
+	readerCtx := context.WithValue(ctx, auth.CtxSession, &auth.RequestSession{
+		UserID: reader,
+		Roles:  []role.Role{role.User},
+	})
+
+	// Each author publishes 3 posts at the same time and an older one
+	var posts []store.ID
+	for _, author := range authors {
+		_, err := str.FollowUser(readerCtx, reader, author)
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			post, err := str.CreatePost(ctx, now, author, "tie", "contents")
+			require.NoError(t, err)
+			posts = append(posts, post.ID)
+		}
+		post, err := str.CreatePost(
+			ctx,
+			now.Add(-time.Hour),
+			author,
+			"older",
+			"contents",
+		)
+		require.NoError(t, err)
+		posts = append(posts, post.ID)
+	}
+
+	// Read the entire feed page by page
+	var feed []store.ID
+	var after interface{}
+	for page := 0; ; page++ {
+		require.True(t, page < len(posts), "too many pages")
+		response := graph.Query(readerCtx, Query{
+			Query: []byte(`query($after: Cursor) {
+				feed(first: 2, after: $after) {
+					edges { node { id } }
+					pageInfo { hasNextPage endCursor }
+				}
+			}`),
+			Variables: map[string]interface{}{"after": after},
+		})
+		require.Len(t, response.Errors, 0)
+		var data struct {
+			Feed struct {
+				Edges []struct {
+					Node struct {
+						ID store.ID `json:"id"`
+					} `json:"node"`
+				} `json:"edges"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"feed"`
+		}
+		require.NoError(t, json.Unmarshal(response.Data, &data))
+		for _, edge := range data.Feed.Edges {
+			feed = append(feed, edge.Node.ID)
+		}
+		if !data.Feed.PageInfo.HasNextPage {
+			break
+		}
+		after = data.Feed.PageInfo.EndCursor
+	}
+
+	// Every post is listed exactly once, the older ones last
+	require.Len(t, feed, len(posts))
+	require.ElementsMatch(t, posts, feed)
+	require.ElementsMatch(t, []store.ID{posts[3], posts[7]}, feed[6:])
+}
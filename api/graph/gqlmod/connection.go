@@ -0,0 +1,74 @@
+package gqlmod
+
+// PageInfo defines the PageInfo type query object
+type PageInfo struct {
+	HasNextPage     *bool   `json:"hasNextPage"`
+	HasPreviousPage *bool   `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// UserConnection defines the UserConnection type query object
+type UserConnection struct {
+	Edges      []UserEdge `json:"edges"`
+	PageInfo   *PageInfo  `json:"pageInfo"`
+	TotalCount *int       `json:"totalCount"`
+}
+
+// UserEdge defines the UserEdge type query object
+type UserEdge struct {
+	Cursor *string `json:"cursor"`
+	Node   *User   `json:"node"`
+}
+
+// PostConnection defines the PostConnection type query object
+type PostConnection struct {
+	Edges      []PostEdge `json:"edges"`
+	PageInfo   *PageInfo  `json:"pageInfo"`
+	TotalCount *int       `json:"totalCount"`
+}
+
+// PostEdge defines the PostEdge type query object
+type PostEdge struct {
+	Cursor *string `json:"cursor"`
+	Node   *Post   `json:"node"`
+}
+
+// ReactionConnection defines the ReactionConnection type query object
+type ReactionConnection struct {
+	Edges      []ReactionEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount *int           `json:"totalCount"`
+}
+
+// ReactionEdge defines the ReactionEdge type query object
+type ReactionEdge struct {
+	Cursor *string   `json:"cursor"`
+	Node   *Reaction `json:"node"`
+}
+
+// NotificationConnection defines the NotificationConnection type query object
+type NotificationConnection struct {
+	Edges      []NotificationEdge `json:"edges"`
+	PageInfo   *PageInfo          `json:"pageInfo"`
+	TotalCount *int               `json:"totalCount"`
+}
+
+// NotificationEdge defines the NotificationEdge type query object
+type NotificationEdge struct {
+	Cursor *string       `json:"cursor"`
+	Node   *Notification `json:"node"`
+}
+
+// ReportConnection defines the ReportConnection type query object
+type ReportConnection struct {
+	Edges      []ReportEdge `json:"edges"`
+	PageInfo   *PageInfo    `json:"pageInfo"`
+	TotalCount *int         `json:"totalCount"`
+}
+
+// ReportEdge defines the ReportEdge type query object
+type ReportEdge struct {
+	Cursor *string `json:"cursor"`
+	Node   *Report `json:"node"`
+}
@@ -0,0 +1,18 @@
+package gqlmod
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+)
+
+// Notification defines the Notification type query object
+type Notification struct {
+	ID       *store.ID          `json:"id"`
+	Creation *time.Time         `json:"creation"`
+	Kind     *notification.Kind `json:"kind"`
+	Read     *bool              `json:"read"`
+	Actor    *User              `json:"actor"`
+	Subject  interface{}        `json:"subject"`
+}
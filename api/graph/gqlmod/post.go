@@ -8,10 +8,14 @@ import (
 
 // Post defines the Post type query object
 type Post struct {
-	ID        *store.ID  `json:"id"`
-	Creation  *time.Time `json:"creation"`
-	Author    *User      `json:"author"`
-	Title     *string    `json:"title"`
-	Contents  *string    `json:"contents"`
-	Reactions []Reaction `json:"reactions"`
+	ID            *store.ID           `json:"id"`
+	Creation      *time.Time          `json:"creation"`
+	LastEdited    *time.Time          `json:"lastEdited"`
+	Author        *User               `json:"author"`
+	Title         *string             `json:"title"`
+	Contents      *string             `json:"contents"`
+	Hidden        *bool               `json:"hidden"`
+	Reactions     *ReactionConnection `json:"reactions"`
+	Revisions     []PostRevision      `json:"revisions"`
+	ReactionStats *ReactionStats      `json:"reactionStats"`
 }
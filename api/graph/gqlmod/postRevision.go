@@ -0,0 +1,16 @@
+package gqlmod
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// PostRevision defines the PostRevision type query object
+type PostRevision struct {
+	ID       *store.ID  `json:"id"`
+	Creation *time.Time `json:"creation"`
+	Editor   *User      `json:"editor"`
+	Title    *string    `json:"title"`
+	Contents *string    `json:"contents"`
+}
@@ -9,11 +9,14 @@ import (
 
 // Reaction defines the Reaction type query object
 type Reaction struct {
-	ID        *store.ID        `json:"id"`
-	Subject   interface{}      `json:"subject"`
-	Creation  *time.Time       `json:"creation"`
-	Author    *User            `json:"author"`
-	Message   *string          `json:"message"`
-	Emotion   *emotion.Emotion `json:"emotion"`
-	Reactions []Reaction       `json:"reactions"`
+	ID            *store.ID           `json:"id"`
+	Subject       interface{}         `json:"subject"`
+	Creation      *time.Time          `json:"creation"`
+	Author        *User               `json:"author"`
+	Message       *string             `json:"message"`
+	Emotion       *emotion.Emotion    `json:"emotion"`
+	Hidden        *bool               `json:"hidden"`
+	Reactions     *ReactionConnection `json:"reactions"`
+	Revisions     []ReactionRevision  `json:"revisions"`
+	ReactionStats *ReactionStats      `json:"reactionStats"`
 }
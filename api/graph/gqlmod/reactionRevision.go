@@ -0,0 +1,15 @@
+package gqlmod
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// ReactionRevision defines the ReactionRevision type query object
+type ReactionRevision struct {
+	ID       *store.ID  `json:"id"`
+	Creation *time.Time `json:"creation"`
+	Editor   *User      `json:"editor"`
+	Message  *string    `json:"message"`
+}
@@ -0,0 +1,15 @@
+package gqlmod
+
+import "github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+
+// ReactionStats defines the ReactionStats type query object
+type ReactionStats struct {
+	Total    *int           `json:"total"`
+	Emotions []EmotionCount `json:"emotions"`
+}
+
+// EmotionCount defines the EmotionCount type query object
+type EmotionCount struct {
+	Emotion *emotion.Emotion `json:"emotion"`
+	Count   *int             `json:"count"`
+}
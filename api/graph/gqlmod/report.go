@@ -0,0 +1,17 @@
+package gqlmod
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// Report defines the Report type query object
+type Report struct {
+	ID       *store.ID   `json:"id"`
+	Creation *time.Time  `json:"creation"`
+	Reporter *User       `json:"reporter"`
+	Subject  interface{} `json:"subject"`
+	Reason   *string     `json:"reason"`
+	Open     *bool       `json:"open"`
+}
@@ -6,7 +6,9 @@ import (
 
 // Session defines the Session type query object
 type Session struct {
-	Key      *string    `json:"key"`
-	Creation *time.Time `json:"creation"`
-	User     *User      `json:"user"`
+	Key        *string    `json:"key"`
+	Creation   *time.Time `json:"creation"`
+	LastAccess *time.Time `json:"lastAccess"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	User       *User      `json:"user"`
 }
@@ -4,15 +4,22 @@ import (
 	"time"
 
 	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 )
 
 // User defines the User type query object
 type User struct {
-	ID                 *store.ID  `json:"id"`
-	Creation           *time.Time `json:"creation"`
-	Email              *string    `json:"email"`
-	DisplayName        *string    `json:"displayName"`
-	Posts              []Post     `json:"posts"`
-	Sessions           []Session  `json:"sessions"`
-	PublishedReactions []Reaction `json:"publishedReactions"`
+	ID                      *store.ID               `json:"id"`
+	Creation                *time.Time              `json:"creation"`
+	Email                   *string                 `json:"email"`
+	EmailVerified           *bool                   `json:"emailVerified"`
+	DisplayName             *string                 `json:"displayName"`
+	Roles                   []role.Role             `json:"roles"`
+	Posts                   *PostConnection         `json:"posts"`
+	Sessions                []Session               `json:"sessions"`
+	PublishedReactions      *ReactionConnection     `json:"publishedReactions"`
+	Followers               *UserConnection         `json:"followers"`
+	Following               *UserConnection         `json:"following"`
+	Notifications           *NotificationConnection `json:"notifications"`
+	UnreadNotificationCount *int                    `json:"unreadNotificationCount"`
 }
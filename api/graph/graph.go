@@ -6,10 +6,12 @@ import (
 	"fmt"
 
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
 
 	"github.com/graph-gophers/graphql-go"
+	"github.com/romshark/dgraph_graphql_go/api/clock"
+	"github.com/romshark/dgraph_graphql_go/api/eventbus"
 	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
-	"github.com/romshark/dgraph_graphql_go/api/graph/resolver"
 	rsv "github.com/romshark/dgraph_graphql_go/api/graph/resolver"
 	"github.com/romshark/dgraph_graphql_go/api/passhash"
 	"github.com/romshark/dgraph_graphql_go/api/sesskeygen"
@@ -18,24 +20,67 @@ import (
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
+// maxParallelism defines the maximum number of fields resolved in parallel
+// per request. Resolvers waiting for a batch of the request loaders occupy
+// a slot each, thus it must allow for a full page of nodes to be batched
+const maxParallelism = 256
+
 // Graph represents the graph resolution engine
 type Graph struct {
 	resolver *rsv.Resolver
 	schema   *graphql.Schema
 	shield   gqlshield.GraphQLShield
+
+	// shieldClientRoles maps the clients to their shield client roles
+	shieldClientRoles auth.GQLShieldClientRoles
+
+	// onUnexpectedErr is called for every unexpected error
+	// before it's masked in the response
+	onUnexpectedErr func(error)
+
+	// persistedQueries holds the documents registered
+	// by automatic persisted query clients
+	persistedQueries *persistedQueries
 }
 
 // Query represents the graph query structure
 type Query struct {
 	Query         []byte
 	OperationName string
-	Variables     map[string]*string
+
+	// Variables are the values of the query variables decoded from JSON
+	Variables map[string]interface{}
+
+	// PersistedQueryHash identifies a persisted query by the SHA-256 hash
+	// of its document, which is either the normalized document
+	// of a whitelisted query or the document as sent by the client.
+	// The query is looked up by the hash if Query is empty,
+	// otherwise the hash must match the query which is then registered
+	// to be looked up by the hash later on
+	PersistedQueryHash string
+
+	// QueryOnly rejects all operations except queries when true
+	QueryOnly bool
+}
+
+// Location represents a location in the query document
+type Location struct {
+	Line   int
+	Column int
 }
 
 // ResponseError represents a response error object
 type ResponseError struct {
 	Code    string
 	Message string
+
+	// Path is the path of the field the error occurred in,
+	// it's nil for errors not related to a particular field
+	Path []interface{}
+
+	// Locations are the locations in the query document
+	// the error refers to
+	Locations []Location
 }
 
 // Error implements the error interface
@@ -43,10 +88,13 @@ func (err *ResponseError) Error() string {
 	return fmt.Sprintf("%s: %s", err.Code, err.Message)
 }
 
-// Response represents a response object
+// Response represents a response object.
+// Data is nil if the request failed before execution,
+// otherwise it contains the partial result of the fields
+// that were resolved successfully
 type Response struct {
-	Data  []byte
-	Error *ResponseError
+	Data   []byte
+	Errors []*ResponseError
 }
 
 // New creates a new graph resolver instance
@@ -55,106 +103,262 @@ func New(
 	validator validator.Validator,
 	sessionKeyGenerator sesskeygen.SessionKeyGenerator,
 	passwordHasher passhash.PasswordHasher,
+	sessionTTL auth.SessionTTL,
+	eventBus eventbus.EventBus,
+	mailer mailer.Mailer,
+	tokenTTL auth.TokenTTL,
+	clock clock.Clock,
 	shield gqlshield.GraphQLShield,
+	shieldClientRoles auth.GQLShieldClientRoles,
+	onUnexpectedErr func(error),
 ) (*Graph, error) {
+	if onUnexpectedErr == nil {
+		return nil, errors.New(
+			"missing unexpected error handler during graph initialization",
+		)
+	}
 	rsv, err := rsv.New(
 		str,
 		validator,
 		sessionKeyGenerator,
 		passwordHasher,
+		sessionTTL,
+		eventBus,
+		mailer,
+		tokenTTL,
+		clock,
+		onUnexpectedErr,
 	)
 	if err != nil {
 		return nil, err
 	}
-	shm := graphql.MustParseSchema(schema, rsv)
+	shm := graphql.MustParseSchema(
+		schema,
+		rsv,
+		graphql.MaxParallelism(maxParallelism),
+	)
 	return &Graph{
-		resolver: rsv,
-		schema:   shm,
-		shield:   shield,
+		resolver:          rsv,
+		schema:            shm,
+		shield:            shield,
+		shieldClientRoles: shieldClientRoles,
+		onUnexpectedErr:   onUnexpectedErr,
+		persistedQueries:  newPersistedQueries(maxPersistedQueries),
 	}, nil
 }
 
-// Query executes a graph query and returns a JSON encoded result (or an error)
-func (graph *Graph) Query(
+// AwaitBackgroundTasks blocks until all background tasks
+// such as the delivery of password reset mails are done
+func (graph *Graph) AwaitBackgroundTasks() {
+	graph.resolver.AwaitBackgroundTasks()
+}
+
+// prepare checks the query against the shield, validates it
+// and returns the query string with the arguments ready for execution
+func (graph *Graph) prepare(
 	ctx context.Context,
 	query Query,
-) ([]byte, error) {
-	clientRole := auth.GQLShieldClientRegular
-
-	// Try to read the shield client role identifier from session
-	if session, isSession := ctx.Value(
+) (
+	queryStr string,
+	args map[string]interface{},
+	opType operationType,
+	err error,
+) {
+	// Try to read the session, the client is a guest if there's none
+	session, isSession := ctx.Value(
 		auth.CtxSession,
-	).(*auth.RequestSession); isSession {
-		clientRole = session.ShieldClientRole
+	).(*auth.RequestSession)
+
+	clientRoles := graph.shieldClientRoles.Resolve(session)
+	if len(clientRoles) < 1 {
+		err = strerr.New(
+			strerr.ErrUnauthorized,
+			"no shield client role assigned",
+		)
+		return
 	}
 
-	// Ensure the query is whitelisted for this client
-	// and the arguments are valid
-	queryString := []byte(query.Query)
-	var err error
-	queryString, err = graph.shield.Check(
-		int(clientRole),
-		queryString,
-		query.Variables,
-	)
+	// Look up the documents registered by automatic persisted query clients,
+	// whitelisted queries are looked up by the shield
+	register := false
+	if query.PersistedQueryHash != "" {
+		if len(query.Query) > 0 {
+			register = true
+		} else if doc, found := graph.persistedQueries.get(
+			query.PersistedQueryHash,
+		); found {
+			query.Query = doc
+		}
+	}
+
+	// Ensure the query is whitelisted for any of the client roles
+	// and the arguments are valid, the most privileged role is tried first
+	var queryString []byte
+	for _, clientRole := range clientRoles {
+		queryString, err = graph.checkShield(int(clientRole), query)
+		if gqlshield.ErrCode(err) != gqlshield.ErrUnauthorized {
+			break
+		}
+	}
+	if err == nil &&
+		register &&
+		!matchesPersistedQueryHash(
+			query.PersistedQueryHash,
+			query.Query,
+			queryString,
+		) {
+		err = strerr.New(
+			strerr.ErrInvalidInput,
+			"persisted query hash mismatch",
+		)
+		return
+	}
 	if err != nil {
 		switch gqlshield.ErrCode(err) {
+		case gqlshield.ErrPersistedQueryNotFound:
+			err = strerr.New(
+				strerr.ErrPersistedQueryNotFound,
+				err.(gqlshield.Error).Message,
+			)
 		case gqlshield.ErrWrongInput:
-			return nil, strerr.New(
+			err = strerr.New(
 				strerr.ErrUnauthorized,
 				err.Error(),
 			)
 		case gqlshield.ErrUnauthorized:
-			return nil, strerr.New(
+			err = strerr.New(
 				strerr.ErrUnauthorized,
 				"query blacklisted for this client",
 			)
-		default:
-			// Unexpected error
-			return nil, err
 		}
+		return
 	}
 
-	args := make(map[string]interface{}, len(query.Variables))
+	// Copy the variables since the execution adds default values
+	args = make(map[string]interface{}, len(query.Variables))
 	for name, val := range query.Variables {
-		if val != nil {
-			args[name] = *val
-		} else {
-			args[name] = nil
-		}
+		args[name] = val
 	}
 
 	// Validate query
-	queryStr := string(queryString)
+	queryStr = string(queryString)
 	if errs := graph.schema.Validate(queryStr); errs != nil {
-		return nil, GQLError{errs: errs}
+		err = GQLError{errs: errs}
+		return
+	}
+
+	opType = findOperationType(queryStr, query.OperationName)
+	if query.QueryOnly && opType != operationQuery {
+		err = strerr.New(
+			strerr.ErrInvalidInput,
+			"only query operations are permitted",
+		)
+		return
 	}
 
-	var resolverErr error
+	// Reject mutations issued by read-only debug clients
+	if isSession &&
+		session.IsDebug &&
+		session.DebugMode != auth.DebugModeReadWrite {
+		switch opType {
+		case operationQuery, operationSubscription:
+		default:
+			err = strerr.New(
+				strerr.ErrUnauthorized,
+				"the debug user is in read-only mode",
+			)
+			return
+		}
+	}
+
+	if register {
+		graph.persistedQueries.register(query.PersistedQueryHash, query.Query)
+	}
+	return
+}
+
+// checkShield checks the query against the shield on behalf of the client role
+// and returns the whitelisted query string
+func (graph *Graph) checkShield(
+	clientRole int,
+	query Query,
+) ([]byte, error) {
+	if len(query.Query) < 1 && query.PersistedQueryHash != "" {
+		// Look up the persisted query
+		return graph.shield.CheckPersisted(
+			clientRole,
+			query.PersistedQueryHash,
+			query.Variables,
+		)
+	}
+	// Copy the query string since it's mutated during normalization
+	// and may need to be checked again for another client role
+	return graph.shield.Check(
+		clientRole,
+		append([]byte(nil), query.Query...),
+		query.Variables,
+	)
+}
+
+// Query executes a graph query. Errors of individual fields are reported
+// alongside the partial data of the fields that were resolved successfully
+func (graph *Graph) Query(ctx context.Context, query Query) Response {
+	queryStr, args, opType, err := graph.prepare(ctx, query)
+	if err != nil {
+		return Response{Errors: graph.requestErrors(err)}
+	}
+
+	if opType == operationSubscription {
+		return Response{Errors: graph.requestErrors(strerr.New(
+			strerr.ErrInvalidInput,
+			"subscriptions are only supported over WebSocket",
+		))}
+	}
 
 	// Execute query
-	rep := graph.schema.Exec(
-		context.WithValue(
-			ctx,
-			resolver.CtxErrorRef,
-			&resolverErr,
-		),
+	return graph.response(graph.schema.Exec(
+		graph.resolver.WithLoaders(ctx),
 		queryStr,
 		query.OperationName,
 		args,
-	)
+	))
+}
 
-	if resolverErr != nil {
-		return nil, resolverErr
+// Subscribe executes a graph subscription and returns a channel
+// of results which is closed when either the subscription
+// ends or the context is canceled. The returned errors are not nil
+// if the subscription was rejected
+func (graph *Graph) Subscribe(
+	ctx context.Context,
+	query Query,
+) (<-chan Response, []*ResponseError) {
+	queryStr, args, _, err := graph.prepare(ctx, query)
+	if err != nil {
+		return nil, graph.requestErrors(err)
 	}
 
-	if rep.Errors != nil {
-		err := GQLError{errs: rep.Errors}
-		// Return an untyped error to prevent it from leaking to the client
-		// because GQLError instances are served as error feedback to clients
-		// but unexpected errors should not be leaked!
-		return nil, errors.New(err.Error())
+	responses, err := graph.schema.Subscribe(
+		ctx,
+		queryStr,
+		query.OperationName,
+		args,
+	)
+	if err != nil {
+		return nil, graph.requestErrors(err)
 	}
 
-	return rep.Data, nil
+	stream := make(chan Response)
+	go func() {
+		defer close(stream)
+		for rep := range responses {
+			response := graph.response(rep.(*graphql.Response))
+			// Keep draining the responses after the context is canceled
+			// until the subscription is closed by the schema
+			select {
+			case stream <- response:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return stream, nil
 }
@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoaderCacheWrites tests whether the fields resolved after a write
+// don't receive the values loaded before the write within the same request
+func TestLoaderCacheWrites(t *testing.T) {
+	graph, str, _ := newTestGraph(t, testSetup{})
+
+	ctx := context.Background()
+	now := time.Now()
+	author, err := str.CreateUser(ctx, now, "1@test.test", "author", "pass")
+	require.NoError(t, err)
+	post, err := str.CreatePost(ctx, now, author.ID, "title A", "contents")
+	require.NoError(t, err)
+
+	response := graph.Query(context.WithValue(
+		ctx,
+		auth.CtxSession,
+		&auth.RequestSession{IsDebug: true, DebugMode: auth.DebugModeReadWrite},
+	), Query{
+		Query: []byte(`mutation($post: Identifier!, $author: Identifier!) {
+			first: editPost(post: $post, editor: $author, newTitle: "title B") {
+				revisions { title }
+			}
+			second: editPost(post: $post, editor: $author, newTitle: "title C") {
+				revisions { title }
+			}
+		}`),
+		Variables: map[string]interface{}{
+			"post":   string(post.ID),
+			"author": string(author.ID),
+		},
+	})
+	require.Len(t, response.Errors, 0)
+
+	type revisions struct {
+		Revisions []struct {
+			Title string `json:"title"`
+		} `json:"revisions"`
+	}
+	var data struct {
+		First  revisions `json:"first"`
+		Second revisions `json:"second"`
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &data))
+	require.Len(t, data.First.Revisions, 1)
+	require.Len(t, data.Second.Revisions, 2)
+	require.Equal(t, "title B", data.Second.Revisions[1].Title)
+}
@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
+	"github.com/stretchr/testify/require"
+)
+
+// failingMailer fails to deliver any mail
+type failingMailer struct{}
+
+func (failingMailer) Send(mailer.Mail) error {
+	return errors.New("mail server unreachable")
+}
+
+// TestMailFailure tests whether users are created and edited
+// and password resets are requested even if the mails can't be delivered
+func TestMailFailure(t *testing.T) {
+	var unexpectedErrs []error
+	graph, str, _ := newTestGraph(t, testSetup{
+		mailer: failingMailer{},
+		onUnexpectedErr: func(err error) {
+			unexpectedErrs = append(unexpectedErrs, err)
+		},
+	})
+
+	ctx := context.WithValue(
+		context.Background(),
+		auth.CtxSession,
+		&auth.RequestSession{IsDebug: true, DebugMode: auth.DebugModeReadWrite},
+	)
+	query := func(query string, vars map[string]interface{}) {
+		response := graph.Query(ctx, Query{
+			Query:     []byte(query),
+			Variables: vars,
+		})
+		require.Len(t, response.Errors, 0)
+	}
+
+	query(`mutation {
+		createUser(
+			email: "1@test.test"
+			displayName: "user"
+			password: "testpass"
+		) { id }
+	}`, nil)
+	require.Len(t, unexpectedErrs, 1)
+
+	usr, err := str.CreateSession(
+		context.Background(),
+		"key",
+		time.Now(),
+		"1@test.test",
+		"testpass",
+	)
+	require.NoError(t, err)
+
+	query(`mutation($id: Identifier!) {
+		editUser(user: $id, editor: $id, newEmail: "2@test.test") { id }
+	}`, map[string]interface{}{"id": string(usr.User.ID)})
+	require.Len(t, unexpectedErrs, 2)
+
+	// The password reset request succeeds regardless of the mail delivery
+	// and regardless of whether the email is registered
+	query(`mutation {
+		requestPasswordReset(email: "2@test.test")
+	}`, nil)
+	query(`mutation {
+		requestPasswordReset(email: "unknown@test.test")
+	}`, nil)
+	graph.AwaitBackgroundTasks()
+	require.Len(t, unexpectedErrs, 3)
+}
@@ -0,0 +1,104 @@
+package graph
+
+import "strings"
+
+// operationType represents the type of a GraphQL operation
+type operationType string
+
+const (
+	operationQuery        operationType = "query"
+	operationMutation     operationType = "mutation"
+	operationSubscription operationType = "subscription"
+)
+
+// findOperationType returns the type of the operation
+// that's going to be executed in the given (validated) query document.
+// Returns an empty string if the operation wasn't found
+func findOperationType(
+	document string,
+	operationName string,
+) operationType {
+	tokens := topLevelTokens(document)
+	var found []operationType
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] == "{" {
+			// Anonymous query shorthand
+			if operationName == "" {
+				found = append(found, operationQuery)
+			}
+			continue
+		}
+
+		// Read the definition header up until its selection set
+		kind, name := tokens[i], ""
+		if i+1 < len(tokens) && tokens[i+1] != "{" {
+			name = tokens[i+1]
+		}
+		for i < len(tokens) && tokens[i] != "{" {
+			i++
+		}
+
+		switch operationType(kind) {
+		case operationQuery, operationMutation, operationSubscription:
+			if operationName == "" || operationName == name {
+				found = append(found, operationType(kind))
+			}
+		}
+	}
+	if len(found) != 1 {
+		return ""
+	}
+	return found[0]
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
+
+// topLevelTokens returns the names and opening braces found
+// at the top level of the given query document skipping comments,
+// strings and everything enclosed in braces or parentheses
+func topLevelTokens(document string) (tokens []string) {
+	depth := 0
+	for i := 0; i < len(document); i++ {
+		c := document[i]
+		switch {
+		case c == '#':
+			// Skip comment
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(document[i:], `"""`):
+			// Skip block string
+			end := strings.Index(document[i+3:], `"""`)
+			if end < 0 {
+				return
+			}
+			i += 3 + end + 2
+		case c == '"':
+			// Skip string
+			for i++; i < len(document) && document[i] != '"'; i++ {
+				if document[i] == '\\' {
+					i++
+				}
+			}
+		case c == '{' || c == '(':
+			if depth == 0 && c == '{' {
+				tokens = append(tokens, "{")
+			}
+			depth++
+		case c == '}' || c == ')':
+			depth--
+		case depth == 0 && isNameChar(c):
+			start := i
+			for i+1 < len(document) && isNameChar(document[i+1]) {
+				i++
+			}
+			tokens = append(tokens, document[start:i+1])
+		}
+	}
+	return
+}
@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFindOperationType tests findOperationType
+func TestFindOperationType(t *testing.T) {
+	cases := []struct {
+		document      string
+		operationName string
+		expected      operationType
+	}{
+		{`{ users { id } }`, "", operationQuery},
+		{`query { users { id } }`, "", operationQuery},
+		{`query Q($a: String = "}") { users { id } }`, "", operationQuery},
+		{`mutation { createUser(a: "{") { id } }`, "", operationMutation},
+		{`subscription S { postCreated { id } }`, "S", operationSubscription},
+		{
+			`# mutation
+			fragment F on User { id }
+			query Q { users { ...F } }`,
+			"",
+			operationQuery,
+		},
+		{
+			`"""mutation { }"""
+			query Q { users { id } }
+			mutation M { createUser { id } }`,
+			"M",
+			operationMutation,
+		},
+		// Ambiguous
+		{
+			`query Q { users { id } } mutation M { createUser { id } }`,
+			"",
+			"",
+		},
+		// Inexistent
+		{`query Q { users { id } }`, "M", ""},
+	}
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			require.Equal(
+				t,
+				c.expected,
+				findOperationType(c.document, c.operationName),
+			)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/api/passhash"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// unreliableStore fails to upgrade password hashes while failing is set
+type unreliableStore struct {
+	store.Store
+	failing bool
+}
+
+func (str *unreliableStore) UpgradePasswordHash(
+	ctx context.Context,
+	user store.ID,
+	oldPasswordHash string,
+	newPasswordHash string,
+) (bool, error) {
+	if str.failing {
+		return false, errors.New("store unavailable")
+	}
+	return str.Store.UpgradePasswordHash(
+		ctx,
+		user,
+		oldPasswordHash,
+		newPasswordHash,
+	)
+}
+
+// TestPasswordRehash tests whether password hashes produced by an older
+// algorithm are upgraded when the user signs in
+func TestPasswordRehash(t *testing.T) {
+	hasher := passhash.Argon2id{Memory: 1024, Time: 1, Parallelism: 1}
+
+	var str *unreliableStore
+	var unexpectedErrs []error
+	graph, _, _ := newTestGraph(t, testSetup{
+		wrapStore: func(memStore store.Store) store.Store {
+			str = &unreliableStore{Store: memStore}
+			return str
+		},
+		passwordHasher: hasher,
+		onUnexpectedErr: func(err error) {
+			unexpectedErrs = append(unexpectedErrs, err)
+		},
+	})
+
+	// Create a user with a bcrypt password hash
+	ctx := context.Background()
+	email, password := "1@test.test", "testpass"
+	oldHash, err := passhash.Bcrypt{Cost: bcrypt.MinCost}.Hash(
+		[]byte(password),
+	)
+	require.NoError(t, err)
+	_, err = str.CreateUser(ctx, time.Now(), email, "user", string(oldHash))
+	require.NoError(t, err)
+
+	// storedHash returns the password hash stored for the user
+	storedHash := func() string {
+		sess, err := str.CreateSession(ctx, "key", time.Now(), email, password)
+		require.NoError(t, err)
+		return sess.User.Password
+	}
+	signIn := func() {
+		response := graph.Query(
+			context.WithValue(ctx, auth.CtxSession, &auth.RequestSession{}),
+			Query{
+				Query: []byte(`mutation($email: String!, $password: String!) {
+					createSession(email: $email, password: $password) { key }
+				}`),
+				Variables: map[string]interface{}{
+					"email":    email,
+					"password": password,
+				},
+			},
+		)
+		require.Len(t, response.Errors, 0)
+	}
+
+	// Failing upgrades don't fail the sign-in
+	str.failing = true
+	signIn()
+	require.Len(t, unexpectedErrs, 1)
+	require.Equal(t, string(oldHash), storedHash())
+
+	str.failing = false
+	signIn()
+	upgradedHash := storedHash()
+	require.True(t, strings.HasPrefix(upgradedHash, "$argon2id$"))
+	require.False(t, hasher.NeedsRehash([]byte(upgradedHash)))
+
+	// Up-to-date hashes aren't replaced
+	signIn()
+	require.Equal(t, upgradedHash, storedHash())
+	require.Len(t, unexpectedErrs, 1)
+}
@@ -0,0 +1,50 @@
+package graph
+
+import "sync"
+
+// maxPersistedQueries defines the maximum number of query documents
+// registered by automatic persisted query clients kept at the same time
+const maxPersistedQueries = 1024
+
+// persistedQueries caches the query documents registered by automatic
+// persisted query clients by their hash. The oldest documents are evicted
+// when the limit is reached. Registered documents are still checked
+// against the shield whenever they're executed
+type persistedQueries struct {
+	lock  *sync.RWMutex
+	limit int
+	docs  map[string][]byte
+	order []string
+}
+
+func newPersistedQueries(limit int) *persistedQueries {
+	return &persistedQueries{
+		lock:  &sync.RWMutex{},
+		limit: limit,
+		docs:  make(map[string][]byte, limit),
+		order: make([]string, 0, limit),
+	}
+}
+
+// get returns the query document identified by the given hash
+func (pq *persistedQueries) get(hash string) ([]byte, bool) {
+	pq.lock.RLock()
+	defer pq.lock.RUnlock()
+	doc, found := pq.docs[hash]
+	return doc, found
+}
+
+// register registers the query document by the given hash
+func (pq *persistedQueries) register(hash string, doc []byte) {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+	if _, registered := pq.docs[hash]; registered {
+		return
+	}
+	if len(pq.order) >= pq.limit {
+		delete(pq.docs, pq.order[0])
+		pq.order = pq.order[1:]
+	}
+	pq.docs[hash] = append([]byte(nil), doc...)
+	pq.order = append(pq.order, hash)
+}
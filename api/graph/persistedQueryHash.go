@@ -0,0 +1,38 @@
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+)
+
+// persistedQueryHash returns the hex encoded SHA-256 hash of the query
+// document the way persisted query clients (such as Apollo) compute it.
+// The query string is received JSON-escaped, thus the hash is computed
+// over the unescaped document
+func persistedQueryHash(query []byte) (string, error) {
+	quoted := make([]byte, 0, len(query)+2)
+	quoted = append(quoted, '"')
+	quoted = append(quoted, query...)
+	quoted = append(quoted, '"')
+
+	var document string
+	if err := json.Unmarshal(quoted, &document); err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(document))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// matchesPersistedQueryHash returns true if the hash identifies either
+// the query document as sent by the client or its normalized version
+// which whitelisted queries are identified by
+func matchesPersistedQueryHash(hash string, query, normalized []byte) bool {
+	if hash == gqlshield.HashQuery(normalized) {
+		return true
+	}
+	documentHash, err := persistedQueryHash(query)
+	return err == nil && hash == documentHash
+}
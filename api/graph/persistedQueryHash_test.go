@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPersistedQueryHash tests whether persistedQueryHash matches the hashes
+// computed by Apollo clients over the query document they send
+func TestPersistedQueryHash(t *testing.T) {
+	document := "query User($id: Identifier!) {\n" +
+		"  user(id: $id) {\n" +
+		"    displayName\n" +
+		"    __typename\n" +
+		"  }\n" +
+		"}\n"
+
+	// Apollo clients hash the document as it's sent
+	apolloHash := sha256.Sum256([]byte(document))
+
+	// The transports pass the query as it's encoded in the JSON request
+	encoded, err := json.Marshal(document)
+	require.NoError(t, err)
+	query := encoded[1 : len(encoded)-1]
+
+	hash, err := persistedQueryHash(query)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(apolloHash[:]), hash)
+
+	// Malformed escape sequences
+	_, err = persistedQueryHash([]byte(`query { \x }`))
+	require.Error(t, err)
+}
+
+// TestPersistedQueries tests registering documents and evicting
+// the oldest ones when the limit is reached
+func TestPersistedQueries(t *testing.T) {
+	pq := newPersistedQueries(2)
+	pq.register("a", []byte("{ a }"))
+	pq.register("b", []byte("{ b }"))
+	pq.register("a", []byte("{ a }"))
+
+	doc, found := pq.get("a")
+	require.True(t, found)
+	require.Equal(t, "{ a }", string(doc))
+
+	pq.register("c", []byte("{ c }"))
+	_, found = pq.get("a")
+	require.False(t, found)
+	for _, hash := range []string{"b", "c"} {
+		_, found = pq.get(hash)
+		require.True(t, found)
+	}
+}
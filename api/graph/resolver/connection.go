@@ -0,0 +1,296 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+const (
+	// defaultPageSize defines the number of nodes per page
+	// when neither first nor last is specified
+	defaultPageSize = 20
+
+	// maxPageSize defines the maximum number of nodes per page
+	maxPageSize = 100
+)
+
+// ConnectionParams represents the Relay connection arguments
+type ConnectionParams struct {
+	First  *int32
+	After  *Cursor
+	Last   *int32
+	Before *Cursor
+}
+
+// list describes a list of graph nodes ordered by uid
+type list struct {
+	// rootFunc selects the nodes of root lists (such as has(Post.id))
+	rootFunc string
+
+	// nodeUID and edge select the nodes of nested lists
+	nodeUID string
+	edge    string
+
+	// filter optionally excludes nodes from the list
+	filter string
+}
+
+// page represents a resolved connection page
+type page struct {
+	uids            []string
+	totalCount      int
+	hasNextPage     bool
+	hasPreviousPage bool
+}
+
+// pageArgs represents validated connection arguments
+type pageArgs struct {
+	first  int
+	after  string
+	last   int
+	before string
+}
+
+func (params ConnectionParams) validate() (args pageArgs, err error) {
+	args.first, args.last = -1, -1
+	if params.First != nil {
+		if *params.First < 0 || *params.First > maxPageSize {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"first must be between 0 and %d",
+				maxPageSize,
+			)
+			return
+		}
+		args.first = int(*params.First)
+	}
+	if params.Last != nil {
+		if *params.Last < 0 || *params.Last > maxPageSize {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"last must be between 0 and %d",
+				maxPageSize,
+			)
+			return
+		}
+		args.last = int(*params.Last)
+	}
+	if params.First == nil && params.Last == nil {
+		args.first = defaultPageSize
+	}
+	if params.After != nil {
+		if args.after, err = params.After.uid(); err != nil {
+			err = strerr.Wrap(strerr.ErrInvalidInput, err)
+			return
+		}
+	}
+	if params.Before != nil {
+		if args.before, err = params.Before.uid(); err != nil {
+			err = strerr.Wrap(strerr.ErrInvalidInput, err)
+			return
+		}
+	}
+	return
+}
+
+// isBackward returns true if the page is selected by last only
+func (args pageArgs) isBackward() bool {
+	return args.last >= 0 && args.first < 0
+}
+
+// dbArgs returns the pagination arguments of the database query.
+// An additional node is requested to find out whether there's
+// a next page (or a previous page when paginating backward)
+func (args pageArgs) dbArgs() []string {
+	dbArgs := []string{fmt.Sprintf("first: %d", args.first+1)}
+	if args.isBackward() {
+		// Negative values select the last nodes of the list
+		dbArgs[0] = fmt.Sprintf("first: %d", -(args.last + 1))
+	}
+	if args.after != "" {
+		dbArgs = append(dbArgs, "after: "+args.after)
+	}
+	return dbArgs
+}
+
+// beforeVar defines the name of the uid variable holding
+// the nodes following the before cursor
+const beforeVar = "following"
+
+// beforeFilter returns the filter excluding the before cursor
+// and the nodes following it held by the beforeVar uid variable,
+// returns an empty string if there's no before cursor
+func beforeFilter(before string) string {
+	if before == "" {
+		return ""
+	}
+	return fmt.Sprintf("not uid(%s) and not uid(%s)", beforeVar, before)
+}
+
+func parseUID(uid string) uint64 {
+	v, _ := strconv.ParseUint(strings.TrimPrefix(uid, "0x"), 16, 64)
+	return v
+}
+
+// page resolves the node identifiers of the requested page of the list
+func (rsv *Resolver) page(
+	ctx context.Context,
+	lst list,
+	params ConnectionParams,
+) (pg page, err error) {
+	args, err := params.validate()
+	if err != nil {
+		return
+	}
+
+	var uids []dgraph.UID
+	if lst.rootFunc != "" {
+		var qr struct {
+			Page  []dgraph.UID `json:"page"`
+			Total []struct {
+				Count int `json:"count"`
+			} `json:"total"`
+		}
+		beforeBlock := ""
+		if args.before != "" {
+			beforeBlock = fmt.Sprintf(
+				"%s as var(func: %s, after: %s)",
+				beforeVar,
+				lst.rootFunc,
+				args.before,
+			)
+		}
+		filter := filterDirective(lst.filter)
+		if err = rsv.str.Query(
+			ctx,
+			fmt.Sprintf(
+				`{
+					%s
+					page(func: %s, %s) %s { uid }
+					total(func: %s) %s { count(uid) }
+				}`,
+				beforeBlock,
+				lst.rootFunc,
+				strings.Join(args.dbArgs(), ", "),
+				filterDirective(lst.filter, beforeFilter(args.before)),
+				lst.rootFunc,
+				filter,
+			),
+			&qr,
+		); err != nil {
+			return
+		}
+		uids = qr.Page
+		if len(qr.Total) > 0 {
+			pg.totalCount = qr.Total[0].Count
+		}
+	} else {
+		var value interface{}
+		value, err = rsv.loaders(ctx).page(
+			rsv,
+			lst.edge,
+			"("+strings.Join(args.dbArgs(), ", ")+")",
+			lst.filter,
+			args.before,
+		).load(
+			ctx,
+			lst.nodeUID,
+		)
+		if err != nil {
+			return
+		}
+		if value != nil {
+			nested := value.(*nodePage)
+			uids = nested.uids
+			pg.totalCount = nested.total
+		}
+	}
+
+	pg.uids = make([]string, len(uids))
+	for i, uid := range uids {
+		pg.uids[i] = uid.NodeID
+	}
+
+	// The nodes following the before cursor are only known to exist
+	// when the cursor is given, likewise for the after cursor
+	pg.hasPreviousPage = args.after != ""
+	pg.hasNextPage = args.before != ""
+	if args.isBackward() {
+		if len(pg.uids) > args.last {
+			pg.hasPreviousPage = true
+			pg.uids = pg.uids[len(pg.uids)-args.last:]
+		}
+		return
+	}
+	if len(pg.uids) > args.first {
+		pg.hasNextPage = true
+		pg.uids = pg.uids[:args.first]
+	}
+
+	// Combining first and last is discouraged but permitted,
+	// last then selects the last nodes of the first ones
+	if args.last >= 0 && len(pg.uids) > args.last {
+		pg.hasPreviousPage = true
+		pg.uids = pg.uids[len(pg.uids)-args.last:]
+	}
+	return
+}
+
+// filterDirective returns the @filter directive
+// matching all of the given non-empty filters,
+// returns an empty string if there are none
+func filterDirective(filters ...string) string {
+	nonEmpty := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		if filter != "" {
+			nonEmpty = append(nonEmpty, filter)
+		}
+	}
+	if len(nonEmpty) < 1 {
+		return ""
+	}
+	return "@filter(" + strings.Join(nonEmpty, " and ") + ")"
+}
+
+// uidList returns the page node identifiers as a uid function argument list
+func (pg page) uidList() string {
+	return strings.Join(pg.uids, ", ")
+}
+
+// PageInfo represents the resolver of the identically named type
+type PageInfo struct {
+	page page
+}
+
+// HasNextPage resolves PageInfo.hasNextPage
+func (rsv *PageInfo) HasNextPage() bool {
+	return rsv.page.hasNextPage
+}
+
+// HasPreviousPage resolves PageInfo.hasPreviousPage
+func (rsv *PageInfo) HasPreviousPage() bool {
+	return rsv.page.hasPreviousPage
+}
+
+// StartCursor resolves PageInfo.startCursor
+func (rsv *PageInfo) StartCursor() *Cursor {
+	if len(rsv.page.uids) < 1 {
+		return nil
+	}
+	cursor := newCursor(rsv.page.uids[0])
+	return &cursor
+}
+
+// EndCursor resolves PageInfo.endCursor
+func (rsv *PageInfo) EndCursor() *Cursor {
+	if len(rsv.page.uids) < 1 {
+		return nil
+	}
+	cursor := newCursor(rsv.page.uids[len(rsv.page.uids)-1])
+	return &cursor
+}
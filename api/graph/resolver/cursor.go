@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const cursorPrefix = "cursor:"
+
+var cursorUIDPattern = regexp.MustCompile(`^0x[0-9a-f]+$`)
+
+// Cursor represents an opaque Relay connection cursor
+type Cursor string
+
+// newCursor creates a new cursor pointing to the given graph node
+func newCursor(uid string) Cursor {
+	return Cursor(base64.RawURLEncoding.EncodeToString(
+		[]byte(cursorPrefix + uid),
+	))
+}
+
+// uid returns the graph node identifier the cursor points to
+func (c Cursor) uid() (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return "", errors.New("invalid cursor")
+	}
+	uid := string(decoded[len(cursorPrefix):])
+	if !cursorUIDPattern.MatchString(uid) {
+		return "", errors.New("invalid cursor")
+	}
+	return uid, nil
+}
+
+// ImplementsGraphQLType implements the GraphQL scalar type interface
+func (c Cursor) ImplementsGraphQLType(name string) bool {
+	return name == "Cursor"
+}
+
+// UnmarshalGraphQL implements the GraphQL scalar type interface
+func (c *Cursor) UnmarshalGraphQL(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return errors.New("wrong type, cursor string expected")
+	}
+	if _, err := Cursor(str).uid(); err != nil {
+		return err
+	}
+	*c = Cursor(str)
+	return nil
+}
@@ -0,0 +1,154 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// Feed resolves Query.feed
+func (rsv *Resolver) Feed(
+	ctx context.Context,
+	params struct {
+		First *int32
+		After *Cursor
+	},
+) (*PostConnection, error) {
+	if err := auth.Authorize(ctx, auth.IsUser{}); err != nil {
+		return nil, err
+	}
+	args, err := ConnectionParams{
+		First: params.First,
+		After: params.After,
+	}.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var client string
+	if session, isSession := ctx.Value(
+		auth.CtxSession,
+	).(*auth.RequestSession); isSession {
+		client = string(session.UserID)
+	}
+
+	// Posts are ordered by creation time and by ascending uid on equal
+	// creation times. Each followed user contributes the posts following
+	// the cursor to the candidates: the posts created before the post
+	// the cursor points to and those created at the same time
+	// with a greater uid, both limited to a page each
+	varDefs := "$id: string"
+	vars := map[string]string{"$id": client}
+	visible := visibilityFilter(ctx, "Post.hidden")
+	olderFilter, tiesSelection := filterDirective(visible), ""
+	var after time.Time
+	if args.after != "" {
+		var cursorPost struct {
+			Posts []dgraph.Post `json:"posts"`
+		}
+		if err := rsv.str.Query(
+			ctx,
+			fmt.Sprintf(
+				`{ posts(func: uid(%s)) @filter(has(Post.id)) { Post.creation } }`,
+				args.after,
+			),
+			&cursorPost,
+		); err != nil {
+			return nil, err
+		}
+		if len(cursorPost.Posts) < 1 {
+			return nil, strerr.New(
+				strerr.ErrInvalidInput,
+				"cursor doesn't point to a post",
+			)
+		}
+		after = cursorPost.Posts[0].Creation
+		varDefs += ", $after: string"
+		vars["$after"] = after.Format(time.RFC3339Nano)
+		olderFilter = filterDirective("lt(Post.creation, $after)", visible)
+		tiesSelection = fmt.Sprintf(
+			`ties: User.posts (
+				after: %s,
+				first: %d
+			) %s {
+				uid
+				Post.creation
+			}`,
+			args.after,
+			args.first+1,
+			filterDirective("eq(Post.creation, $after)", visible),
+		)
+	}
+
+	var qr struct {
+		Feed []struct {
+			Following []struct {
+				Total int           `json:"total"`
+				Older []dgraph.Post `json:"older"`
+				Ties  []dgraph.Post `json:"ties"`
+			} `json:"User.following"`
+		} `json:"feed"`
+	}
+	if err := rsv.str.QueryVars(
+		ctx,
+		fmt.Sprintf(
+			`query Feed(%s) {
+				feed(func: eq(User.id, $id)) {
+					User.following {
+						total: count(User.posts %s)
+						older: User.posts (
+							orderdesc: Post.creation,
+							first: %d
+						) %s {
+							uid
+							Post.creation
+						}
+						%s
+					}
+				}
+			}`,
+			varDefs,
+			filterDirective(visible),
+			args.first+1,
+			olderFilter,
+			tiesSelection,
+		),
+		vars,
+		&qr,
+	); err != nil {
+		return nil, err
+	}
+
+	// Merge the candidates of all followed users
+	pg := page{hasPreviousPage: args.after != ""}
+	var candidates []dgraph.Post
+	if len(qr.Feed) > 0 {
+		for _, followee := range qr.Feed[0].Following {
+			pg.totalCount += followee.Total
+			candidates = append(candidates, followee.Older...)
+			candidates = append(candidates, followee.Ties...)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i].Creation, candidates[j].Creation
+		if !ci.Equal(cj) {
+			return ci.After(cj)
+		}
+		return parseUID(candidates[i].UID) < parseUID(candidates[j].UID)
+	})
+
+	if len(candidates) > args.first {
+		candidates = candidates[:args.first]
+		pg.hasNextPage = true
+	}
+	pg.uids = make([]string, len(candidates))
+	for i, post := range candidates {
+		pg.uids[i] = post.UID
+	}
+	return rsv.postConnectionPage(ctx, pg)
+}
@@ -0,0 +1,170 @@
+package resolver
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// loaderWait defines the period a loader waits for further keys
+	// before it fetches the collected keys in a single batch.
+	// It only needs to cover the time the concurrently executed resolvers
+	// take to request their keys, longer periods only add latency
+	// without reducing the number of fetches (see BenchmarkLoader)
+	loaderWait = 250 * time.Microsecond
+
+	// loaderMaxWait defines the maximum period a loader collects keys for
+	loaderMaxWait = 5 * time.Millisecond
+
+	// loaderMaxBatch defines the maximum number of keys per batch,
+	// a full batch is fetched immediately
+	loaderMaxBatch = maxPageSize
+)
+
+// fetchFunc fetches the values of the given keys.
+// Keys missing in the returned map resolve to nil
+type fetchFunc func(
+	ctx context.Context,
+	keys []string,
+) (map[string]interface{}, error)
+
+// loaderEntry represents a cached value
+// which is resolved when done is closed
+type loaderEntry struct {
+	key   string
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// loaderBatch represents a batch of keys waiting to be fetched
+type loaderBatch struct {
+	entries []*loaderEntry
+	timer   *time.Timer
+	start   time.Time
+}
+
+// loader coalesces the keys requested during a short period of time
+// into a single batch and caches the fetched values.
+// A loader is scoped to a single request and must not be shared
+// across requests, its cache is cleared after writes only
+type loader struct {
+	fetch fetchFunc
+	wait  time.Duration
+	lock  sync.Mutex
+	cache map[string]*loaderEntry
+	batch *loaderBatch
+}
+
+func newLoader(fetch fetchFunc) *loader {
+	return &loader{
+		fetch: fetch,
+		wait:  loaderWait,
+		cache: make(map[string]*loaderEntry),
+	}
+}
+
+// load returns the value of the given key
+func (ldr *loader) load(ctx context.Context, key string) (interface{}, error) {
+	values, err := ldr.loadAll(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// loadAll returns the values of the given keys in the order of the keys
+func (ldr *loader) loadAll(
+	ctx context.Context,
+	keys []string,
+) ([]interface{}, error) {
+	entries := make([]*loaderEntry, len(keys))
+
+	ldr.lock.Lock()
+	for i, key := range keys {
+		entry, cached := ldr.cache[key]
+		if !cached {
+			entry = &loaderEntry{key: key, done: make(chan struct{})}
+			ldr.cache[key] = entry
+			ldr.enqueue(ctx, entry)
+		}
+		entries[i] = entry
+	}
+	ldr.lock.Unlock()
+
+	values := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		values[i] = entry.value
+	}
+	return values, nil
+}
+
+// clear drops the cached values
+// making subsequent loads fetch the keys again
+func (ldr *loader) clear() {
+	ldr.lock.Lock()
+	ldr.cache = make(map[string]*loaderEntry)
+	ldr.lock.Unlock()
+}
+
+// enqueue adds the entry to the current batch and postpones its dispatch
+// until either no further keys are requested for the duration of the wait,
+// loaderMaxWait is exceeded or the batch is full.
+// The lock must be held by the caller
+func (ldr *loader) enqueue(ctx context.Context, entry *loaderEntry) {
+	if ldr.batch == nil {
+		batch := &loaderBatch{start: time.Now()}
+		batch.timer = time.AfterFunc(ldr.wait, func() {
+			ldr.lock.Lock()
+			if ldr.batch != batch {
+				// Already dispatched
+				ldr.lock.Unlock()
+				return
+			}
+			ldr.batch = nil
+			ldr.lock.Unlock()
+			ldr.dispatch(ctx, batch.entries)
+		})
+		ldr.batch = batch
+	}
+
+	batch := ldr.batch
+	batch.entries = append(batch.entries, entry)
+	switch {
+	case len(batch.entries) >= loaderMaxBatch:
+		batch.timer.Stop()
+		ldr.batch = nil
+		go ldr.dispatch(ctx, batch.entries)
+	case time.Since(batch.start) < loaderMaxWait:
+		batch.timer.Reset(ldr.wait)
+	}
+}
+
+// dispatch fetches the values of the batch and resolves its entries
+func (ldr *loader) dispatch(ctx context.Context, batch []*loaderEntry) {
+	if len(batch) < 1 {
+		return
+	}
+	keys := make([]string, len(batch))
+	for i, entry := range batch {
+		keys[i] = entry.key
+	}
+	values, err := ldr.fetch(ctx, keys)
+	for _, entry := range batch {
+		if err != nil {
+			entry.err = err
+		} else {
+			entry.value = values[entry.key]
+		}
+		close(entry.done)
+	}
+}
@@ -0,0 +1,59 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// BenchmarkLoader measures the latency of loading the keys
+// requested by concurrently executed resolvers, as for the items of a list,
+// from a store with a fixed round-trip time
+func BenchmarkLoader(b *testing.B) {
+	const roundTrip = time.Millisecond
+
+	for _, wait := range []time.Duration{
+		0,
+		100 * time.Microsecond,
+		loaderWait,
+		2 * time.Millisecond,
+	} {
+		for _, keys := range []int{10, 50} {
+			name := fmt.Sprintf("wait=%s/keys=%d", wait, keys)
+			b.Run(name, func(b *testing.B) {
+				var fetches int64
+				for i := 0; i < b.N; i++ {
+					ldr := newLoader(func(
+						ctx context.Context,
+						keys []string,
+					) (map[string]interface{}, error) {
+						atomic.AddInt64(&fetches, 1)
+						time.Sleep(roundTrip)
+						return nil, nil
+					})
+					ldr.wait = wait
+
+					var wg sync.WaitGroup
+					wg.Add(keys)
+					for k := 0; k < keys; k++ {
+						go func(key string) {
+							defer wg.Done()
+							if _, err := ldr.load(
+								context.Background(),
+								key,
+							); err != nil {
+								b.Error(err)
+							}
+						}(strconv.Itoa(k))
+					}
+					wg.Wait()
+				}
+				b.ReportMetric(float64(fetches)/float64(b.N), "fetches/op")
+			})
+		}
+	}
+}
@@ -0,0 +1,823 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+)
+
+// ctxKey represents a context.Context value key type
+type ctxKey int
+
+// ctxLoaders defines the context.Context request loaders value key
+const ctxLoaders ctxKey = 1
+
+// loaders represents the set of loaders of a single request
+type loaders struct {
+	// users loads users by uid
+	users *loader
+
+	// posts loads posts by uid
+	posts *loader
+
+	// reactions loads reactions by uid
+	reactions *loader
+
+	// subjects loads reaction subjects by uid
+	subjects *loader
+
+	// notifications loads notifications by uid
+	notifications *loader
+
+	// reports loads reports by uid
+	reports *loader
+
+	// sessionUsers loads the owners of sessions by session uid
+	sessionUsers *loader
+
+	// userSessions loads the sessions of users by user uid
+	userSessions *loader
+
+	// postRevisionLists loads the revisions of posts by post uid
+	postRevisionLists *loader
+
+	// reactionRevisionLists loads the revisions of reactions by reaction uid
+	reactionRevisionLists *loader
+
+	// reactionStatistics loads the number of reactions per emotion
+	// to posts and reactions by uid
+	reactionStatistics *loader
+
+	// pages holds the nested list page loaders
+	// by edge, pagination arguments, filter and before cursor
+	pagesLock sync.Mutex
+	pages     map[string]*loader
+}
+
+// nodePage represents a page of a nested list of a particular node
+type nodePage struct {
+	uids  []dgraph.UID
+	total int
+}
+
+// WithLoaders returns a copy of the request context carrying
+// a new set of loaders batching and caching the store lookups
+// of the resolvers throughout the request
+func (rsv *Resolver) WithLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxLoaders, rsv.newLoaders())
+}
+
+// loaders returns the request loaders of the given context.
+// A new set of loaders is returned for contexts without loaders
+// such as the contexts of subscriptions which are never shared
+// to avoid serving stale cached data
+func (rsv *Resolver) loaders(ctx context.Context) *loaders {
+	if ldr, ok := ctx.Value(ctxLoaders).(*loaders); ok {
+		return ldr
+	}
+	return rsv.newLoaders()
+}
+
+func (rsv *Resolver) newLoaders() *loaders {
+	return &loaders{
+		users:                 newLoader(rsv.fetchUsers),
+		posts:                 newLoader(rsv.fetchPosts),
+		reactions:             newLoader(rsv.fetchReactions),
+		subjects:              newLoader(rsv.fetchSubjects),
+		notifications:         newLoader(rsv.fetchNotifications),
+		reports:               newLoader(rsv.fetchReports),
+		sessionUsers:          newLoader(rsv.fetchSessionUsers),
+		userSessions:          newLoader(rsv.fetchUserSessions),
+		postRevisionLists:     newLoader(rsv.fetchPostRevisions),
+		reactionRevisionLists: newLoader(rsv.fetchReactionRevisions),
+		reactionStatistics:    newLoader(rsv.fetchReactionStats),
+		pages:                 make(map[string]*loader),
+	}
+}
+
+// clear drops the values cached by the loaders.
+// Must be called after each write to the store
+// to not serve the values loaded before the write
+// to the subsequent fields of the request
+func (ldr *loaders) clear() {
+	for _, l := range []*loader{
+		ldr.users,
+		ldr.posts,
+		ldr.reactions,
+		ldr.subjects,
+		ldr.notifications,
+		ldr.reports,
+		ldr.sessionUsers,
+		ldr.userSessions,
+		ldr.postRevisionLists,
+		ldr.reactionRevisionLists,
+		ldr.reactionStatistics,
+	} {
+		l.clear()
+	}
+	ldr.pagesLock.Lock()
+	ldr.pages = make(map[string]*loader)
+	ldr.pagesLock.Unlock()
+}
+
+// page returns the page loader of the given edge,
+// pagination arguments, filter and before cursor
+func (ldr *loaders) page(
+	rsv *Resolver,
+	edge string,
+	edgeArgs string,
+	filter string,
+	before string,
+) *loader {
+	key := edge + edgeArgs + filter + before
+	ldr.pagesLock.Lock()
+	defer ldr.pagesLock.Unlock()
+	pageLoader, exists := ldr.pages[key]
+	if !exists {
+		pageLoader = newLoader(func(
+			ctx context.Context,
+			uids []string,
+		) (map[string]interface{}, error) {
+			return rsv.fetchPages(ctx, edge, edgeArgs, filter, before, uids)
+		})
+		ldr.pages[key] = pageLoader
+	}
+	return pageLoader
+}
+
+// user loads the user by uid, returns nil if it doesn't exist
+func (ldr *loaders) user(
+	ctx context.Context,
+	uid string,
+) (*dgraph.User, error) {
+	value, err := ldr.users.load(ctx, uid)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.(*dgraph.User), nil
+}
+
+// post loads the post by uid, returns nil if it doesn't exist
+func (ldr *loaders) post(
+	ctx context.Context,
+	uid string,
+) (*dgraph.Post, error) {
+	value, err := ldr.posts.load(ctx, uid)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.(*dgraph.Post), nil
+}
+
+// reaction loads the reaction by uid, returns nil if it doesn't exist
+func (ldr *loaders) reaction(
+	ctx context.Context,
+	uid string,
+) (*dgraph.Reaction, error) {
+	value, err := ldr.reactions.load(ctx, uid)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.(*dgraph.Reaction), nil
+}
+
+// subject loads the reaction subject by uid, returns nil if it doesn't exist
+func (ldr *loaders) subject(
+	ctx context.Context,
+	uid string,
+) (*dgraph.ReactionSubject, error) {
+	value, err := ldr.subjects.load(ctx, uid)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.(*dgraph.ReactionSubject), nil
+}
+
+// sessionUser loads the owner of the session by session uid,
+// returns nil if it doesn't exist
+func (ldr *loaders) sessionUser(
+	ctx context.Context,
+	sessionUID string,
+) (*dgraph.User, error) {
+	value, err := ldr.sessionUsers.load(ctx, sessionUID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.(*dgraph.User), nil
+}
+
+// sessions loads the sessions of the user by user uid
+func (ldr *loaders) sessions(
+	ctx context.Context,
+	userUID string,
+) ([]dgraph.Session, error) {
+	value, err := ldr.userSessions.load(ctx, userUID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.([]dgraph.Session), nil
+}
+
+// postRevisions loads the revisions of the post by post uid
+func (ldr *loaders) postRevisions(
+	ctx context.Context,
+	postUID string,
+) ([]dgraph.PostRevision, error) {
+	value, err := ldr.postRevisionLists.load(ctx, postUID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.([]dgraph.PostRevision), nil
+}
+
+// reactionRevisions loads the revisions of the reaction by reaction uid
+func (ldr *loaders) reactionRevisions(
+	ctx context.Context,
+	reactionUID string,
+) ([]dgraph.ReactionRevision, error) {
+	value, err := ldr.reactionRevisionLists.load(ctx, reactionUID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.([]dgraph.ReactionRevision), nil
+}
+
+// reactionStats loads the number of reactions per emotion
+// to the post or reaction by uid
+func (ldr *loaders) reactionStats(
+	ctx context.Context,
+	uid string,
+) (emotionCounts, error) {
+	value, err := ldr.reactionStatistics.load(ctx, uid)
+	if err != nil || value == nil {
+		return emotionCounts{}, err
+	}
+	return value.(emotionCounts), nil
+}
+
+// allUsers loads the users by uid omitting inexistent ones
+func (ldr *loaders) allUsers(
+	ctx context.Context,
+	uids []string,
+) ([]*dgraph.User, error) {
+	values, err := ldr.users.loadAll(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*dgraph.User, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			users = append(users, value.(*dgraph.User))
+		}
+	}
+	return users, nil
+}
+
+// allPosts loads the posts by uid omitting inexistent ones
+func (ldr *loaders) allPosts(
+	ctx context.Context,
+	uids []string,
+) ([]*dgraph.Post, error) {
+	values, err := ldr.posts.loadAll(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	posts := make([]*dgraph.Post, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			posts = append(posts, value.(*dgraph.Post))
+		}
+	}
+	return posts, nil
+}
+
+// allReactions loads the reactions by uid omitting inexistent ones
+func (ldr *loaders) allReactions(
+	ctx context.Context,
+	uids []string,
+) ([]*dgraph.Reaction, error) {
+	values, err := ldr.reactions.loadAll(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	reactions := make([]*dgraph.Reaction, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			reactions = append(reactions, value.(*dgraph.Reaction))
+		}
+	}
+	return reactions, nil
+}
+
+// allNotifications loads the notifications by uid omitting inexistent ones
+func (ldr *loaders) allNotifications(
+	ctx context.Context,
+	uids []string,
+) ([]*dgraph.Notification, error) {
+	values, err := ldr.notifications.loadAll(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	notifications := make([]*dgraph.Notification, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			notifications = append(notifications, value.(*dgraph.Notification))
+		}
+	}
+	return notifications, nil
+}
+
+// allReports loads the reports by uid omitting inexistent ones
+func (ldr *loaders) allReports(
+	ctx context.Context,
+	uids []string,
+) ([]*dgraph.Report, error) {
+	values, err := ldr.reports.loadAll(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*dgraph.Report, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			reports = append(reports, value.(*dgraph.Report))
+		}
+	}
+	return reports, nil
+}
+
+// uidFunc returns the uid function selecting the given nodes
+func uidFunc(uids []string) string {
+	return "uid(" + strings.Join(uids, ", ") + ")"
+}
+
+func (rsv *Resolver) fetchUsers(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Users []dgraph.User `json:"users"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				users(func: %s) {
+					uid
+					User.id
+					User.creation
+					User.email
+					User.emailVerified
+					User.displayName
+					User.roles
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Users))
+	for i := range result.Users {
+		usr := &result.Users[i]
+		if usr.ID != "" {
+			values[usr.UID] = usr
+		}
+	}
+	return values, nil
+}
+
+func (rsv *Resolver) fetchPosts(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Posts []dgraph.Post `json:"posts"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				posts(func: %s) {
+					uid
+					Post.id
+					Post.creation
+					Post.lastEdited
+					Post.title
+					Post.contents
+					Post.hidden
+					Post.author {
+						uid
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Posts))
+	for i := range result.Posts {
+		post := &result.Posts[i]
+		if post.ID != "" {
+			values[post.UID] = post
+		}
+	}
+	return values, nil
+}
+
+func (rsv *Resolver) fetchReactions(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Reactions []dgraph.Reaction `json:"reactions"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				reactions(func: %s) {
+					uid
+					Reaction.id
+					Reaction.creation
+					Reaction.emotion
+					Reaction.message
+					Reaction.hidden
+					Reaction.author {
+						uid
+					}
+					Reaction.subject {
+						uid
+						Post.id
+						Reaction.id
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Reactions))
+	for i := range result.Reactions {
+		reaction := &result.Reactions[i]
+		if reaction.ID != "" {
+			values[reaction.UID] = reaction
+		}
+	}
+	return values, nil
+}
+
+// fetchNotifications loads notifications omitting the references
+// to actors and subjects which were deleted
+func (rsv *Resolver) fetchNotifications(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Notifications []dgraph.Notification `json:"notifications"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				notifications(func: %s) {
+					uid
+					Notification.id
+					Notification.creation
+					Notification.kind
+					Notification.read
+					Notification.actor @filter(has(User.id)) {
+						uid
+					}
+					Notification.subject @filter(
+						has(Post.id) or has(Reaction.id)
+					) {
+						uid
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Notifications))
+	for i := range result.Notifications {
+		notif := &result.Notifications[i]
+		if notif.ID != "" {
+			values[notif.UID] = notif
+		}
+	}
+	return values, nil
+}
+
+// fetchReports loads reports omitting the references
+// to reporters which were deleted
+func (rsv *Resolver) fetchReports(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Reports []dgraph.Report `json:"reports"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				reports(func: %s) {
+					uid
+					Report.id
+					Report.creation
+					Report.reason
+					Report.open
+					Report.reporter @filter(has(User.id)) {
+						uid
+					}
+					Report.subject {
+						uid
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Reports))
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		if report.ID != "" {
+			values[report.UID] = report
+		}
+	}
+	return values, nil
+}
+
+func (rsv *Resolver) fetchSubjects(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Subjects []dgraph.ReactionSubject `json:"subjects"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				subjects(func: %s) {
+					uid
+
+					Post.id
+					Post.creation
+					Post.lastEdited
+					Post.author {
+						uid
+					}
+					Post.title
+					Post.contents
+
+					Reaction.id
+					Reaction.creation
+					Reaction.author {
+						uid
+					}
+					Reaction.subject {
+						uid
+						Post.id
+						Reaction.id
+					}
+					Reaction.message
+					Reaction.emotion
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Subjects))
+	for i := range result.Subjects {
+		subject := &result.Subjects[i]
+		values[*subject.UID()] = subject
+	}
+	return values, nil
+}
+
+func (rsv *Resolver) fetchSessionUsers(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Sessions []dgraph.Session `json:"sessions"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				sessions(func: %s) {
+					uid
+					Session.user {
+						uid
+						User.id
+						User.creation
+						User.email
+						User.displayName
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Sessions))
+	for _, sess := range result.Sessions {
+		if len(sess.User) > 0 {
+			values[sess.UID] = &sess.User[0]
+		}
+	}
+	return values, nil
+}
+
+func (rsv *Resolver) fetchUserSessions(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Users []dgraph.User `json:"users"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				users(func: %s) {
+					uid
+					User.sessions {
+						uid
+						Session.key
+						Session.creation
+						Session.lastAccess
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Users))
+	for _, usr := range result.Users {
+		values[usr.UID] = usr.Sessions
+	}
+	return values, nil
+}
+
+func (rsv *Resolver) fetchPostRevisions(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Posts []dgraph.Post `json:"posts"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				posts(func: %s) {
+					uid
+					Post.revisions (orderasc: PostRevision.creation) {
+						uid
+						PostRevision.id
+						PostRevision.creation
+						PostRevision.title
+						PostRevision.contents
+						PostRevision.editor {
+							uid
+						}
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Posts))
+	for _, post := range result.Posts {
+		values[post.UID] = post.Revisions
+	}
+	return values, nil
+}
+
+func (rsv *Resolver) fetchReactionRevisions(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	var result struct {
+		Reactions []dgraph.Reaction `json:"reactions"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				reactions(func: %s) {
+					uid
+					Reaction.revisions (orderasc: ReactionRevision.creation) {
+						uid
+						ReactionRevision.id
+						ReactionRevision.creation
+						ReactionRevision.message
+						ReactionRevision.editor {
+							uid
+						}
+					}
+				}
+			}`,
+			uidFunc(uids),
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Reactions))
+	for _, reaction := range result.Reactions {
+		values[reaction.UID] = reaction.Revisions
+	}
+	return values, nil
+}
+
+// fetchPages fetches a page of the nested list of each of the given nodes
+// excluding the before cursor and the nodes following it
+func (rsv *Resolver) fetchPages(
+	ctx context.Context,
+	edge string,
+	edgeArgs string,
+	filter string,
+	before string,
+	uids []string,
+) (map[string]interface{}, error) {
+	directive := filterDirective(filter)
+	pageDirective := directive
+	beforeBlock := ""
+	if before != "" {
+		pageDirective = filterDirective(filter, beforeFilter(before))
+		beforeBlock = fmt.Sprintf(
+			"var(func: %s) { %s as %s (after: %s) { uid } }",
+			uidFunc(uids),
+			beforeVar,
+			edge,
+			before,
+		)
+	}
+	var result struct {
+		Nodes []struct {
+			UID   string       `json:"uid"`
+			Page  []dgraph.UID `json:"page"`
+			Total int          `json:"total"`
+		} `json:"nodes"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				%s
+				nodes(func: %s) {
+					uid
+					total: count(%s %s)
+					page: %s %s %s { uid }
+				}
+			}`,
+			beforeBlock,
+			uidFunc(uids),
+			edge,
+			directive,
+			edge,
+			edgeArgs,
+			pageDirective,
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(result.Nodes))
+	for _, node := range result.Nodes {
+		values[node.UID] = &nodePage{
+			uids:  node.Page,
+			total: node.Total,
+		}
+	}
+	return values, nil
+}
@@ -14,7 +14,7 @@ func (rsv *Resolver) Authenticate(
 	params struct {
 		SessionKey string
 	},
-) *Session {
+) (*Session, error) {
 	var queryResult struct {
 		Session []dgraph.Session `json:"session"`
 	}
@@ -25,41 +25,60 @@ func (rsv *Resolver) Authenticate(
 				uid
 				Session.key
 				Session.creation
+				Session.lastAccess
 				Session.user {
 					uid
 					User.id
+					User.roles
 				}
 			}
 		}`,
 		map[string]string{"$sessionKey": params.SessionKey},
 		&queryResult,
 	); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	if len(queryResult.Session) < 1 {
 		err := strerr.New(strerr.ErrInvalidInput, "session not found")
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	sess := queryResult.Session[0]
 
+	// Reject expired sessions
+	accessTime := rsv.clock.Now()
+	if rsv.sessionTTL.IsExpired(
+		sess.Creation,
+		sess.LastAccess,
+		accessTime,
+	) {
+		err := strerr.New(strerr.ErrInvalidInput, "session expired")
+		return nil, err
+	}
+
+	// Renew the session
+	if err := rsv.str.TouchSession(ctx, sess.Key, accessTime); err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
 	// Dynamically update the session on successful sign-in
 	if session, isSession := ctx.Value(
 		auth.CtxSession,
 	).(*auth.RequestSession); isSession {
 		session.Creation = sess.Creation
 		session.UserID = sess.User[0].ID
-		session.ShieldClientRole = auth.GQLShieldClientRegular
+		session.Roles = sess.User[0].Roles
 	}
 
 	return &Session{
-		root:     rsv,
-		uid:      sess.UID,
-		key:      sess.Key,
-		creation: sess.Creation,
-		userUID:  sess.User[0].UID,
-	}
+		root:       rsv,
+		uid:        sess.UID,
+		key:        sess.Key,
+		creation:   sess.Creation,
+		lastAccess: accessTime,
+		userUID:    sess.User[0].UID,
+	}, nil
 }
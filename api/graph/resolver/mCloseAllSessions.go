@@ -13,12 +13,11 @@ func (rsv *Resolver) CloseAllSessions(
 	params struct {
 		User string
 	},
-) []string {
+) ([]string, error) {
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(params.User),
 	}); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	result, err := rsv.str.CloseAllSessions(
@@ -26,9 +25,10 @@ func (rsv *Resolver) CloseAllSessions(
 		store.ID(params.User),
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
-	return result
+	rsv.loaders(ctx).clear()
+
+	return result, nil
 }
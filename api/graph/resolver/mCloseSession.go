@@ -12,10 +12,9 @@ func (rsv *Resolver) CloseSession(
 	params struct {
 		Key string
 	},
-) bool {
+) (bool, error) {
 	if err := auth.Authorize(ctx, auth.IsUser{}); err != nil {
-		rsv.error(ctx, err)
-		return false
+		return false, err
 	}
 
 	result, err := rsv.str.CloseSession(
@@ -23,9 +22,10 @@ func (rsv *Resolver) CloseSession(
 		params.Key,
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return false
+		return false, err
 	}
 
-	return result
+	rsv.loaders(ctx).clear()
+
+	return result, nil
 }
@@ -2,7 +2,6 @@ package resolver
 
 import (
 	"context"
-	"time"
 
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
 	"github.com/romshark/dgraph_graphql_go/store"
@@ -17,27 +16,24 @@ func (rsv *Resolver) CreatePost(
 		Title    string
 		Contents string
 	},
-) *Post {
+) (*Post, error) {
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(params.Author),
 	}); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	// Validate input
 	if err := rsv.validator.PostTitle(params.Title); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if err := rsv.validator.PostContents(params.Contents); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
-	creationTime := time.Now()
+	creationTime := rsv.clock.Now()
 
 	newPost, err := rsv.str.CreatePost(
 		ctx,
@@ -47,11 +43,12 @@ func (rsv *Resolver) CreatePost(
 		params.Contents,
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
-	return &Post{
+	rsv.loaders(ctx).clear()
+
+	post := &Post{
 		root:      rsv,
 		uid:       newPost.UID,
 		id:        newPost.ID,
@@ -60,4 +57,7 @@ func (rsv *Resolver) CreatePost(
 		contents:  params.Contents,
 		authorUID: newPost.Author.UID,
 	}
+	rsv.eventBus.Publish(topicPostCreated, post)
+
+	return post, nil
 }
@@ -2,7 +2,6 @@ package resolver
 
 import (
 	"context"
-	"time"
 
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
 	"github.com/romshark/dgraph_graphql_go/store"
@@ -19,12 +18,11 @@ func (rsv *Resolver) CreateReaction(
 		Emotion string
 		Message string
 	},
-) *Reaction {
+) (*Reaction, error) {
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(params.Author),
 	}); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	emot := emotion.Emotion(params.Emotion)
@@ -32,16 +30,14 @@ func (rsv *Resolver) CreateReaction(
 	// Validate input
 	if err := rsv.validator.ReactionMessage(params.Message); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if err := emotion.Validate(emot); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
-	creationTime := time.Now()
+	creationTime := rsv.clock.Now()
 
 	// Create new reaction entity
 	newReaction, err := rsv.str.CreateReaction(
@@ -53,11 +49,12 @@ func (rsv *Resolver) CreateReaction(
 		params.Message,
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
-	return &Reaction{
+	rsv.loaders(ctx).clear()
+
+	reaction := &Reaction{
 		root:       rsv,
 		uid:        newReaction.UID,
 		id:         newReaction.ID,
@@ -67,4 +64,10 @@ func (rsv *Resolver) CreateReaction(
 		emotion:    emot,
 		message:    params.Message,
 	}
+	rsv.eventBus.Publish(topicReactionCreated, reactionCreatedEvent{
+		reaction: reaction,
+		subject:  store.ID(params.Subject),
+	})
+
+	return reaction, nil
 }
@@ -2,9 +2,10 @@ package resolver
 
 import (
 	"context"
-	"time"
 
+	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
@@ -15,17 +16,16 @@ func (rsv *Resolver) CreateSession(
 		Email    string
 		Password string
 	},
-) *Session {
+) (*Session, error) {
 	// Validate inputs
 	if len(params.Email) < 1 || len(params.Password) < 1 {
 		err := strerr.New(strerr.ErrInvalidInput, "missing credentials")
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	// Generate session key
 	key := rsv.sessionKeyGenerator.Generate()
-	creationTime := time.Now()
+	creationTime := rsv.clock.Now()
 
 	newSession, err := rsv.str.CreateSession(
 		ctx,
@@ -35,8 +35,23 @@ func (rsv *Resolver) CreateSession(
 		params.Password,
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	// Upgrade the password hash if it was produced by an older algorithm
+	// or weaker parameters, the password is known only at sign-in.
+	// A failed upgrade doesn't fail the sign-in, it's retried the next time
+	if rsv.passwordHasher.NeedsRehash([]byte(newSession.User.Password)) {
+		if err := rsv.upgradePasswordHash(
+			ctx,
+			newSession.User.ID,
+			newSession.User.Password,
+			params.Password,
+		); err != nil {
+			rsv.onUnexpectedErr(errors.Wrap(err, "password hash upgrade"))
+		}
 	}
 
 	// Dynamically update the session on successful sign-in
@@ -45,14 +60,35 @@ func (rsv *Resolver) CreateSession(
 	).(*auth.RequestSession); isSession {
 		session.Creation = creationTime
 		session.UserID = newSession.User.ID
-		session.ShieldClientRole = auth.GQLShieldClientRegular
+		session.Roles = newSession.User.Roles
 	}
 
 	return &Session{
-		root:     rsv,
-		uid:      newSession.UID,
-		key:      key,
-		creation: creationTime,
-		userUID:  newSession.User.UID,
+		root:       rsv,
+		uid:        newSession.UID,
+		key:        key,
+		creation:   creationTime,
+		lastAccess: newSession.LastAccess,
+		userUID:    newSession.User.UID,
+	}, nil
+}
+
+// upgradePasswordHash rehashes the password replacing the old hash
+func (rsv *Resolver) upgradePasswordHash(
+	ctx context.Context,
+	user store.ID,
+	oldHash string,
+	password string,
+) error {
+	passwordHash, err := rsv.passwordHasher.Hash([]byte(password))
+	if err != nil {
+		return err
 	}
+	_, err = rsv.str.UpgradePasswordHash(
+		ctx,
+		user,
+		oldHash,
+		string(passwordHash),
+	)
+	return err
 }
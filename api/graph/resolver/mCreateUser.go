@@ -2,8 +2,9 @@ package resolver
 
 import (
 	"context"
-	"time"
 
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
@@ -15,32 +16,28 @@ func (rsv *Resolver) CreateUser(
 		DisplayName string
 		Password    string
 	},
-) *User {
+) (*User, error) {
 	// Validate inputs
 	if err := rsv.validator.UserDisplayName(params.DisplayName); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if err := rsv.validator.Email(params.Email); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if err := rsv.validator.Password(params.Password); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	// Create password hash
 	passwordHash, err := rsv.passwordHasher.Hash([]byte(params.Password))
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
-	creationTime := time.Now()
+	creationTime := rsv.clock.Now()
 
 	transactRes, err := rsv.str.CreateUser(
 		ctx,
@@ -50,8 +47,19 @@ func (rsv *Resolver) CreateUser(
 		string(passwordHash),
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	// Request the verification of the email address,
+	// the user is created regardless of whether the mail is delivered
+	if err := rsv.sendToken(
+		ctx,
+		token.EmailVerification,
+		params.Email,
+	); err != nil {
+		rsv.onUnexpectedErr(errors.Wrap(err, "email verification mail"))
 	}
 
 	return &User{
@@ -61,5 +69,5 @@ func (rsv *Resolver) CreateUser(
 		creation:    creationTime,
 		displayName: params.DisplayName,
 		email:       params.Email,
-	}
+	}, nil
 }
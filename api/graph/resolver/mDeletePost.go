@@ -0,0 +1,31 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// DeletePost resolves Mutation.deletePost
+func (rsv *Resolver) DeletePost(
+	ctx context.Context,
+	params struct {
+		Post string
+	},
+) (bool, error) {
+	if err := auth.Authorize(ctx, auth.IsUser{}); err != nil {
+		return false, err
+	}
+
+	if _, err := rsv.str.DeletePost(
+		ctx,
+		store.ID(params.Post),
+	); err != nil {
+		return false, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return true, nil
+}
@@ -0,0 +1,31 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// DeleteReaction resolves Mutation.deleteReaction
+func (rsv *Resolver) DeleteReaction(
+	ctx context.Context,
+	params struct {
+		Reaction string
+	},
+) (bool, error) {
+	if err := auth.Authorize(ctx, auth.IsUser{}); err != nil {
+		return false, err
+	}
+
+	if _, err := rsv.str.DeleteReaction(
+		ctx,
+		store.ID(params.Reaction),
+	); err != nil {
+		return false, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return true, nil
+}
@@ -0,0 +1,31 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// DeleteUser resolves Mutation.deleteUser
+func (rsv *Resolver) DeleteUser(
+	ctx context.Context,
+	params struct {
+		User string
+	},
+) (bool, error) {
+	if err := auth.Authorize(ctx, auth.IsUser{}); err != nil {
+		return false, err
+	}
+
+	if _, err := rsv.str.DeleteUser(
+		ctx,
+		store.ID(params.User),
+	); err != nil {
+		return false, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return true, nil
+}
@@ -0,0 +1,32 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// DismissReport resolves Mutation.dismissReport
+func (rsv *Resolver) DismissReport(
+	ctx context.Context,
+	params struct {
+		Report string
+	},
+) (*Report, error) {
+	if err := auth.Authorize(ctx, auth.HasRole{
+		Role: role.Moderator,
+	}); err != nil {
+		return nil, err
+	}
+
+	report, err := rsv.str.DismissReport(ctx, store.ID(params.Report))
+	if err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return rsv.newReport(report), nil
+}
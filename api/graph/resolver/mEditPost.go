@@ -17,32 +17,28 @@ func (rsv *Resolver) EditPost(
 		NewTitle    *string
 		NewContents *string
 	},
-) *Post {
+) (*Post, error) {
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(params.Editor),
 	}); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	// Validate input
 	if params.NewTitle == nil && params.NewContents == nil {
 		err := strerr.New(strerr.ErrInvalidInput, "no changes")
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if params.NewTitle != nil {
 		if err := rsv.validator.PostTitle(*params.NewTitle); err != nil {
 			err = strerr.Wrap(strerr.ErrInvalidInput, err)
-			rsv.error(ctx, err)
-			return nil
+			return nil, err
 		}
 	}
 	if params.NewContents != nil {
 		if err := rsv.validator.PostContents(*params.NewContents); err != nil {
 			err = strerr.Wrap(strerr.ErrInvalidInput, err)
-			rsv.error(ctx, err)
-			return nil
+			return nil, err
 		}
 	}
 
@@ -50,21 +46,27 @@ func (rsv *Resolver) EditPost(
 		ctx,
 		store.ID(params.Post),
 		store.ID(params.Editor),
+		rsv.clock.Now(),
 		params.NewTitle,
 		params.NewContents,
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
-	return &Post{
-		root:      rsv,
-		uid:       mutatedPost.UID,
-		id:        store.ID(params.Post),
-		creation:  mutatedPost.Creation,
-		title:     mutatedPost.Title,
-		contents:  mutatedPost.Contents,
-		authorUID: mutatedPost.Author.UID,
+	rsv.loaders(ctx).clear()
+
+	post := &Post{
+		root:       rsv,
+		uid:        mutatedPost.UID,
+		id:         store.ID(params.Post),
+		creation:   mutatedPost.Creation,
+		lastEdited: mutatedPost.LastEdited,
+		title:      mutatedPost.Title,
+		contents:   mutatedPost.Contents,
+		authorUID:  mutatedPost.Author.UID,
 	}
+	rsv.eventBus.Publish(topicPostEdited, post)
+
+	return post, nil
 }
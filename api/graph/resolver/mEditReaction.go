@@ -16,32 +16,32 @@ func (rsv *Resolver) EditReaction(
 		Editor     string
 		NewMessage string
 	},
-) *Reaction {
+) (*Reaction, error) {
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(params.Editor),
 	}); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	// Validate input
 	if err := rsv.validator.ReactionMessage(params.NewMessage); err != nil {
 		err = strerr.Wrap(strerr.ErrInvalidInput, err)
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	mutatedReaction, _, err := rsv.str.EditReaction(
 		ctx,
 		store.ID(params.Reaction),
 		store.ID(params.Editor),
+		rsv.clock.Now(),
 		params.NewMessage,
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
+	rsv.loaders(ctx).clear()
+
 	return &Reaction{
 		root:       rsv,
 		uid:        mutatedReaction.UID,
@@ -51,5 +51,5 @@ func (rsv *Resolver) EditReaction(
 		message:    mutatedReaction.Message,
 		authorUID:  mutatedReaction.Author.UID,
 		subjectUID: mutatedReaction.Subject.NodeID(),
-	}
+	}, nil
 }
@@ -3,8 +3,10 @@ package resolver
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
 	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
@@ -17,38 +19,33 @@ func (rsv *Resolver) EditUser(
 		NewEmail    *string
 		NewPassword *string
 	},
-) *User {
+) (*User, error) {
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(params.Editor),
 	}); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(params.User),
 	}); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 
 	// Validate input
 	if params.NewEmail == nil && params.NewPassword == nil {
 		err := strerr.New(strerr.ErrInvalidInput, "no changes")
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if params.NewEmail != nil {
 		if err := rsv.validator.Email(*params.NewEmail); err != nil {
 			err = strerr.Wrap(strerr.ErrInvalidInput, err)
-			rsv.error(ctx, err)
-			return nil
+			return nil, err
 		}
 	}
 	if params.NewPassword != nil {
 		if err := rsv.validator.Password(*params.NewPassword); err != nil {
 			err = strerr.Wrap(strerr.ErrInvalidInput, err)
-			rsv.error(ctx, err)
-			return nil
+			return nil, err
 		}
 	}
 
@@ -58,13 +55,12 @@ func (rsv *Resolver) EditUser(
 			[]byte(*params.NewPassword),
 		)
 		if err != nil {
-			rsv.error(ctx, err)
-			return nil
+			return nil, err
 		}
 		*params.NewPassword = string(passwordHash)
 	}
 
-	mutatedUser, _, err := rsv.str.EditUser(
+	mutatedUser, changes, err := rsv.str.EditUser(
 		ctx,
 		store.ID(params.User),
 		store.ID(params.Editor),
@@ -72,8 +68,21 @@ func (rsv *Resolver) EditUser(
 		params.NewPassword,
 	)
 	if err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	// Request the verification of the new email address,
+	// the change is kept regardless of whether the mail is delivered
+	if changes.Email {
+		if err := rsv.sendToken(
+			ctx,
+			token.EmailVerification,
+			mutatedUser.Email,
+		); err != nil {
+			rsv.onUnexpectedErr(errors.Wrap(err, "email verification mail"))
+		}
 	}
 
 	return &User{
@@ -83,5 +92,5 @@ func (rsv *Resolver) EditUser(
 		creation:    mutatedUser.Creation,
 		displayName: mutatedUser.DisplayName,
 		email:       mutatedUser.Email,
-	}
+	}, nil
 }
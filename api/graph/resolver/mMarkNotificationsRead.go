@@ -0,0 +1,56 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// MarkNotificationsRead resolves Mutation.markNotificationsRead
+func (rsv *Resolver) MarkNotificationsRead(
+	ctx context.Context,
+	params struct {
+		User          string
+		Notifications *[]string
+	},
+) ([]store.ID, error) {
+	if err := auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(params.User),
+	}); err != nil {
+		return nil, err
+	}
+
+	var notifications []store.ID
+	if params.Notifications != nil {
+		if len(*params.Notifications) > maxPageSize {
+			return nil, strerr.Newf(
+				strerr.ErrInvalidInput,
+				"at most %d notifications can be marked at once",
+				maxPageSize,
+			)
+		}
+		notifications = make([]store.ID, len(*params.Notifications))
+		for i, id := range *params.Notifications {
+			notifications[i] = store.ID(id)
+		}
+	}
+
+	result, err := rsv.str.MarkNotificationsRead(
+		ctx,
+		store.ID(params.User),
+		notifications,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	ids := make([]store.ID, len(result))
+	for i, notif := range result {
+		ids[i] = notif.ID
+	}
+	return ids, nil
+}
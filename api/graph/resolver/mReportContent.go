@@ -0,0 +1,46 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// ReportContent resolves Mutation.reportContent
+func (rsv *Resolver) ReportContent(
+	ctx context.Context,
+	params struct {
+		Reporter string
+		Subject  string
+		Reason   string
+	},
+) (*Report, error) {
+	if err := auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(params.Reporter),
+	}); err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	if err := rsv.validator.ReportReason(params.Reason); err != nil {
+		err = strerr.Wrap(strerr.ErrInvalidInput, err)
+		return nil, err
+	}
+
+	report, err := rsv.str.ReportContent(
+		ctx,
+		rsv.clock.Now(),
+		store.ID(params.Reporter),
+		store.ID(params.Subject),
+		params.Reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return rsv.newReport(report), nil
+}
@@ -0,0 +1,40 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// RequestPasswordReset resolves Mutation.requestPasswordReset
+func (rsv *Resolver) RequestPasswordReset(
+	ctx context.Context,
+	params struct {
+		Email string
+	},
+) (bool, error) {
+	// Validate input
+	if err := rsv.validator.Email(params.Email); err != nil {
+		err = strerr.Wrap(strerr.ErrInvalidInput, err)
+		return false, err
+	}
+
+	// Issue and mail the token in the background to respond the same way
+	// and just as fast whether or not the email is registered
+	rsv.background.Add(1)
+	go func() {
+		defer rsv.background.Done()
+		err := rsv.sendToken(
+			context.Background(),
+			token.PasswordReset,
+			params.Email,
+		)
+		if err != nil &&
+			strerr.ErrorCode(err) != string(strerr.ErrInvalidInput) {
+			rsv.onUnexpectedErr(errors.Wrap(err, "password reset mail"))
+		}
+	}()
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// ResetPassword resolves Mutation.resetPassword
+func (rsv *Resolver) ResetPassword(
+	ctx context.Context,
+	params struct {
+		Token       string
+		NewPassword string
+	},
+) (bool, error) {
+	// Validate input
+	if len(params.Token) < 1 {
+		err := strerr.New(strerr.ErrInvalidInput, "missing token")
+		return false, err
+	}
+	if err := rsv.validator.Password(params.NewPassword); err != nil {
+		err = strerr.Wrap(strerr.ErrInvalidInput, err)
+		return false, err
+	}
+
+	// Create password hash
+	passwordHash, err := rsv.passwordHasher.Hash([]byte(params.NewPassword))
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := rsv.str.ResetPassword(
+		ctx,
+		params.Token,
+		rsv.clock.Now().Add(-rsv.tokenTTL.Of(token.PasswordReset)),
+		string(passwordHash),
+	); err != nil {
+		return false, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return true, nil
+}
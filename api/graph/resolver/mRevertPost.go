@@ -0,0 +1,51 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// RevertPost resolves Mutation.revertPost
+func (rsv *Resolver) RevertPost(
+	ctx context.Context,
+	params struct {
+		Post     string
+		Editor   string
+		Revision string
+	},
+) (*Post, error) {
+	if err := auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(params.Editor),
+	}); err != nil {
+		return nil, err
+	}
+
+	mutatedPost, err := rsv.str.RevertPost(
+		ctx,
+		store.ID(params.Post),
+		store.ID(params.Editor),
+		store.ID(params.Revision),
+		rsv.clock.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	post := &Post{
+		root:       rsv,
+		uid:        mutatedPost.UID,
+		id:         store.ID(params.Post),
+		creation:   mutatedPost.Creation,
+		lastEdited: mutatedPost.LastEdited,
+		title:      mutatedPost.Title,
+		contents:   mutatedPost.Contents,
+		authorUID:  mutatedPost.Author.UID,
+	}
+	rsv.eventBus.Publish(topicPostEdited, post)
+
+	return post, nil
+}
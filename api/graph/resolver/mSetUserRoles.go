@@ -0,0 +1,51 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// SetUserRoles resolves Mutation.setUserRoles
+func (rsv *Resolver) SetUserRoles(
+	ctx context.Context,
+	params struct {
+		User  string
+		Roles []string
+	},
+) (*User, error) {
+	if err := auth.Authorize(ctx, auth.HasRole{
+		Role: role.Admin,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	roles := make([]role.Role, len(params.Roles))
+	for i, r := range params.Roles {
+		roles[i] = role.Role(r)
+		if err := role.Validate(roles[i]); err != nil {
+			err = strerr.Wrap(strerr.ErrInvalidInput, err)
+			return nil, err
+		}
+	}
+
+	usr, err := rsv.str.SetUserRoles(ctx, store.ID(params.User), roles)
+	if err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return &User{
+		root:        rsv,
+		uid:         usr.UID,
+		id:          usr.ID,
+		creation:    usr.Creation,
+		email:       usr.Email,
+		displayName: usr.DisplayName,
+	}, nil
+}
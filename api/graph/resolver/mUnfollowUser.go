@@ -0,0 +1,43 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// UnfollowUser resolves Mutation.unfollowUser
+func (rsv *Resolver) UnfollowUser(
+	ctx context.Context,
+	params struct {
+		Follower string
+		User     string
+	},
+) (*User, error) {
+	if err := auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(params.Follower),
+	}); err != nil {
+		return nil, err
+	}
+
+	usr, err := rsv.str.UnfollowUser(
+		ctx,
+		store.ID(params.Follower),
+		store.ID(params.User),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return &User{
+		root:        rsv,
+		uid:         usr.UID,
+		id:          usr.ID,
+		creation:    usr.Creation,
+		email:       usr.Email,
+		displayName: usr.DisplayName,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// UnhideContent resolves Mutation.unhideContent
+func (rsv *Resolver) UnhideContent(
+	ctx context.Context,
+	params struct {
+		Subject string
+	},
+) (*ReactionSubject, error) {
+	if err := auth.Authorize(ctx, auth.HasRole{
+		Role: role.Moderator,
+	}); err != nil {
+		return nil, err
+	}
+
+	subject, err := rsv.str.UnhideContent(ctx, store.ID(params.Subject))
+	if err != nil {
+		return nil, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return rsv.reactionSubject(ctx, subject.NodeID())
+}
@@ -0,0 +1,34 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// VerifyEmail resolves Mutation.verifyEmail
+func (rsv *Resolver) VerifyEmail(
+	ctx context.Context,
+	params struct {
+		Token string
+	},
+) (bool, error) {
+	// Validate input
+	if len(params.Token) < 1 {
+		err := strerr.New(strerr.ErrInvalidInput, "missing token")
+		return false, err
+	}
+
+	if _, err := rsv.str.VerifyEmail(
+		ctx,
+		params.Token,
+		rsv.clock.Now().Add(-rsv.tokenTTL.Of(token.EmailVerification)),
+	); err != nil {
+		return false, err
+	}
+
+	rsv.loaders(ctx).clear()
+
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// sendToken issues a token of the given kind to the user identified
+// by the email and mails it to the user
+func (rsv *Resolver) sendToken(
+	ctx context.Context,
+	kind token.Kind,
+	email string,
+) error {
+	// Generate token key
+	key := rsv.sessionKeyGenerator.Generate()
+
+	tok, err := rsv.str.CreateToken(ctx, kind, key, rsv.clock.Now(), email)
+	if err != nil {
+		return err
+	}
+
+	var subject, purpose string
+	switch kind {
+	case token.PasswordReset:
+		subject = "Reset your password"
+		purpose = "reset your password"
+	case token.EmailVerification:
+		subject = "Verify your email address"
+		purpose = "verify your email address"
+	}
+
+	return rsv.mailer.Send(mailer.Mail{
+		To:      tok.User.Email,
+		Subject: subject,
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nuse the following token to %s:\n\n%s\n\n"+
+				"The token expires in %s.\n",
+			tok.User.DisplayName,
+			purpose,
+			key,
+			rsv.tokenTTL.Of(kind),
+		),
+	})
+}
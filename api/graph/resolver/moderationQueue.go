@@ -0,0 +1,29 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// ModerationQueue resolves Query.moderationQueue
+func (rsv *Resolver) ModerationQueue(
+	ctx context.Context,
+	params struct {
+		First *int32
+		After *Cursor
+	},
+) (*ReportConnection, error) {
+	if err := auth.Authorize(ctx, auth.HasRole{
+		Role: role.Moderator,
+	}); err != nil {
+		return nil, err
+	}
+	return rsv.reportConnection(ctx, list{
+		rootFunc: "has(Report.open)",
+	}, ConnectionParams{
+		First: params.First,
+		After: params.After,
+	})
+}
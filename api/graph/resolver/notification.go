@@ -0,0 +1,73 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+)
+
+// Notification represents the resolver of the identically named type
+type Notification struct {
+	root       *Resolver
+	uid        string
+	actorUID   string
+	subjectUID string
+	id         store.ID
+	creation   time.Time
+	kind       notification.Kind
+	read       bool
+}
+
+// ID resolves Notification.id
+func (rsv *Notification) ID() store.ID {
+	return rsv.id
+}
+
+// Creation resolves Notification.creation
+func (rsv *Notification) Creation() graphql.Time {
+	return graphql.Time{
+		Time: rsv.creation,
+	}
+}
+
+// Kind resolves Notification.kind
+func (rsv *Notification) Kind() string {
+	return string(rsv.kind)
+}
+
+// Read resolves Notification.read
+func (rsv *Notification) Read() bool {
+	return rsv.read
+}
+
+// Actor resolves Notification.actor
+func (rsv *Notification) Actor(ctx context.Context) (*User, error) {
+	if rsv.actorUID == "" {
+		return nil, nil
+	}
+	actor, err := rsv.root.loaders(ctx).user(ctx, rsv.actorUID)
+	if err != nil || actor == nil {
+		return nil, err
+	}
+	return &User{
+		root:        rsv.root,
+		uid:         actor.UID,
+		id:          store.ID(actor.ID),
+		creation:    actor.Creation,
+		email:       actor.Email,
+		displayName: actor.DisplayName,
+	}, nil
+}
+
+// Subject resolves Notification.subject
+func (rsv *Notification) Subject(
+	ctx context.Context,
+) (*ReactionSubject, error) {
+	if rsv.subjectUID == "" {
+		return nil, nil
+	}
+	return rsv.root.reactionSubject(ctx, rsv.subjectUID)
+}
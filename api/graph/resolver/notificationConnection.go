@@ -0,0 +1,83 @@
+package resolver
+
+import "context"
+
+// NotificationConnection represents the resolver
+// of the identically named type
+type NotificationConnection struct {
+	page  page
+	edges []*NotificationEdge
+}
+
+// Edges resolves NotificationConnection.edges
+func (rsv *NotificationConnection) Edges() []*NotificationEdge {
+	return rsv.edges
+}
+
+// PageInfo resolves NotificationConnection.pageInfo
+func (rsv *NotificationConnection) PageInfo() *PageInfo {
+	return &PageInfo{page: rsv.page}
+}
+
+// TotalCount resolves NotificationConnection.totalCount
+func (rsv *NotificationConnection) TotalCount() int32 {
+	return int32(rsv.page.totalCount)
+}
+
+// NotificationEdge represents the resolver of the identically named type
+type NotificationEdge struct {
+	node *Notification
+}
+
+// Cursor resolves NotificationEdge.cursor
+func (rsv *NotificationEdge) Cursor() Cursor {
+	return newCursor(rsv.node.uid)
+}
+
+// Node resolves NotificationEdge.node
+func (rsv *NotificationEdge) Node() *Notification {
+	return rsv.node
+}
+
+// notificationConnection resolves a page of the given list of notifications
+func (rsv *Resolver) notificationConnection(
+	ctx context.Context,
+	lst list,
+	params ConnectionParams,
+) (*NotificationConnection, error) {
+	pg, err := rsv.page(ctx, lst, params)
+	if err != nil {
+		return nil, err
+	}
+	conn := &NotificationConnection{
+		page:  pg,
+		edges: make([]*NotificationEdge, 0, len(pg.uids)),
+	}
+	if len(pg.uids) < 1 {
+		return conn, nil
+	}
+
+	notifications, err := rsv.loaders(ctx).allNotifications(ctx, pg.uids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, notif := range notifications {
+		node := &Notification{
+			root:     rsv,
+			uid:      notif.UID,
+			id:       notif.ID,
+			creation: notif.Creation,
+			kind:     notif.Kind,
+			read:     notif.Read,
+		}
+		if len(notif.Actor) > 0 {
+			node.actorUID = notif.Actor[0].UID
+		}
+		if len(notif.Subject) > 0 {
+			node.subjectUID = notif.Subject[0].NodeID
+		}
+		conn.edges = append(conn.edges, &NotificationEdge{node: node})
+	}
+	return conn, nil
+}
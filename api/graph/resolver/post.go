@@ -6,18 +6,18 @@ import (
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/romshark/dgraph_graphql_go/store"
-	"github.com/romshark/dgraph_graphql_go/store/dgraph"
 )
 
 // Post represents the resolver of the identically named type
 type Post struct {
-	root      *Resolver
-	uid       string
-	authorUID string
-	id        store.ID
-	creation  time.Time
-	title     string
-	contents  string
+	root       *Resolver
+	uid        string
+	authorUID  string
+	id         store.ID
+	creation   time.Time
+	lastEdited time.Time
+	title      string
+	contents   string
 }
 
 // ID resolves Post.id
@@ -26,39 +26,19 @@ func (rsv *Post) ID() store.ID {
 }
 
 // Author resolves Post.author
-func (rsv *Post) Author(ctx context.Context) *User {
-	var query struct {
-		Author []dgraph.User `json:"author"`
+func (rsv *Post) Author(ctx context.Context) (*User, error) {
+	author, err := rsv.root.loaders(ctx).user(ctx, rsv.authorUID)
+	if err != nil || author == nil {
+		return nil, err
 	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query Author($nodeId: string) {
-			author(func: uid($nodeId)) {
-				uid
-				User.id
-				User.creation
-				User.email
-				User.displayName
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.authorUID,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
-	}
-
-	author := query.Author[0]
 	return &User{
 		root:        rsv.root,
-		uid:         rsv.authorUID,
+		uid:         author.UID,
 		id:          store.ID(author.ID),
 		creation:    author.Creation,
 		email:       author.Email,
 		displayName: author.DisplayName,
-	}
+	}, nil
 }
 
 // Creation resolves Post.creation
@@ -68,6 +48,16 @@ func (rsv *Post) Creation() graphql.Time {
 	}
 }
 
+// LastEdited resolves Post.lastEdited
+func (rsv *Post) LastEdited() *graphql.Time {
+	if rsv.lastEdited.IsZero() {
+		return nil
+	}
+	return &graphql.Time{
+		Time: rsv.lastEdited,
+	}
+}
+
 // Title resolves Post.title
 func (rsv *Post) Title() string {
 	return rsv.title
@@ -78,54 +68,60 @@ func (rsv *Post) Contents() string {
 	return rsv.contents
 }
 
-// Reactions resolves Post.reactions
-func (rsv *Post) Reactions(ctx context.Context) []*Reaction {
-	var query struct {
-		Posts []dgraph.Post `json:"posts"`
-	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query ReactionsToPost($nodeId: string) {
-			posts(func: uid($nodeId)) {
-				Post.reactions {
-					uid
-					Reaction.id
-					Reaction.creation
-					Reaction.emotion
-					Reaction.message
-					Reaction.author {
-						uid
-					}
-				}
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.uid,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
+// Hidden resolves Post.hidden
+func (rsv *Post) Hidden(ctx context.Context) (bool, error) {
+	post, err := rsv.root.loaders(ctx).post(ctx, rsv.uid)
+	if err != nil || post == nil {
+		return false, err
 	}
+	return post.Hidden, nil
+}
 
-	if len(query.Posts) < 1 {
-		return nil
+// Reactions resolves Post.reactions
+func (rsv *Post) Reactions(
+	ctx context.Context,
+	params ConnectionParams,
+) (*ReactionConnection, error) {
+	conn, err := rsv.root.reactionConnection(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    "Post.reactions",
+		filter:  visibilityFilter(ctx, "Reaction.hidden"),
+	}, params)
+	if err != nil {
+		return nil, err
 	}
+	return conn, nil
+}
 
-	post := query.Posts[0]
-	resolvers := make([]*Reaction, len(post.Reactions))
-	for i, reaction := range post.Reactions {
-		resolvers[i] = &Reaction{
-			root:       rsv.root,
-			uid:        reaction.UID,
-			id:         reaction.ID,
-			subjectUID: rsv.uid,
-			authorUID:  reaction.Author[0].UID,
-			creation:   reaction.Creation,
-			emotion:    reaction.Emotion,
-			message:    reaction.Message,
+// Revisions resolves Post.revisions
+func (rsv *Post) Revisions(ctx context.Context) ([]*PostRevision, error) {
+	revisions, err := rsv.root.loaders(ctx).postRevisions(ctx, rsv.uid)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PostRevision, len(revisions))
+	for i, revision := range revisions {
+		result[i] = &PostRevision{
+			root:     rsv.root,
+			uid:      revision.UID,
+			id:       revision.ID,
+			creation: revision.Creation,
+			title:    revision.Title,
+			contents: revision.Contents,
+		}
+		if len(revision.Editor) > 0 {
+			result[i].editorUID = revision.Editor[0].UID
 		}
 	}
+	return result, nil
+}
 
-	return resolvers
+// ReactionStats resolves Post.reactionStats
+func (rsv *Post) ReactionStats(
+	ctx context.Context,
+	params struct {
+		Nested bool
+	},
+) (*ReactionStats, error) {
+	return rsv.root.reactionStats(ctx, rsv.uid, params.Nested)
 }
@@ -0,0 +1,85 @@
+package resolver
+
+import "context"
+
+// PostConnection represents the resolver of the identically named type
+type PostConnection struct {
+	page  page
+	edges []*PostEdge
+}
+
+// Edges resolves PostConnection.edges
+func (rsv *PostConnection) Edges() []*PostEdge {
+	return rsv.edges
+}
+
+// PageInfo resolves PostConnection.pageInfo
+func (rsv *PostConnection) PageInfo() *PageInfo {
+	return &PageInfo{page: rsv.page}
+}
+
+// TotalCount resolves PostConnection.totalCount
+func (rsv *PostConnection) TotalCount() int32 {
+	return int32(rsv.page.totalCount)
+}
+
+// PostEdge represents the resolver of the identically named type
+type PostEdge struct {
+	node *Post
+}
+
+// Cursor resolves PostEdge.cursor
+func (rsv *PostEdge) Cursor() Cursor {
+	return newCursor(rsv.node.uid)
+}
+
+// Node resolves PostEdge.node
+func (rsv *PostEdge) Node() *Post {
+	return rsv.node
+}
+
+// postConnection resolves a page of the given list of posts
+func (rsv *Resolver) postConnection(
+	ctx context.Context,
+	lst list,
+	params ConnectionParams,
+) (*PostConnection, error) {
+	pg, err := rsv.page(ctx, lst, params)
+	if err != nil {
+		return nil, err
+	}
+	return rsv.postConnectionPage(ctx, pg)
+}
+
+// postConnectionPage resolves the posts of a resolved page
+func (rsv *Resolver) postConnectionPage(
+	ctx context.Context,
+	pg page,
+) (*PostConnection, error) {
+	conn := &PostConnection{
+		page:  pg,
+		edges: make([]*PostEdge, 0, len(pg.uids)),
+	}
+	if len(pg.uids) < 1 {
+		return conn, nil
+	}
+
+	posts, err := rsv.loaders(ctx).allPosts(ctx, pg.uids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, post := range posts {
+		conn.edges = append(conn.edges, &PostEdge{node: &Post{
+			root:       rsv,
+			uid:        post.UID,
+			id:         post.ID,
+			title:      post.Title,
+			contents:   post.Contents,
+			creation:   post.Creation,
+			lastEdited: post.LastEdited,
+			authorUID:  post.Author[0].UID,
+		}})
+	}
+	return conn, nil
+}
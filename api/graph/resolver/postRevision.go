@@ -0,0 +1,61 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// PostRevision represents the resolver of the identically named type
+type PostRevision struct {
+	root      *Resolver
+	uid       string
+	editorUID string
+	id        store.ID
+	creation  time.Time
+	title     string
+	contents  string
+}
+
+// ID resolves PostRevision.id
+func (rsv *PostRevision) ID() store.ID {
+	return rsv.id
+}
+
+// Creation resolves PostRevision.creation
+func (rsv *PostRevision) Creation() graphql.Time {
+	return graphql.Time{
+		Time: rsv.creation,
+	}
+}
+
+// Editor resolves PostRevision.editor
+func (rsv *PostRevision) Editor(ctx context.Context) (*User, error) {
+	if rsv.editorUID == "" {
+		return nil, nil
+	}
+	editor, err := rsv.root.loaders(ctx).user(ctx, rsv.editorUID)
+	if err != nil || editor == nil {
+		return nil, err
+	}
+	return &User{
+		root:        rsv.root,
+		uid:         editor.UID,
+		id:          store.ID(editor.ID),
+		creation:    editor.Creation,
+		email:       editor.Email,
+		displayName: editor.DisplayName,
+	}, nil
+}
+
+// Title resolves PostRevision.title
+func (rsv *PostRevision) Title() string {
+	return rsv.title
+}
+
+// Contents resolves PostRevision.contents
+func (rsv *PostRevision) Contents() string {
+	return rsv.contents
+}
@@ -2,13 +2,10 @@ package resolver
 
 import (
 	"context"
-	"reflect"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
-	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/store"
-	"github.com/romshark/dgraph_graphql_go/store/dgraph"
 	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
 )
 
@@ -37,100 +34,16 @@ func (rsv *Reaction) Creation() graphql.Time {
 }
 
 // Subject resolves Reaction.subject
-func (rsv *Reaction) Subject(ctx context.Context) *ReactionSubject {
-	var query struct {
-		Subject []dgraph.ReactionSubject `json:"subject"`
-	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query ReactionSubject($nodeId: string) {
-			subject(func: uid($nodeId)) {
-				uid
-
-				Post.id
-				Post.creation
-				Post.author {
-					uid
-				}
-				Post.title
-				Post.contents
-
-				Reaction.id
-				Reaction.creation
-				Reaction.author {
-					uid
-				}
-				Reaction.message
-				Reaction.emotion
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.subjectUID,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
-	}
-
-	subject := query.Subject[0]
-
-	switch v := subject.V.(type) {
-	case *dgraph.Post:
-		return &ReactionSubject{&Post{
-			root:      rsv.root,
-			uid:       v.UID,
-			id:        v.ID,
-			creation:  v.Creation,
-			title:     v.Title,
-			contents:  v.Contents,
-			authorUID: v.Author[0].UID,
-		}}
-	case *dgraph.Reaction:
-		return &ReactionSubject{&Reaction{
-			root:       rsv.root,
-			uid:        v.UID,
-			authorUID:  v.Author[0].UID,
-			subjectUID: rsv.uid,
-			id:         v.ID,
-			creation:   v.Creation,
-			emotion:    v.Emotion,
-			message:    v.Message,
-		}}
-	}
-	rsv.root.error(ctx, errors.Errorf(
-		"unsupported union ReactionSubject type: %s",
-		reflect.TypeOf(subject.V),
-	))
-	return nil
+func (rsv *Reaction) Subject(ctx context.Context) (*ReactionSubject, error) {
+	return rsv.root.reactionSubject(ctx, rsv.subjectUID)
 }
 
 // Author resolves Reaction.author
 func (rsv *Reaction) Author(ctx context.Context) (*User, error) {
-	var query struct {
-		Author []dgraph.User `json:"author"`
-	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query ReactionAuthor($nodeId: string) {
-			author(func: uid($nodeId)) {
-				uid
-				User.id
-				User.creation
-				User.email
-				User.displayName
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.authorUID,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
+	author, err := rsv.root.loaders(ctx).user(ctx, rsv.authorUID)
+	if err != nil || author == nil {
 		return nil, err
 	}
-
-	author := query.Author[0]
 	return &User{
 		root:        rsv.root,
 		uid:         author.UID,
@@ -151,53 +64,61 @@ func (rsv *Reaction) Message() string {
 	return rsv.message
 }
 
-// Reactions resolves Reaction.reactions
-func (rsv *Reaction) Reactions(ctx context.Context) ([]*Reaction, error) {
-	var query struct {
-		Reaction []dgraph.Reaction `json:"reaction"`
+// Hidden resolves Reaction.hidden
+func (rsv *Reaction) Hidden(ctx context.Context) (bool, error) {
+	reaction, err := rsv.root.loaders(ctx).reaction(ctx, rsv.uid)
+	if err != nil || reaction == nil {
+		return false, err
 	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query SubReactions($nodeId: string) {
-			reaction(func: uid($nodeId)) {
-				Reaction.reactions {
-					uid
-					Reaction.id
-					Reaction.creation
-					Reaction.emotion
-					Reaction.message
-					Reaction.author {
-						uid
-					}
-				}
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.uid,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
+	return reaction.Hidden, nil
+}
+
+// Reactions resolves Reaction.reactions
+func (rsv *Reaction) Reactions(
+	ctx context.Context,
+	params ConnectionParams,
+) (*ReactionConnection, error) {
+	conn, err := rsv.root.reactionConnection(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    "Reaction.reactions",
+		filter:  visibilityFilter(ctx, "Reaction.hidden"),
+	}, params)
+	if err != nil {
 		return nil, err
 	}
+	return conn, nil
+}
 
-	if len(query.Reaction) < 1 {
-		return nil, nil
+// Revisions resolves Reaction.revisions
+func (rsv *Reaction) Revisions(
+	ctx context.Context,
+) ([]*ReactionRevision, error) {
+	revisions, err := rsv.root.loaders(ctx).reactionRevisions(ctx, rsv.uid)
+	if err != nil {
+		return nil, err
 	}
-
-	resolvers := make([]*Reaction, len(query.Reaction[0].Reactions))
-	for i, subReaction := range query.Reaction[0].Reactions {
-		resolvers[i] = &Reaction{
-			root:       rsv.root,
-			uid:        subReaction.UID,
-			id:         subReaction.ID,
-			authorUID:  subReaction.Author[0].UID,
-			subjectUID: rsv.uid,
-			creation:   subReaction.Creation,
-			emotion:    subReaction.Emotion,
-			message:    subReaction.Message,
+	result := make([]*ReactionRevision, len(revisions))
+	for i, revision := range revisions {
+		result[i] = &ReactionRevision{
+			root:     rsv.root,
+			uid:      revision.UID,
+			id:       revision.ID,
+			creation: revision.Creation,
+			message:  revision.Message,
+		}
+		if len(revision.Editor) > 0 {
+			result[i].editorUID = revision.Editor[0].UID
 		}
 	}
+	return result, nil
+}
 
-	return resolvers, nil
+// ReactionStats resolves Reaction.reactionStats
+func (rsv *Reaction) ReactionStats(
+	ctx context.Context,
+	params struct {
+		Nested bool
+	},
+) (*ReactionStats, error) {
+	return rsv.root.reactionStats(ctx, rsv.uid, params.Nested)
 }
@@ -0,0 +1,77 @@
+package resolver
+
+import "context"
+
+// ReactionConnection represents the resolver of the identically named type
+type ReactionConnection struct {
+	page  page
+	edges []*ReactionEdge
+}
+
+// Edges resolves ReactionConnection.edges
+func (rsv *ReactionConnection) Edges() []*ReactionEdge {
+	return rsv.edges
+}
+
+// PageInfo resolves ReactionConnection.pageInfo
+func (rsv *ReactionConnection) PageInfo() *PageInfo {
+	return &PageInfo{page: rsv.page}
+}
+
+// TotalCount resolves ReactionConnection.totalCount
+func (rsv *ReactionConnection) TotalCount() int32 {
+	return int32(rsv.page.totalCount)
+}
+
+// ReactionEdge represents the resolver of the identically named type
+type ReactionEdge struct {
+	node *Reaction
+}
+
+// Cursor resolves ReactionEdge.cursor
+func (rsv *ReactionEdge) Cursor() Cursor {
+	return newCursor(rsv.node.uid)
+}
+
+// Node resolves ReactionEdge.node
+func (rsv *ReactionEdge) Node() *Reaction {
+	return rsv.node
+}
+
+// reactionConnection resolves a page of the given list of reactions
+func (rsv *Resolver) reactionConnection(
+	ctx context.Context,
+	lst list,
+	params ConnectionParams,
+) (*ReactionConnection, error) {
+	pg, err := rsv.page(ctx, lst, params)
+	if err != nil {
+		return nil, err
+	}
+	conn := &ReactionConnection{
+		page:  pg,
+		edges: make([]*ReactionEdge, 0, len(pg.uids)),
+	}
+	if len(pg.uids) < 1 {
+		return conn, nil
+	}
+
+	reactions, err := rsv.loaders(ctx).allReactions(ctx, pg.uids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		conn.edges = append(conn.edges, &ReactionEdge{node: &Reaction{
+			root:       rsv,
+			uid:        reaction.UID,
+			id:         reaction.ID,
+			emotion:    reaction.Emotion,
+			message:    reaction.Message,
+			creation:   reaction.Creation,
+			authorUID:  reaction.Author[0].UID,
+			subjectUID: *reaction.Subject[0].UID(),
+		}})
+	}
+	return conn, nil
+}
@@ -0,0 +1,55 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// ReactionRevision represents the resolver of the identically named type
+type ReactionRevision struct {
+	root      *Resolver
+	uid       string
+	editorUID string
+	id        store.ID
+	creation  time.Time
+	message   string
+}
+
+// ID resolves ReactionRevision.id
+func (rsv *ReactionRevision) ID() store.ID {
+	return rsv.id
+}
+
+// Creation resolves ReactionRevision.creation
+func (rsv *ReactionRevision) Creation() graphql.Time {
+	return graphql.Time{
+		Time: rsv.creation,
+	}
+}
+
+// Editor resolves ReactionRevision.editor
+func (rsv *ReactionRevision) Editor(ctx context.Context) (*User, error) {
+	if rsv.editorUID == "" {
+		return nil, nil
+	}
+	editor, err := rsv.root.loaders(ctx).user(ctx, rsv.editorUID)
+	if err != nil || editor == nil {
+		return nil, err
+	}
+	return &User{
+		root:        rsv.root,
+		uid:         editor.UID,
+		id:          store.ID(editor.ID),
+		creation:    editor.Creation,
+		email:       editor.Email,
+		displayName: editor.DisplayName,
+	}, nil
+}
+
+// Message resolves ReactionRevision.message
+func (rsv *ReactionRevision) Message() string {
+	return rsv.message
+}
@@ -0,0 +1,191 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+)
+
+// emotionCounts represents the number of reactions per emotion
+type emotionCounts map[emotion.Emotion]int
+
+// add adds the counts of the groups of an emotion aggregation
+func (counts emotionCounts) add(stats []dgraph.ReactionStats) {
+	for _, stat := range stats {
+		for _, group := range stat.Groups {
+			counts[group.Emotion] += group.Count
+		}
+	}
+}
+
+// ReactionStats represents the resolver of the identically named type
+type ReactionStats struct {
+	counts emotionCounts
+}
+
+// Total resolves ReactionStats.total
+func (rsv *ReactionStats) Total() int32 {
+	total := 0
+	for _, count := range rsv.counts {
+		total += count
+	}
+	return int32(total)
+}
+
+// Emotions resolves ReactionStats.emotions
+func (rsv *ReactionStats) Emotions() []*EmotionCount {
+	values := emotion.Values()
+	emotions := make([]*EmotionCount, len(values))
+	for i, emo := range values {
+		emotions[i] = &EmotionCount{
+			emotion: emo,
+			count:   rsv.counts[emo],
+		}
+	}
+	return emotions
+}
+
+// EmotionCount represents the resolver of the identically named type
+type EmotionCount struct {
+	emotion emotion.Emotion
+	count   int
+}
+
+// Emotion resolves EmotionCount.emotion
+func (rsv *EmotionCount) Emotion() string {
+	return string(rsv.emotion)
+}
+
+// Count resolves EmotionCount.count
+func (rsv *EmotionCount) Count() int32 {
+	return int32(rsv.count)
+}
+
+// reactionStatsLevel represents the aggregated reactions
+// to a post or a reaction
+type reactionStatsLevel struct {
+	counts emotionCounts
+
+	// nested references the reactions to the node
+	nested []string
+}
+
+// fetchReactionStatsLevel aggregates the reactions to each of the given
+// nodes (either posts or reactions) grouped by emotion
+// omitting hidden reactions unless the client can moderate.
+// The reactions are only referenced if withNested is true
+func (rsv *Resolver) fetchReactionStatsLevel(
+	ctx context.Context,
+	uids []string,
+	withNested bool,
+) (map[string]*reactionStatsLevel, error) {
+	directive := filterDirective(visibilityFilter(ctx, "Reaction.hidden"))
+	nestedSelection := ""
+	if withNested {
+		nestedSelection = fmt.Sprintf(
+			`
+				nestedToPost: Post.reactions %s { uid }
+				nestedToReaction: Reaction.reactions %s { uid }
+			`,
+			directive,
+			directive,
+		)
+	}
+	var result struct {
+		Nodes []struct {
+			UID               string                 `json:"uid"`
+			PostReactions     []dgraph.ReactionStats `json:"Post.reactions"`
+			ReactionReactions []dgraph.ReactionStats `json:"Reaction.reactions"`
+			NestedToPost      []dgraph.UID           `json:"nestedToPost"`
+			NestedToReaction  []dgraph.UID           `json:"nestedToReaction"`
+		} `json:"nodes"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				nodes(func: %s) {
+					uid
+					Post.reactions %s @groupby(Reaction.emotion) {
+						count(uid)
+					}
+					Reaction.reactions %s @groupby(Reaction.emotion) {
+						count(uid)
+					}
+					%s
+				}
+			}`,
+			uidFunc(uids),
+			directive,
+			directive,
+			nestedSelection,
+		),
+		&result,
+	); err != nil {
+		return nil, err
+	}
+	levels := make(map[string]*reactionStatsLevel, len(result.Nodes))
+	for _, node := range result.Nodes {
+		level := &reactionStatsLevel{counts: emotionCounts{}}
+		level.counts.add(node.PostReactions)
+		level.counts.add(node.ReactionReactions)
+		for _, nested := range append(
+			node.NestedToPost,
+			node.NestedToReaction...,
+		) {
+			level.nested = append(level.nested, nested.NodeID)
+		}
+		levels[node.UID] = level
+	}
+	return levels, nil
+}
+
+func (rsv *Resolver) fetchReactionStats(
+	ctx context.Context,
+	uids []string,
+) (map[string]interface{}, error) {
+	levels, err := rsv.fetchReactionStatsLevel(ctx, uids, false)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(levels))
+	for uid, level := range levels {
+		values[uid] = level.counts
+	}
+	return values, nil
+}
+
+// reactionStats resolves the reaction statistics of the given node
+// (either a post or a reaction). Nested reactions are aggregated
+// level by level, a single query per level of depth
+func (rsv *Resolver) reactionStats(
+	ctx context.Context,
+	nodeUID string,
+	nested bool,
+) (*ReactionStats, error) {
+	if !nested {
+		counts, err := rsv.loaders(ctx).reactionStats(ctx, nodeUID)
+		if err != nil {
+			return nil, err
+		}
+		return &ReactionStats{counts: counts}, nil
+	}
+
+	stats := &ReactionStats{counts: emotionCounts{}}
+	for level := []string{nodeUID}; len(level) > 0; {
+		levels, err := rsv.fetchReactionStatsLevel(ctx, level, true)
+		if err != nil {
+			return nil, err
+		}
+		level = nil
+		for _, lvl := range levels {
+			for emo, count := range lvl.counts {
+				stats.counts[emo] += count
+			}
+			level = append(level, lvl.nested...)
+		}
+	}
+	return stats, nil
+}
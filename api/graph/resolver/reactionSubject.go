@@ -1,5 +1,13 @@
 package resolver
 
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+)
+
 // ReactionSubject implements the identically named union type
 type ReactionSubject struct {
 	subject interface{}
@@ -16,3 +24,44 @@ func (un *ReactionSubject) ToPost() (*Post, bool) {
 	res, ok := un.subject.(*Post)
 	return res, ok
 }
+
+// reactionSubject resolves the post or reaction identified by uid,
+// returns nil if it doesn't exist
+func (rsv *Resolver) reactionSubject(
+	ctx context.Context,
+	uid string,
+) (*ReactionSubject, error) {
+	subject, err := rsv.loaders(ctx).subject(ctx, uid)
+	if err != nil || subject == nil {
+		return nil, err
+	}
+
+	switch v := subject.V.(type) {
+	case *dgraph.Post:
+		return &ReactionSubject{&Post{
+			root:       rsv,
+			uid:        v.UID,
+			id:         v.ID,
+			creation:   v.Creation,
+			lastEdited: v.LastEdited,
+			title:      v.Title,
+			contents:   v.Contents,
+			authorUID:  v.Author[0].UID,
+		}}, nil
+	case *dgraph.Reaction:
+		return &ReactionSubject{&Reaction{
+			root:       rsv,
+			uid:        v.UID,
+			authorUID:  v.Author[0].UID,
+			subjectUID: *v.Subject[0].UID(),
+			id:         v.ID,
+			creation:   v.Creation,
+			emotion:    v.Emotion,
+			message:    v.Message,
+		}}, nil
+	}
+	return nil, errors.Errorf(
+		"unsupported union ReactionSubject type: %s",
+		reflect.TypeOf(subject.V),
+	)
+}
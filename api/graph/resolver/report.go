@@ -0,0 +1,86 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// Report represents the resolver of the identically named type
+type Report struct {
+	root        *Resolver
+	uid         string
+	reporterUID string
+	subjectUID  string
+	id          store.ID
+	creation    time.Time
+	reason      string
+	open        bool
+}
+
+// ID resolves Report.id
+func (rsv *Report) ID() store.ID {
+	return rsv.id
+}
+
+// Creation resolves Report.creation
+func (rsv *Report) Creation() graphql.Time {
+	return graphql.Time{
+		Time: rsv.creation,
+	}
+}
+
+// Reason resolves Report.reason
+func (rsv *Report) Reason() string {
+	return rsv.reason
+}
+
+// Open resolves Report.open
+func (rsv *Report) Open() bool {
+	return rsv.open
+}
+
+// Reporter resolves Report.reporter
+func (rsv *Report) Reporter(ctx context.Context) (*User, error) {
+	if rsv.reporterUID == "" {
+		return nil, nil
+	}
+	reporter, err := rsv.root.loaders(ctx).user(ctx, rsv.reporterUID)
+	if err != nil || reporter == nil {
+		return nil, err
+	}
+	return &User{
+		root:        rsv.root,
+		uid:         reporter.UID,
+		id:          store.ID(reporter.ID),
+		creation:    reporter.Creation,
+		email:       reporter.Email,
+		displayName: reporter.DisplayName,
+	}, nil
+}
+
+// Subject resolves Report.subject
+func (rsv *Report) Subject(ctx context.Context) (*ReactionSubject, error) {
+	return rsv.root.reactionSubject(ctx, rsv.subjectUID)
+}
+
+// newReport returns the resolver of the given report
+func (rsv *Resolver) newReport(report store.Report) *Report {
+	result := &Report{
+		root:     rsv,
+		uid:      report.UID,
+		id:       report.ID,
+		creation: report.Creation,
+		reason:   report.Reason,
+		open:     report.Open,
+	}
+	if report.Reporter != nil {
+		result.reporterUID = report.Reporter.UID
+	}
+	if report.Subject != nil {
+		result.subjectUID = report.Subject.NodeID()
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package resolver
+
+import "context"
+
+// ReportConnection represents the resolver of the identically named type
+type ReportConnection struct {
+	page  page
+	edges []*ReportEdge
+}
+
+// Edges resolves ReportConnection.edges
+func (rsv *ReportConnection) Edges() []*ReportEdge {
+	return rsv.edges
+}
+
+// PageInfo resolves ReportConnection.pageInfo
+func (rsv *ReportConnection) PageInfo() *PageInfo {
+	return &PageInfo{page: rsv.page}
+}
+
+// TotalCount resolves ReportConnection.totalCount
+func (rsv *ReportConnection) TotalCount() int32 {
+	return int32(rsv.page.totalCount)
+}
+
+// ReportEdge represents the resolver of the identically named type
+type ReportEdge struct {
+	node *Report
+}
+
+// Cursor resolves ReportEdge.cursor
+func (rsv *ReportEdge) Cursor() Cursor {
+	return newCursor(rsv.node.uid)
+}
+
+// Node resolves ReportEdge.node
+func (rsv *ReportEdge) Node() *Report {
+	return rsv.node
+}
+
+// reportConnection resolves a page of the given list of reports
+func (rsv *Resolver) reportConnection(
+	ctx context.Context,
+	lst list,
+	params ConnectionParams,
+) (*ReportConnection, error) {
+	pg, err := rsv.page(ctx, lst, params)
+	if err != nil {
+		return nil, err
+	}
+	conn := &ReportConnection{
+		page:  pg,
+		edges: make([]*ReportEdge, 0, len(pg.uids)),
+	}
+	if len(pg.uids) < 1 {
+		return conn, nil
+	}
+
+	reports, err := rsv.loaders(ctx).allReports(ctx, pg.uids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, report := range reports {
+		node := &Report{
+			root:     rsv,
+			uid:      report.UID,
+			id:       report.ID,
+			creation: report.Creation,
+			reason:   report.Reason,
+			open:     report.Open,
+		}
+		if len(report.Reporter) > 0 {
+			node.reporterUID = report.Reporter[0].UID
+		}
+		if len(report.Subject) > 0 {
+			node.subjectUID = report.Subject[0].NodeID
+		}
+		conn.edges = append(conn.edges, &ReportEdge{node: node})
+	}
+	return conn, nil
+}
@@ -2,8 +2,13 @@ package resolver
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/api/clock"
+	"github.com/romshark/dgraph_graphql_go/api/eventbus"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
 	"github.com/romshark/dgraph_graphql_go/api/passhash"
 	"github.com/romshark/dgraph_graphql_go/api/sesskeygen"
 	"github.com/romshark/dgraph_graphql_go/api/validator"
@@ -11,18 +16,24 @@ import (
 	"github.com/romshark/dgraph_graphql_go/store/dgraph"
 )
 
-// CtxKey represents a context.Context value key type
-type CtxKey int
-
-// CtxErrorRef defines the context.Context error reference value key
-const CtxErrorRef CtxKey = 1
-
 // Resolver represents the root Graph resolver
 type Resolver struct {
 	str                 store.Store
 	validator           validator.Validator
 	sessionKeyGenerator sesskeygen.SessionKeyGenerator
 	passwordHasher      passhash.PasswordHasher
+	sessionTTL          auth.SessionTTL
+	eventBus            eventbus.EventBus
+	mailer              mailer.Mailer
+	tokenTTL            auth.TokenTTL
+	clock               clock.Clock
+
+	// onUnexpectedErr is called for unexpected errors
+	// that don't fail the request
+	onUnexpectedErr func(error)
+
+	// background tracks the tasks outliving the requests
+	background sync.WaitGroup
 }
 
 // New creates a new graph resolver instance
@@ -31,6 +42,12 @@ func New(
 	validator validator.Validator,
 	sessionKeyGenerator sesskeygen.SessionKeyGenerator,
 	passwordHasher passhash.PasswordHasher,
+	sessionTTL auth.SessionTTL,
+	eventBus eventbus.EventBus,
+	mailer mailer.Mailer,
+	tokenTTL auth.TokenTTL,
+	clock clock.Clock,
+	onUnexpectedErr func(error),
 ) (*Resolver, error) {
 	if sessionKeyGenerator == nil {
 		return nil, errors.Errorf(
@@ -42,92 +59,73 @@ func New(
 			"missing password hasher during resolver initialization",
 		)
 	}
+	if eventBus == nil {
+		return nil, errors.Errorf(
+			"missing event bus during resolver initialization",
+		)
+	}
+	if mailer == nil {
+		return nil, errors.Errorf(
+			"missing mailer during resolver initialization",
+		)
+	}
+	if clock == nil {
+		return nil, errors.Errorf(
+			"missing clock during resolver initialization",
+		)
+	}
+	if onUnexpectedErr == nil {
+		return nil, errors.Errorf(
+			"missing unexpected error handler during resolver initialization",
+		)
+	}
 
 	return &Resolver{
 		str:                 str,
 		validator:           validator,
 		sessionKeyGenerator: sessionKeyGenerator,
 		passwordHasher:      passwordHasher,
+		sessionTTL:          sessionTTL,
+		eventBus:            eventBus,
+		mailer:              mailer,
+		tokenTTL:            tokenTTL,
+		clock:               clock,
+		onUnexpectedErr:     onUnexpectedErr,
 	}, nil
 }
 
+// AwaitBackgroundTasks blocks until all background tasks are done
+func (rsv *Resolver) AwaitBackgroundTasks() {
+	rsv.background.Wait()
+}
+
 // Users resolves Query.users
-func (rsv *Resolver) Users(ctx context.Context) []*User {
-	var result struct {
-		Users []dgraph.User `json:"users"`
-	}
-	if err := rsv.str.Query(
-		ctx,
-		`{
-			users(func: has(User.id)) {
-				uid
-				User.id
-				User.creation
-				User.email
-				User.displayName
-			}
-		}`,
-		&result,
-	); err != nil {
-		rsv.error(ctx, err)
-		return nil
-	}
-	resolvers := make([]*User, len(result.Users))
-	for i, usr := range result.Users {
-		resolvers[i] = &User{
-			root:        rsv,
-			uid:         usr.UID,
-			id:          store.ID(usr.ID),
-			displayName: usr.DisplayName,
-			email:       usr.Email,
-			creation:    usr.Creation,
-		}
+func (rsv *Resolver) Users(
+	ctx context.Context,
+	params ConnectionParams,
+) (*UserConnection, error) {
+	conn, err := rsv.userConnection(ctx, list{
+		rootFunc: "has(User.id)",
+	}, params)
+	if err != nil {
+		return nil, err
 	}
-	return resolvers
+	return conn, nil
 }
 
 // Posts resolves Query.posts
-func (rsv *Resolver) Posts(ctx context.Context) []*Post {
-	var result struct {
-		Posts []dgraph.Post `json:"posts"`
-	}
-	if err := rsv.str.Query(
-		ctx,
-		`{
-			posts(func: has(Post.id)) {
-				uid
-				Post.id
-				Post.creation
-				Post.title
-				Post.contents
-				Post.author {
-					uid
-				}
-			}
-		}`,
-		&result,
-	); err != nil {
-		rsv.error(ctx, err)
-		return nil
-	}
-
-	if len(result.Posts) < 1 {
-		return nil
-	}
-
-	resolvers := make([]*Post, len(result.Posts))
-	for i, post := range result.Posts {
-		resolvers[i] = &Post{
-			root:      rsv,
-			uid:       post.UID,
-			id:        post.ID,
-			title:     post.Title,
-			contents:  post.Contents,
-			creation:  post.Creation,
-			authorUID: post.Author[0].UID,
-		}
+func (rsv *Resolver) Posts(
+	ctx context.Context,
+	params ConnectionParams,
+) (*PostConnection, error) {
+	conn, err := rsv.postConnection(ctx, list{
+		rootFunc: "has(Post.id)",
+		filter:   visibilityFilter(ctx, "Post.hidden"),
+	}, params)
+	if err != nil {
+		return nil, err
 	}
-	return resolvers
+	return conn, nil
 }
 
 // User resolves Query.user
@@ -136,7 +134,7 @@ func (rsv *Resolver) User(
 	params struct {
 		ID string
 	},
-) *User {
+) (*User, error) {
 	var result struct {
 		Users []dgraph.User `json:"users"`
 	}
@@ -156,11 +154,10 @@ func (rsv *Resolver) User(
 		},
 		&result,
 	); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if len(result.Users) < 1 {
-		return nil
+		return nil, nil
 	}
 
 	usr := result.Users[0]
@@ -171,7 +168,7 @@ func (rsv *Resolver) User(
 		displayName: usr.DisplayName,
 		email:       usr.Email,
 		creation:    usr.Creation,
-	}
+	}, nil
 }
 
 // Post resolves Query.post
@@ -180,7 +177,7 @@ func (rsv *Resolver) Post(
 	params struct {
 		ID string
 	},
-) *Post {
+) (*Post, error) {
 	var result struct {
 		Posts []dgraph.Post `json:"posts"`
 	}
@@ -191,6 +188,7 @@ func (rsv *Resolver) Post(
 				uid
 				Post.id
 				Post.creation
+				Post.lastEdited
 				Post.title
 				Post.contents
 				Post.author {
@@ -203,23 +201,23 @@ func (rsv *Resolver) Post(
 		},
 		&result,
 	); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if len(result.Posts) < 1 {
-		return nil
+		return nil, nil
 	}
 
 	post := result.Posts[0]
 	return &Post{
-		root:      rsv,
-		uid:       post.UID,
-		id:        post.ID,
-		title:     post.Title,
-		contents:  post.Contents,
-		creation:  post.Creation,
-		authorUID: post.Author[0].UID,
-	}
+		root:       rsv,
+		uid:        post.UID,
+		id:         post.ID,
+		title:      post.Title,
+		contents:   post.Contents,
+		creation:   post.Creation,
+		lastEdited: post.LastEdited,
+		authorUID:  post.Author[0].UID,
+	}, nil
 }
 
 // Reaction resolves Query.reaction
@@ -228,7 +226,7 @@ func (rsv *Resolver) Reaction(
 	params struct {
 		ID string
 	},
-) *Reaction {
+) (*Reaction, error) {
 	var result struct {
 		Reactions []dgraph.Reaction `json:"reactions"`
 	}
@@ -256,11 +254,10 @@ func (rsv *Resolver) Reaction(
 		},
 		&result,
 	); err != nil {
-		rsv.error(ctx, err)
-		return nil
+		return nil, err
 	}
 	if len(result.Reactions) < 1 {
-		return nil
+		return nil, nil
 	}
 
 	reaction := result.Reactions[0]
@@ -273,11 +270,5 @@ func (rsv *Resolver) Reaction(
 		creation:   reaction.Creation,
 		authorUID:  reaction.Author[0].UID,
 		subjectUID: *reaction.Subject[0].UID(),
-	}
-}
-
-// error writes an error to the resolver context for the API server to read
-func (rsv *Resolver) error(ctx context.Context, err error) {
-	ctxErr := ctx.Value(CtxErrorRef).(*error)
-	*ctxErr = err
+	}, nil
 }
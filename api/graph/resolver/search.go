@@ -0,0 +1,272 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+const (
+	// maxSearchTextLen defines the maximum length of a post search text
+	maxSearchTextLen = 256
+
+	// minSearchPrefixLen defines the minimum length of a user search prefix,
+	// shorter prefixes can't be looked up in the trigram index
+	minSearchPrefixLen = 3
+
+	// maxSearchPrefixLen defines the maximum length of a user search prefix
+	maxSearchPrefixLen = 64
+
+	// maxSearchCandidates defines the maximum number of matching users
+	// ranked per user search
+	maxSearchCandidates = 1000
+
+	// maxSearchUsers defines the maximum number of users returned
+	// by a user search
+	maxSearchUsers = defaultPageSize
+)
+
+// searchWords splits the text into distinct lower-case words
+func searchWords(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	distinct := make([]string, 0, len(words))
+	known := make(map[string]bool, len(words))
+	for _, word := range words {
+		if !known[word] {
+			known[word] = true
+			distinct = append(distinct, word)
+		}
+	}
+	return distinct
+}
+
+// reverseUIDs returns the uids in reverse order
+func reverseUIDs(uids []dgraph.UID) []string {
+	reversed := make([]string, len(uids))
+	for i, uid := range uids {
+		reversed[len(uids)-1-i] = uid.NodeID
+	}
+	return reversed
+}
+
+// SearchPosts resolves Query.searchPosts
+func (rsv *Resolver) SearchPosts(
+	ctx context.Context,
+	params struct {
+		Text  string
+		First *int32
+		After *Cursor
+	},
+) (*PostConnection, error) {
+	if len(params.Text) > maxSearchTextLen {
+		return nil, strerr.Newf(
+			strerr.ErrInvalidInput,
+			"search text too long (%d / %d)",
+			len(params.Text),
+			maxSearchTextLen,
+		)
+	}
+	words := searchWords(params.Text)
+	if len(words) < 1 {
+		return nil, strerr.New(
+			strerr.ErrInvalidInput,
+			"search text contains no words",
+		)
+	}
+	args, err := ConnectionParams{
+		First: params.First,
+		After: params.After,
+	}.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Posts matching in the title rank higher than posts matching
+	// in the contents only, newer posts rank higher within either.
+	// Newer posts have greater uids, the last posts of both lists
+	// preceding the cursor (if any) are thus selected.
+	// An additional post is requested to find out
+	// whether there's a next page
+	pageArgs := fmt.Sprintf("first: %d", -(args.first + 1))
+	cursorBlocks := ""
+	pageFilter := ""
+	if args.after != "" {
+		cursorBlocks = fmt.Sprintf(
+			`cursorTitle(func: uid(%s)) @filter(uid(titleMatches)) { uid }
+			cursorContents(func: uid(%s)) @filter(uid(contentsMatches)) {
+				uid
+			}
+			%s as var(func: uid(titleMatches, contentsMatches), after: %s)
+			contentsAll(func: uid(contentsMatches), %s) { uid }`,
+			args.after,
+			args.after,
+			beforeVar,
+			args.after,
+			pageArgs,
+		)
+		pageFilter = filterDirective(beforeFilter(args.after))
+	}
+	visibility := visibilityFilter(ctx, "Post.hidden")
+	var qr struct {
+		Total []struct {
+			Count int `json:"count"`
+		} `json:"total"`
+		CursorTitle    []dgraph.UID `json:"cursorTitle"`
+		CursorContents []dgraph.UID `json:"cursorContents"`
+		Title          []dgraph.UID `json:"title"`
+		Contents       []dgraph.UID `json:"contents"`
+		ContentsAll    []dgraph.UID `json:"contentsAll"`
+	}
+	if err := rsv.str.QueryVars(
+		ctx,
+		fmt.Sprintf(
+			`query SearchPosts($text: string) {
+				titleMatches as var(func: anyoftext(Post.title, $text)) %s
+				contentsMatches as var(
+					func: anyoftext(Post.contents, $text)
+				) %s
+				total(func: uid(titleMatches, contentsMatches)) { count(uid) }
+				%s
+				title(func: uid(titleMatches), %s) %s { uid }
+				contents(func: uid(contentsMatches), %s) %s { uid }
+			}`,
+			filterDirective(visibility),
+			filterDirective("not uid(titleMatches)", visibility),
+			cursorBlocks,
+			pageArgs,
+			pageFilter,
+			pageArgs,
+			pageFilter,
+		),
+		map[string]string{
+			"$text": strings.Join(words, " "),
+		},
+		&qr,
+	); err != nil {
+		return nil, err
+	}
+
+	pg := page{hasPreviousPage: args.after != ""}
+	if len(qr.Total) > 0 {
+		pg.totalCount = qr.Total[0].Count
+	}
+	switch {
+	case args.after == "":
+		pg.uids = append(reverseUIDs(qr.Title), reverseUIDs(qr.Contents)...)
+	case len(qr.CursorTitle) > 0:
+		// All posts matching in the contents only follow
+		// the posts matching in the title
+		pg.uids = append(
+			reverseUIDs(qr.Title),
+			reverseUIDs(qr.ContentsAll)...,
+		)
+	case len(qr.CursorContents) > 0:
+		pg.uids = reverseUIDs(qr.Contents)
+	default:
+		return nil, strerr.New(
+			strerr.ErrInvalidInput,
+			"cursor doesn't point to a search result",
+		)
+	}
+	if len(pg.uids) > args.first {
+		pg.hasNextPage = true
+		pg.uids = pg.uids[:args.first]
+	}
+	return rsv.postConnectionPage(ctx, pg)
+}
+
+// SearchUsers resolves Query.searchUsers
+func (rsv *Resolver) SearchUsers(
+	ctx context.Context,
+	params struct {
+		Prefix string
+	},
+) ([]*User, error) {
+	prefix := strings.TrimSpace(params.Prefix)
+	if len(prefix) < minSearchPrefixLen || len(prefix) > maxSearchPrefixLen {
+		return nil, strerr.Newf(
+			strerr.ErrInvalidInput,
+			"search prefix must be between %d and %d characters long",
+			minSearchPrefixLen,
+			maxSearchPrefixLen,
+		)
+	}
+	for _, r := range prefix {
+		if !unicode.IsPrint(r) {
+			return nil, strerr.New(
+				strerr.ErrInvalidInput,
+				"search prefix contains non-printable characters",
+			)
+		}
+	}
+
+	// Regular expressions can't be passed as variables,
+	// the prefix is therefore escaped and embedded into the query
+	expr := strings.Replace(regexp.QuoteMeta(prefix), "/", `\/`, -1)
+
+	var qr struct {
+		Users []dgraph.User `json:"users"`
+	}
+	if err := rsv.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{
+				users(
+					func: regexp(User.displayName, /^%s/i),
+					first: %d
+				) {
+					uid
+					User.id
+					User.creation
+					User.email
+					User.displayName
+				}
+			}`,
+			expr,
+			maxSearchCandidates,
+		),
+		&qr,
+	); err != nil {
+		return nil, err
+	}
+
+	// Rank exact matches first followed by the shortest
+	// and alphabetically lowest display names
+	users := qr.Users
+	sort.Slice(users, func(i, j int) bool {
+		iExact := strings.EqualFold(users[i].DisplayName, prefix)
+		jExact := strings.EqualFold(users[j].DisplayName, prefix)
+		if iExact != jExact {
+			return iExact
+		}
+		if len(users[i].DisplayName) != len(users[j].DisplayName) {
+			return len(users[i].DisplayName) < len(users[j].DisplayName)
+		}
+		return users[i].DisplayName < users[j].DisplayName
+	})
+	if len(users) > maxSearchUsers {
+		users = users[:maxSearchUsers]
+	}
+
+	result := make([]*User, len(users))
+	for i, usr := range users {
+		result[i] = &User{
+			root:        rsv,
+			uid:         usr.UID,
+			id:          store.ID(usr.ID),
+			displayName: usr.DisplayName,
+			email:       usr.Email,
+			creation:    usr.Creation,
+		}
+	}
+	return result, nil
+}
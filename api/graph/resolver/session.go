@@ -6,16 +6,16 @@ import (
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/romshark/dgraph_graphql_go/store"
-	"github.com/romshark/dgraph_graphql_go/store/dgraph"
 )
 
 // Session represents the resolver of the identically named type
 type Session struct {
-	root     *Resolver
-	uid      string
-	key      string
-	creation time.Time
-	userUID  string
+	root       *Resolver
+	uid        string
+	key        string
+	creation   time.Time
+	lastAccess time.Time
+	userUID    string
 }
 
 // Key resolves Session.key
@@ -28,36 +28,26 @@ func (rsv *Session) Creation() graphql.Time {
 	return graphql.Time{Time: rsv.creation}
 }
 
+// LastAccess resolves Session.lastAccess
+func (rsv *Session) LastAccess() graphql.Time {
+	return graphql.Time{Time: rsv.lastAccess}
+}
+
+// ExpiresAt resolves Session.expiresAt
+func (rsv *Session) ExpiresAt() graphql.Time {
+	return graphql.Time{
+		Time: rsv.root.sessionTTL.ExpiresAt(rsv.creation, rsv.lastAccess),
+	}
+}
+
 // User resolves Session.user
 func (rsv *Session) User(
 	ctx context.Context,
-) *User {
-	var query struct {
-		Sessions []dgraph.Session `json:"session"`
-	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query SessionUser($nodeId: string) {
-			session(func: uid($nodeId)) {
-				Session.user {
-					uid
-					User.id
-					User.creation
-					User.email
-					User.displayName
-				}
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.uid,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
+) (*User, error) {
+	owner, err := rsv.root.loaders(ctx).sessionUser(ctx, rsv.uid)
+	if err != nil || owner == nil {
+		return nil, err
 	}
-
-	owner := query.Sessions[0].User[0]
 	return &User{
 		root:        rsv.root,
 		uid:         owner.UID,
@@ -65,5 +55,5 @@ func (rsv *Session) User(
 		creation:    owner.Creation,
 		email:       owner.Email,
 		displayName: owner.DisplayName,
-	}
+	}, nil
 }
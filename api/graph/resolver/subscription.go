@@ -0,0 +1,89 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/eventbus"
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+const (
+	topicPostCreated     eventbus.Topic = "postCreated"
+	topicPostEdited      eventbus.Topic = "postEdited"
+	topicReactionCreated eventbus.Topic = "reactionCreated"
+)
+
+// reactionCreatedEvent represents the event published on reaction creation
+type reactionCreatedEvent struct {
+	reaction *Reaction
+	subject  store.ID
+}
+
+// PostCreated resolves Subscription.postCreated
+func (rsv *Resolver) PostCreated(ctx context.Context) <-chan *Post {
+	events := rsv.eventBus.Subscribe(ctx, topicPostCreated)
+	stream := make(chan *Post)
+	go func() {
+		defer close(stream)
+		for event := range events {
+			select {
+			case stream <- event.(*Post):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stream
+}
+
+// ReactionCreated resolves Subscription.reactionCreated
+func (rsv *Resolver) ReactionCreated(
+	ctx context.Context,
+	params struct {
+		Subject string
+	},
+) <-chan *Reaction {
+	events := rsv.eventBus.Subscribe(ctx, topicReactionCreated)
+	stream := make(chan *Reaction)
+	go func() {
+		defer close(stream)
+		for event := range events {
+			created := event.(reactionCreatedEvent)
+			if created.subject != store.ID(params.Subject) {
+				continue
+			}
+			select {
+			case stream <- created.reaction:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stream
+}
+
+// PostEdited resolves Subscription.postEdited
+func (rsv *Resolver) PostEdited(
+	ctx context.Context,
+	params struct {
+		ID string
+	},
+) <-chan *Post {
+	events := rsv.eventBus.Subscribe(ctx, topicPostEdited)
+	stream := make(chan *Post)
+	go func() {
+		defer close(stream)
+		for event := range events {
+			post := event.(*Post)
+			if post.id != store.ID(params.ID) {
+				continue
+			}
+			select {
+			case stream <- post:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stream
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
 	"github.com/romshark/dgraph_graphql_go/store"
-	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 )
 
 // User represents the resolver of the identically named type
@@ -33,16 +33,31 @@ func (rsv *User) Creation() graphql.Time {
 }
 
 // Email resolves User.email
-func (rsv *User) Email(ctx context.Context) string {
+func (rsv *User) Email(ctx context.Context) (string, error) {
 	// Check permissions
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(rsv.id),
 	}); err != nil {
-		rsv.root.error(ctx, err)
-		return ""
+		return "", err
 	}
 
-	return rsv.email
+	return rsv.email, nil
+}
+
+// EmailVerified resolves User.emailVerified
+func (rsv *User) EmailVerified(ctx context.Context) (bool, error) {
+	// Check permissions
+	if err := auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(rsv.id),
+	}); err != nil {
+		return false, err
+	}
+
+	usr, err := rsv.root.loaders(ctx).user(ctx, rsv.uid)
+	if err != nil || usr == nil {
+		return false, err
+	}
+	return usr.EmailVerified, nil
 }
 
 // DisplayName resolves User.displayName
@@ -50,169 +65,164 @@ func (rsv *User) DisplayName() string {
 	return rsv.displayName
 }
 
+// Roles resolves User.roles
+func (rsv *User) Roles(ctx context.Context) ([]string, error) {
+	usr, err := rsv.root.loaders(ctx).user(ctx, rsv.uid)
+	if err != nil || usr == nil {
+		return nil, err
+	}
+	roles := role.Normalize(usr.Roles)
+	result := make([]string, len(roles))
+	for i, r := range roles {
+		result[i] = string(r)
+	}
+	return result, nil
+}
+
 // Posts resolves User.posts
 func (rsv *User) Posts(
 	ctx context.Context,
-) []*Post {
-	var query struct {
-		Users []dgraph.User `json:"users"`
-	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query Posts($nodeId: string) {
-			users(func: uid($nodeId)) {
-				User.posts {
-					uid
-					Post.id
-					Post.creation
-					Post.title
-					Post.contents
-				}
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.uid,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
-	}
-
-	if len(query.Users) < 1 {
-		return nil
-	}
-
-	usr := query.Users[0]
-	resolvers := make([]*Post, len(usr.Posts))
-	for i, post := range usr.Posts {
-		resolvers[i] = &Post{
-			root:      rsv.root,
-			uid:       post.UID,
-			id:        post.ID,
-			creation:  post.Creation,
-			title:     post.Title,
-			contents:  post.Contents,
-			authorUID: rsv.uid,
-		}
+	params ConnectionParams,
+) (*PostConnection, error) {
+	conn, err := rsv.root.postConnection(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    "User.posts",
+		filter:  visibilityFilter(ctx, "Post.hidden"),
+	}, params)
+	if err != nil {
+		return nil, err
 	}
-
-	return resolvers
+	return conn, nil
 }
 
 // Sessions resolves User.sessions
 func (rsv *User) Sessions(
 	ctx context.Context,
-) []*Session {
+) ([]*Session, error) {
 	// Check permissions
 	if err := auth.Authorize(ctx, auth.IsOwner{
 		Owner: store.ID(rsv.id),
 	}); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
-	}
-
-	var query struct {
-		Users []dgraph.User `json:"users"`
-	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query Sessions($nodeId: string) {
-			users(func: uid($nodeId)) {
-				User.sessions {
-					uid
-					Session.key
-					Session.creation
-				}
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.uid,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
-	}
-
-	if len(query.Users) < 1 {
-		return nil
-	}
-
-	usr := query.Users[0]
-	resolvers := make([]*Session, len(usr.Sessions))
-	for i, sess := range usr.Sessions {
+		return nil, err
+	}
+
+	sessions, err := rsv.root.loaders(ctx).sessions(ctx, rsv.uid)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*Session, len(sessions))
+	for i, sess := range sessions {
 		resolvers[i] = &Session{
-			root:     rsv.root,
-			uid:      sess.UID,
-			key:      sess.Key,
-			creation: sess.Creation,
-			userUID:  rsv.uid,
+			root:       rsv.root,
+			uid:        sess.UID,
+			key:        sess.Key,
+			creation:   sess.Creation,
+			lastAccess: sess.LastAccess,
+			userUID:    rsv.uid,
 		}
 	}
 
-	return resolvers
+	return resolvers, nil
 }
 
 // PublishedReactions resolves User.publishedReactions
 func (rsv *User) PublishedReactions(
 	ctx context.Context,
-) []*Reaction {
-	var query struct {
-		Users []dgraph.User `json:"users"`
-	}
-	if err := rsv.root.str.QueryVars(
-		ctx,
-		`query PublishedReactions($nodeId: string) {
-			users(func: uid($nodeId)) {
-				User.publishedReactions {
-					uid
-					Reaction.id
-					Reaction.creation
-					Reaction.emotion
-					Reaction.message
-					Reaction.subject {
-						uid
-						Post.id
-						Reaction.id
-					}
-				}
-			}
-		}`,
-		map[string]string{
-			"$nodeId": rsv.uid,
-		},
-		&query,
-	); err != nil {
-		rsv.root.error(ctx, err)
-		return nil
-	}
-
-	if len(query.Users) < 1 {
-		return nil
-	}
-
-	usr := query.Users[0]
-	resolvers := make([]*Reaction, len(usr.PublishedReactions))
-	for i, reaction := range usr.PublishedReactions {
-		var subjectUID string
-		switch v := reaction.Subject[0].V.(type) {
-		case *dgraph.Post:
-			subjectUID = v.UID
-		case *dgraph.Reaction:
-			subjectUID = v.UID
-		}
-		resolvers[i] = &Reaction{
-			root:       rsv.root,
-			uid:        reaction.UID,
-			id:         reaction.ID,
-			subjectUID: subjectUID,
-			authorUID:  rsv.uid,
-			creation:   reaction.Creation,
-			emotion:    reaction.Emotion,
-			message:    reaction.Message,
-		}
+	params ConnectionParams,
+) (*ReactionConnection, error) {
+	conn, err := rsv.root.reactionConnection(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    "User.publishedReactions",
+		filter:  visibilityFilter(ctx, "Reaction.hidden"),
+	}, params)
+	if err != nil {
+		return nil, err
 	}
+	return conn, nil
+}
 
-	return resolvers
+// Followers resolves User.followers
+func (rsv *User) Followers(
+	ctx context.Context,
+	params ConnectionParams,
+) (*UserConnection, error) {
+	conn, err := rsv.root.userConnection(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    "~User.following",
+	}, params)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Following resolves User.following
+func (rsv *User) Following(
+	ctx context.Context,
+	params ConnectionParams,
+) (*UserConnection, error) {
+	conn, err := rsv.root.userConnection(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    "User.following",
+	}, params)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Notifications resolves User.notifications
+func (rsv *User) Notifications(
+	ctx context.Context,
+	params struct {
+		UnreadOnly bool
+		First      *int32
+		After      *Cursor
+	},
+) (*NotificationConnection, error) {
+	// Check permissions
+	if err := auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(rsv.id),
+	}); err != nil {
+		return nil, err
+	}
+
+	edge := "User.notifications"
+	if params.UnreadOnly {
+		edge = "User.unreadNotifications"
+	}
+	conn, err := rsv.root.notificationConnection(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    edge,
+	}, ConnectionParams{
+		First: params.First,
+		After: params.After,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// UnreadNotificationCount resolves User.unreadNotificationCount
+func (rsv *User) UnreadNotificationCount(
+	ctx context.Context,
+) (int32, error) {
+	// Check permissions
+	if err := auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(rsv.id),
+	}); err != nil {
+		return 0, err
+	}
+
+	first := int32(0)
+	pg, err := rsv.root.page(ctx, list{
+		nodeUID: rsv.uid,
+		edge:    "User.unreadNotifications",
+	}, ConnectionParams{First: &first})
+	if err != nil {
+		return 0, err
+	}
+	return int32(pg.totalCount), nil
 }
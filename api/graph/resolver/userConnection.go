@@ -0,0 +1,79 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// UserConnection represents the resolver of the identically named type
+type UserConnection struct {
+	page  page
+	edges []*UserEdge
+}
+
+// Edges resolves UserConnection.edges
+func (rsv *UserConnection) Edges() []*UserEdge {
+	return rsv.edges
+}
+
+// PageInfo resolves UserConnection.pageInfo
+func (rsv *UserConnection) PageInfo() *PageInfo {
+	return &PageInfo{page: rsv.page}
+}
+
+// TotalCount resolves UserConnection.totalCount
+func (rsv *UserConnection) TotalCount() int32 {
+	return int32(rsv.page.totalCount)
+}
+
+// UserEdge represents the resolver of the identically named type
+type UserEdge struct {
+	node *User
+}
+
+// Cursor resolves UserEdge.cursor
+func (rsv *UserEdge) Cursor() Cursor {
+	return newCursor(rsv.node.uid)
+}
+
+// Node resolves UserEdge.node
+func (rsv *UserEdge) Node() *User {
+	return rsv.node
+}
+
+// userConnection resolves a page of the given list of users
+func (rsv *Resolver) userConnection(
+	ctx context.Context,
+	lst list,
+	params ConnectionParams,
+) (*UserConnection, error) {
+	pg, err := rsv.page(ctx, lst, params)
+	if err != nil {
+		return nil, err
+	}
+	conn := &UserConnection{
+		page:  pg,
+		edges: make([]*UserEdge, 0, len(pg.uids)),
+	}
+	if len(pg.uids) < 1 {
+		return conn, nil
+	}
+
+	users, err := rsv.loaders(ctx).allUsers(ctx, pg.uids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, usr := range users {
+		conn.edges = append(conn.edges, &UserEdge{node: &User{
+			root:        rsv,
+			uid:         usr.UID,
+			id:          store.ID(usr.ID),
+			displayName: usr.DisplayName,
+			email:       usr.Email,
+			creation:    usr.Creation,
+		}})
+	}
+	return conn, nil
+}
@@ -0,0 +1,27 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// canModerate returns true if the client is either a moderator
+// or the debug user
+func canModerate(ctx context.Context) bool {
+	session, isSession := ctx.Value(
+		auth.CtxSession,
+	).(*auth.RequestSession)
+	return isSession && (session.IsDebug || session.HasRole(role.Moderator))
+}
+
+// visibilityFilter returns the filter excluding the nodes hidden
+// by the moderators from the lists unless the client can moderate,
+// in which case no filter is returned
+func visibilityFilter(ctx context.Context, hiddenPredicate string) string {
+	if canModerate(ctx) {
+		return ""
+	}
+	return "not has(" + hiddenPredicate + ")"
+}
@@ -4,15 +4,55 @@ var schema = `
 schema {
 	query: Query
 	mutation: Mutation
+	subscription: Subscription
 }
 
 type Query {
-	users: [User!]!
-	posts: [Post!]!
+	users(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): UserConnection!
+	posts(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): PostConnection!
 
 	user(id: Identifier!): User
 	post(id: Identifier!): Post
 	reaction(id: Identifier!): Reaction
+
+	# searchPosts returns the posts containing any of the words of the text
+	# ordered by relevance, matches in the title rank higher than
+	# matches in the contents, newer posts rank higher on equal relevance
+	searchPosts(
+		text: String!
+		first: Int
+		after: Cursor
+	): PostConnection!
+
+	# searchUsers returns the users whose display name starts with
+	# the given prefix (case-insensitive) ordered by relevance
+	searchUsers(
+		prefix: String!
+	): [User!]!
+
+	# feed returns the posts of the users followed by the client
+	# ordered by creation time, newest first
+	feed(
+		first: Int
+		after: Cursor
+	): PostConnection!
+
+	# moderationQueue lists the open reports, oldest first.
+	# It can only be accessed by moderators
+	moderationQueue(
+		first: Int
+		after: Cursor
+	): ReportConnection!
 }
 
 type Mutation {
@@ -41,6 +81,27 @@ type Mutation {
 		password: String!
 	): User!
 
+	# requestPasswordReset mails a password reset token to the user
+	# registered under the given email. It succeeds even if the email
+	# isn't registered to not reveal which emails are
+	requestPasswordReset(
+		email: String!
+	): Boolean!
+
+	# resetPassword replaces the password of the user
+	# the password reset token was issued to.
+	# Tokens can only be used once
+	resetPassword(
+		token: String!
+		newPassword: String!
+	): Boolean!
+
+	# verifyEmail marks the email address of the user
+	# the email verification token was issued to as verified
+	verifyEmail(
+		token: String!
+	): Boolean!
+
 	createPost(
 		author: Identifier!
 		title: String!
@@ -73,19 +134,113 @@ type Mutation {
 		editor: Identifier!
 		newMessage: String!
 	): Reaction!
+
+	# revertPost restores the title and contents of the given revision
+	# recording the current ones as a new revision edited by the editor
+	revertPost(
+		post: Identifier!
+		editor: Identifier!
+		revision: Identifier!
+	): Post!
+
+	# followUser makes the follower follow the user
+	# and returns the followed user
+	followUser(
+		follower: Identifier!
+		user: Identifier!
+	): User!
+
+	# unfollowUser makes the follower stop following the user
+	# and returns the unfollowed user
+	unfollowUser(
+		follower: Identifier!
+		user: Identifier!
+	): User!
+
+	# markNotificationsRead marks the given notifications of the user
+	# as read, all unread notifications are marked if notifications is null.
+	# Returns the identifiers of the notifications which weren't read before
+	markNotificationsRead(
+		user: Identifier!
+		notifications: [Identifier!]
+	): [Identifier!]!
+
+	# reportContent asks the moderators to review the post or reaction
+	reportContent(
+		reporter: Identifier!
+		subject: Identifier!
+		reason: String!
+	): Report!
+
+	# dismissReport closes the report leaving its subject visible,
+	# it can only be performed by moderators
+	dismissReport(
+		report: Identifier!
+	): Report!
+
+	# hideContent hides the post or reaction from public lists
+	# and closes all of its open reports,
+	# it can only be performed by moderators
+	hideContent(
+		subject: Identifier!
+	): ReactionSubject!
+
+	# unhideContent makes the hidden post or reaction publicly listed again,
+	# it can only be performed by moderators
+	unhideContent(
+		subject: Identifier!
+	): ReactionSubject!
+
+	# setUserRoles replaces the roles of the user, the user role
+	# is always retained. It can only be performed by admins
+	setUserRoles(
+		user: Identifier!
+		roles: [Role!]!
+	): User!
+
+	# deletePost deletes the post including all of its reactions
+	deletePost(
+		post: Identifier!
+	): Boolean!
+
+	# deleteReaction deletes the reaction including all nested reactions
+	deleteReaction(
+		reaction: Identifier!
+	): Boolean!
+
+	# deleteUser deletes the user including all of its sessions,
+	# posts, published reactions and received notifications
+	deleteUser(
+		user: Identifier!
+	): Boolean!
+}
+
+type Subscription {
+	postCreated: Post!
+	reactionCreated(subject: Identifier!): Reaction!
+	postEdited(id: Identifier!): Post!
 }
 
 type Session {
 	key: String!
 	user: User!
 	creation: Time!
+	lastAccess: Time!
+	expiresAt: Time!
 }
 
 type User {
 	id: Identifier!
 	creation: Time!
 	displayName: String!
-	posts: [Post!]!
+	# roles lists the roles of the user ordered by privilege
+	roles: [Role!]!
+	posts(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): PostConnection!
 
 	# The list of active sessions can only be accessed by the profile owner
 	sessions: [Session!]!
@@ -93,17 +248,76 @@ type User {
 	# The email address can only be accessed by the profile owner
 	email: String!
 
+	# emailVerified is true if the email address was verified,
+	# it can only be accessed by the profile owner
+	emailVerified: Boolean!
+
 	# publishedReactions lists all reactions published by the user
-	publishedReactions: [Reaction!]!
+	publishedReactions(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): ReactionConnection!
+
+	# followers lists the users following the user
+	followers(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): UserConnection!
+
+	# following lists the users followed by the user
+	following(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): UserConnection!
+
+	# notifications lists the notifications received by the user,
+	# oldest first. Notifications can only be accessed by the profile owner
+	notifications(
+		unreadOnly: Boolean = false
+		first: Int
+		after: Cursor
+	): NotificationConnection!
+
+	# unreadNotificationCount can only be accessed by the profile owner
+	unreadNotificationCount: Int!
 }
 
 type Post {
 	id: Identifier!
 	author: User!
 	creation: Time!
+	# lastEdited is null if the post was never edited
+	lastEdited: Time
+	title: String!
+	contents: String!
+	# hidden posts are excluded from the lists of non-moderators
+	hidden: Boolean!
+	reactions(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): ReactionConnection!
+	# reactionStats counts the reactions to the post per emotion,
+	# reactions to reactions are included if nested is true
+	reactionStats(nested: Boolean = false): ReactionStats!
+	# revisions lists the previous versions of the post, oldest first
+	revisions: [PostRevision!]!
+}
+
+type PostRevision {
+	id: Identifier!
+	creation: Time!
+	# editor is null if the editor was deleted
+	editor: User
 	title: String!
 	contents: String!
-	reactions: [Reaction!]!
 }
 
 union ReactionSubject = Reaction | Post
@@ -115,7 +329,129 @@ type Reaction {
 	author: User!
 	emotion: Emotion!
 	message: String!
-	reactions: [Reaction!]!
+	# hidden reactions are excluded from the lists of non-moderators
+	hidden: Boolean!
+	reactions(
+		first: Int
+		after: Cursor
+		last: Int
+		before: Cursor
+	): ReactionConnection!
+	# reactionStats counts the reactions to the reaction per emotion,
+	# reactions to reactions are included if nested is true
+	reactionStats(nested: Boolean = false): ReactionStats!
+	# revisions lists the previous messages of the reaction, oldest first
+	revisions: [ReactionRevision!]!
+}
+
+type ReactionRevision {
+	id: Identifier!
+	creation: Time!
+	# editor is null if the editor was deleted
+	editor: User
+	message: String!
+}
+
+# Notification informs its recipient about a reaction
+# to one of its posts or reactions or about a mention
+# (such as "@displayName") in a post or reaction
+type Notification {
+	id: Identifier!
+	creation: Time!
+	kind: NotificationKind!
+	read: Boolean!
+	# actor is null if the user causing the notification was deleted
+	actor: User
+	# subject is the new reaction or the mentioning post or reaction,
+	# it's null if the subject was deleted
+	subject: ReactionSubject
+}
+
+# Report asks the moderators to review a post or reaction,
+# it's open until it's either dismissed or its subject is hidden
+type Report {
+	id: Identifier!
+	creation: Time!
+	# reporter is null if the reporter was deleted
+	reporter: User
+	subject: ReactionSubject!
+	reason: String!
+	open: Boolean!
+}
+
+# ReactionStats describes the number of reactions per emotion
+type ReactionStats {
+	total: Int!
+	# emotions lists every emotion including those without reactions
+	emotions: [EmotionCount!]!
+}
+
+type EmotionCount {
+	emotion: Emotion!
+	count: Int!
+}
+
+# PageInfo describes the position of a page within a connection
+type PageInfo {
+	hasNextPage: Boolean!
+	hasPreviousPage: Boolean!
+	startCursor: Cursor
+	endCursor: Cursor
+}
+
+type UserConnection {
+	edges: [UserEdge!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type UserEdge {
+	cursor: Cursor!
+	node: User!
+}
+
+type PostConnection {
+	edges: [PostEdge!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type PostEdge {
+	cursor: Cursor!
+	node: Post!
+}
+
+type ReactionConnection {
+	edges: [ReactionEdge!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type ReactionEdge {
+	cursor: Cursor!
+	node: Reaction!
+}
+
+type NotificationConnection {
+	edges: [NotificationEdge!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type NotificationEdge {
+	cursor: Cursor!
+	node: Notification!
+}
+
+type ReportConnection {
+	edges: [ReportEdge!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type ReportEdge {
+	cursor: Cursor!
+	node: Report!
 }
 
 enum Emotion {
@@ -126,6 +462,20 @@ enum Emotion {
 	thoughtful
 }
 
+enum NotificationKind {
+	reaction
+	mention
+}
+
+enum Role {
+	user
+	moderator
+	admin
+}
+
 scalar Identifier
 scalar Time
+
+# Cursor is an opaque connection cursor
+scalar Cursor
 `
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShieldClientRoles tests whether whitelisted queries are permitted
+// to the clients assigned a client role the query is whitelisted for
+func TestShieldClientRoles(t *testing.T) {
+	const (
+		guest = iota + 1
+		debug
+		regular
+		moderator
+	)
+
+	// Admins aren't assigned a client role of their own
+	graph, _, shield := newTestGraph(t, testSetup{
+		shieldRoles: []gqlshield.ClientRole{
+			{ID: guest, Name: "guest"},
+			{ID: debug, Name: "debug"},
+			{ID: regular, Name: "regular"},
+			{ID: moderator, Name: "moderator"},
+		},
+		shieldClientRoles: auth.GQLShieldClientRoles{
+			Guest: guest,
+			Debug: debug,
+			Users: map[role.Role]auth.GQLShieldClientRole{
+				role.User:      regular,
+				role.Moderator: moderator,
+			},
+		},
+	})
+
+	regularQuery := `{ posts { totalCount } }`
+	moderatorQuery := `{ users { totalCount } }`
+	_, err := shield.WhitelistQueries(
+		gqlshield.Entry{
+			Query:          regularQuery,
+			Name:           "regular",
+			WhitelistedFor: []int{regular},
+		},
+		gqlshield.Entry{
+			Query:          moderatorQuery,
+			Name:           "moderator",
+			WhitelistedFor: []int{debug, moderator},
+		},
+	)
+	require.NoError(t, err)
+
+	// query executes the query and returns the error codes of the response
+	query := func(session *auth.RequestSession, query string) []string {
+		ctx := context.WithValue(context.Background(), auth.CtxSession, session)
+		response := graph.Query(ctx, Query{Query: []byte(query)})
+		codes := make([]string, len(response.Errors))
+		for i, err := range response.Errors {
+			codes[i] = err.Code
+		}
+		return codes
+	}
+	userSession := func(roles ...role.Role) *auth.RequestSession {
+		return &auth.RequestSession{UserID: "user", Roles: roles}
+	}
+	unauthorized := []string{string(strerr.ErrUnauthorized)}
+
+	for name, session := range map[string]*auth.RequestSession{
+		"guest":   &auth.RequestSession{},
+		"debug":   &auth.RequestSession{IsDebug: true},
+		"regular": userSession(role.User),
+	} {
+		t.Run(name+"/moderatorQuery", func(t *testing.T) {
+			expected := unauthorized
+			if session.IsDebug {
+				expected = []string{}
+			}
+			require.Equal(t, expected, query(session, moderatorQuery))
+		})
+	}
+
+	for name, session := range map[string]*auth.RequestSession{
+		"moderator": userSession(role.User, role.Moderator),
+		"admin":     userSession(role.User, role.Admin),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, []string{}, query(session, moderatorQuery))
+			require.Equal(t, []string{}, query(session, regularQuery))
+		})
+	}
+
+	// Users are regular users even if their roles are unknown
+	require.Equal(t, []string{}, query(userSession(), regularQuery))
+	require.Equal(t, unauthorized, query(userSession(), moderatorQuery))
+}
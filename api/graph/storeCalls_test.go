@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStore counts the queries issued to the underlying store
+type countingStore struct {
+	store.Store
+	calls int64
+}
+
+func (str *countingStore) Query(
+	ctx context.Context,
+	query string,
+	result interface{},
+) error {
+	atomic.AddInt64(&str.calls, 1)
+	return str.Store.Query(ctx, query, result)
+}
+
+func (str *countingStore) QueryVars(
+	ctx context.Context,
+	query string,
+	vars map[string]string,
+	result interface{},
+) error {
+	atomic.AddInt64(&str.calls, 1)
+	return str.Store.QueryVars(ctx, query, vars, result)
+}
+
+// newCountingGraph creates a graph on top of an in-memory store
+// populated with the entities of the API query tests
+func newCountingGraph(t *testing.T) (*Graph, *countingStore) {
+	var str *countingStore
+	graph, _, _ := newTestGraph(t, testSetup{
+		wrapStore: func(memStore store.Store) store.Store {
+			str = &countingStore{Store: memStore}
+			return str
+		},
+	})
+
+	ctx := context.Background()
+	now := time.Now()
+	createUser := func(name, email string) store.ID {
+		usr, err := str.CreateUser(ctx, now, email, name, "testpass")
+		require.NoError(t, err)
+		_, err = str.CreateSession(ctx, name+"key", now, email, "testpass")
+		require.NoError(t, err)
+		return usr.ID
+	}
+	createPost := func(author store.ID, title string) store.ID {
+		post, err := str.CreatePost(ctx, now, author, title, "contents")
+		require.NoError(t, err)
+		return post.ID
+	}
+	createReaction := func(author, subject store.ID) store.ID {
+		reaction, err := str.CreateReaction(
+			ctx,
+			now,
+			author,
+			subject,
+			emotion.Happy,
+			"message",
+		)
+		require.NoError(t, err)
+		return reaction.ID
+	}
+
+	userA := createUser("first", "1@test.test")
+	userB := createUser("second", "2@test.test")
+	userC := createUser("third", "3@test.test")
+	postA1 := createPost(userA, "Post A1")
+	postA2 := createPost(userA, "Post A2")
+	createPost(userB, "Post B1")
+	createReaction(userB, postA1)
+	reactionB2 := createReaction(userB, postA2)
+	createReaction(userC, postA1)
+	createReaction(userC, reactionB2)
+
+	return graph, str
+}
+
+// TestStoreCalls tests whether the lookups of the resolvers
+// are batched into a single store query per field and level
+func TestStoreCalls(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		calls int64
+	}{
+		{
+			// page, users, posts pages, posts (authors are cached)
+			"User.posts",
+			`{ users { edges { node {
+				id
+				posts { edges { node { id author { id } } } }
+			} } } }`,
+			4,
+		},
+		{
+			// page, posts, authors
+			"Post.author",
+			`{ posts { edges { node { id author { id displayName } } } } }`,
+			3,
+		},
+		{
+			// page, users, sessions, session users
+			"User.sessions",
+			`{ users { edges { node {
+				sessions { key user { id } }
+			} } } }`,
+			4,
+		},
+		{
+			// page, posts, reactions pages, reactions, authors
+			"Post.reactions",
+			`{ posts { edges { node {
+				reactions { edges { node { id author { id } } } }
+			} } } }`,
+			5,
+		},
+		{
+			// page, users, reactions pages, reactions, subjects
+			"User.publishedReactions",
+			`{ users { edges { node {
+				publishedReactions { edges { node {
+					id
+					subject {
+						... on Post { id }
+						... on Reaction { id }
+					}
+				} } }
+			} } } }`,
+			5,
+		},
+		{
+			// page, posts, reactions pages, reactions,
+			// sub-reactions pages, sub-reactions
+			"Reaction.reactions",
+			`{ posts { edges { node {
+				reactions { edges { node {
+					reactions { edges { node { id message } } }
+				} } }
+			} } } }`,
+			6,
+		},
+	}
+
+	// query executes the query and returns the number of store calls
+	query := func(t *testing.T, query string) int64 {
+		graph, str := newCountingGraph(t)
+		ctx := context.WithValue(
+			context.Background(),
+			auth.CtxSession,
+			&auth.RequestSession{
+				IsDebug:   true,
+				DebugMode: auth.DebugModeReadWrite,
+			},
+		)
+		response := graph.Query(ctx, Query{Query: []byte(query)})
+		require.Len(t, response.Errors, 0)
+		require.NotNil(t, response.Data)
+		return atomic.LoadInt64(&str.calls)
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Batches are collected over a short period of time
+			// and could occasionally be split by the scheduler,
+			// thus the fewest calls of several attempts are compared
+			calls := query(t, c.query)
+			for i := 0; i < 2 && calls != c.calls; i++ {
+				if attempt := query(t, c.query); attempt < calls {
+					calls = attempt
+				}
+			}
+			require.Equal(t, c.calls, calls)
+		})
+	}
+}
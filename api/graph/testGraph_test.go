@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/clock"
+	"github.com/romshark/dgraph_graphql_go/api/eventbus"
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
+	"github.com/romshark/dgraph_graphql_go/api/passhash"
+	"github.com/romshark/dgraph_graphql_go/api/sesskeygen"
+	"github.com/romshark/dgraph_graphql_go/api/validator"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/romshark/dgraph_graphql_go/store/memory"
+	"github.com/stretchr/testify/require"
+)
+
+// testSetup defines the dependencies of a test graph,
+// the zero values are replaced by the defaults
+type testSetup struct {
+	// wrapStore wraps the in-memory store, the store is used as is when nil
+	wrapStore func(store.Store) store.Store
+
+	// passwordHasher defaults to passhash.Mock
+	passwordHasher passhash.PasswordHasher
+
+	// mailer defaults to a mail log discarding all mails
+	mailer mailer.Mailer
+
+	// shieldRoles defaults to a single client role (1)
+	// assigned to all clients, whitelisting is disabled unless
+	// the client roles are defined
+	shieldRoles       []gqlshield.ClientRole
+	shieldClientRoles auth.GQLShieldClientRoles
+
+	// onUnexpectedErr fails the test by default
+	onUnexpectedErr func(error)
+}
+
+// newTestGraph creates a graph on top of a new in-memory store
+// returning the graph, the (wrapped) store and the shield
+func newTestGraph(t *testing.T, setup testSetup) (
+	*Graph,
+	store.Store,
+	gqlshield.GraphQLShield,
+) {
+	if setup.passwordHasher == nil {
+		setup.passwordHasher = passhash.Mock{}
+	}
+	if setup.mailer == nil {
+		setup.mailer = mailer.NewLog(ioutil.Discard)
+	}
+	if setup.onUnexpectedErr == nil {
+		setup.onUnexpectedErr = func(err error) {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+	shieldConf := gqlshield.Config{WhitelistOption: gqlshield.WhitelistEnabled}
+	if setup.shieldRoles == nil {
+		shieldConf.WhitelistOption = gqlshield.WhitelistDisabled
+		setup.shieldRoles = []gqlshield.ClientRole{{ID: 1, Name: "client"}}
+		setup.shieldClientRoles = auth.GQLShieldClientRoles{
+			Guest: 1,
+			Debug: 1,
+			Users: map[role.Role]auth.GQLShieldClientRole{role.User: 1},
+		}
+	}
+
+	logger := log.New(ioutil.Discard, "", 0)
+	hasher := setup.passwordHasher
+	str := memory.NewStore(
+		func(password, hash string) bool {
+			return hasher.Compare([]byte(password), []byte(hash))
+		},
+		logger,
+		logger,
+	)
+	if setup.wrapStore != nil {
+		str = setup.wrapStore(str)
+	}
+	require.NoError(t, str.Prepare())
+
+	vld, err := validator.NewValidator(false, validator.Config{
+		PasswordLenMin:        6,
+		PasswordLenMax:        256,
+		EmailLenMax:           96,
+		PostContentsLenMin:    1,
+		PostContentsLenMax:    256,
+		PostTitleLenMin:       2,
+		PostTitleLenMax:       64,
+		ReactionMessageLenMin: 1,
+		ReactionMessageLenMax: 256,
+		ReportReasonLenMin:    1,
+		ReportReasonLenMax:    256,
+		UserDisplayNameLenMin: 2,
+		UserDisplayNameLenMax: 64,
+	})
+	require.NoError(t, err)
+	shield, err := gqlshield.NewGraphQLShield(shieldConf, setup.shieldRoles...)
+	require.NoError(t, err)
+
+	graph, err := New(
+		str,
+		vld,
+		sesskeygen.NewDefault(),
+		setup.passwordHasher,
+		auth.SessionTTL{Absolute: time.Hour},
+		eventbus.New(),
+		setup.mailer,
+		auth.TokenTTL{},
+		clock.System{},
+		shield,
+		setup.shieldClientRoles,
+		setup.onUnexpectedErr,
+	)
+	require.NoError(t, err)
+	return graph, str, shield
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Log implements the Mailer interface writing the mails to a writer
+// as JSON Lines instead of delivering them
+type Log struct {
+	lock   *sync.Mutex
+	writer io.Writer
+}
+
+// NewLog creates a new mail log writing to the given writer
+func NewLog(writer io.Writer) *Log {
+	return &Log{
+		lock:   &sync.Mutex{},
+		writer: writer,
+	}
+}
+
+// Send writes the mail to the log
+func (m *Log) Send(mail Mail) error {
+	encoded, err := json.Marshal(mail)
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	_, err = m.writer.Write(append(encoded, '\n'))
+	return err
+}
+
+// ReadLog reads all mails written to a mail log
+func ReadLog(reader io.Reader) ([]Mail, error) {
+	var mails []Mail
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var mail Mail
+		if err := json.Unmarshal(scanner.Bytes(), &mail); err != nil {
+			return nil, err
+		}
+		mails = append(mails, mail)
+	}
+	return mails, scanner.Err()
+}
@@ -0,0 +1,27 @@
+package mailer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLog tests whether the mails written to a mail log are read back
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	log := mailer.NewLog(&buf)
+
+	mails := []mailer.Mail{
+		{To: "1@tst.tst", Subject: "first", Body: "multi\nline\nbody"},
+		{To: "2@tst.tst", Subject: "second", Body: "body"},
+	}
+	for _, mail := range mails {
+		require.NoError(t, log.Send(mail))
+	}
+
+	read, err := mailer.ReadLog(&buf)
+	require.NoError(t, err)
+	require.Equal(t, mails, read)
+}
@@ -0,0 +1,14 @@
+package mailer
+
+// Mail represents a plain text email message
+type Mail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer defines the interface of an email delivery service
+type Mailer interface {
+	// Send delivers the mail to its recipient
+	Send(mail Mail) error
+}
@@ -0,0 +1,80 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPConfig defines the SMTP mailer configurations
+type SMTPConfig struct {
+	// Host is the address of the SMTP server including the port
+	Host string
+
+	// Username and Password are used for PLAIN authentication,
+	// authentication is skipped if Username is empty
+	Username string
+	Password string
+
+	// From is the address of the sender
+	From string
+}
+
+// SMTP implements the Mailer interface delivering mails over SMTP
+type SMTP struct {
+	conf SMTPConfig
+	auth smtp.Auth
+}
+
+// NewSMTP creates a new SMTP mailer instance
+func NewSMTP(conf SMTPConfig) (*SMTP, error) {
+	hostname, _, err := net.SplitHostPort(conf.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP host: %s", err)
+	}
+	if conf.From == "" {
+		return nil, errors.New("missing sender address")
+	}
+
+	var auth smtp.Auth
+	if conf.Username != "" {
+		auth = smtp.PlainAuth("", conf.Username, conf.Password, hostname)
+	}
+	return &SMTP{
+		conf: conf,
+		auth: auth,
+	}, nil
+}
+
+// Send delivers the mail to the SMTP server
+func (m *SMTP) Send(mail Mail) error {
+	// Prevent header injection through the recipient address
+	if strings.ContainsAny(mail.To, "\r\n") {
+		return fmt.Errorf("invalid recipient address: %q", mail.To)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.conf.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", mail.To)
+	fmt.Fprintf(
+		&msg,
+		"Subject: %s\r\n",
+		mime.QEncoding.Encode("utf-8", mail.Subject),
+	)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.Replace(mail.Body, "\n", "\r\n", -1))
+
+	return smtp.SendMail(
+		m.conf.Host,
+		m.auth,
+		m.conf.From,
+		[]string{mail.To},
+		msg.Bytes(),
+	)
+}
@@ -6,13 +6,22 @@ import (
 
 	"github.com/romshark/dgraph_graphql_go/store"
 	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 )
 
+// sessionTouchInterval defines the maximum resolution of the session
+// last access time to avoid writing to the database on every request
+const sessionTouchInterval = time.Minute
+
 // onAuth is invoked by the transport layer during client authentication
 func (srv *server) onAuth(
 	ctx context.Context,
 	sessionKey string,
-) (userID store.ID, sessionCreationTime time.Time) {
+) (
+	userID store.ID,
+	sessionCreationTime time.Time,
+	roles []role.Role,
+) {
 	// Search for the user session by key
 	var result struct {
 		Session []dgraph.Session `json:"session"`
@@ -22,9 +31,11 @@ func (srv *server) onAuth(
 		`query Session($sessionKey: string) {
 			session(func: eq(Session.key, $sessionKey)) {
 				Session.creation
+				Session.lastAccess
 				Session.user {
 					uid
 					User.id
+					User.roles
 				}
 			}
 		}`,
@@ -39,8 +50,28 @@ func (srv *server) onAuth(
 	if len(result.Session) < 1 {
 		return
 	}
+	sess := result.Session[0]
+
+	// Reject expired sessions
+	now := srv.conf.Clock.Now()
+	ttl := srv.conf.Session.TTL()
+	if ttl.IsExpired(sess.Creation, sess.LastAccess, now) {
+		return
+	}
+
+	// Renew the session
+	touchInterval := sessionTouchInterval
+	if ttl.Idle/10 < touchInterval {
+		touchInterval = ttl.Idle / 10
+	}
+	if now.Sub(sess.LastAccess) >= touchInterval {
+		if err := srv.store.TouchSession(ctx, sessionKey, now); err != nil {
+			srv.logErrf("session renewal: %s", err)
+		}
+	}
 
-	userID = store.ID(result.Session[0].User[0].ID)
-	sessionCreationTime = result.Session[0].Creation
+	userID = store.ID(sess.User[0].ID)
+	sessionCreationTime = sess.Creation
+	roles = sess.User[0].Roles
 	return
 }
@@ -1,11 +1,25 @@
 package api
 
-import "context"
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/config"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+)
 
 // onDebugAuth handles a debug client authentication request
 func (srv *server) onDebugAuth(
 	ctx context.Context,
 	sessionKey string,
-) bool {
-	return string(srv.debugSessionKey) == sessionKey
+) (ok bool, mode auth.DebugMode) {
+	if len(srv.debugSessionKey) < 1 ||
+		string(srv.debugSessionKey) != sessionKey {
+		return
+	}
+	ok = true
+	mode = auth.DebugModeReadOnly
+	if srv.conf.DebugUser.Mode == config.DebugUserRW {
+		mode = auth.DebugModeReadWrite
+	}
+	return
 }
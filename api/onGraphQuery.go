@@ -6,7 +6,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/api/graph"
-	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
 type stackTracer interface {
@@ -31,36 +30,6 @@ func (srv *server) handleUnexpectedError(err error) {
 func (srv *server) onGraphQuery(
 	ctx context.Context,
 	query graph.Query,
-) (graph.Response, error) {
-	// Resolve query
-	replyData, err := srv.graph.Query(ctx, query)
-
-	if err != nil {
-		errCode := strerr.ErrorCode(err)
-		if errCode != "" {
-			// Expected user error
-			return graph.Response{
-				Error: &graph.ResponseError{
-					Code:    errCode,
-					Message: err.Error(),
-				},
-			}, nil
-		}
-
-		if gqlErr, isGQLErr := err.(graph.GQLError); isGQLErr {
-			// Expected GraphQL error
-			return graph.Response{
-				Error: &graph.ResponseError{
-					Message: gqlErr.Error(),
-				},
-			}, nil
-		}
-
-		// Unexpected internal server error
-		srv.handleUnexpectedError(err)
-		return graph.Response{}, err
-	}
-
-	// Reply successfully
-	return graph.Response{Data: replyData}, nil
+) graph.Response {
+	return srv.graph.Query(ctx, query)
 }
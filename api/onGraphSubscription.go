@@ -0,0 +1,15 @@
+package api
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+)
+
+// onGraphSubscription handles a graph subscription
+func (srv *server) onGraphSubscription(
+	ctx context.Context,
+	query graph.Query,
+) (<-chan graph.Response, []*graph.ResponseError) {
+	return srv.graph.Subscribe(ctx, query)
+}
@@ -0,0 +1,253 @@
+package passhash
+
+import (
+	"bytes"
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idSaltLen = 16
+	argon2idKeyLen  = 32
+
+	// argon2idMaxMemory limits the memory (in KiB) a stored hash may demand
+	// to protect against maliciously or mistakenly expensive hashes
+	argon2idMaxMemory = 4 * 1024 * 1024
+
+	// argon2idMaxTime limits the number of passes a stored hash may demand
+	argon2idMaxTime = 16
+
+	// argon2idMaxParallelism limits the number of threads
+	// a stored hash may demand, below the maximum of its uint8 representation
+	argon2idMaxParallelism = 64
+
+	// argon2idMaxKeyLen limits the length of a stored key
+	argon2idMaxKeyLen = 1024
+)
+
+// argon2idPrefix prefixes Argon2id hashes which are encoded as:
+// $argon2id$v=<version>$m=<memory>,t=<time>,p=<parallelism>$<salt>$<key>
+var argon2idPrefix = []byte("$argon2id$")
+
+// Argon2id implements the PasswordHasher interface using Argon2id
+type Argon2id struct {
+	// Memory defines the amount of memory used in KiB (at most 4 GiB),
+	// 64 MiB are used when zero
+	Memory uint32
+
+	// Time defines the number of passes over the memory (at most 16),
+	// a single pass is made when zero
+	Time uint32
+
+	// Parallelism defines the number of threads used (at most 64),
+	// 4 threads are used when zero
+	Parallelism uint8
+}
+
+// argon2idParams represents the parameters an Argon2id hash was produced with
+type argon2idParams struct {
+	memory      uint32
+	time        uint32
+	parallelism uint8
+}
+
+func (h Argon2id) params() argon2idParams {
+	params := argon2idParams{
+		memory:      h.Memory,
+		time:        h.Time,
+		parallelism: h.Parallelism,
+	}
+	if params.memory == 0 {
+		params.memory = 64 * 1024
+	}
+	if params.time == 0 {
+		params.time = 1
+	}
+	if params.parallelism == 0 {
+		params.parallelism = 4
+	}
+	return params
+}
+
+// Validate returns an error if the parameters are invalid
+func (h Argon2id) Validate() error {
+	params := h.params()
+	if params.memory < 8*uint32(params.parallelism) {
+		return errors.Errorf(
+			"argon2id memory (%d KiB) must be at least 8 KiB per thread",
+			params.memory,
+		)
+	}
+	if params.memory > argon2idMaxMemory {
+		return errors.Errorf(
+			"argon2id memory (%d KiB) exceeds the maximum (%d KiB)",
+			params.memory,
+			argon2idMaxMemory,
+		)
+	}
+	if params.time > argon2idMaxTime {
+		return errors.Errorf(
+			"argon2id time (%d) exceeds the maximum (%d)",
+			params.time,
+			argon2idMaxTime,
+		)
+	}
+	if params.parallelism > argon2idMaxParallelism {
+		return errors.Errorf(
+			"argon2id parallelism (%d) exceeds the maximum (%d)",
+			params.parallelism,
+			argon2idMaxParallelism,
+		)
+	}
+	return nil
+}
+
+// Hash salts and hashes the given password returning the resulting hash
+func (h Argon2id) Hash(password []byte) ([]byte, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := cryptoRand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "generate salt")
+	}
+	params := h.params()
+	key := argon2.IDKey(
+		password,
+		salt,
+		params.time,
+		params.memory,
+		params.parallelism,
+		argon2idKeyLen,
+	)
+	return []byte(fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		params.memory,
+		params.time,
+		params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)), nil
+}
+
+// Compare returns true if the given password corresponds to the given hash,
+// otherwise returns false
+func (h Argon2id) Compare(password, hash []byte) bool {
+	return compare(password, hash)
+}
+
+// NeedsRehash returns true if the given hash isn't an Argon2id hash
+// or was produced using weaker parameters
+func (h Argon2id) NeedsRehash(hash []byte) bool {
+	params, _, _, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	current := h.params()
+	return params.memory < current.memory ||
+		params.time < current.time ||
+		params.parallelism < current.parallelism
+}
+
+// isArgon2id returns true if the given hash is an Argon2id hash
+func isArgon2id(hash []byte) bool {
+	return bytes.HasPrefix(hash, argon2idPrefix)
+}
+
+// decodeArgon2id decodes an encoded Argon2id hash
+func decodeArgon2id(hash []byte) (
+	params argon2idParams,
+	salt []byte,
+	key []byte,
+	err error,
+) {
+	// "", "argon2id", version, parameters, salt, key
+	parts := strings.Split(string(hash), "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		err = errors.New("malformed argon2id hash")
+		return
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		err = errors.Wrap(err, "malformed argon2id version")
+		return
+	}
+	if version != argon2.Version {
+		err = errors.Errorf("unsupported argon2 version: %d", version)
+		return
+	}
+	if _, err = fmt.Sscanf(
+		parts[3],
+		"m=%d,t=%d,p=%d",
+		&params.memory,
+		&params.time,
+		&params.parallelism,
+	); err != nil {
+		err = errors.Wrap(err, "malformed argon2id parameters")
+		return
+	}
+	// argon2.IDKey panics when time or parallelism are zero
+	switch {
+	case params.time < 1:
+		err = errors.New("invalid argon2id time: must be at least 1")
+		return
+	case params.parallelism < 1:
+		err = errors.New("invalid argon2id parallelism: must be at least 1")
+		return
+	case params.memory > argon2idMaxMemory:
+		err = errors.Errorf(
+			"invalid argon2id memory: %d KiB exceeds the maximum (%d KiB)",
+			params.memory,
+			argon2idMaxMemory,
+		)
+		return
+	case params.time > argon2idMaxTime:
+		err = errors.Errorf(
+			"invalid argon2id time: %d exceeds the maximum (%d)",
+			params.time,
+			argon2idMaxTime,
+		)
+		return
+	case params.parallelism > argon2idMaxParallelism:
+		err = errors.Errorf(
+			"invalid argon2id parallelism: %d exceeds the maximum (%d)",
+			params.parallelism,
+			argon2idMaxParallelism,
+		)
+		return
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		err = errors.Wrap(err, "malformed argon2id salt")
+		return
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		err = errors.Wrap(err, "malformed argon2id key")
+		return
+	}
+	if len(key) < 1 || len(key) > argon2idMaxKeyLen {
+		err = errors.Errorf("invalid argon2id key length: %d", len(key))
+		return
+	}
+	return
+}
+
+func compareArgon2id(password, hash []byte) bool {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return false
+	}
+	actual := argon2.IDKey(
+		password,
+		salt,
+		params.time,
+		params.memory,
+		params.parallelism,
+		uint32(len(key)),
+	)
+	return subtle.ConstantTimeCompare(actual, key) == 1
+}
@@ -1,18 +1,44 @@
 package passhash
 
 import (
+	"bytes"
+
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // Bcrypt implements the PasswordHasher interface using bcrypt
-type Bcrypt struct{}
+type Bcrypt struct {
+	// Cost defines the bcrypt cost factor,
+	// bcrypt.DefaultCost is used when zero
+	Cost int
+}
+
+func (h Bcrypt) cost() int {
+	if h.Cost == 0 {
+		return bcrypt.DefaultCost
+	}
+	return h.Cost
+}
+
+// Validate returns an error if the cost is out of range
+func (h Bcrypt) Validate() error {
+	if h.Cost != 0 && (h.Cost < bcrypt.MinCost || h.Cost > bcrypt.MaxCost) {
+		return errors.Errorf(
+			"bcrypt cost (%d) out of range [%d, %d]",
+			h.Cost,
+			bcrypt.MinCost,
+			bcrypt.MaxCost,
+		)
+	}
+	return nil
+}
 
 // Hash salts and hashes the given password returning the resulting hash
 func (h Bcrypt) Hash(password []byte) ([]byte, error) {
 	hash, err := bcrypt.GenerateFromPassword(
 		[]byte(password),
-		bcrypt.DefaultCost,
+		h.cost(),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "generate password hash")
@@ -23,6 +49,24 @@ func (h Bcrypt) Hash(password []byte) ([]byte, error) {
 // Compare returns true if the given password corresponds to the given hash,
 // otherwise returns false
 func (h Bcrypt) Compare(password, hash []byte) bool {
-	err := bcrypt.CompareHashAndPassword(hash, password)
-	return err == nil
+	return compare(password, hash)
+}
+
+// NeedsRehash returns true if the given hash isn't a bcrypt hash
+// or was produced using a lower cost
+func (h Bcrypt) NeedsRehash(hash []byte) bool {
+	if !isBcrypt(hash) {
+		return true
+	}
+	cost, err := bcrypt.Cost(hash)
+	return err != nil || cost < h.cost()
+}
+
+// isBcrypt returns true if the given hash is a bcrypt hash
+func isBcrypt(hash []byte) bool {
+	return bytes.HasPrefix(hash, []byte("$2"))
+}
+
+func compareBcrypt(password, hash []byte) bool {
+	return bcrypt.CompareHashAndPassword(hash, password) == nil
 }
@@ -15,3 +15,8 @@ func (h Mock) Hash(password []byte) ([]byte, error) {
 func (h Mock) Compare(password, hash []byte) bool {
 	return bytes.Equal(password, hash)
 }
+
+// NeedsRehash always returns false
+func (h Mock) NeedsRehash(hash []byte) bool {
+	return false
+}
@@ -8,4 +8,20 @@ type PasswordHasher interface {
 	// Compare must return true if the given password corresponds to
 	// the given hash, otherwise must return false
 	Compare(password, hash []byte) bool
+
+	// NeedsRehash must return true if the given hash was produced
+	// by another algorithm or weaker parameters than the hasher would use
+	NeedsRehash(hash []byte) bool
+}
+
+// compare returns true if the given password corresponds to the given hash
+// regardless of which of the supported algorithms produced it
+func compare(password, hash []byte) bool {
+	switch {
+	case isBcrypt(hash):
+		return compareBcrypt(password, hash)
+	case isArgon2id(hash):
+		return compareArgon2id(password, hash)
+	}
+	return false
 }
@@ -0,0 +1,112 @@
+package passhash_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/passhash"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestCoexistence tests whether hashes produced by either algorithm
+// are verified by both hashers
+func TestCoexistence(t *testing.T) {
+	bcryptHasher := passhash.Bcrypt{Cost: bcrypt.MinCost}
+	argon2idHasher := passhash.Argon2id{Memory: 1024, Time: 1, Parallelism: 1}
+	password := []byte("testpass")
+
+	for name, hasher := range map[string]passhash.PasswordHasher{
+		"bcrypt":   bcryptHasher,
+		"argon2id": argon2idHasher,
+	} {
+		t.Run(name, func(t *testing.T) {
+			hash, err := hasher.Hash(password)
+			require.NoError(t, err)
+
+			require.True(t, bcryptHasher.Compare(password, hash))
+			require.True(t, argon2idHasher.Compare(password, hash))
+			require.False(t, bcryptHasher.Compare([]byte("wrong"), hash))
+			require.False(t, argon2idHasher.Compare([]byte("wrong"), hash))
+			require.False(t, hasher.NeedsRehash(hash))
+		})
+	}
+
+	require.False(t, argon2idHasher.Compare(password, []byte("$argon2id$")))
+	require.False(t, bcryptHasher.Compare(password, password))
+}
+
+// TestNeedsRehash tests whether hashes produced by another algorithm
+// or weaker parameters are reported to need rehashing
+func TestNeedsRehash(t *testing.T) {
+	password := []byte("testpass")
+	hash := func(hasher passhash.PasswordHasher) []byte {
+		hash, err := hasher.Hash(password)
+		require.NoError(t, err)
+		return hash
+	}
+
+	weakArgon2id := hash(passhash.Argon2id{Memory: 1024, Time: 1, Parallelism: 1})
+	weakBcrypt := hash(passhash.Bcrypt{Cost: bcrypt.MinCost})
+
+	argon2idHasher := passhash.Argon2id{Memory: 2048, Time: 2, Parallelism: 1}
+	require.True(t, argon2idHasher.NeedsRehash(weakArgon2id))
+	require.True(t, argon2idHasher.NeedsRehash(weakBcrypt))
+	require.False(t, passhash.Argon2id{
+		Memory:      512,
+		Time:        1,
+		Parallelism: 1,
+	}.NeedsRehash(weakArgon2id))
+
+	bcryptHasher := passhash.Bcrypt{Cost: bcrypt.MinCost + 1}
+	require.True(t, bcryptHasher.NeedsRehash(weakBcrypt))
+	require.True(t, bcryptHasher.NeedsRehash(weakArgon2id))
+}
+
+// TestValidate tests hasher parameter validation
+func TestValidate(t *testing.T) {
+	require.NoError(t, passhash.Bcrypt{}.Validate())
+	require.NoError(t, passhash.Argon2id{}.Validate())
+	require.Error(t, passhash.Bcrypt{Cost: bcrypt.MaxCost + 1}.Validate())
+	require.Error(t, passhash.Argon2id{Memory: 8, Parallelism: 2}.Validate())
+	require.Error(t, passhash.Argon2id{Memory: 1 << 31}.Validate())
+	require.NoError(t, passhash.Argon2id{Time: 16, Parallelism: 64}.Validate())
+	require.Error(t, passhash.Argon2id{Time: 17}.Validate())
+	require.Error(t, passhash.Argon2id{Parallelism: 255}.Validate())
+}
+
+// TestMalformedArgon2id tests whether hashes with malformed or out of bounds
+// parameters are rejected instead of being computed
+func TestMalformedArgon2id(t *testing.T) {
+	hasher := passhash.Argon2id{Memory: 1024, Time: 1, Parallelism: 1}
+	password := []byte("testpass")
+
+	// Salt and key are taken from a valid hash of the password
+	valid, err := hasher.Hash(password)
+	require.NoError(t, err)
+	parts := strings.Split(string(valid), "$")
+	require.Len(t, parts, 6)
+	salt, key := parts[4], parts[5]
+
+	for name, hash := range map[string]string{
+		"zeroTime":         "$argon2id$v=19$m=1024,t=0,p=1$" + salt + "$" + key,
+		"zeroParallelism":  "$argon2id$v=19$m=1024,t=1,p=0$" + salt + "$" + key,
+		"excessiveMemory":  "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + key,
+		"parallelismRange": "$argon2id$v=19$m=1024,t=1,p=256$" + salt + "$" + key,
+		"excessiveTime":    "$argon2id$v=19$m=1024,t=4294967295,p=1$" + salt + "$" + key,
+		"excessiveThreads": "$argon2id$v=19$m=1024,t=1,p=255$" + salt + "$" + key,
+		"unknownVersion":   "$argon2id$v=16$m=1024,t=1,p=1$" + salt + "$" + key,
+		"emptyKey":         "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$",
+		"excessiveKey": "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$" +
+			base64.RawStdEncoding.EncodeToString(make([]byte, 1025)),
+		"missingParams": "$argon2id$v=19$" + salt + "$" + key,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NotPanics(t, func() {
+				require.False(t, hasher.Compare(password, []byte(hash)))
+			})
+			require.True(t, hasher.NeedsRehash([]byte(hash)))
+		})
+	}
+}
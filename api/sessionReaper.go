@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// runSessionReaper periodically deletes expired sessions and tokens
+// until the server is shut down
+func (srv *server) runSessionReaper() {
+	defer srv.reaper.Done()
+
+	ticker := time.NewTicker(srv.conf.Session.ReaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-srv.stopReaper:
+			return
+		case <-ticker.C:
+			srv.closeExpiredSessions()
+			srv.deleteExpiredTokens()
+		}
+	}
+}
+
+// closeExpiredSessions deletes all currently expired sessions
+func (srv *server) closeExpiredSessions() {
+	now := srv.conf.Clock.Now()
+	closed, err := srv.store.CloseExpiredSessions(
+		context.Background(),
+		now.Add(-srv.conf.Session.AbsoluteTTL),
+		now.Add(-srv.conf.Session.IdleTTL),
+	)
+	if err != nil {
+		srv.logErrf("session reaper: %s", err)
+		return
+	}
+	if len(closed) > 0 {
+		srv.conf.DebugLog.Printf(
+			"session reaper: %d expired sessions closed",
+			len(closed),
+		)
+	}
+}
+
+// deleteExpiredTokens deletes all currently expired tokens,
+// expired tokens aren't deleted when they're used
+func (srv *server) deleteExpiredTokens() {
+	ttl := srv.conf.Token.TTL()
+	for _, kind := range []token.Kind{
+		token.PasswordReset,
+		token.EmailVerification,
+	} {
+		deleted, err := srv.store.DeleteExpiredTokens(
+			context.Background(),
+			kind,
+			srv.conf.Clock.Now().Add(-ttl.Of(kind)),
+		)
+		if err != nil {
+			srv.logErrf("session reaper: %s", err)
+			continue
+		}
+		if deleted > 0 {
+			srv.conf.DebugLog.Printf(
+				"session reaper: %d expired %s tokens deleted",
+				deleted,
+				kind,
+			)
+		}
+	}
+}
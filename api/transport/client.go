@@ -26,4 +26,27 @@ type Client interface {
 		vars map[string]interface{},
 		result interface{},
 	) error
+
+	// QueryPersisted performs a parameterized persisted API query
+	// identified by the hash of the query
+	QueryPersisted(
+		queryHash string,
+		vars map[string]interface{},
+		result interface{},
+	) error
+
+	// Subscribe starts a parameterized API subscription
+	Subscribe(
+		query string,
+		vars map[string]interface{},
+	) (Subscription, error)
+}
+
+// Subscription defines the interface of an active API subscription
+type Subscription interface {
+	// Next blocks until the next result is received
+	Next(result interface{}) error
+
+	// Close stops the subscription
+	Close() error
 }
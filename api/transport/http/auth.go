@@ -8,45 +8,51 @@ import (
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
 )
 
-// auth reads the session key from the authorization header, approaches the API
-// server in order to verify the session key and if a session is returned it
-// moves it to the request context
-func (t *Server) auth(req *http.Request) *http.Request {
+// session approaches the API server in order to verify the session key
+// read from the given authorization header value and returns the session.
+// Returns an empty guest session if the client isn't authenticated
+func (t *Server) session(
+	ctx context.Context,
+	authHeader string,
+) *auth.RequestSession {
 	// Set default (empty) session
-	session := &auth.RequestSession{
-		ShieldClientRole: auth.GQLShieldClientGuest,
-	}
-	req = req.WithContext(context.WithValue(
-		req.Context(),
-		auth.CtxSession,
-		session,
-	))
-
-	// Try read the HTTP Authorization header
-	authHeader := req.Header.Get("Authorization")
-	if len(authHeader) < 1 {
-		return req
-	}
+	session := &auth.RequestSession{}
 
 	tokens := strings.Split(authHeader, " ")
 	if len(tokens) < 2 {
-		return req
+		return session
 	}
 
 	if tokens[0] == "Bearer" {
 		// Treat the authorization header as session key bearer token
-		userID, sessionCreationTime := t.onAuth(req.Context(), tokens[1])
-		session.UserID = userID
-		session.Creation = sessionCreationTime
-		session.ShieldClientRole = auth.GQLShieldClientRegular
+		userID, sessionCreationTime, roles := t.onAuth(ctx, tokens[1])
+		if userID != "" {
+			session.UserID = userID
+			session.Roles = roles
+			session.Creation = sessionCreationTime
+		}
 	} else if tokens[0] == "Debug" {
 		// Treat the authorization header as debug session key bearer token
-		if t.onDebugAuth(req.Context(), tokens[1]) {
+		if ok, mode := t.onDebugAuth(ctx, tokens[1]); ok {
 			session.IsDebug = true
-			session.ShieldClientRole = auth.GQLShieldClientDebug
+			session.DebugMode = mode
 		}
 	}
 
-	// Put context in the request context
-	return req
+	return session
+}
+
+// auth reads the session key from the authorization header, approaches the API
+// server in order to verify the session key and if a session is returned it
+// moves it to the request context
+func (t *Server) auth(req *http.Request) *http.Request {
+	// Try read the HTTP Authorization header
+	session := t.session(req.Context(), req.Header.Get("Authorization"))
+
+	// Put session in the request context
+	return req.WithContext(context.WithValue(
+		req.Context(),
+		auth.CtxSession,
+		session,
+	))
 }
@@ -10,7 +10,6 @@ import (
 	"net/url"
 
 	"github.com/pkg/errors"
-	"github.com/romshark/dgraph_graphql_go/api/graph"
 	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
 	trn "github.com/romshark/dgraph_graphql_go/api/transport"
 )
@@ -31,13 +30,20 @@ func NewClient(host url.URL, conf ClientConfig) (trn.Client, error) {
 		return nil, errors.Wrap(err, "client cookie jar init")
 	}
 
+	httpClt := &http.Client{
+		Timeout: conf.Timeout,
+		Jar:     cookieJar,
+	}
+	if conf.TLS != nil {
+		httpClt.Transport = &http.Transport{
+			TLSClientConfig: conf.TLS,
+		}
+	}
+
 	// Initialize client
 	return &Client{
-		host: host,
-		httpClt: &http.Client{
-			Timeout: conf.Timeout,
-			Jar:     cookieJar,
-		},
+		host:    host,
+		httpClt: httpClt,
 	}, nil
 }
 
@@ -49,6 +55,18 @@ func (c *Client) Query(
 	return c.QueryVar(query, nil, result)
 }
 
+// authHeader returns the authorization header value
+// or an empty string if the client isn't authenticated
+func (c *Client) authHeader() string {
+	if c.sessionKey == "" {
+		return ""
+	}
+	if c.isDebug {
+		return "Debug " + c.sessionKey
+	}
+	return "Bearer " + c.sessionKey
+}
+
 // QueryVar implements the transport.Client interface
 func (c *Client) QueryVar(
 	query string,
@@ -85,13 +103,46 @@ func (c *Client) QueryVar(
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 
-	// Set authorization headers if authentication
-	if c.sessionKey != "" {
-		if c.isDebug {
-			req.Header.Set("Authorization", "Debug "+c.sessionKey)
-		} else {
-			req.Header.Set("Authorization", "Bearer "+c.sessionKey)
+	return c.doQuery(req, result)
+}
+
+// QueryPersisted implements the transport.Client interface
+func (c *Client) QueryPersisted(
+	queryHash string,
+	vars map[string]interface{},
+	result interface{},
+) error {
+	params := url.Values{}
+	params.Set("id", queryHash)
+	if vars != nil {
+		marshed, err := json.Marshal(vars)
+		if err != nil {
+			return errors.Wrap(err, "variables marshal")
 		}
+		params.Set("variables", string(marshed))
+	}
+
+	u := c.host
+	u.Path = "/g"
+	u.RawQuery = params.Encode()
+
+	// Initialize request
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "query GET request creation")
+	}
+
+	return c.doQuery(req, result)
+}
+
+// doQuery performs the graph query request
+// and decodes the response data into result
+func (c *Client) doQuery(req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", mediaTypeGraphQLResponse)
+
+	// Set authorization headers if authentication
+	if authHeader := c.authHeader(); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
 	}
 
 	// Perform request
@@ -108,9 +159,11 @@ func (c *Client) QueryVar(
 
 	responseDecoderJSON := json.NewDecoder(resp.Body)
 
+	// The data of partially successful queries is decoded
+	// even though the first error is returned
 	res := struct {
-		Data  interface{}         `json:"data"`
-		Error *graphResponseError `json:"errors"`
+		Data   interface{}          `json:"data"`
+		Errors []GraphResponseError `json:"errors"`
 	}{
 		Data: result,
 	}
@@ -118,11 +171,8 @@ func (c *Client) QueryVar(
 		return errors.Wrap(err, "response decode JSON")
 	}
 
-	if res.Error != nil {
-		return &graph.ResponseError{
-			Code:    res.Error.Code,
-			Message: res.Error.Message,
-		}
+	if len(res.Errors) > 0 {
+		return res.Errors[0].ResponseError()
 	}
 
 	return nil
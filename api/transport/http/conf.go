@@ -58,6 +58,10 @@ func (conf *ServerConfig) Prepare() error {
 // ClientConfig defines the HTTP client transport layer configuration
 type ClientConfig struct {
 	Timeout time.Duration
+
+	// TLS defines the TLS configuration used for HTTPS connections.
+	// The default configuration is used if TLS is nil
+	TLS *tls.Config
 }
 
 // SetDefaults sets the default configuration
@@ -2,53 +2,53 @@ package http
 
 import (
 	"encoding/json"
+	"mime"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/api/graph"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
-var graphRespHead = []byte(`{"data":`)
-var graphRespTail = []byte(`}`)
+// Graph response media types
+const (
+	mediaTypeJSON            = "application/json"
+	mediaTypeGraphQLResponse = "application/graphql-response+json"
+)
 
-type graphResponseError struct {
-	Code    string `json:"c"`
-	Message string `json:"m"`
+// graphQuery represents the JSON graph query structure
+type graphQuery struct {
+	Query         json.RawMessage        `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    struct {
+		PersistedQuery *persistedQuery `json:"persistedQuery"`
+	} `json:"extensions"`
 }
 
-type graphResponse struct {
-	Data  []byte              `json:"data"`
-	Error *graphResponseError `json:"errors"`
+// persistedQuery represents the persisted query extension
+type persistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
 }
 
-// graphQuery represents the JSON graph query structure
-type graphQuery struct {
-	Query         json.RawMessage    `json:"query"`
-	OperationName string             `json:"operationName"`
-	Variables     map[string]*string `json:"variables"`
-}
+// persistedQueryVersion defines the supported persisted query version
+const persistedQueryVersion = 1
 
 // handleGraphQuery handles a graph query request
 func (t *Server) handleGraphQuery(
 	resp http.ResponseWriter,
 	req *http.Request,
 ) {
-	handleUnexpectedErr := func(err error, logErr bool) {
-		http.Error(
-			resp,
-			http.StatusText(http.StatusInternalServerError),
-			http.StatusInternalServerError,
-		)
-		if logErr {
-			t.errorLog.Print(err)
-		}
-	}
-
 	// Decode graph query
 	requestDecoderJSON := json.NewDecoder(req.Body)
 	var graphQuery graphQuery
 	if err := requestDecoderJSON.Decode(&graphQuery); err != nil {
-		handleUnexpectedErr(errors.Wrap(err, "graph query JSON decode"), true)
+		t.replyGraphErr(resp, req, &graph.ResponseError{
+			Code:    string(strerr.ErrInvalidInput),
+			Message: "invalid graph query JSON",
+		})
 		return
 	}
 
@@ -57,52 +57,177 @@ func (t *Server) handleGraphQuery(
 		query = []byte(graphQuery.Query)[1 : len(graphQuery.Query)-1]
 	}
 
-	response, err := t.onGraphQuery(
-		req.Context(),
-		graph.Query{
-			Query:         query,
-			OperationName: graphQuery.OperationName,
-			Variables:     graphQuery.Variables,
-		},
-	)
-	if err != nil {
-		handleUnexpectedErr(err, false)
+	var persistedQueryHash string
+	if pq := graphQuery.Extensions.PersistedQuery; pq != nil {
+		if pq.Version != persistedQueryVersion {
+			t.replyGraphErr(resp, req, &graph.ResponseError{
+				Code:    string(strerr.ErrInvalidInput),
+				Message: "unsupported persisted query version",
+			})
+			return
+		}
+		persistedQueryHash = pq.Sha256Hash
+	}
+
+	t.serveGraphQuery(resp, req, graph.Query{
+		Query:              query,
+		OperationName:      graphQuery.OperationName,
+		Variables:          graphQuery.Variables,
+		PersistedQueryHash: persistedQueryHash,
+	})
+}
+
+// handlePersistedGraphQuery handles a persisted graph query request
+// identified by the "id" URL query parameter. Only query operations
+// are permitted since GET requests must not have side-effects
+func (t *Server) handlePersistedGraphQuery(
+	resp http.ResponseWriter,
+	req *http.Request,
+) {
+	params := req.URL.Query()
+
+	queryHash := params.Get("id")
+	if queryHash == "" {
+		t.replyGraphErr(resp, req, &graph.ResponseError{
+			Code:    string(strerr.ErrInvalidInput),
+			Message: "missing persisted query id",
+		})
 		return
 	}
 
-	jsonEncoder := json.NewEncoder(resp)
-
-	if response.Error != nil {
-		// User error
-		resp.WriteHeader(http.StatusBadRequest)
-
-		if err := jsonEncoder.Encode(graphResponse{
-			Error: &graphResponseError{
-				Code:    response.Error.Code,
-				Message: response.Error.Message,
-			},
-		}); err != nil {
-			handleUnexpectedErr(
-				errors.Wrap(err, "graph response JSON encode"),
-				true,
-			)
+	var variables map[string]interface{}
+	if vars := params.Get("variables"); vars != "" {
+		if err := json.Unmarshal([]byte(vars), &variables); err != nil {
+			t.replyGraphErr(resp, req, &graph.ResponseError{
+				Code:    string(strerr.ErrInvalidInput),
+				Message: "invalid variables",
+			})
+			return
 		}
-		return
 	}
 
-	// Reply successfully
-	resp.Header().Set("Content-Type", "application/json")
+	// The response depends on the session of the client
+	// and may contain private data which must neither be stored
+	// by shared caches nor reused without revalidation
+	resp.Header().Set("Vary", "Authorization")
+	resp.Header().Set("Cache-Control", "private, no-cache")
 
-	if _, err := resp.Write(graphRespHead); err != nil {
-		handleUnexpectedErr(errors.Wrap(err, "response head write"), true)
-		return
+	t.serveGraphQuery(resp, req, graph.Query{
+		OperationName:      params.Get("operationName"),
+		Variables:          variables,
+		PersistedQueryHash: queryHash,
+		QueryOnly:          true,
+	})
+}
+
+// handleUnexpectedErr replies with an internal server error
+func (t *Server) handleUnexpectedErr(
+	resp http.ResponseWriter,
+	err error,
+	logErr bool,
+) {
+	http.Error(
+		resp,
+		http.StatusText(http.StatusInternalServerError),
+		http.StatusInternalServerError,
+	)
+	if logErr {
+		t.errorLog.Print(err)
 	}
-	if _, err := resp.Write(response.Data); err != nil {
-		handleUnexpectedErr(errors.Wrap(err, "response data write"), true)
-		return
+}
+
+// acceptsGraphQLResponse returns true if the client accepts
+// the application/graphql-response+json media type
+func acceptsGraphQLResponse(req *http.Request) bool {
+	for _, accepted := range strings.Split(req.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(accepted)
+		if err == nil && mediaType == mediaTypeGraphQLResponse {
+			return true
+		}
 	}
-	if _, err := resp.Write(graphRespTail); err != nil {
-		handleUnexpectedErr(errors.Wrap(err, "response tail write"), true)
+	return false
+}
+
+// replyGraph replies with the given graph response.
+// Following the GraphQL over HTTP specification, responses without data
+// are replied with status 400 if the client accepts
+// the application/graphql-response+json media type,
+// legacy application/json responses are always replied with status 200.
+// Internal errors preventing the execution are replied with status 500
+func (t *Server) replyGraph(
+	resp http.ResponseWriter,
+	req *http.Request,
+	response graph.Response,
+) {
+	mediaType := mediaTypeJSON
+	if acceptsGraphQLResponse(req) {
+		mediaType = mediaTypeGraphQLResponse
+	}
+
+	status := http.StatusOK
+	if response.Data == nil {
+		if mediaType == mediaTypeGraphQLResponse {
+			status = http.StatusBadRequest
+		}
+		for _, err := range response.Errors {
+			if err.Code == string(strerr.ErrInternal) {
+				status = http.StatusInternalServerError
+				break
+			}
+		}
+	}
+
+	t.writeGraphResponse(resp, mediaType, status, response)
+}
+
+// replyGraphErr replies with the given error
+// to a request that isn't a well-formed graph query
+func (t *Server) replyGraphErr(
+	resp http.ResponseWriter,
+	req *http.Request,
+	responseErr *graph.ResponseError,
+) {
+	mediaType := mediaTypeJSON
+	if acceptsGraphQLResponse(req) {
+		mediaType = mediaTypeGraphQLResponse
+	}
+	t.writeGraphResponse(
+		resp,
+		mediaType,
+		http.StatusBadRequest,
+		graph.Response{Errors: []*graph.ResponseError{responseErr}},
+	)
+}
+
+// writeGraphResponse writes the JSON encoded graph response
+func (t *Server) writeGraphResponse(
+	resp http.ResponseWriter,
+	mediaType string,
+	status int,
+	response graph.Response,
+) {
+	body, err := json.Marshal(newGraphResponse(response))
+	if err != nil {
+		t.handleUnexpectedErr(
+			resp,
+			errors.Wrap(err, "graph response JSON encode"),
+			true,
+		)
 		return
 	}
+
+	resp.Header().Set("Content-Type", mediaType)
+	resp.WriteHeader(status)
+	if _, err := resp.Write(body); err != nil {
+		t.debugLog.Printf("graph response write: %s", err)
+	}
+}
+
+// serveGraphQuery executes the graph query and replies with its result
+func (t *Server) serveGraphQuery(
+	resp http.ResponseWriter,
+	req *http.Request,
+	query graph.Query,
+) {
+	t.replyGraph(resp, req, t.onGraphQuery(req.Context(), query))
 }
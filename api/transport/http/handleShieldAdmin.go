@@ -0,0 +1,194 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+)
+
+// handleShieldAdmin handles a GraphQL shield administration request.
+// Only the debug user is allowed to access the shield administration
+// endpoints, modifications require the debug user to be in read-write mode
+func (t *Server) handleShieldAdmin(
+	resp http.ResponseWriter,
+	req *http.Request,
+) {
+	session, _ := req.Context().Value(auth.CtxSession).(*auth.RequestSession)
+	if session == nil || !session.IsDebug {
+		http.Error(
+			resp,
+			http.StatusText(http.StatusForbidden),
+			http.StatusForbidden,
+		)
+		return
+	}
+
+	if req.Method != "GET" && session.DebugMode != auth.DebugModeReadWrite {
+		http.Error(
+			resp,
+			"the debug user is in read-only mode",
+			http.StatusForbidden,
+		)
+		return
+	}
+
+	switch req.Method + " " + req.URL.Path {
+	case "GET /shield/queries":
+		t.handleShieldListQueries(resp, req)
+	case "POST /shield/queries":
+		t.handleShieldWhitelistQueries(resp, req)
+	case "DELETE /shield/queries":
+		t.handleShieldRemoveQuery(resp, req)
+	case "PUT /shield/queries/roles":
+		t.handleShieldUpdateQueryRoles(resp, req)
+	default:
+		http.Error(
+			resp,
+			http.StatusText(http.StatusNotFound),
+			http.StatusNotFound,
+		)
+	}
+}
+
+// shieldInternalErr replies with an internal server error and logs it
+func (t *Server) shieldInternalErr(resp http.ResponseWriter, err error) {
+	http.Error(
+		resp,
+		http.StatusText(http.StatusInternalServerError),
+		http.StatusInternalServerError,
+	)
+	t.errorLog.Print(err)
+}
+
+// shieldReply replies with the JSON encoded data
+func (t *Server) shieldReply(resp http.ResponseWriter, data interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(data); err != nil {
+		t.errorLog.Printf("shield admin response JSON encode: %s", err)
+	}
+}
+
+// shieldQuery returns the whitelisted query identified by name
+// or nil if there's none
+func (t *Server) shieldQuery(name string) (gqlshield.Query, error) {
+	queries, err := t.shield.ListQueries()
+	if err != nil {
+		return nil, err
+	}
+	return queries[name], nil
+}
+
+// handleShieldListQueries lists all whitelisted queries ordered by name
+func (t *Server) handleShieldListQueries(
+	resp http.ResponseWriter,
+	req *http.Request,
+) {
+	queries, err := t.shield.ListQueries()
+	if err != nil {
+		t.shieldInternalErr(resp, errors.Wrap(err, "listing shield queries"))
+		return
+	}
+
+	list := make([]ShieldQuery, 0, len(queries))
+	for _, query := range queries {
+		list = append(list, newShieldQuery(query))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	t.shieldReply(resp, list)
+}
+
+// handleShieldWhitelistQueries whitelists new queries
+func (t *Server) handleShieldWhitelistQueries(
+	resp http.ResponseWriter,
+	req *http.Request,
+) {
+	var entries []ShieldEntry
+	if err := json.NewDecoder(req.Body).Decode(&entries); err != nil {
+		http.Error(resp, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	newEntries := make([]gqlshield.Entry, len(entries))
+	for i, entry := range entries {
+		newEntries[i] = gqlshield.Entry{
+			Name:           entry.Name,
+			Query:          entry.Query,
+			Parameters:     entry.Parameters,
+			WhitelistedFor: entry.WhitelistedFor,
+		}
+	}
+
+	queries, err := t.shield.WhitelistQueries(newEntries...)
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list := make([]ShieldQuery, len(queries))
+	for i, query := range queries {
+		list[i] = newShieldQuery(query)
+	}
+
+	t.shieldReply(resp, list)
+}
+
+// handleShieldRemoveQuery removes the query identified by the name
+// provided in the "name" URL query parameter
+func (t *Server) handleShieldRemoveQuery(
+	resp http.ResponseWriter,
+	req *http.Request,
+) {
+	query, err := t.shieldQuery(req.URL.Query().Get("name"))
+	if err != nil {
+		t.shieldInternalErr(resp, errors.Wrap(err, "finding shield query"))
+		return
+	}
+	if query == nil {
+		http.Error(resp, "query not found", http.StatusNotFound)
+		return
+	}
+
+	if err := t.shield.RemoveQuery(query); err != nil {
+		t.shieldInternalErr(resp, errors.Wrap(err, "removing shield query"))
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+// handleShieldUpdateQueryRoles replaces the roles a query is whitelisted for
+func (t *Server) handleShieldUpdateQueryRoles(
+	resp http.ResponseWriter,
+	req *http.Request,
+) {
+	var update ShieldRolesUpdate
+	if err := json.NewDecoder(req.Body).Decode(&update); err != nil {
+		http.Error(resp, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	query, err := t.shieldQuery(update.Name)
+	if err != nil {
+		t.shieldInternalErr(resp, errors.Wrap(err, "finding shield query"))
+		return
+	}
+	if query == nil {
+		http.Error(resp, "query not found", http.StatusNotFound)
+		return
+	}
+
+	updated, err := t.shield.UpdateQueryRoles(query, update.WhitelistedFor)
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t.shieldReply(resp, newShieldQuery(updated))
+}
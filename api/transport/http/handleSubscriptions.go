@@ -0,0 +1,297 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// wsProtocol defines the supported WebSocket subprotocol
+const wsProtocol = "graphql-ws"
+
+// wsKeepAliveInterval defines how often keep-alive messages are sent
+const wsKeepAliveInterval = 20 * time.Second
+
+// graphql-ws message types
+const (
+	wsConnectionInit      = "connection_init"
+	wsConnectionAck       = "connection_ack"
+	wsConnectionError     = "connection_error"
+	wsConnectionKeepAlive = "ka"
+	wsConnectionTerminate = "connection_terminate"
+	wsStart               = "start"
+	wsStop                = "stop"
+	wsData                = "data"
+	wsError               = "error"
+	wsComplete            = "complete"
+)
+
+// wsMessage represents a graphql-ws protocol message
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsInitPayload represents a graphql-ws connection initialization payload
+type wsInitPayload struct {
+	Authorization string `json:"Authorization"`
+}
+
+var wsUpgrader = websocket.Upgrader{
+	Subprotocols: []string{wsProtocol},
+	// Clients authenticate through the authorization header
+	// or the connection initialization payload, never through cookies,
+	// therefore connections from any origin are accepted
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// wsSubscription represents an active subscription
+type wsSubscription struct {
+	cancel context.CancelFunc
+}
+
+// wsConnection represents a graphql-ws client connection
+type wsConnection struct {
+	t             *Server
+	conn          *websocket.Conn
+	writeLock     *sync.Mutex
+	session       *auth.RequestSession
+	initialized   bool
+	subscriptions map[string]*wsSubscription
+	subsLock      *sync.Mutex
+	subsWait      *sync.WaitGroup
+}
+
+// handleSubscriptions handles graphql-ws WebSocket connections
+func (t *Server) handleSubscriptions(
+	resp http.ResponseWriter,
+	req *http.Request,
+) {
+	conn, err := wsUpgrader.Upgrade(resp, req, nil)
+	if err != nil {
+		// The upgrader already replied with an HTTP error
+		return
+	}
+	if conn.Subprotocol() != wsProtocol {
+		conn.Close()
+		return
+	}
+
+	// Register the connection to close it on shutdown
+	if !t.trackWSConn(conn) {
+		conn.Close()
+		return
+	}
+	defer t.untrackWSConn(conn)
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	c := &wsConnection{
+		t:             t,
+		conn:          conn,
+		writeLock:     &sync.Mutex{},
+		session:       req.Context().Value(auth.CtxSession).(*auth.RequestSession),
+		subscriptions: make(map[string]*wsSubscription),
+		subsLock:      &sync.Mutex{},
+		subsWait:      &sync.WaitGroup{},
+	}
+	c.serve(ctx)
+
+	// Wait for all subscriptions to end before closing the connection
+	cancel()
+	c.subsWait.Wait()
+	conn.Close()
+}
+
+// write writes a message to the connection
+func (c *wsConnection) write(msg wsMessage) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if err := c.conn.WriteJSON(msg); err != nil {
+		c.t.debugLog.Printf("WebSocket write: %s", err)
+	}
+}
+
+// writePayload writes a message with the given payload to the connection
+func (c *wsConnection) writePayload(
+	id string,
+	msgType string,
+	payload interface{},
+) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		c.t.errorLog.Print(errors.Wrap(err, "WebSocket payload JSON encode"))
+		return
+	}
+	c.write(wsMessage{ID: id, Type: msgType, Payload: encoded})
+}
+
+// writeErr writes an operation error message to the connection
+func (c *wsConnection) writeErr(id string, errs ...*graph.ResponseError) {
+	payload := make([]GraphResponseError, len(errs))
+	for i, err := range errs {
+		payload[i] = newGraphResponseError(err)
+	}
+	c.writePayload(id, wsError, payload)
+}
+
+// serve reads messages until the connection is either closed or terminated
+func (c *wsConnection) serve(ctx context.Context) {
+	for {
+		var msg wsMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case wsConnectionInit:
+			if !c.init(ctx, msg) {
+				return
+			}
+		case wsStart:
+			if !c.initialized {
+				c.writePayload("", wsConnectionError, GraphResponseError{
+					Message: "connection not initialized",
+				})
+				return
+			}
+			c.start(ctx, msg)
+		case wsStop:
+			c.stop(msg.ID)
+		case wsConnectionTerminate:
+			return
+		default:
+			c.writeErr(msg.ID, &graph.ResponseError{
+				Message: "unsupported message type: " + msg.Type,
+			})
+		}
+	}
+}
+
+// init initializes the connection optionally authenticating the client.
+// Connections can only be initialized once
+func (c *wsConnection) init(ctx context.Context, msg wsMessage) bool {
+	if c.initialized {
+		c.writePayload("", wsConnectionError, GraphResponseError{
+			Message: "connection already initialized",
+		})
+		return false
+	}
+
+	var payload wsInitPayload
+	if len(msg.Payload) > 0 {
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			c.writePayload("", wsConnectionError, GraphResponseError{
+				Message: "invalid connection initialization payload",
+			})
+			return false
+		}
+	}
+
+	// Authenticate the client if authorization is provided
+	if payload.Authorization != "" {
+		c.session = c.t.session(ctx, payload.Authorization)
+	}
+	c.initialized = true
+	c.write(wsMessage{Type: wsConnectionAck})
+
+	// Keep the connection alive
+	c.subsWait.Add(1)
+	go func() {
+		defer c.subsWait.Done()
+		ticker := time.NewTicker(wsKeepAliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.write(wsMessage{Type: wsConnectionKeepAlive})
+			}
+		}
+	}()
+	return true
+}
+
+// start starts a new subscription
+func (c *wsConnection) start(ctx context.Context, msg wsMessage) {
+	var payload graphQuery
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		c.writeErr(msg.ID, &graph.ResponseError{
+			Code:    string(strerr.ErrInvalidInput),
+			Message: "invalid subscription payload",
+		})
+		return
+	}
+
+	var query []byte
+	if len(payload.Query) > 2 {
+		query = []byte(payload.Query)[1 : len(payload.Query)-1]
+	}
+
+	// Stop previous subscriptions with the same identifier
+	c.stop(msg.ID)
+
+	subCtx, cancel := context.WithCancel(context.WithValue(
+		ctx,
+		auth.CtxSession,
+		c.session,
+	))
+
+	stream, respErrs := c.t.onGraphSubscription(subCtx, graph.Query{
+		Query:         query,
+		OperationName: payload.OperationName,
+		Variables:     payload.Variables,
+	})
+	if respErrs != nil {
+		cancel()
+		c.writeErr(msg.ID, respErrs...)
+		return
+	}
+
+	sub := &wsSubscription{cancel: cancel}
+	c.subsLock.Lock()
+	c.subscriptions[msg.ID] = sub
+	c.subsLock.Unlock()
+
+	c.subsWait.Add(1)
+	go func() {
+		defer c.subsWait.Done()
+		for response := range stream {
+			c.writePayload(msg.ID, wsData, newGraphResponse(response))
+		}
+
+		// Notify the client unless the subscription was stopped
+		if subCtx.Err() == nil {
+			c.write(wsMessage{ID: msg.ID, Type: wsComplete})
+		}
+		cancel()
+
+		// Unregister unless replaced by a newer subscription
+		c.subsLock.Lock()
+		if c.subscriptions[msg.ID] == sub {
+			delete(c.subscriptions, msg.ID)
+		}
+		c.subsLock.Unlock()
+	}()
+}
+
+// stop stops the subscription identified by id
+func (c *wsConnection) stop(id string) {
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+	if sub, ok := c.subscriptions[id]; ok {
+		sub.cancel()
+		delete(c.subscriptions, id)
+	}
+}
@@ -1,13 +1,86 @@
 package http
 
+import (
+	"encoding/json"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+)
+
+// GraphErrorLocation represents the location of an error in the query
+type GraphErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphErrorExtensions represents the extensions of a response error object
+type GraphErrorExtensions struct {
+	Code string `json:"code,omitempty"`
+}
+
 // GraphResponseError represents a response error object
 type GraphResponseError struct {
-	Code    string `json:"c,omitempty"`
-	Message string `json:"m,omitempty"`
+	Message    string                `json:"message"`
+	Path       []interface{}         `json:"path,omitempty"`
+	Locations  []GraphErrorLocation  `json:"locations,omitempty"`
+	Extensions *GraphErrorExtensions `json:"extensions,omitempty"`
 }
 
 // GraphResponse represents a response object
 type GraphResponse struct {
-	Data  interface{}         `json:"d,omitempty"`
-	Error *GraphResponseError `json:"e,omitempty"`
+	Data   json.RawMessage      `json:"data,omitempty"`
+	Errors []GraphResponseError `json:"errors,omitempty"`
+}
+
+// newGraphResponseError creates a new response error object
+func newGraphResponseError(err *graph.ResponseError) GraphResponseError {
+	respErr := GraphResponseError{
+		Message: err.Message,
+		Path:    err.Path,
+	}
+	if len(err.Locations) > 0 {
+		respErr.Locations = make([]GraphErrorLocation, len(err.Locations))
+		for i, loc := range err.Locations {
+			respErr.Locations[i] = GraphErrorLocation{
+				Line:   loc.Line,
+				Column: loc.Column,
+			}
+		}
+	}
+	if err.Code != "" {
+		respErr.Extensions = &GraphErrorExtensions{Code: err.Code}
+	}
+	return respErr
+}
+
+// newGraphResponse creates a new response object
+func newGraphResponse(response graph.Response) GraphResponse {
+	resp := GraphResponse{Data: response.Data}
+	if len(response.Errors) > 0 {
+		resp.Errors = make([]GraphResponseError, len(response.Errors))
+		for i, err := range response.Errors {
+			resp.Errors[i] = newGraphResponseError(err)
+		}
+	}
+	return resp
+}
+
+// ResponseError converts the response error object
+func (err GraphResponseError) ResponseError() *graph.ResponseError {
+	respErr := &graph.ResponseError{
+		Message: err.Message,
+		Path:    err.Path,
+	}
+	if len(err.Locations) > 0 {
+		respErr.Locations = make([]graph.Location, len(err.Locations))
+		for i, loc := range err.Locations {
+			respErr.Locations[i] = graph.Location{
+				Line:   loc.Line,
+				Column: loc.Column,
+			}
+		}
+	}
+	if err.Extensions != nil {
+		respErr.Code = err.Extensions.Code
+	}
+	return respErr
 }
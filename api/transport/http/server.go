@@ -6,24 +6,32 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 
+	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
 	trn "github.com/romshark/dgraph_graphql_go/api/transport"
 )
 
 // Server represents an HTTP based server transport implementation
 type Server struct {
-	addrReadWait *sync.WaitGroup
-	conf         ServerConfig
-	httpSrv      *http.Server
-	addr         net.Addr
-	onGraphQuery trn.OnGraphQuery
-	onAuth       trn.OnAuth
-	onDebugAuth  trn.OnDebugAuth
-	onDebugSess  trn.OnDebugSess
-	debugLog     *log.Logger
-	errorLog     *log.Logger
+	addrReadWait        *sync.WaitGroup
+	conf                ServerConfig
+	httpSrv             *http.Server
+	addr                net.Addr
+	onGraphQuery        trn.OnGraphQuery
+	onGraphSubscription trn.OnGraphSubscription
+	onAuth              trn.OnAuth
+	onDebugAuth         trn.OnDebugAuth
+	onDebugSess         trn.OnDebugSess
+	shield              gqlshield.GraphQLShield
+	debugLog            *log.Logger
+	errorLog            *log.Logger
+	wsLock              *sync.Mutex
+	wsConns             map[*websocket.Conn]struct{}
+	wsClosed            bool
 }
 
 // NewServer creates a new unencrypted JSON based HTTP transport.
@@ -36,6 +44,8 @@ func NewServer(conf ServerConfig) (trn.Server, error) {
 	t := &Server{
 		addrReadWait: &sync.WaitGroup{},
 		conf:         conf,
+		wsLock:       &sync.Mutex{},
+		wsConns:      make(map[*websocket.Conn]struct{}),
 	}
 	t.httpSrv = &http.Server{
 		Addr:    conf.Host,
@@ -53,15 +63,20 @@ func NewServer(conf ServerConfig) (trn.Server, error) {
 // Init implements the transport.Transport interface
 func (t *Server) Init(
 	onGraphQuery trn.OnGraphQuery,
+	onGraphSubscription trn.OnGraphSubscription,
 	onAuth trn.OnAuth,
 	onDebugAuth trn.OnDebugAuth,
 	onDebugSess trn.OnDebugSess,
+	shield gqlshield.GraphQLShield,
 	debugLog *log.Logger,
 	errorLog *log.Logger,
 ) error {
 	if onGraphQuery == nil {
 		panic("missing onGraphQuery callback")
 	}
+	if onGraphSubscription == nil {
+		panic("missing onGraphSubscription callback")
+	}
 	if onAuth == nil {
 		panic("missing onAuth callback")
 	}
@@ -71,10 +86,15 @@ func (t *Server) Init(
 	if onDebugSess == nil {
 		panic("missing onDebugSess callback")
 	}
+	if shield == nil {
+		panic("missing shield")
+	}
 	t.onGraphQuery = onGraphQuery
+	t.onGraphSubscription = onGraphSubscription
 	t.onAuth = onAuth
 	t.onDebugAuth = onDebugAuth
 	t.onDebugSess = onDebugSess
+	t.shield = shield
 	t.debugLog = debugLog
 	t.errorLog = errorLog
 	return nil
@@ -123,7 +143,36 @@ func (t *Server) Run() error {
 
 // Shutdown implements the transport.Transport interface
 func (t *Server) Shutdown(ctx context.Context) error {
-	return t.httpSrv.Shutdown(ctx)
+	err := t.httpSrv.Shutdown(ctx)
+
+	// Close all hijacked WebSocket connections
+	t.wsLock.Lock()
+	t.wsClosed = true
+	for conn := range t.wsConns {
+		conn.Close()
+	}
+	t.wsLock.Unlock()
+
+	return err
+}
+
+// trackWSConn registers an open WebSocket connection.
+// Returns false if the server is shutting down
+func (t *Server) trackWSConn(conn *websocket.Conn) bool {
+	t.wsLock.Lock()
+	defer t.wsLock.Unlock()
+	if t.wsClosed {
+		return false
+	}
+	t.wsConns[conn] = struct{}{}
+	return true
+}
+
+// untrackWSConn unregisters a closed WebSocket connection
+func (t *Server) untrackWSConn(conn *websocket.Conn) {
+	t.wsLock.Lock()
+	defer t.wsLock.Unlock()
+	delete(t.wsConns, conn)
 }
 
 // ServeHTTP implements the http.Handler interface
@@ -132,6 +181,12 @@ func (t *Server) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	// of the request
 	req = t.auth(req)
 
+	// Serve the GraphQL shield administration endpoints
+	if strings.HasPrefix(req.URL.Path, "/shield/") {
+		t.handleShieldAdmin(resp, req)
+		return
+	}
+
 	switch req.Method {
 	case "POST":
 		switch req.URL.Path {
@@ -149,8 +204,12 @@ func (t *Server) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		}
 	case "GET":
 		switch req.URL.Path {
+		case "/g":
+			t.handlePersistedGraphQuery(resp, req)
 		case "/playground":
 			t.servePlayground(resp, req)
+		case "/ws":
+			t.handleSubscriptions(resp, req)
 		default:
 			// Unsupported path
 			http.Error(
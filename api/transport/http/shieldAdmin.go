@@ -0,0 +1,46 @@
+package http
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+)
+
+// ShieldQuery represents a whitelisted query
+// exposed by the GraphQL shield administration endpoints
+type ShieldQuery struct {
+	ID             string                         `json:"id"`
+	Hash           string                         `json:"hash"`
+	Name           string                         `json:"name"`
+	Query          string                         `json:"query"`
+	Creation       time.Time                      `json:"creation"`
+	Parameters     map[string]gqlshield.Parameter `json:"parameters"`
+	WhitelistedFor []int                          `json:"whitelistedFor"`
+}
+
+// ShieldEntry represents a query to be whitelisted
+type ShieldEntry struct {
+	Name           string                         `json:"name"`
+	Query          string                         `json:"query"`
+	Parameters     map[string]gqlshield.Parameter `json:"parameters"`
+	WhitelistedFor []int                          `json:"whitelistedFor"`
+}
+
+// ShieldRolesUpdate represents an update of the roles
+// a whitelisted query is whitelisted for
+type ShieldRolesUpdate struct {
+	Name           string `json:"name"`
+	WhitelistedFor []int  `json:"whitelistedFor"`
+}
+
+func newShieldQuery(query gqlshield.Query) ShieldQuery {
+	return ShieldQuery{
+		ID:             string(query.ID()),
+		Hash:           query.Hash(),
+		Name:           query.Name(),
+		Query:          string(query.Query()),
+		Creation:       query.Creation(),
+		Parameters:     query.Parameters(),
+		WhitelistedFor: query.WhitelistedFor(),
+	}
+}
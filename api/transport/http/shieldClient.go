@@ -0,0 +1,136 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ShieldClient represents a GraphQL shield administration client
+type ShieldClient struct {
+	clt *Client
+}
+
+// NewShieldClient creates a new GraphQL shield administration client.
+// The client must be signed in as the debug user before use
+func NewShieldClient(host url.URL, conf ClientConfig) (*ShieldClient, error) {
+	clt, err := NewClient(host, conf)
+	if err != nil {
+		return nil, err
+	}
+	return &ShieldClient{clt: clt.(*Client)}, nil
+}
+
+// SignInDebug signs the client into the debug user
+func (c *ShieldClient) SignInDebug(username, password string) error {
+	return c.clt.SignInDebug(username, password)
+}
+
+// request performs a shield administration request and decodes
+// the response into result if result isn't nil
+func (c *ShieldClient) request(
+	method string,
+	path string,
+	query url.Values,
+	body interface{},
+	result interface{},
+) error {
+	u := c.clt.host
+	u.Path = path
+	u.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		marshed, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "request body marshal")
+		}
+		reqBody = bytes.NewBuffer(marshed)
+	}
+
+	req, err := http.NewRequest(method, u.String(), reqBody)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s request creation", method, path)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authHeader := c.clt.authHeader(); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	resp, err := c.clt.httpClt.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s request", method, path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf(
+			"%s %s: %d: %s",
+			method,
+			path,
+			resp.StatusCode,
+			strings.TrimSpace(string(msg)),
+		)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, "response decode JSON")
+	}
+	return nil
+}
+
+// ListQueries returns all whitelisted queries ordered by name
+func (c *ShieldClient) ListQueries() (result []ShieldQuery, err error) {
+	err = c.request("GET", "/shield/queries", nil, nil, &result)
+	return
+}
+
+// WhitelistQueries whitelists the given queries
+func (c *ShieldClient) WhitelistQueries(
+	entries ...ShieldEntry,
+) (result []ShieldQuery, err error) {
+	err = c.request("POST", "/shield/queries", nil, entries, &result)
+	return
+}
+
+// RemoveQuery removes the query identified by name from the whitelist
+func (c *ShieldClient) RemoveQuery(name string) error {
+	return c.request(
+		"DELETE",
+		"/shield/queries",
+		url.Values{"name": []string{name}},
+		nil,
+		nil,
+	)
+}
+
+// UpdateQueryRoles replaces the roles the query identified by name
+// is whitelisted for
+func (c *ShieldClient) UpdateQueryRoles(
+	name string,
+	whitelistedFor []int,
+) (result ShieldQuery, err error) {
+	err = c.request(
+		"PUT",
+		"/shield/queries/roles",
+		nil,
+		ShieldRolesUpdate{
+			Name:           name,
+			WhitelistedFor: whitelistedFor,
+		},
+		&result,
+	)
+	return
+}
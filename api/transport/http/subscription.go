@@ -0,0 +1,195 @@
+package http
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	trn "github.com/romshark/dgraph_graphql_go/api/transport"
+)
+
+// subscriptionID defines the identifier of the client subscription
+const subscriptionID = "1"
+
+// syncID defines the identifier of the synchronization query
+// performed to ensure the server has started the subscription
+const syncID = "sync"
+
+// ErrSubscriptionComplete is returned when the server completed
+// the subscription
+var ErrSubscriptionComplete = errors.New("subscription complete")
+
+// subscription represents a graphql-ws based client subscription
+type subscription struct {
+	conn      *websocket.Conn
+	closeOnce *sync.Once
+}
+
+// Subscribe implements the transport.Client interface
+func (c *Client) Subscribe(
+	query string,
+	vars map[string]interface{},
+) (trn.Subscription, error) {
+	u := c.host
+	u.Path = "/ws"
+	u.Scheme = "ws"
+	if c.host.Scheme == "https" {
+		u.Scheme = "wss"
+	}
+
+	dialer := websocket.Dialer{
+		Subprotocols:     []string{wsProtocol},
+		HandshakeTimeout: c.httpClt.Timeout,
+	}
+	conn, _, err := dialer.Dial(u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "WebSocket dial")
+	}
+	sub := &subscription{conn: conn, closeOnce: &sync.Once{}}
+
+	write := func(id, msgType string, payload interface{}) error {
+		var encoded []byte
+		if payload != nil {
+			if encoded, err = json.Marshal(payload); err != nil {
+				return err
+			}
+		}
+		return conn.WriteJSON(wsMessage{
+			ID:      id,
+			Type:    msgType,
+			Payload: encoded,
+		})
+	}
+
+	// Initialize and authenticate the connection
+	if err := write("", wsConnectionInit, wsInitPayload{
+		Authorization: c.authHeader(),
+	}); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "connection init")
+	}
+	if _, err := sub.read(wsConnectionAck); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "connection init")
+	}
+
+	// Start the subscription
+	if err := write(subscriptionID, wsStart, struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}{
+		Query:     query,
+		Variables: vars,
+	}); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "subscription start")
+	}
+
+	// The server handles messages in order, thus when the response
+	// to the synchronization query is received the subscription is started
+	if err := write(syncID, wsStart, struct {
+		Query string `json:"query"`
+	}{
+		Query: "{__typename}",
+	}); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "subscription sync")
+	}
+	for {
+		msg, err := sub.read("")
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if msg.ID == syncID &&
+			(msg.Type == wsComplete || msg.Type == wsError) {
+			break
+		}
+		if msg.ID == subscriptionID && msg.Type == wsError {
+			// The subscription was rejected
+			conn.Close()
+			return nil, decodeErrorPayload(msg.Payload)
+		}
+	}
+
+	return sub, nil
+}
+
+// read reads the next message skipping keep-alive messages
+func (sub *subscription) read(expectedType string) (wsMessage, error) {
+	for {
+		var msg wsMessage
+		if err := sub.conn.ReadJSON(&msg); err != nil {
+			return msg, errors.Wrap(err, "WebSocket read")
+		}
+		if msg.Type == wsConnectionKeepAlive {
+			continue
+		}
+		if expectedType != "" && msg.Type != expectedType {
+			return msg, errors.Errorf(
+				"unexpected message type: %s (expected: %s)",
+				msg.Type,
+				expectedType,
+			)
+		}
+		return msg, nil
+	}
+}
+
+// decodeErrorPayload returns the first error of an error payload
+func decodeErrorPayload(payload json.RawMessage) error {
+	var respErrs []GraphResponseError
+	if err := json.Unmarshal(payload, &respErrs); err != nil {
+		return errors.Wrap(err, "error payload decode JSON")
+	}
+	if len(respErrs) < 1 {
+		return errors.New("empty error payload")
+	}
+	return respErrs[0].ResponseError()
+}
+
+// Next implements the transport.Subscription interface
+func (sub *subscription) Next(result interface{}) error {
+	for {
+		msg, err := sub.read("")
+		if err != nil {
+			return err
+		}
+		if msg.ID != subscriptionID {
+			continue
+		}
+
+		switch msg.Type {
+		case wsData:
+			payload := struct {
+				Data   interface{}          `json:"data"`
+				Errors []GraphResponseError `json:"errors"`
+			}{
+				Data: result,
+			}
+			if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+				return errors.Wrap(err, "data payload decode JSON")
+			}
+			if len(payload.Errors) > 0 {
+				return payload.Errors[0].ResponseError()
+			}
+			return nil
+		case wsError:
+			return decodeErrorPayload(msg.Payload)
+		case wsComplete:
+			return ErrSubscriptionComplete
+		}
+	}
+}
+
+// Close implements the transport.Subscription interface
+func (sub *subscription) Close() (err error) {
+	sub.closeOnce.Do(func() {
+		// Terminate gracefully, the connection is closed regardless
+		_ = sub.conn.WriteJSON(wsMessage{ID: subscriptionID, Type: wsStop})
+		_ = sub.conn.WriteJSON(wsMessage{Type: wsConnectionTerminate})
+		err = sub.conn.Close()
+	})
+	return
+}
@@ -5,18 +5,37 @@ import (
 	"log"
 	"time"
 
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
 	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
 	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 )
 
 // OnGraphQuery defines the graph query callback function
-type OnGraphQuery func(context.Context, graph.Query) (graph.Response, error)
+type OnGraphQuery func(context.Context, graph.Query) graph.Response
+
+// OnGraphSubscription defines the graph subscription callback function.
+// The returned response errors reject the subscription
+type OnGraphSubscription func(context.Context, graph.Query) (
+	<-chan graph.Response,
+	[]*graph.ResponseError,
+)
 
 // OnAuth defines the client authentication callback function
-type OnAuth func(ctx context.Context, sessionKey string) (store.ID, time.Time)
+// returning the session owner, the session creation time
+// and the roles of the session owner
+type OnAuth func(ctx context.Context, sessionKey string) (
+	store.ID,
+	time.Time,
+	[]role.Role,
+)
 
 // OnDebugAuth defines the debug authentication callback function
-type OnDebugAuth func(ctx context.Context, sessionKey string) bool
+type OnDebugAuth func(
+	ctx context.Context,
+	sessionKey string,
+) (ok bool, mode auth.DebugMode)
 
 // OnDebugSess defines the debug session creation callback function
 type OnDebugSess func(ctx context.Context, username, password string) []byte
@@ -26,12 +45,15 @@ type OnDebugSess func(ctx context.Context, username, password string) []byte
 // by a single goroutine!
 type Server interface {
 	// Init initializes the server transport implementation.
-	// The provided callbacks must be registered and invoked accordingly
+	// The provided callbacks must be registered and invoked accordingly.
+	// The shield is administered by the debug user
 	Init(
 		onGraphQuery OnGraphQuery,
+		onGraphSubscription OnGraphSubscription,
 		onAuth OnAuth,
 		onDebugAuth OnDebugAuth,
 		onDebugSess OnDebugSess,
+		shield gqlshield.GraphQLShield,
 		debugLog *log.Logger,
 		errorLog *log.Logger,
 	) error
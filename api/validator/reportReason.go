@@ -0,0 +1,23 @@
+package validator
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ReportReason implements the Validator interface
+func (vld *validator) ReportReason(v string) error {
+	if uint(len(v)) < vld.conf.ReportReasonLenMin {
+		return errors.Errorf(
+			"Report.reason too short (min: %d)",
+			vld.conf.ReportReasonLenMin,
+		)
+	}
+	if uint(len(v)) > vld.conf.ReportReasonLenMax {
+		return errors.Errorf(
+			"Report.reason too long (%d / %d)",
+			len(v),
+			vld.conf.ReportReasonLenMax,
+		)
+	}
+	return nil
+}
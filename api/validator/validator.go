@@ -29,6 +29,10 @@ type Validator interface {
 	// invalid, otherwise returns nil
 	ReactionMessage(v string) error
 
+	// ReportReason returns an error if the given report reason is invalid,
+	// otherwise returns nil
+	ReportReason(v string) error
+
 	// UserDisplayName returns an error if the given user display name is
 	// invalid, otherwise returns nil
 	UserDisplayName(v string) error
@@ -45,6 +49,8 @@ type Config struct {
 	PostTitleLenMax       uint
 	ReactionMessageLenMin uint
 	ReactionMessageLenMax uint
+	ReportReasonLenMin    uint
+	ReportReasonLenMax    uint
 	UserDisplayNameLenMin uint
 	UserDisplayNameLenMax uint
 }
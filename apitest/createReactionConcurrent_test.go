@@ -0,0 +1,122 @@
+package apitest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateReactionConcurrent tests concurrent reaction creation
+// on a single subject which provokes transaction conflicts
+// that must be resolved by retrying the aborted transactions
+func TestCreateReactionConcurrent(t *testing.T) {
+	requireDgraph(t)
+
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	debug := ts.Debug()
+
+	const authors = 4
+	const reactionsPerAuthor = 8
+
+	postAuthor := debug.Help.OK.CreateUser("first", "1@test.test", "testpass")
+	post := debug.Help.OK.CreatePost(
+		*postAuthor.ID,
+		"test post",
+		"test content",
+	)
+
+	authorIDs := make([]store.ID, authors)
+	for i := range authorIDs {
+		author := debug.Help.OK.CreateUser(
+			fmt.Sprintf("author%d", i),
+			fmt.Sprintf("author%d@test.test", i),
+			"testpass",
+		)
+		authorIDs[i] = *author.ID
+	}
+
+	// Create all reactions concurrently
+	errs := make(chan error, authors*reactionsPerAuthor)
+	wg := sync.WaitGroup{}
+	for _, authorID := range authorIDs {
+		for i := 0; i < reactionsPerAuthor; i++ {
+			wg.Add(1)
+			go func(authorID store.ID, i int) {
+				defer wg.Done()
+				var result struct {
+					CreateReaction *gqlmod.Reaction `json:"createReaction"`
+				}
+				errs <- debug.QueryVar(
+					`mutation (
+						$author: Identifier!
+						$subject: Identifier!
+						$emotion: Emotion!
+						$message: String!
+					) {
+						createReaction(
+							author: $author
+							subject: $subject
+							emotion: $emotion
+							message: $message
+						) {
+							id
+						}
+					}`,
+					map[string]interface{}{
+						"author":  string(authorID),
+						"subject": string(*post.ID),
+						"emotion": string(emotion.Happy),
+						"message": fmt.Sprintf("reaction %d", i),
+					},
+					&result,
+				)
+			}(authorID, i)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// Ensure all reactions were linked to the post and their authors
+	var query struct {
+		Post *gqlmod.Post `json:"post"`
+		User *gqlmod.User `json:"user"`
+	}
+	require.NoError(t, debug.QueryVar(
+		`query($postId: Identifier!, $authorId: Identifier!) {
+			post(id: $postId) {
+				reactions { totalCount }
+			}
+			user(id: $authorId) {
+				publishedReactions { totalCount }
+			}
+		}`,
+		map[string]interface{}{
+			"postId":   string(*post.ID),
+			"authorId": string(authorIDs[0]),
+		},
+		&query,
+	))
+	require.NotNil(t, query.Post)
+	require.Equal(
+		t,
+		authors*reactionsPerAuthor,
+		*query.Post.Reactions.TotalCount,
+	)
+	require.NotNil(t, query.User)
+	require.Equal(
+		t,
+		reactionsPerAuthor,
+		*query.User.PublishedReactions.TotalCount,
+	)
+}
@@ -0,0 +1,107 @@
+package apitest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateUserConcurrent tests whether only one of several concurrent
+// registrations with the same email or display name succeeds.
+// The registrations race only on Dgraph where the conflicting
+// transactions are detected through the @upsert indexes and retried
+func TestCreateUserConcurrent(t *testing.T) {
+	requireDgraph(t)
+
+	const registrations = 8
+
+	// register concurrently registers users with the given
+	// display names and emails and returns the number of successful
+	// registrations ensuring all others were rejected as invalid input
+	register := func(
+		t *testing.T,
+		ts *setup.TestSetup,
+		displayName func(i int) string,
+		email func(i int) string,
+	) (succeeded int) {
+		debug := ts.Debug()
+		errs := make(chan error, registrations)
+		wg := sync.WaitGroup{}
+		wg.Add(registrations)
+		for i := 0; i < registrations; i++ {
+			go func(i int) {
+				defer wg.Done()
+				var result struct {
+					CreateUser *gqlmod.User `json:"createUser"`
+				}
+				errs <- debug.QueryVar(
+					`mutation (
+						$email: String!
+						$displayName: String!
+						$password: String!
+					) {
+						createUser(
+							email: $email
+							displayName: $displayName
+							password: $password
+						) {
+							id
+						}
+					}`,
+					map[string]interface{}{
+						"displayName": displayName(i),
+						"email":       email(i),
+						"password":    "testpass",
+					},
+					&result,
+				)
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			require.IsType(t, &graph.ResponseError{}, err)
+			require.Equal(
+				t,
+				string(errors.ErrInvalidInput),
+				err.(*graph.ResponseError).Code,
+			)
+		}
+		return
+	}
+
+	t.Run("email", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		require.Equal(t, 1, register(
+			t,
+			ts,
+			func(i int) string { return fmt.Sprintf("user%d", i) },
+			func(i int) string { return "same@test.test" },
+		))
+	})
+
+	t.Run("displayName", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		require.Equal(t, 1, register(
+			t,
+			ts,
+			func(i int) string { return "same" },
+			func(i int) string { return fmt.Sprintf("%d@test.test", i) },
+		))
+	})
+}
@@ -0,0 +1,102 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/config"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDebugUserMode tests the debug user access modes
+func TestDebugUserMode(t *testing.T) {
+	setupTest := func(
+		t *testing.T,
+		mode config.DebugUserMode,
+	) *setup.TestSetup {
+		context := tcx
+		context.DebugUserMode = mode
+		return setup.New(t, context)
+	}
+
+	queryUsers := func(t *testing.T, ts *setup.TestSetup) []gqlmod.User {
+		var query struct {
+			Users *gqlmod.UserConnection `json:"users"`
+		}
+		require.NoError(t, ts.Debug().Query(
+			`{ users { edges { node { id } } } }`,
+			&query,
+		))
+		require.NotNil(t, query.Users)
+		users := make([]gqlmod.User, len(query.Users.Edges))
+		for i, edge := range query.Users.Edges {
+			users[i] = *edge.Node
+		}
+		return users
+	}
+
+	// Test the read-write debug user performing mutations
+	t.Run("read-write", func(t *testing.T) {
+		ts := setupTest(t, config.DebugUserRW)
+		defer ts.Teardown()
+
+		ts.Debug().Help.OK.CreateUser("usr", "t1@te.te", "testpass")
+		require.Len(t, queryUsers(t, ts), 1)
+	})
+
+	// Test the read-only debug user being denied mutations
+	t.Run("read-only", func(t *testing.T) {
+		ts := setupTest(t, config.DebugUserReadOnly)
+		defer ts.Teardown()
+
+		ts.Debug().Help.ERR.CreateUser(
+			errors.ErrUnauthorized,
+			"usr",
+			"t1@te.te",
+			"testpass",
+		)
+		require.Len(t, queryUsers(t, ts), 0)
+	})
+
+	// Test the read-only debug user reading owner-restricted fields
+	t.Run("read-only/ownerFields", func(t *testing.T) {
+		ts := setupTest(t, config.DebugUserReadOnly)
+		defer ts.Teardown()
+
+		var created struct {
+			CreateUser *gqlmod.User `json:"createUser"`
+		}
+		require.NoError(t, ts.Guest().Query(
+			`mutation {
+				createUser(
+					email: "t1@te.te"
+					displayName: "usr"
+					password: "testpass"
+				) { id }
+			}`,
+			&created,
+		))
+		require.NotNil(t, created.CreateUser)
+
+		var query struct {
+			User *gqlmod.User `json:"user"`
+		}
+		require.NoError(t, ts.Debug().QueryVar(
+			`query($id: Identifier!) {
+				user(id: $id) {
+					email
+					emailVerified
+				}
+			}`,
+			map[string]interface{}{
+				"id": string(*created.CreateUser.ID),
+			},
+			&query,
+		))
+		require.NotNil(t, query.User)
+		require.Equal(t, "t1@te.te", *query.User.Email)
+		require.False(t, *query.User.EmailVerified)
+	})
+}
@@ -0,0 +1,51 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestDeletePostAuth tests post deletion authorization
+func TestDeletePostAuth(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		post *gqlmod.Post,
+	) {
+		ts = setup.New(t, tcx)
+		debug := ts.Debug()
+
+		author := debug.Help.OK.CreateUser(
+			"fooBarowich",
+			"author@tst.tst",
+			"testpass",
+		)
+		post = debug.Help.OK.CreatePost(
+			*author.ID,
+			"example title",
+			"example contents",
+		)
+		return
+	}
+
+	// Test deleting posts as a guest
+	t.Run("guest (noauth)", func(t *testing.T) {
+		ts, post := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.DeletePost(errors.ErrUnauthorized, *post.ID)
+	})
+
+	// Test deleting posts of other users
+	t.Run("non-author (noauth)", func(t *testing.T) {
+		ts, post := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Debug().Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		otherClt, _ := ts.Client("2@tst.tst", "testpass")
+
+		otherClt.Help.ERR.DeletePost(errors.ErrUnauthorized, *post.ID)
+	})
+}
@@ -0,0 +1,38 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestDeletePostErr tests all possible post deletion errors
+func TestDeletePostErr(t *testing.T) {
+	t.Run("inexistentPost", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		ts.Debug().Help.ERR.DeletePost(
+			errors.ErrInvalidInput,
+			store.NewID(),
+		)
+	})
+
+	t.Run("repeatedDeletion", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+		debug := ts.Debug()
+
+		author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+		post := debug.Help.OK.CreatePost(
+			*author.ID,
+			"example title",
+			"example contents",
+		)
+
+		debug.Help.OK.DeletePost(*post.ID)
+		debug.Help.ERR.DeletePost(errors.ErrInvalidInput, *post.ID)
+	})
+}
@@ -0,0 +1,90 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeletePost tests post deletion including the reaction cascade
+func TestDeletePost(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+	debug := ts.Debug()
+
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	authorClt, _ := ts.Client("1@tst.tst", "testpass")
+	reactor := debug.Help.OK.CreateUser("reactor", "2@tst.tst", "testpass")
+
+	post := debug.Help.OK.CreatePost(
+		*author.ID,
+		"example title",
+		"example contents",
+	)
+	otherPost := debug.Help.OK.CreatePost(
+		*author.ID,
+		"other title",
+		"other contents",
+	)
+	reaction := debug.Help.OK.CreateReaction(
+		*reactor.ID,
+		*post.ID,
+		emotion.Happy,
+		"sample message",
+	)
+	nestedReaction := debug.Help.OK.CreateReaction(
+		*author.ID,
+		*reaction.ID,
+		emotion.Excited,
+		"nested message",
+	)
+
+	authorClt.Help.OK.DeletePost(*post.ID)
+
+	// Ensure all reactions were deleted along with the post
+	var query struct {
+		Reaction       *gqlmod.Reaction       `json:"reaction"`
+		NestedReaction *gqlmod.Reaction       `json:"nestedReaction"`
+		Author         *gqlmod.User           `json:"author"`
+		Reactor        *gqlmod.User           `json:"reactor"`
+		Posts          *gqlmod.PostConnection `json:"posts"`
+	}
+	require.NoError(t, debug.QueryVar(
+		`query(
+			$reactionId: Identifier!
+			$nestedReactionId: Identifier!
+			$authorId: Identifier!
+			$reactorId: Identifier!
+		) {
+			reaction(id: $reactionId) { id }
+			nestedReaction: reaction(id: $nestedReactionId) { id }
+			author: user(id: $authorId) {
+				posts { edges { node { id } } }
+				publishedReactions { totalCount }
+			}
+			reactor: user(id: $reactorId) {
+				publishedReactions { totalCount }
+			}
+			posts { totalCount }
+		}`,
+		map[string]interface{}{
+			"reactionId":       string(*reaction.ID),
+			"nestedReactionId": string(*nestedReaction.ID),
+			"authorId":         string(*author.ID),
+			"reactorId":        string(*reactor.ID),
+		},
+		&query,
+	))
+	require.Nil(t, query.Reaction)
+	require.Nil(t, query.NestedReaction)
+	require.NotNil(t, query.Author)
+	require.Len(t, query.Author.Posts.Edges, 1)
+	require.Equal(t, *otherPost.ID, *query.Author.Posts.Edges[0].Node.ID)
+	require.Equal(t, 0, *query.Author.PublishedReactions.TotalCount)
+	require.NotNil(t, query.Reactor)
+	require.Equal(t, 0, *query.Reactor.PublishedReactions.TotalCount)
+	require.Equal(t, 1, *query.Posts.TotalCount)
+}
@@ -0,0 +1,64 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestDeleteReactionAuth tests reaction deletion authorization
+func TestDeleteReactionAuth(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		reaction *gqlmod.Reaction,
+	) {
+		ts = setup.New(t, tcx)
+		debug := ts.Debug()
+
+		author := debug.Help.OK.CreateUser(
+			"fooBarowich",
+			"author@tst.tst",
+			"testpass",
+		)
+		post := debug.Help.OK.CreatePost(
+			*author.ID,
+			"example title",
+			"example contents",
+		)
+		reaction = debug.Help.OK.CreateReaction(
+			*author.ID,
+			*post.ID,
+			emotion.Happy,
+			"sample message",
+		)
+		return
+	}
+
+	// Test deleting reactions as a guest
+	t.Run("guest (noauth)", func(t *testing.T) {
+		ts, reaction := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.DeleteReaction(
+			errors.ErrUnauthorized,
+			*reaction.ID,
+		)
+	})
+
+	// Test deleting reactions of other users
+	t.Run("non-author (noauth)", func(t *testing.T) {
+		ts, reaction := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Debug().Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		otherClt, _ := ts.Client("2@tst.tst", "testpass")
+
+		otherClt.Help.ERR.DeleteReaction(
+			errors.ErrUnauthorized,
+			*reaction.ID,
+		)
+	})
+}
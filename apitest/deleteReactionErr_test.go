@@ -0,0 +1,22 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestDeleteReactionErr tests all possible reaction deletion errors
+func TestDeleteReactionErr(t *testing.T) {
+	t.Run("inexistentReaction", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		ts.Debug().Help.ERR.DeleteReaction(
+			errors.ErrInvalidInput,
+			store.NewID(),
+		)
+	})
+}
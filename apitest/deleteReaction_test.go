@@ -0,0 +1,93 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeleteReaction tests reaction deletion including
+// the nested reaction cascade
+func TestDeleteReaction(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+	debug := ts.Debug()
+
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	reactor := debug.Help.OK.CreateUser("reactor", "2@tst.tst", "testpass")
+	reactorClt, _ := ts.Client("2@tst.tst", "testpass")
+
+	post := debug.Help.OK.CreatePost(
+		*author.ID,
+		"example title",
+		"example contents",
+	)
+	reaction := debug.Help.OK.CreateReaction(
+		*reactor.ID,
+		*post.ID,
+		emotion.Happy,
+		"sample message",
+	)
+	nestedReaction := debug.Help.OK.CreateReaction(
+		*author.ID,
+		*reaction.ID,
+		emotion.Excited,
+		"nested message",
+	)
+	otherReaction := debug.Help.OK.CreateReaction(
+		*author.ID,
+		*post.ID,
+		emotion.Thoughtful,
+		"other message",
+	)
+
+	reactorClt.Help.OK.DeleteReaction(*reaction.ID)
+
+	// Ensure the nested reaction was deleted while the post
+	// and the other reaction were preserved
+	var query struct {
+		Post           *gqlmod.Post     `json:"post"`
+		NestedReaction *gqlmod.Reaction `json:"nestedReaction"`
+		Author         *gqlmod.User     `json:"author"`
+		Reactor        *gqlmod.User     `json:"reactor"`
+	}
+	require.NoError(t, debug.QueryVar(
+		`query(
+			$postId: Identifier!
+			$nestedReactionId: Identifier!
+			$authorId: Identifier!
+			$reactorId: Identifier!
+		) {
+			post(id: $postId) {
+				reactions { edges { node { id } } }
+			}
+			nestedReaction: reaction(id: $nestedReactionId) { id }
+			author: user(id: $authorId) {
+				publishedReactions { totalCount }
+			}
+			reactor: user(id: $reactorId) {
+				publishedReactions { totalCount }
+			}
+		}`,
+		map[string]interface{}{
+			"postId":           string(*post.ID),
+			"nestedReactionId": string(*nestedReaction.ID),
+			"authorId":         string(*author.ID),
+			"reactorId":        string(*reactor.ID),
+		},
+		&query,
+	))
+	require.NotNil(t, query.Post)
+	require.Len(t, query.Post.Reactions.Edges, 1)
+	require.Equal(
+		t,
+		*otherReaction.ID,
+		*query.Post.Reactions.Edges[0].Node.ID,
+	)
+	require.Nil(t, query.NestedReaction)
+	require.Equal(t, 1, *query.Author.PublishedReactions.TotalCount)
+	require.Equal(t, 0, *query.Reactor.PublishedReactions.TotalCount)
+}
@@ -0,0 +1,44 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestDeleteUserAuth tests user deletion authorization
+func TestDeleteUserAuth(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		usr *gqlmod.User,
+	) {
+		ts = setup.New(t, tcx)
+		usr = ts.Debug().Help.OK.CreateUser(
+			"fooBarowich",
+			"usr@tst.tst",
+			"testpass",
+		)
+		return
+	}
+
+	// Test deleting users as a guest
+	t.Run("guest (noauth)", func(t *testing.T) {
+		ts, usr := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.DeleteUser(errors.ErrUnauthorized, *usr.ID)
+	})
+
+	// Test deleting other users
+	t.Run("non-owner (noauth)", func(t *testing.T) {
+		ts, usr := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Debug().Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		otherClt, _ := ts.Client("2@tst.tst", "testpass")
+
+		otherClt.Help.ERR.DeleteUser(errors.ErrUnauthorized, *usr.ID)
+	})
+}
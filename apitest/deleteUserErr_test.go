@@ -0,0 +1,22 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestDeleteUserErr tests all possible user deletion errors
+func TestDeleteUserErr(t *testing.T) {
+	t.Run("inexistentUser", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		ts.Debug().Help.ERR.DeleteUser(
+			errors.ErrInvalidInput,
+			store.NewID(),
+		)
+	})
+}
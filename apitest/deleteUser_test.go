@@ -0,0 +1,100 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeleteUser tests user deletion including the cascade of sessions,
+// posts and published reactions
+func TestDeleteUser(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+	debug := ts.Debug()
+
+	usr := debug.Help.OK.CreateUser("usr", "1@tst.tst", "testpass")
+	usrClt, _ := ts.Client("1@tst.tst", "testpass")
+	other := debug.Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+
+	usrPost := debug.Help.OK.CreatePost(
+		*usr.ID,
+		"user title",
+		"user contents",
+	)
+	otherPost := debug.Help.OK.CreatePost(
+		*other.ID,
+		"other title",
+		"other contents",
+	)
+	otherReaction := debug.Help.OK.CreateReaction(
+		*other.ID,
+		*usrPost.ID,
+		emotion.Happy,
+		"other message",
+	)
+	usrReaction := debug.Help.OK.CreateReaction(
+		*usr.ID,
+		*otherPost.ID,
+		emotion.Excited,
+		"user message",
+	)
+
+	usrClt.Help.OK.DeleteUser(*usr.ID)
+
+	// Ensure the posts, reactions and sessions of the user were deleted
+	var query struct {
+		Post          *gqlmod.Post           `json:"post"`
+		OtherPost     *gqlmod.Post           `json:"otherPost"`
+		OtherReaction *gqlmod.Reaction       `json:"otherReaction"`
+		UsrReaction   *gqlmod.Reaction       `json:"usrReaction"`
+		Other         *gqlmod.User           `json:"other"`
+		Users         *gqlmod.UserConnection `json:"users"`
+	}
+	require.NoError(t, debug.QueryVar(
+		`query(
+			$usrPostId: Identifier!
+			$otherPostId: Identifier!
+			$otherReactionId: Identifier!
+			$usrReactionId: Identifier!
+			$otherId: Identifier!
+		) {
+			post(id: $usrPostId) { id }
+			otherPost: post(id: $otherPostId) {
+				reactions { totalCount }
+			}
+			otherReaction: reaction(id: $otherReactionId) { id }
+			usrReaction: reaction(id: $usrReactionId) { id }
+			other: user(id: $otherId) {
+				publishedReactions { totalCount }
+			}
+			users { totalCount }
+		}`,
+		map[string]interface{}{
+			"usrPostId":       string(*usrPost.ID),
+			"otherPostId":     string(*otherPost.ID),
+			"otherReactionId": string(*otherReaction.ID),
+			"usrReactionId":   string(*usrReaction.ID),
+			"otherId":         string(*other.ID),
+		},
+		&query,
+	))
+	require.Nil(t, query.Post)
+	require.NotNil(t, query.OtherPost)
+	require.Equal(t, 0, *query.OtherPost.Reactions.TotalCount)
+	require.Nil(t, query.OtherReaction)
+	require.Nil(t, query.UsrReaction)
+	require.Equal(t, 0, *query.Other.PublishedReactions.TotalCount)
+	require.Equal(t, 1, *query.Users.TotalCount)
+
+	// Ensure the user can no longer sign in
+	debug.Help.ERR.CreateSession(
+		errors.ErrWrongCreds,
+		"1@tst.tst",
+		"testpass",
+	)
+}
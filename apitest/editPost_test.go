@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/stretchr/testify/require"
 )
 
 // TestEditPost tests post editing
@@ -20,10 +21,32 @@ func TestEditPost(t *testing.T) {
 	// Test edit
 	newTitle := "new test post"
 	newContents := "new test contents"
-	authorClt.Help.OK.EditPost(
+	edited := authorClt.Help.OK.EditPost(
 		*post.ID,
 		*author.ID,
 		&newTitle,
 		&newContents,
 	)
+	require.Len(t, edited.Revisions, 1)
+
+	// Test partial edit
+	newerTitle := "newer test post"
+	edited = authorClt.Help.OK.EditPost(
+		*post.ID,
+		*author.ID,
+		&newerTitle,
+		nil,
+	)
+	require.Len(t, edited.Revisions, 2)
+	require.Equal(t, newTitle, *edited.Revisions[1].Title)
+	require.Equal(t, newContents, *edited.Revisions[1].Contents)
+
+	// Test edit without changes
+	edited = authorClt.Help.OK.EditPost(
+		*post.ID,
+		*author.ID,
+		&newerTitle,
+		&newContents,
+	)
+	require.Len(t, edited.Revisions, 2)
 }
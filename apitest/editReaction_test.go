@@ -5,6 +5,7 @@ import (
 
 	"github.com/romshark/dgraph_graphql_go/apitest/setup"
 	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/stretchr/testify/require"
 )
 
 // TestEditReaction tests reaction editing
@@ -25,9 +26,19 @@ func TestEditReaction(t *testing.T) {
 	)
 
 	// Test edit
-	authorClt.Help.OK.EditReaction(
+	edited := authorClt.Help.OK.EditReaction(
 		*reaction.ID,
 		*author.ID,
 		"new message",
 	)
+	require.Len(t, edited.Revisions, 1)
+	require.Equal(t, "sample message", *edited.Revisions[0].Message)
+
+	// Test edit without changes
+	edited = authorClt.Help.OK.EditReaction(
+		*reaction.ID,
+		*author.ID,
+		"new message",
+	)
+	require.Len(t, edited.Revisions, 1)
 }
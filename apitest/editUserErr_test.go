@@ -113,4 +113,20 @@ func TestEditUserErr(t *testing.T) {
 			nil,
 		)
 	})
+
+	t.Run("reservedEmail", func(t *testing.T) {
+		ts, debug, user := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.OK.CreateUser("other", "other@email.test", "testpass")
+
+		reservedEmail := "other@email.test"
+		debug.Help.ERR.EditUser(
+			errors.ErrInvalidInput,
+			*user.ID,
+			*user.ID,
+			&reservedEmail,
+			nil,
+		)
+	})
 }
@@ -0,0 +1,58 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeedErr tests all possible feed errors
+func TestFeedErr(t *testing.T) {
+	requireErr := func(t *testing.T, code errors.Code, err error) {
+		require.Error(t, err)
+		require.IsType(t, &graph.ResponseError{}, err)
+		require.Equal(t, string(code), err.(*graph.ResponseError).Code)
+	}
+
+	const query = `query(
+		$first: Int
+		$after: Cursor
+	) {
+		feed(first: $first, after: $after) {
+			totalCount
+		}
+	}`
+
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	ts.Debug().Help.OK.CreateUser("reader", "r@tst.tst", "testpass")
+	readerClt, _ := ts.Client("r@tst.tst", "testpass")
+
+	t.Run("guest", func(t *testing.T) {
+		var result struct{}
+		requireErr(t, errors.ErrUnauthorized, ts.Guest().QueryVar(
+			query,
+			map[string]interface{}{},
+			&result,
+		))
+	})
+
+	invalidParams := map[string]map[string]interface{}{
+		"invalidPageSize": {"first": 101},
+		"unknownCursor":   {"after": "Y3Vyc29yOjB4ZmZmZmZm"},
+	}
+	for tName, params := range invalidParams {
+		t.Run(tName, func(t *testing.T) {
+			var result struct{}
+			requireErr(t, errors.ErrInvalidInput, readerClt.QueryVar(
+				query,
+				params,
+				&result,
+			))
+		})
+	}
+}
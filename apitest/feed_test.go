@@ -0,0 +1,110 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeed tests the feed of posts of followed users
+func TestFeed(t *testing.T) {
+	type result struct {
+		Feed *gqlmod.PostConnection `json:"feed"`
+	}
+
+	// titles returns the titles of the posts of the feed
+	titles := func(r result) []string {
+		titles := make([]string, len(r.Feed.Edges))
+		for i, edge := range r.Feed.Edges {
+			titles[i] = *edge.Node.Title
+		}
+		return titles
+	}
+
+	feed := func(
+		clt *setup.Client,
+		vars map[string]interface{},
+	) (r result) {
+		require.NoError(t, clt.QueryVar(
+			`query(
+				$first: Int
+				$after: Cursor
+			) {
+				feed(first: $first, after: $after) {
+					totalCount
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						endCursor
+					}
+					edges {
+						node {
+							title
+						}
+					}
+				}
+			}`,
+			vars,
+			&r,
+		))
+		return
+	}
+
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	debug := ts.Debug()
+	reader := debug.Help.OK.CreateUser("reader", "r@tst.tst", "testpass")
+	readerClt, _ := ts.Client("r@tst.tst", "testpass")
+	authorA := debug.Help.OK.CreateUser("authorA", "a@tst.tst", "testpass")
+	authorB := debug.Help.OK.CreateUser("authorB", "b@tst.tst", "testpass")
+	other := debug.Help.OK.CreateUser("other", "o@tst.tst", "testpass")
+
+	readerClt.Help.OK.FollowUser(*reader.ID, *authorA.ID)
+	readerClt.Help.OK.FollowUser(*reader.ID, *authorB.ID)
+
+	debug.Help.OK.CreatePost(*authorA.ID, "first post", "contents")
+	debug.Help.OK.CreatePost(*authorB.ID, "second post", "contents")
+	debug.Help.OK.CreatePost(*other.ID, "unfollowed post", "contents")
+	debug.Help.OK.CreatePost(*reader.ID, "own post", "contents")
+	debug.Help.OK.CreatePost(*authorA.ID, "third post", "contents")
+
+	t.Run("newestFirst", func(t *testing.T) {
+		r := feed(readerClt, map[string]interface{}{})
+		require.Equal(t, 3, *r.Feed.TotalCount)
+		require.Equal(t, []string{
+			"third post",
+			"second post",
+			"first post",
+		}, titles(r))
+		require.False(t, *r.Feed.PageInfo.HasNextPage)
+		require.False(t, *r.Feed.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		first := feed(readerClt, map[string]interface{}{"first": 2})
+		require.Equal(t, []string{"third post", "second post"}, titles(first))
+		require.True(t, *first.Feed.PageInfo.HasNextPage)
+
+		second := feed(readerClt, map[string]interface{}{
+			"first": 2,
+			"after": *first.Feed.PageInfo.EndCursor,
+		})
+		require.Equal(t, []string{"first post"}, titles(second))
+		require.False(t, *second.Feed.PageInfo.HasNextPage)
+		require.True(t, *second.Feed.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("unfollowed", func(t *testing.T) {
+		otherClt, _ := ts.Client("o@tst.tst", "testpass")
+		r := feed(otherClt, map[string]interface{}{})
+		require.Equal(t, 0, *r.Feed.TotalCount)
+		require.Len(t, r.Feed.Edges, 0)
+
+		readerClt.Help.OK.UnfollowUser(*reader.ID, *authorA.ID)
+		r = feed(readerClt, map[string]interface{}{})
+		require.Equal(t, []string{"second post"}, titles(r))
+	})
+}
@@ -0,0 +1,65 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestFollowUserAuth tests user following and unfollowing authorization
+func TestFollowUserAuth(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		follower *gqlmod.User,
+		user *gqlmod.User,
+		userClt *setup.Client,
+	) {
+		ts = setup.New(t, tcx)
+		debug := ts.Debug()
+
+		follower = debug.Help.OK.CreateUser("follower", "1@tst.tst", "testpass")
+		user = debug.Help.OK.CreateUser("followee", "2@tst.tst", "testpass")
+		userClt, _ = ts.Client("2@tst.tst", "testpass")
+		return
+	}
+
+	// Test following users as a guest
+	t.Run("guest (noauth)", func(t *testing.T) {
+		ts, follower, user, _ := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.FollowUser(
+			errors.ErrUnauthorized,
+			*follower.ID,
+			*user.ID,
+		)
+	})
+
+	// Test following users on behalf of other users
+	t.Run("non-follower (noauth)", func(t *testing.T) {
+		ts, follower, user, userClt := setupTest(t)
+		defer ts.Teardown()
+
+		userClt.Help.ERR.FollowUser(
+			errors.ErrUnauthorized,
+			*follower.ID, // Someone else
+			*user.ID,
+		)
+	})
+
+	// Test unfollowing users on behalf of other users
+	t.Run("unfollow non-follower (noauth)", func(t *testing.T) {
+		ts, follower, user, userClt := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Debug().Help.OK.FollowUser(*follower.ID, *user.ID)
+
+		userClt.Help.ERR.UnfollowUser(
+			errors.ErrUnauthorized,
+			*follower.ID, // Someone else
+			*user.ID,
+		)
+	})
+}
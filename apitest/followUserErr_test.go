@@ -0,0 +1,72 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestFollowUserErr tests all possible user following errors
+func TestFollowUserErr(t *testing.T) {
+	testSetup := func(t *testing.T) (
+		ts *setup.TestSetup,
+		debug *setup.Client,
+		follower *gqlmod.User,
+		user *gqlmod.User,
+	) {
+		ts = setup.New(t, tcx)
+		debug = ts.Debug()
+
+		follower = debug.Help.OK.CreateUser("follower", "1@tst.tst", "testpass")
+		user = debug.Help.OK.CreateUser("followee", "2@tst.tst", "testpass")
+		return
+	}
+
+	t.Run("self", func(t *testing.T) {
+		ts, debug, follower, _ := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.FollowUser(
+			errors.ErrInvalidInput,
+			*follower.ID,
+			*follower.ID,
+		)
+	})
+
+	t.Run("inexistentFollower", func(t *testing.T) {
+		ts, debug, _, user := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.FollowUser(
+			errors.ErrInvalidInput,
+			store.NewID(), // Inexistent follower
+			*user.ID,
+		)
+	})
+
+	t.Run("inexistentUser", func(t *testing.T) {
+		ts, debug, follower, _ := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.FollowUser(
+			errors.ErrInvalidInput,
+			*follower.ID,
+			store.NewID(), // Inexistent user
+		)
+	})
+
+	t.Run("alreadyFollowing", func(t *testing.T) {
+		ts, debug, follower, user := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.OK.FollowUser(*follower.ID, *user.ID)
+		debug.Help.ERR.FollowUser(
+			errors.ErrInvalidInput,
+			*follower.ID,
+			*user.ID,
+		)
+	})
+}
@@ -0,0 +1,93 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFollowUser tests following and unfollowing users
+func TestFollowUser(t *testing.T) {
+	// followers returns the identifiers of the followers
+	// and the followed users of the given user
+	followers := func(
+		t *testing.T,
+		ts *setup.TestSetup,
+		user store.ID,
+	) (followers []store.ID, following []store.ID) {
+		var result struct {
+			User *gqlmod.User `json:"user"`
+		}
+		require.NoError(t, ts.Debug().QueryVar(
+			`query($id: Identifier!) {
+				user(id: $id) {
+					followers {
+						edges {
+							node {
+								id
+							}
+						}
+					}
+					following {
+						edges {
+							node {
+								id
+							}
+						}
+					}
+				}
+			}`,
+			map[string]interface{}{"id": string(user)},
+			&result,
+		))
+		require.NotNil(t, result.User)
+		for _, edge := range result.User.Followers.Edges {
+			followers = append(followers, *edge.Node.ID)
+		}
+		for _, edge := range result.User.Following.Edges {
+			following = append(following, *edge.Node.ID)
+		}
+		return
+	}
+
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	// Prepare
+	debug := ts.Debug()
+	userA := debug.Help.OK.CreateUser("userA", "a@tst.tst", "testpass")
+	userB := debug.Help.OK.CreateUser("userB", "b@tst.tst", "testpass")
+	userC := debug.Help.OK.CreateUser("userC", "c@tst.tst", "testpass")
+	cltA, _ := ts.Client("a@tst.tst", "testpass")
+	cltB, _ := ts.Client("b@tst.tst", "testpass")
+
+	// Test follow
+	cltA.Help.OK.FollowUser(*userA.ID, *userB.ID)
+	cltA.Help.OK.FollowUser(*userA.ID, *userC.ID)
+	cltB.Help.OK.FollowUser(*userB.ID, *userC.ID)
+
+	fwrs, fwng := followers(t, ts, *userA.ID)
+	require.Len(t, fwrs, 0)
+	require.ElementsMatch(t, []store.ID{*userB.ID, *userC.ID}, fwng)
+
+	fwrs, fwng = followers(t, ts, *userC.ID)
+	require.ElementsMatch(t, []store.ID{*userA.ID, *userB.ID}, fwrs)
+	require.Len(t, fwng, 0)
+
+	// Test unfollow
+	cltA.Help.OK.UnfollowUser(*userA.ID, *userC.ID)
+
+	fwrs, _ = followers(t, ts, *userC.ID)
+	require.Equal(t, []store.ID{*userB.ID}, fwrs)
+
+	// Test deleting a followed user
+	debug.Help.OK.DeleteUser(*userB.ID)
+
+	_, fwng = followers(t, ts, *userA.ID)
+	require.Len(t, fwng, 0)
+	fwrs, _ = followers(t, ts, *userC.ID)
+	require.Len(t, fwrs, 0)
+}
@@ -6,20 +6,40 @@ import (
 	"os"
 	"testing"
 
+	"github.com/romshark/dgraph_graphql_go/api/config"
 	"github.com/romshark/dgraph_graphql_go/apitest/setup"
 )
 
 // stats represents the global statistics recorder the setups must use
 var stats = setup.NewStatisticsRecorder()
+
+// dbDriver defines the database driver the API tests are run against.
+// The tests run against the Dgraph database by default,
+// the in-memory store can be used when no database is available
+var dbDriver = flag.String(
+	"dbdriver",
+	string(config.DBDriverDgraph),
+	"database driver (dgraph or memory)",
+)
 var dbHost = flag.String("dbhost", "localhost:10180", "database host address")
 var srvHost = flag.String("host", "localhost:8080", "API server host address")
 
 var tcx setup.TestContext
 
+// requireDgraph skips tests relying on the behavior of the Dgraph database
+// such as transaction conflicts when run against the in-memory store,
+// which serializes its transactions and never aborts them
+func requireDgraph(t *testing.T) {
+	if tcx.DBDriver != config.DBDriverDgraph {
+		t.Skip("requires the Dgraph database driver")
+	}
+}
+
 // TestMain runs the API tests and computes & prints the statistics
 func TestMain(m *testing.M) {
 	flag.Parse()
 	tcx.Stats = stats
+	tcx.DBDriver = config.DBDriver(*dbDriver)
 	tcx.DBHost = *dbHost
 	tcx.SrvHost = *srvHost
 
@@ -0,0 +1,60 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestMarkNotificationsReadErr tests all possible errors
+// of marking notifications read
+func TestMarkNotificationsReadErr(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	debug := ts.Debug()
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	reactor := debug.Help.OK.CreateUser("reactor", "2@tst.tst", "testpass")
+	post := debug.Help.OK.CreatePost(*author.ID, "title", "contents")
+	debug.Help.OK.CreateReaction(*reactor.ID, *post.ID, emotion.Happy, "msg")
+	_, authorNotifs := notifications(t, debug, *author.ID, false)
+
+	t.Run("inexistentUser", func(t *testing.T) {
+		debug.Help.ERR.MarkNotificationsRead(
+			errors.ErrInvalidInput,
+			store.NewID(), // Inexistent user
+			nil,
+		)
+	})
+
+	t.Run("inexistentNotification", func(t *testing.T) {
+		debug.Help.ERR.MarkNotificationsRead(
+			errors.ErrInvalidInput,
+			*author.ID,
+			[]store.ID{store.NewID()}, // Inexistent notification
+		)
+	})
+
+	t.Run("foreignNotification", func(t *testing.T) {
+		debug.Help.ERR.MarkNotificationsRead(
+			errors.ErrInvalidInput,
+			*reactor.ID,
+			authorNotifs, // Notifications of someone else
+		)
+	})
+
+	t.Run("tooManyNotifications", func(t *testing.T) {
+		ids := make([]store.ID, 101)
+		for i := range ids {
+			ids[i] = authorNotifs[0]
+		}
+		debug.Help.ERR.MarkNotificationsRead(
+			errors.ErrInvalidInput,
+			*author.ID,
+			ids,
+		)
+	})
+}
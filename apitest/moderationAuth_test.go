@@ -0,0 +1,125 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModerationAuth tests content moderation authorization
+func TestModerationAuth(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		reporter *gqlmod.User,
+		post *gqlmod.Post,
+		report *gqlmod.Report,
+		userClt *setup.Client,
+		moderatorClt *setup.Client,
+	) {
+		ts = setup.New(t, tcx)
+		debug := ts.Debug()
+
+		author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+		reporter = debug.Help.OK.CreateUser("reporter", "2@tst.tst", "testpass")
+		moderator := debug.Help.OK.CreateUser("moderator", "3@tst.tst", "testpass")
+		debug.Help.OK.SetUserRoles(*moderator.ID, role.Moderator)
+
+		post = debug.Help.OK.CreatePost(*author.ID, "title", "contents")
+		report = debug.Help.OK.ReportContent(*reporter.ID, *post.ID, "spam")
+
+		userClt, _ = ts.Client("1@tst.tst", "testpass")
+		moderatorClt, _ = ts.Client("3@tst.tst", "testpass")
+		return
+	}
+
+	// Test reading the moderation queue as a guest and a regular user
+	t.Run("moderationQueue (noauth)", func(t *testing.T) {
+		ts, _, _, _, userClt, _ := setupTest(t)
+		defer ts.Teardown()
+
+		for _, clt := range []*setup.Client{ts.Guest(), userClt} {
+			var result struct {
+				ModerationQueue *gqlmod.ReportConnection `json:"moderationQueue"`
+			}
+			err := clt.Query(
+				`{
+					moderationQueue {
+						totalCount
+					}
+				}`,
+				&result,
+			)
+			require.IsType(t, &graph.ResponseError{}, err)
+			require.Equal(
+				t,
+				string(errors.ErrUnauthorized),
+				err.(*graph.ResponseError).Code,
+			)
+		}
+	})
+
+	// Test reporting content as a guest
+	t.Run("report guest (noauth)", func(t *testing.T) {
+		ts, reporter, post, _, _, _ := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.ReportContent(
+			errors.ErrUnauthorized,
+			*reporter.ID,
+			*post.ID,
+			"spam",
+		)
+	})
+
+	// Test reporting content on behalf of other users
+	t.Run("report non-reporter (noauth)", func(t *testing.T) {
+		ts, reporter, post, _, userClt, _ := setupTest(t)
+		defer ts.Teardown()
+
+		userClt.Help.ERR.ReportContent(
+			errors.ErrUnauthorized,
+			*reporter.ID, // Someone else
+			*post.ID,
+			"spam",
+		)
+	})
+
+	// Test moderating content as a guest and a regular user
+	t.Run("moderate non-moderator (noauth)", func(t *testing.T) {
+		ts, _, post, report, userClt, _ := setupTest(t)
+		defer ts.Teardown()
+
+		for _, clt := range []*setup.Client{ts.Guest(), userClt} {
+			clt.Help.ERR.DismissReport(errors.ErrUnauthorized, *report.ID)
+			clt.Help.ERR.HideContent(errors.ErrUnauthorized, *post.ID)
+		}
+
+		ts.Debug().Help.OK.HideContent(*post.ID)
+		for _, clt := range []*setup.Client{ts.Guest(), userClt} {
+			clt.Help.ERR.UnhideContent(errors.ErrUnauthorized, *post.ID)
+		}
+	})
+
+	// Test managing roles as a guest, a regular user and a moderator
+	t.Run("setUserRoles non-admin (noauth)", func(t *testing.T) {
+		ts, reporter, _, _, userClt, moderatorClt := setupTest(t)
+		defer ts.Teardown()
+
+		for _, clt := range []*setup.Client{
+			ts.Guest(),
+			userClt,
+			moderatorClt,
+		} {
+			clt.Help.ERR.SetUserRoles(
+				errors.ErrUnauthorized,
+				*reporter.ID,
+				role.Moderator,
+			)
+		}
+	})
+}
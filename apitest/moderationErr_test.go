@@ -0,0 +1,133 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestModerationErr tests all possible content moderation errors
+func TestModerationErr(t *testing.T) {
+	testSetup := func(t *testing.T) (
+		ts *setup.TestSetup,
+		debug *setup.Client,
+		reporter *gqlmod.User,
+		post *gqlmod.Post,
+	) {
+		ts = setup.New(t, tcx)
+		debug = ts.Debug()
+
+		author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+		reporter = debug.Help.OK.CreateUser("reporter", "2@tst.tst", "testpass")
+		post = debug.Help.OK.CreatePost(*author.ID, "title", "contents")
+		return
+	}
+
+	t.Run("reportInvalidReason", func(t *testing.T) {
+		ts, debug, reporter, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.ReportContent(
+			errors.ErrInvalidInput,
+			*reporter.ID,
+			*post.ID,
+			"", // Empty reason
+		)
+	})
+
+	t.Run("reportInexistentReporter", func(t *testing.T) {
+		ts, debug, _, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.ReportContent(
+			errors.ErrInvalidInput,
+			store.NewID(), // Inexistent reporter
+			*post.ID,
+			"spam",
+		)
+	})
+
+	t.Run("reportInexistentSubject", func(t *testing.T) {
+		ts, debug, reporter, _ := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.ReportContent(
+			errors.ErrInvalidInput,
+			*reporter.ID,
+			store.NewID(), // Inexistent subject
+			"spam",
+		)
+	})
+
+	t.Run("alreadyReported", func(t *testing.T) {
+		ts, debug, reporter, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.OK.ReportContent(*reporter.ID, *post.ID, "spam")
+		debug.Help.ERR.ReportContent(
+			errors.ErrInvalidInput,
+			*reporter.ID,
+			*post.ID,
+			"spam",
+		)
+	})
+
+	t.Run("dismissInexistentReport", func(t *testing.T) {
+		ts, debug, _, _ := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.DismissReport(
+			errors.ErrInvalidInput,
+			store.NewID(), // Inexistent report
+		)
+	})
+
+	t.Run("dismissClosedReport", func(t *testing.T) {
+		ts, debug, reporter, post := testSetup(t)
+		defer ts.Teardown()
+
+		report := debug.Help.OK.ReportContent(*reporter.ID, *post.ID, "spam")
+		debug.Help.OK.DismissReport(*report.ID)
+		debug.Help.ERR.DismissReport(errors.ErrInvalidInput, *report.ID)
+	})
+
+	t.Run("hideInexistentSubject", func(t *testing.T) {
+		ts, debug, _, _ := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.HideContent(
+			errors.ErrInvalidInput,
+			store.NewID(), // Inexistent subject
+		)
+	})
+
+	t.Run("alreadyHidden", func(t *testing.T) {
+		ts, debug, _, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.OK.HideContent(*post.ID)
+		debug.Help.ERR.HideContent(errors.ErrInvalidInput, *post.ID)
+	})
+
+	t.Run("unhideNotHidden", func(t *testing.T) {
+		ts, debug, _, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.UnhideContent(errors.ErrInvalidInput, *post.ID)
+	})
+
+	t.Run("setRolesInexistentUser", func(t *testing.T) {
+		ts, debug, _, _ := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.SetUserRoles(
+			errors.ErrInvalidInput,
+			store.NewID(), // Inexistent user
+			role.Moderator,
+		)
+	})
+}
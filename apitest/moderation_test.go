@@ -0,0 +1,198 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModeration tests reporting, hiding and unhiding content
+func TestModeration(t *testing.T) {
+	// listed returns the identifiers of the posts and reactions
+	// listed to the client
+	listed := func(
+		t *testing.T,
+		clt *setup.Client,
+		author store.ID,
+		post store.ID,
+	) (posts []store.ID, reactions []store.ID) {
+		var result struct {
+			Posts  *gqlmod.PostConnection `json:"posts"`
+			Author *gqlmod.User           `json:"author"`
+			Post   *gqlmod.Post           `json:"post"`
+		}
+		require.NoError(t, clt.QueryVar(
+			`query($author: Identifier!, $post: Identifier!) {
+				posts {
+					totalCount
+					edges { node { id } }
+				}
+				author: user(id: $author) {
+					posts { totalCount }
+					publishedReactions { totalCount }
+				}
+				post(id: $post) {
+					reactions {
+						totalCount
+						edges { node { id } }
+					}
+				}
+			}`,
+			map[string]interface{}{
+				"author": string(author),
+				"post":   string(post),
+			},
+			&result,
+		))
+		for _, edge := range result.Posts.Edges {
+			posts = append(posts, *edge.Node.ID)
+		}
+		require.Equal(t, len(posts), *result.Posts.TotalCount)
+		require.Equal(t, len(posts), *result.Author.Posts.TotalCount)
+		for _, edge := range result.Post.Reactions.Edges {
+			reactions = append(reactions, *edge.Node.ID)
+		}
+		require.Equal(t, len(reactions), *result.Post.Reactions.TotalCount)
+		require.Equal(
+			t,
+			len(reactions),
+			*result.Author.PublishedReactions.TotalCount,
+		)
+		return
+	}
+
+	// queue returns the identifiers of the open reports
+	queue := func(t *testing.T, clt *setup.Client) []store.ID {
+		var result struct {
+			ModerationQueue *gqlmod.ReportConnection `json:"moderationQueue"`
+		}
+		require.NoError(t, clt.Query(
+			`{
+				moderationQueue {
+					edges { node { id } }
+				}
+			}`,
+			&result,
+		))
+		var reports []store.ID
+		for _, edge := range result.ModerationQueue.Edges {
+			reports = append(reports, *edge.Node.ID)
+		}
+		return reports
+	}
+
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	// Prepare
+	debug := ts.Debug()
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	reporter := debug.Help.OK.CreateUser("reporter", "2@tst.tst", "testpass")
+	moderator := debug.Help.OK.CreateUser("moderator", "3@tst.tst", "testpass")
+	debug.Help.OK.SetUserRoles(*moderator.ID, role.Moderator)
+
+	post := debug.Help.OK.CreatePost(*author.ID, "title", "contents")
+	keptPost := debug.Help.OK.CreatePost(*author.ID, "other title", "other")
+	reaction := debug.Help.OK.CreateReaction(
+		*author.ID,
+		*post.ID,
+		emotion.Angry,
+		"rude",
+	)
+
+	authorClt, _ := ts.Client("1@tst.tst", "testpass")
+	reporterClt, _ := ts.Client("2@tst.tst", "testpass")
+	moderatorClt, _ := ts.Client("3@tst.tst", "testpass")
+
+	// Report the post and the reaction
+	postReport := reporterClt.Help.OK.ReportContent(
+		*reporter.ID,
+		*post.ID,
+		"spam",
+	)
+	reactionReport := reporterClt.Help.OK.ReportContent(
+		*reporter.ID,
+		*reaction.ID,
+		"offensive",
+	)
+	require.Equal(t, []store.ID{
+		*postReport.ID,
+		*reactionReport.ID,
+	}, queue(t, moderatorClt))
+
+	// Hide the reaction, which closes its report
+	moderatorClt.Help.OK.HideContent(*reaction.ID)
+	require.Equal(t, []store.ID{*postReport.ID}, queue(t, moderatorClt))
+
+	// Dismiss the post report leaving the post visible
+	moderatorClt.Help.OK.DismissReport(*postReport.ID)
+	require.Len(t, queue(t, moderatorClt), 0)
+
+	// Hide the post
+	moderatorClt.Help.OK.HideContent(*post.ID)
+
+	// Hidden content is excluded from the lists of regular users
+	for _, clt := range []*setup.Client{ts.Guest(), authorClt, reporterClt} {
+		posts, reactions := listed(t, clt, *author.ID, *post.ID)
+		require.Equal(t, []store.ID{*keptPost.ID}, posts)
+		require.Len(t, reactions, 0)
+	}
+
+	// Moderators see hidden content
+	posts, reactions := listed(t, moderatorClt, *author.ID, *post.ID)
+	require.Equal(t, []store.ID{*post.ID, *keptPost.ID}, posts)
+	require.Equal(t, []store.ID{*reaction.ID}, reactions)
+
+	// Unhidden content is listed again
+	moderatorClt.Help.OK.UnhideContent(*post.ID)
+	moderatorClt.Help.OK.UnhideContent(*reaction.ID)
+	posts, reactions = listed(t, ts.Guest(), *author.ID, *post.ID)
+	require.Equal(t, []store.ID{*post.ID, *keptPost.ID}, posts)
+	require.Equal(t, []store.ID{*reaction.ID}, reactions)
+}
+
+// TestModerationHiddenFromSearchAndFeed tests whether hidden posts
+// are excluded from the search results and the feed
+func TestModerationHiddenFromSearchAndFeed(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	// Prepare
+	debug := ts.Debug()
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	follower := debug.Help.OK.CreateUser("follower", "2@tst.tst", "testpass")
+	debug.Help.OK.FollowUser(*follower.ID, *author.ID)
+	hidden := debug.Help.OK.CreatePost(*author.ID, "banana", "hidden")
+	visible := debug.Help.OK.CreatePost(*author.ID, "banana", "visible")
+	debug.Help.OK.HideContent(*hidden.ID)
+
+	followerClt, _ := ts.Client("2@tst.tst", "testpass")
+
+	var result struct {
+		SearchPosts *gqlmod.PostConnection `json:"searchPosts"`
+		Feed        *gqlmod.PostConnection `json:"feed"`
+	}
+	require.NoError(t, followerClt.Query(
+		`{
+			searchPosts(text: "banana") {
+				totalCount
+				edges { node { id } }
+			}
+			feed {
+				totalCount
+				edges { node { id } }
+			}
+		}`,
+		&result,
+	))
+	require.Equal(t, 1, *result.SearchPosts.TotalCount)
+	require.Equal(t, *visible.ID, *result.SearchPosts.Edges[0].Node.ID)
+	require.Equal(t, 1, *result.Feed.TotalCount)
+	require.Len(t, result.Feed.Edges, 1)
+	require.Equal(t, *visible.ID, *result.Feed.Edges[0].Node.ID)
+}
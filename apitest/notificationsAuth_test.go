@@ -0,0 +1,102 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotificationsAuth tests notification access authorization
+func TestNotificationsAuth(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		owner *gqlmod.User,
+		otherClt *setup.Client,
+	) {
+		ts = setup.New(t, tcx)
+		debug := ts.Debug()
+
+		owner = debug.Help.OK.CreateUser("owner", "1@tst.tst", "testpass")
+		debug.Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		otherClt, _ = ts.Client("2@tst.tst", "testpass")
+		return
+	}
+
+	// query queries the notifications of the owner
+	query := func(
+		t *testing.T,
+		clt *setup.Client,
+		owner *gqlmod.User,
+		selection string,
+	) {
+		var result struct {
+			User *gqlmod.User `json:"user"`
+		}
+		err := clt.QueryVar(
+			`query($user: Identifier!) {
+				user(id: $user) { `+selection+` }
+			}`,
+			map[string]interface{}{
+				"user": string(*owner.ID),
+			},
+			&result,
+		)
+		require.IsType(t, &graph.ResponseError{}, err)
+		require.Equal(
+			t,
+			string(errors.ErrUnauthorized),
+			err.(*graph.ResponseError).Code,
+		)
+	}
+
+	for name, selection := range map[string]string{
+		"notifications":           "notifications { totalCount }",
+		"unreadNotificationCount": "unreadNotificationCount",
+	} {
+		selection := selection
+
+		// Test reading notifications as a guest
+		t.Run(name+" guest (noauth)", func(t *testing.T) {
+			ts, owner, _ := setupTest(t)
+			defer ts.Teardown()
+
+			query(t, ts.Guest(), owner, selection)
+		})
+
+		// Test reading notifications of other users
+		t.Run(name+" non-owner (noauth)", func(t *testing.T) {
+			ts, owner, otherClt := setupTest(t)
+			defer ts.Teardown()
+
+			query(t, otherClt, owner, selection)
+		})
+	}
+
+	// Test marking notifications read as a guest
+	t.Run("mark guest (noauth)", func(t *testing.T) {
+		ts, owner, _ := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.MarkNotificationsRead(
+			errors.ErrUnauthorized,
+			*owner.ID,
+			nil,
+		)
+	})
+
+	// Test marking notifications of other users read
+	t.Run("mark non-owner (noauth)", func(t *testing.T) {
+		ts, owner, otherClt := setupTest(t)
+		defer ts.Teardown()
+
+		otherClt.Help.ERR.MarkNotificationsRead(
+			errors.ErrUnauthorized,
+			*owner.ID, // Someone else
+			nil,
+		)
+	})
+}
@@ -0,0 +1,193 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+	"github.com/stretchr/testify/require"
+)
+
+// notificationView represents a simplified notification
+type notificationView struct {
+	Kind    notification.Kind
+	Read    bool
+	Actor   store.ID
+	Subject store.ID
+}
+
+// notifications returns the notifications of the user
+func notifications(
+	t *testing.T,
+	clt *setup.Client,
+	user store.ID,
+	unreadOnly bool,
+) (views []notificationView, ids []store.ID) {
+	var result struct {
+		User *gqlmod.User `json:"user"`
+	}
+	require.NoError(t, clt.QueryVar(
+		`query(
+			$user: Identifier!
+			$unreadOnly: Boolean
+		) {
+			user(id: $user) {
+				notifications(unreadOnly: $unreadOnly) {
+					totalCount
+					edges {
+						node {
+							id
+							kind
+							read
+							actor { id }
+							subject {
+								... on Post { id }
+								... on Reaction { id }
+							}
+						}
+					}
+				}
+			}
+		}`,
+		map[string]interface{}{
+			"user":       string(user),
+			"unreadOnly": unreadOnly,
+		},
+		&result,
+	))
+	require.NotNil(t, result.User)
+	conn := result.User.Notifications
+	require.Equal(t, len(conn.Edges), *conn.TotalCount)
+	for _, edge := range conn.Edges {
+		view := notificationView{
+			Kind: *edge.Node.Kind,
+			Read: *edge.Node.Read,
+		}
+		if edge.Node.Actor != nil {
+			view.Actor = *edge.Node.Actor.ID
+		}
+		if subject, ok := edge.Node.Subject.(map[string]interface{}); ok {
+			view.Subject = store.ID(subject["id"].(string))
+		}
+		views = append(views, view)
+		ids = append(ids, *edge.Node.ID)
+	}
+	return
+}
+
+// TestNotifications tests the notifications caused by reactions
+// and mentions and marking them read
+func TestNotifications(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+	debug := ts.Debug()
+
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	reactor := debug.Help.OK.CreateUser("reactor", "2@tst.tst", "testpass")
+	third := debug.Help.OK.CreateUser("third", "3@tst.tst", "testpass")
+	authorClt, _ := ts.Client("1@tst.tst", "testpass")
+
+	// Mention the reactor in a post, self-mentions and mentions
+	// of inexistent users are ignored
+	post := debug.Help.OK.CreatePost(
+		*author.ID,
+		"example title",
+		"hello @reactor, @author and @nobody (mail@reactor isn't a mention)",
+	)
+
+	// React to the post and to the own reaction
+	reaction := debug.Help.OK.CreateReaction(
+		*reactor.ID,
+		*post.ID,
+		emotion.Happy,
+		"nice",
+	)
+	debug.Help.OK.CreateReaction(
+		*reactor.ID,
+		*reaction.ID,
+		emotion.Thoughtful,
+		"replying to myself",
+	)
+
+	// Reply mentioning the reactor (notified only once) and a third user
+	reply := debug.Help.OK.CreateReaction(
+		*author.ID,
+		*reaction.ID,
+		emotion.Excited,
+		"thanks @reactor, see @third",
+	)
+
+	authorNotifs, _ := notifications(t, authorClt, *author.ID, false)
+	require.Equal(t, []notificationView{
+		{
+			Kind:    notification.Reaction,
+			Actor:   *reactor.ID,
+			Subject: *reaction.ID,
+		},
+	}, authorNotifs)
+
+	reactorNotifs, reactorIDs := notifications(t, debug, *reactor.ID, false)
+	require.Equal(t, []notificationView{
+		{
+			Kind:    notification.Mention,
+			Actor:   *author.ID,
+			Subject: *post.ID,
+		},
+		{
+			Kind:    notification.Reaction,
+			Actor:   *author.ID,
+			Subject: *reply.ID,
+		},
+	}, reactorNotifs)
+
+	thirdNotifs, _ := notifications(t, debug, *third.ID, false)
+	require.Equal(t, []notificationView{
+		{
+			Kind:    notification.Mention,
+			Actor:   *author.ID,
+			Subject: *reply.ID,
+		},
+	}, thirdNotifs)
+
+	// Mark a single notification read
+	marked := debug.Help.OK.MarkNotificationsRead(
+		*reactor.ID,
+		[]store.ID{reactorIDs[0]},
+	)
+	require.Equal(t, []store.ID{reactorIDs[0]}, marked)
+
+	// Notifications already read aren't marked again
+	marked = debug.Help.OK.MarkNotificationsRead(
+		*reactor.ID,
+		[]store.ID{reactorIDs[0]},
+	)
+	require.Len(t, marked, 0)
+
+	reactorNotifs, _ = notifications(t, debug, *reactor.ID, false)
+	require.Len(t, reactorNotifs, 2)
+	require.True(t, reactorNotifs[0].Read)
+	require.False(t, reactorNotifs[1].Read)
+
+	unread, unreadIDs := notifications(t, debug, *reactor.ID, true)
+	require.Len(t, unread, 1)
+	require.Equal(t, []store.ID{reactorIDs[1]}, unreadIDs)
+
+	// Mark all notifications read
+	marked = authorClt.Help.OK.MarkNotificationsRead(*author.ID, nil)
+	require.Len(t, marked, 1)
+	unread, _ = notifications(t, authorClt, *author.ID, true)
+	require.Len(t, unread, 0)
+
+	// Deleting the subject preserves the notification
+	debug.Help.OK.DeleteReaction(*reply.ID)
+	thirdNotifs, _ = notifications(t, debug, *third.ID, false)
+	require.Equal(t, []notificationView{
+		{
+			Kind:  notification.Mention,
+			Actor: *author.ID,
+		},
+	}, thirdNotifs)
+}
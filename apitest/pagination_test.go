@@ -0,0 +1,232 @@
+package apitest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPagination tests cursor-based connection pagination
+func TestPagination(t *testing.T) {
+	const postsQuery = `query(
+		$after: Cursor
+		$before: Cursor
+	) {
+		posts(%s, after: $after, before: $before) {
+			totalCount
+			pageInfo {
+				hasNextPage
+				hasPreviousPage
+				startCursor
+				endCursor
+			}
+			edges {
+				cursor
+				node {
+					id
+					title
+				}
+			}
+		}
+	}`
+
+	type page struct {
+		Posts *gqlmod.PostConnection `json:"posts"`
+	}
+
+	// titles returns the titles of the posts of a page
+	titles := func(p page) []string {
+		titles := make([]string, len(p.Posts.Edges))
+		for i, edge := range p.Posts.Edges {
+			titles[i] = *edge.Node.Title
+		}
+		return titles
+	}
+
+	query := func(
+		s queryTestSetup,
+		window string,
+		vars map[string]interface{},
+	) (p page, err error) {
+		err = s.ts.Debug().QueryVar(
+			fmt.Sprintf(postsQuery, window),
+			vars,
+			&p,
+		)
+		return
+	}
+
+	t.Run("forward", func(t *testing.T) {
+		s := newQueryTestSetup(t, tcx)
+		defer s.Teardown()
+
+		first, err := query(s, "first: 2", nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, *first.Posts.TotalCount)
+		require.Equal(t, []string{"Post A1", "Post A2"}, titles(first))
+		require.True(t, *first.Posts.PageInfo.HasNextPage)
+		require.False(t, *first.Posts.PageInfo.HasPreviousPage)
+		require.Equal(
+			t,
+			*first.Posts.Edges[0].Cursor,
+			*first.Posts.PageInfo.StartCursor,
+		)
+		require.Equal(
+			t,
+			*first.Posts.Edges[1].Cursor,
+			*first.Posts.PageInfo.EndCursor,
+		)
+
+		second, err := query(s, "first: 2", map[string]interface{}{
+			"after": *first.Posts.PageInfo.EndCursor,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, *second.Posts.TotalCount)
+		require.Equal(t, []string{"Post B1"}, titles(second))
+		require.False(t, *second.Posts.PageInfo.HasNextPage)
+		require.True(t, *second.Posts.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		s := newQueryTestSetup(t, tcx)
+		defer s.Teardown()
+
+		last, err := query(s, "last: 2", nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, *last.Posts.TotalCount)
+		require.Equal(t, []string{"Post A2", "Post B1"}, titles(last))
+		require.False(t, *last.Posts.PageInfo.HasNextPage)
+		require.True(t, *last.Posts.PageInfo.HasPreviousPage)
+
+		previous, err := query(s, "last: 2", map[string]interface{}{
+			"before": *last.Posts.PageInfo.StartCursor,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Post A1"}, titles(previous))
+		require.True(t, *previous.Posts.PageInfo.HasNextPage)
+		require.False(t, *previous.Posts.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("nested backward", func(t *testing.T) {
+		s := newQueryTestSetup(t, tcx)
+		defer s.Teardown()
+
+		var author user
+		for _, usr := range s.users {
+			if *usr.DisplayName == "first" {
+				author = usr
+			}
+		}
+
+		// userPosts returns the page of the posts of the author
+		userPosts := func(before interface{}) (p page) {
+			var qr struct {
+				User struct {
+					Posts *gqlmod.PostConnection `json:"posts"`
+				} `json:"user"`
+			}
+			require.NoError(t, s.ts.Debug().QueryVar(
+				`query($id: Identifier!, $before: Cursor) {
+					user(id: $id) {
+						posts(last: 1, before: $before) {
+							totalCount
+							pageInfo {
+								hasNextPage
+								hasPreviousPage
+								startCursor
+							}
+							edges {
+								node {
+									title
+								}
+							}
+						}
+					}
+				}`,
+				map[string]interface{}{"id": *author.ID, "before": before},
+				&qr,
+			))
+			p.Posts = qr.User.Posts
+			return
+		}
+
+		last := userPosts(nil)
+		require.Equal(t, 2, *last.Posts.TotalCount)
+		require.Equal(t, []string{"Post A2"}, titles(last))
+		require.False(t, *last.Posts.PageInfo.HasNextPage)
+		require.True(t, *last.Posts.PageInfo.HasPreviousPage)
+
+		previous := userPosts(*last.Posts.PageInfo.StartCursor)
+		require.Equal(t, 2, *previous.Posts.TotalCount)
+		require.Equal(t, []string{"Post A1"}, titles(previous))
+		require.True(t, *previous.Posts.PageInfo.HasNextPage)
+		require.False(t, *previous.Posts.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		s := newQueryTestSetup(t, tcx)
+		defer s.Teardown()
+
+		empty, err := query(s, "first: 0", nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, *empty.Posts.TotalCount)
+		require.Len(t, empty.Posts.Edges, 0)
+		require.Nil(t, empty.Posts.PageInfo.StartCursor)
+		require.Nil(t, empty.Posts.PageInfo.EndCursor)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		s := newQueryTestSetup(t, tcx)
+		defer s.Teardown()
+
+		for _, window := range []string{"first: -1", "last: 101"} {
+			_, err := query(s, window, nil)
+			require.Error(t, err)
+			require.IsType(t, &graph.ResponseError{}, err)
+			require.Equal(
+				t,
+				string(errors.ErrInvalidInput),
+				err.(*graph.ResponseError).Code,
+			)
+		}
+	})
+	t.Run("page size variable", func(t *testing.T) {
+		s := newQueryTestSetup(t, tcx)
+		defer s.Teardown()
+
+		var p page
+		require.NoError(t, s.ts.Debug().QueryVar(
+			`query($first: Int) {
+				posts(first: $first) {
+					totalCount
+					edges {
+						node {
+							title
+						}
+					}
+				}
+			}`,
+			map[string]interface{}{"first": 2},
+			&p,
+		))
+		require.Equal(t, 3, *p.Posts.TotalCount)
+		require.Equal(t, []string{"Post A1", "Post A2"}, titles(p))
+
+		// Variables of the wrong type are rejected
+		err := s.ts.Debug().QueryVar(
+			`query($first: Int) {
+				posts(first: $first) {
+					totalCount
+				}
+			}`,
+			map[string]interface{}{"first": "2"},
+			&p,
+		)
+		require.Error(t, err)
+		require.IsType(t, &graph.ResponseError{}, err)
+	})
+}
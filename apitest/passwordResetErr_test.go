@@ -0,0 +1,105 @@
+package apitest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestPasswordResetErr tests password reset errors
+func TestPasswordResetErr(t *testing.T) {
+	// Test requesting a password reset using an invalid email
+	t.Run("invalidEmail", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.RequestPasswordReset(
+			errors.ErrInvalidInput,
+			"invalid",
+		)
+	})
+
+	// Test resetting the password using an unknown token
+	t.Run("invalidToken", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.ResetPassword(
+			errors.ErrInvalidInput,
+			"invalid",
+			"newpassword",
+		)
+	})
+
+	// Test resetting the password using an email verification token
+	t.Run("wrongKind", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+
+		ts.Guest().Help.ERR.ResetPassword(
+			errors.ErrInvalidInput,
+			ts.MailedToken(email, token.EmailVerification),
+			"newpassword",
+		)
+	})
+
+	// Test resetting the password using an already used token
+	t.Run("reusedToken", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+		ts.Guest().Help.OK.RequestPasswordReset(email)
+		key := ts.MailedToken(email, token.PasswordReset)
+
+		ts.Guest().Help.OK.ResetPassword(key, "newpassword")
+		ts.Guest().Help.ERR.ResetPassword(
+			errors.ErrInvalidInput,
+			key,
+			"otherpassword",
+		)
+	})
+
+	// Test resetting the password using an expired token
+	t.Run("expiredToken", func(t *testing.T) {
+		context := tcx
+		context.Token.PasswordResetTTL = 500 * time.Millisecond
+		ts := setup.New(t, context)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+		ts.Guest().Help.OK.RequestPasswordReset(email)
+		key := ts.MailedToken(email, token.PasswordReset)
+
+		time.Sleep(600 * time.Millisecond)
+		ts.Guest().Help.ERR.ResetPassword(
+			errors.ErrInvalidInput,
+			key,
+			"newpassword",
+		)
+	})
+
+	// Test resetting the password to an invalid one
+	t.Run("invalidPassword", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+		ts.Guest().Help.OK.RequestPasswordReset(email)
+		key := ts.MailedToken(email, token.PasswordReset)
+
+		ts.Guest().Help.ERR.ResetPassword(errors.ErrInvalidInput, key, "")
+
+		// The token remains usable
+		ts.Guest().Help.OK.ResetPassword(key, "newpassword")
+	})
+}
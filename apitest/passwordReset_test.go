@@ -0,0 +1,78 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPasswordReset tests resetting passwords using mailed tokens
+func TestPasswordReset(t *testing.T) {
+	t.Run("reset", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		oldPassword := "testpass"
+		ts.Debug().Help.OK.CreateUser("user", email, oldPassword)
+		_, session := ts.Client(email, oldPassword)
+
+		require.True(t, ts.Guest().Help.OK.RequestPasswordReset(email))
+		key := ts.MailedToken(email, token.PasswordReset)
+		mails := ts.Mails(email)
+		require.Len(t, mails, 2) // verification and password reset
+		require.Equal(t, "Reset your password", mails[1].Subject)
+
+		newPassword := "newpassword"
+		require.True(t, ts.Guest().Help.OK.ResetPassword(key, newPassword))
+
+		// Test the sessions opened before the reset being closed
+		ts.Guest().Help.ERR.Authenticate(errors.ErrInvalidInput, *session.Key)
+
+		// Test signing in using the old password
+		ts.Guest().Help.ERR.CreateSession(
+			errors.ErrWrongCreds,
+			email,
+			oldPassword,
+		)
+
+		// Test signing in using the new password
+		ts.Guest().Help.OK.CreateSession(email, newPassword)
+	})
+
+	// Test requesting a password reset for an unregistered email
+	t.Run("unknownEmail", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "unknown@tst.tst"
+		require.True(t, ts.Guest().Help.OK.RequestPasswordReset(email))
+		require.Len(t, ts.Mails(email), 0)
+	})
+
+	// Test tokens being replaced by subsequently requested ones
+	t.Run("replaced", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+
+		ts.Guest().Help.OK.RequestPasswordReset(email)
+		replaced := ts.MailedToken(email, token.PasswordReset)
+		ts.Guest().Help.OK.RequestPasswordReset(email)
+		key := ts.MailedToken(email, token.PasswordReset)
+		require.NotEqual(t, replaced, key)
+
+		ts.Guest().Help.ERR.ResetPassword(
+			errors.ErrInvalidInput,
+			replaced,
+			"newpassword",
+		)
+		ts.Guest().Help.OK.ResetPassword(key, "newpassword")
+		ts.Guest().Help.OK.CreateSession(email, "newpassword")
+	})
+}
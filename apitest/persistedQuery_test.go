@@ -0,0 +1,270 @@
+package apitest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/config"
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	thttp "github.com/romshark/dgraph_graphql_go/api/transport/http"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPersistedQuery tests persisted queries identified by their hash
+func TestPersistedQuery(t *testing.T) {
+	// setupTest whitelists the persisted queries
+	// and returns the hashes of the user query and the mutation
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		usr *gqlmod.User,
+		queryHash string,
+		mutationHash string,
+	) {
+		ts = setup.New(t, tcx)
+		usr = ts.Debug().Help.OK.CreateUser("usr", "1@tst.tst", "testpass")
+
+		roles := []int{
+			ts.ShieldClientRole(config.ShieldRoleAssigneeGuest),
+			ts.ShieldClientRole(config.ShieldRoleAssigneeDebug),
+			ts.ShieldClientRole(config.ShieldRoleAssignee(role.User)),
+		}
+		queries, err := ts.Shield().WhitelistQueries(
+			thttp.ShieldEntry{
+				Name: "user",
+				Query: `query($id: Identifier!) {
+					user(id: $id) { id displayName }
+				}`,
+				Parameters: map[string]gqlshield.Parameter{
+					"id": gqlshield.Parameter{MaxValueLength: 64},
+				},
+				WhitelistedFor: roles,
+			},
+			thttp.ShieldEntry{
+				Name: "closeSession",
+				Query: `mutation($key: String!) {
+					closeSession(key: $key)
+				}`,
+				Parameters: map[string]gqlshield.Parameter{
+					"key": gqlshield.Parameter{MaxValueLength: 64},
+				},
+				WhitelistedFor: roles,
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		return ts, usr, queries[0].Hash, queries[1].Hash
+	}
+
+	requireErrCode := func(t *testing.T, code errors.Code, err error) {
+		require.Error(t, err)
+		require.IsType(t, &graph.ResponseError{}, err)
+		require.Equal(t, string(code), err.(*graph.ResponseError).Code)
+	}
+
+	t.Run("get", func(t *testing.T) {
+		ts, usr, queryHash, _ := setupTest(t)
+		defer ts.Teardown()
+
+		var result struct {
+			User *gqlmod.User `json:"user"`
+		}
+		require.NoError(t, ts.Guest().QueryPersisted(
+			queryHash,
+			map[string]interface{}{"id": string(*usr.ID)},
+			&result,
+		))
+		require.NotNil(t, result.User)
+		require.Equal(t, *usr.ID, *result.User.ID)
+		require.Equal(t, "usr", *result.User.DisplayName)
+
+		// Responses are private to the client's session
+		params := url.Values{}
+		params.Set("id", queryHash)
+		params.Set("variables", `{"id":"`+string(*usr.ID)+`"}`)
+		resp, err := http.Get(
+			"http://" + ts.Host() + "/g?" + params.Encode(),
+		)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "Authorization", resp.Header.Get("Vary"))
+		require.Equal(t, "private, no-cache", resp.Header.Get("Cache-Control"))
+	})
+
+	t.Run("post", func(t *testing.T) {
+		ts, usr, queryHash, _ := setupTest(t)
+		defer ts.Teardown()
+
+		post := func(body interface{}) (status int, result struct {
+			Data struct {
+				User *gqlmod.User `json:"user"`
+			} `json:"data"`
+			Errors []thttp.GraphResponseError `json:"errors"`
+		}) {
+			encoded, err := json.Marshal(body)
+			require.NoError(t, err)
+			req, err := http.NewRequest(
+				"POST",
+				"http://"+ts.Host()+"/g",
+				bytes.NewReader(encoded),
+			)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/graphql-response+json")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			return resp.StatusCode, result
+		}
+		extensions := func(hash string) map[string]interface{} {
+			return map[string]interface{}{
+				"persistedQuery": map[string]interface{}{
+					"version":    1,
+					"sha256Hash": hash,
+				},
+			}
+		}
+		variables := map[string]interface{}{"id": string(*usr.ID)}
+
+		// Send the hash only
+		status, result := post(map[string]interface{}{
+			"variables":  variables,
+			"extensions": extensions(queryHash),
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, result.Data.User)
+		require.Equal(t, *usr.ID, *result.Data.User.ID)
+
+		// Send the hash of the query document an Apollo client computes,
+		// it's unknown until the client sends the document along with it
+		document := "query User($id: Identifier!) {\n" +
+			"  user(id: $id) {\n" +
+			"    id\n" +
+			"    displayName\n" +
+			"  }\n" +
+			"}\n"
+		documentHash := sha256.Sum256([]byte(document))
+		apolloHash := hex.EncodeToString(documentHash[:])
+		status, result = post(map[string]interface{}{
+			"variables":  variables,
+			"extensions": extensions(apolloHash),
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Len(t, result.Errors, 1)
+		require.Equal(
+			t,
+			string(errors.ErrPersistedQueryNotFound),
+			result.Errors[0].Extensions.Code,
+		)
+
+		// Send both the hash and the query document
+		// the way Apollo clients do after a persisted query miss
+		status, result = post(map[string]interface{}{
+			"query":      document,
+			"variables":  variables,
+			"extensions": extensions(apolloHash),
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, result.Errors, 0)
+		require.NotNil(t, result.Data.User)
+		require.Equal(t, *usr.ID, *result.Data.User.ID)
+
+		// Send the hash only after the document was registered
+		status, result = post(map[string]interface{}{
+			"variables":  variables,
+			"extensions": extensions(apolloHash),
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, result.Errors, 0)
+		require.NotNil(t, result.Data.User)
+		require.Equal(t, *usr.ID, *result.Data.User.ID)
+
+		// Send the hash of a whitelisted query along with its original,
+		// not normalized, document
+		status, result = post(map[string]interface{}{
+			"query": `query($id: Identifier!) {
+					user(id: $id) { id displayName }
+				}`,
+			"variables":  variables,
+			"extensions": extensions(queryHash),
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, result.Errors, 0)
+		require.NotNil(t, result.Data.User)
+
+		// Send both the hash and a mismatching query
+		status, result = post(map[string]interface{}{
+			"query":      `query($id: Identifier!) { user(id: $id) { id } }`,
+			"variables":  variables,
+			"extensions": extensions(queryHash),
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Len(t, result.Errors, 1)
+		require.Equal(
+			t,
+			string(errors.ErrInvalidInput),
+			result.Errors[0].Extensions.Code,
+		)
+
+		// Send an unknown hash
+		status, result = post(map[string]interface{}{
+			"variables":  variables,
+			"extensions": extensions(gqlshield.HashQuery([]byte("x"))),
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Len(t, result.Errors, 1)
+		require.Equal(
+			t,
+			string(errors.ErrPersistedQueryNotFound),
+			result.Errors[0].Extensions.Code,
+		)
+	})
+
+	t.Run("notFound", func(t *testing.T) {
+		ts, usr, _, _ := setupTest(t)
+		defer ts.Teardown()
+
+		var result struct {
+			User *gqlmod.User `json:"user"`
+		}
+		requireErrCode(
+			t,
+			errors.ErrPersistedQueryNotFound,
+			ts.Guest().QueryPersisted(
+				gqlshield.HashQuery([]byte("inexistent")),
+				map[string]interface{}{"id": string(*usr.ID)},
+				&result,
+			),
+		)
+	})
+
+	// Test performing mutations over GET
+	t.Run("getMutation", func(t *testing.T) {
+		ts, _, _, mutationHash := setupTest(t)
+		defer ts.Teardown()
+
+		var result struct {
+			CloseSession bool `json:"closeSession"`
+		}
+		requireErrCode(
+			t,
+			errors.ErrInvalidInput,
+			ts.Debug().QueryPersisted(
+				mutationHash,
+				map[string]interface{}{"key": "inexistent"},
+				&result,
+			),
+		)
+	})
+}
@@ -16,23 +16,30 @@ func TestQuery(t *testing.T) {
 		defer s.Teardown()
 
 		var query struct {
-			Users []gqlmod.User `json:"users"`
+			Users *gqlmod.UserConnection `json:"users"`
 		}
 		require.NoError(t, s.ts.Debug().Query(
 			`query {
 				users {
-					id
-					creation
-					displayName
-					email
+					totalCount
+					edges {
+						node {
+							id
+							creation
+							displayName
+							email
+						}
+					}
 				}
 			}`,
 			&query,
 		))
-		require.Len(t, query.Users, len(s.users))
-		for _, actual := range query.Users {
+		require.Equal(t, len(s.users), *query.Users.TotalCount)
+		require.Len(t, query.Users.Edges, len(s.users))
+		for _, edge := range query.Users.Edges {
+			actual := edge.Node
 			require.Contains(t, s.users, *actual.ID)
-			compareUsers(t, s.users[*actual.ID], &actual)
+			compareUsers(t, s.users[*actual.ID], actual)
 		}
 	})
 
@@ -41,20 +48,26 @@ func TestQuery(t *testing.T) {
 		defer ts.Teardown()
 
 		var query struct {
-			Users []gqlmod.User `json:"users"`
+			Users *gqlmod.UserConnection `json:"users"`
 		}
 		require.NoError(t, ts.Debug().Query(
 			`query {
 				users {
-					id
-					creation
-					displayName
-					email
+					totalCount
+					edges {
+						node {
+							id
+							creation
+							displayName
+							email
+						}
+					}
 				}
 			}`,
 			&query,
 		))
-		require.Len(t, query.Users, 0)
+		require.Equal(t, 0, *query.Users.TotalCount)
+		require.Len(t, query.Users.Edges, 0)
 	})
 
 	t.Run("posts", func(t *testing.T) {
@@ -62,23 +75,30 @@ func TestQuery(t *testing.T) {
 		defer s.Teardown()
 
 		var query struct {
-			Posts []gqlmod.Post `json:"posts"`
+			Posts *gqlmod.PostConnection `json:"posts"`
 		}
 		require.NoError(t, s.ts.Debug().Query(
 			`query {
 				posts {
-					id
-					creation
-					title
-					contents
+					totalCount
+					edges {
+						node {
+							id
+							creation
+							title
+							contents
+						}
+					}
 				}
 			}`,
 			&query,
 		))
-		require.Len(t, query.Posts, len(s.posts))
-		for _, actual := range query.Posts {
+		require.Equal(t, len(s.posts), *query.Posts.TotalCount)
+		require.Len(t, query.Posts.Edges, len(s.posts))
+		for _, edge := range query.Posts.Edges {
+			actual := edge.Node
 			require.Contains(t, s.posts, *actual.ID)
-			comparePosts(t, s.posts[*actual.ID], &actual)
+			comparePosts(t, s.posts[*actual.ID], actual)
 		}
 	})
 
@@ -87,20 +107,26 @@ func TestQuery(t *testing.T) {
 		defer ts.Teardown()
 
 		var query struct {
-			Posts []gqlmod.Post `json:"posts"`
+			Posts *gqlmod.PostConnection `json:"posts"`
 		}
 		require.NoError(t, ts.Debug().Query(
 			`query {
 				posts {
-					id
-					creation
-					title
-					contents
+					totalCount
+					edges {
+						node {
+							id
+							creation
+							title
+							contents
+						}
+					}
 				}
 			}`,
 			&query,
 		))
-		require.Len(t, query.Posts, 0)
+		require.Equal(t, 0, *query.Posts.TotalCount)
+		require.Len(t, query.Posts.Edges, 0)
 	})
 
 	t.Run("user", func(t *testing.T) {
@@ -269,10 +295,15 @@ func TestQuery(t *testing.T) {
 				`query($userId: Identifier!) {
 					user(id: $userId) {
 						posts {
-							id
-							title
-							contents
-							creation
+							totalCount
+							edges {
+								node {
+									id
+									title
+									contents
+									creation
+								}
+							}
 						}
 					}
 				}`,
@@ -283,12 +314,13 @@ func TestQuery(t *testing.T) {
 			))
 
 			require.NotNil(t, query.User)
-			require.Len(t, query.User.Posts, len(posts))
+			require.Equal(t, len(posts), *query.User.Posts.TotalCount)
+			require.Len(t, query.User.Posts.Edges, len(posts))
 
-			for _, actualPost := range query.User.Posts {
-				id := *actualPost.ID
+			for _, edge := range query.User.Posts.Edges {
+				id := *edge.Node.ID
 				require.Contains(t, posts, id)
-				comparePosts(t, posts[id], &actualPost)
+				comparePosts(t, posts[id], edge.Node)
 			}
 		}
 	})
@@ -412,10 +444,15 @@ func TestQuery(t *testing.T) {
 				`query($postId: Identifier!) {
 					post(id: $postId) {
 						reactions {
-							id
-							emotion
-							message
-							creation
+							totalCount
+							edges {
+								node {
+									id
+									emotion
+									message
+									creation
+								}
+							}
 						}
 					}
 				}`,
@@ -426,12 +463,13 @@ func TestQuery(t *testing.T) {
 			))
 
 			require.NotNil(t, query.Post)
-			require.Len(t, query.Post.Reactions, len(reactions))
+			require.Equal(t, len(reactions), *query.Post.Reactions.TotalCount)
+			require.Len(t, query.Post.Reactions.Edges, len(reactions))
 
-			for _, actualReaction := range query.Post.Reactions {
-				id := *actualReaction.ID
+			for _, edge := range query.Post.Reactions.Edges {
+				id := *edge.Node.ID
 				require.Contains(t, reactions, id)
-				compareReactions(t, reactions[id], &actualReaction)
+				compareReactions(t, reactions[id], edge.Node)
 			}
 		}
 	})
@@ -448,10 +486,15 @@ func TestQuery(t *testing.T) {
 				`query($authorId: Identifier!) {
 					user(id: $authorId) {
 						publishedReactions {
-							id
-							emotion
-							message
-							creation
+							totalCount
+							edges {
+								node {
+									id
+									emotion
+									message
+									creation
+								}
+							}
 						}
 					}
 				}`,
@@ -462,12 +505,13 @@ func TestQuery(t *testing.T) {
 			))
 
 			require.NotNil(t, query.User)
-			require.Len(t, query.User.PublishedReactions, len(reactions))
+			require.Equal(t, len(reactions), *query.User.PublishedReactions.TotalCount)
+			require.Len(t, query.User.PublishedReactions.Edges, len(reactions))
 
-			for _, actualReaction := range query.User.PublishedReactions {
-				id := *actualReaction.ID
+			for _, edge := range query.User.PublishedReactions.Edges {
+				id := *edge.Node.ID
 				require.Contains(t, reactions, id)
-				compareReactions(t, reactions[id], &actualReaction)
+				compareReactions(t, reactions[id], edge.Node)
 			}
 		}
 	})
@@ -484,10 +528,15 @@ func TestQuery(t *testing.T) {
 				`query($subjectReactionId: Identifier!) {
 					reaction(id: $subjectReactionId) {
 						reactions {
-							id
-							emotion
-							message
-							creation
+							totalCount
+							edges {
+								node {
+									id
+									emotion
+									message
+									creation
+								}
+							}
 						}
 					}
 				}`,
@@ -498,12 +547,13 @@ func TestQuery(t *testing.T) {
 			))
 
 			require.NotNil(t, query.Reaction)
-			require.Len(t, query.Reaction.Reactions, len(subReactions))
+			require.Equal(t, len(subReactions), *query.Reaction.Reactions.TotalCount)
+			require.Len(t, query.Reaction.Reactions.Edges, len(subReactions))
 
-			for _, actualReaction := range query.Reaction.Reactions {
-				id := *actualReaction.ID
+			for _, edge := range query.Reaction.Reactions.Edges {
+				id := *edge.Node.ID
 				require.Contains(t, subReactions, id)
-				compareReactions(t, subReactions[id], &actualReaction)
+				compareReactions(t, subReactions[id], edge.Node)
 			}
 		}
 	})
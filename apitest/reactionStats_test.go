@@ -0,0 +1,177 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReactionStats tests the per-emotion reaction statistics
+// of posts and reactions with and without nested reactions
+func TestReactionStats(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+	debug := ts.Debug()
+
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	reactor := debug.Help.OK.CreateUser("reactor", "2@tst.tst", "testpass")
+
+	post := debug.Help.OK.CreatePost(
+		*author.ID,
+		"example title",
+		"example contents",
+	)
+	emptyPost := debug.Help.OK.CreatePost(
+		*author.ID,
+		"empty title",
+		"empty contents",
+	)
+	happy := debug.Help.OK.CreateReaction(
+		*reactor.ID,
+		*post.ID,
+		emotion.Happy,
+		"happy",
+	)
+	debug.Help.OK.CreateReaction(
+		*author.ID,
+		*post.ID,
+		emotion.Happy,
+		"also happy",
+	)
+	angry := debug.Help.OK.CreateReaction(
+		*reactor.ID,
+		*post.ID,
+		emotion.Angry,
+		"angry",
+	)
+	excited := debug.Help.OK.CreateReaction(
+		*author.ID,
+		*happy.ID,
+		emotion.Excited,
+		"excited",
+	)
+	debug.Help.OK.CreateReaction(
+		*reactor.ID,
+		*excited.ID,
+		emotion.Happy,
+		"deeply nested",
+	)
+
+	type result struct {
+		Post       *gqlmod.Post     `json:"post"`
+		DirectPost *gqlmod.Post     `json:"directPost"`
+		EmptyPost  *gqlmod.Post     `json:"emptyPost"`
+		Reaction   *gqlmod.Reaction `json:"reaction"`
+	}
+	// queryStats queries the reaction statistics as the given client
+	queryStats := func(clt *setup.Client) (query result) {
+		require.NoError(t, clt.QueryVar(
+			`query(
+				$postId: Identifier!
+				$emptyPostId: Identifier!
+				$reactionId: Identifier!
+			) {
+				post(id: $postId) {
+					reactionStats(nested: true) {
+						total
+						emotions { emotion count }
+					}
+				}
+				directPost: post(id: $postId) {
+					reactionStats {
+						total
+						emotions { emotion count }
+					}
+				}
+				emptyPost: post(id: $emptyPostId) {
+					reactionStats(nested: true) {
+						total
+						emotions { emotion count }
+					}
+				}
+				reaction(id: $reactionId) {
+					reactionStats {
+						total
+						emotions { emotion count }
+					}
+				}
+			}`,
+			map[string]interface{}{
+				"postId":      string(*post.ID),
+				"emptyPostId": string(*emptyPost.ID),
+				"reactionId":  string(*happy.ID),
+			},
+			&query,
+		))
+		return
+	}
+	query := queryStats(debug)
+
+	// counts returns the counts of the statistics by emotion
+	// ensuring every emotion is listed exactly once
+	counts := func(stats *gqlmod.ReactionStats) map[emotion.Emotion]int {
+		require.NotNil(t, stats)
+		require.Len(t, stats.Emotions, len(emotion.Values()))
+		counts := make(map[emotion.Emotion]int, len(stats.Emotions))
+		for _, emo := range stats.Emotions {
+			counts[*emo.Emotion] = *emo.Count
+		}
+		require.Len(t, counts, len(emotion.Values()))
+		return counts
+	}
+
+	// Nested reactions
+	require.NotNil(t, query.Post)
+	require.Equal(t, 5, *query.Post.ReactionStats.Total)
+	require.Equal(t, map[emotion.Emotion]int{
+		emotion.Happy:      3,
+		emotion.Angry:      1,
+		emotion.Excited:    1,
+		emotion.Fearful:    0,
+		emotion.Thoughtful: 0,
+	}, counts(query.Post.ReactionStats))
+
+	// Direct reactions only
+	require.NotNil(t, query.DirectPost)
+	require.Equal(t, 3, *query.DirectPost.ReactionStats.Total)
+	require.Equal(t, map[emotion.Emotion]int{
+		emotion.Happy:      2,
+		emotion.Angry:      1,
+		emotion.Excited:    0,
+		emotion.Fearful:    0,
+		emotion.Thoughtful: 0,
+	}, counts(query.DirectPost.ReactionStats))
+
+	// No reactions
+	require.NotNil(t, query.EmptyPost)
+	require.Equal(t, 0, *query.EmptyPost.ReactionStats.Total)
+	for emo, count := range counts(query.EmptyPost.ReactionStats) {
+		require.Zero(t, count, emo)
+	}
+
+	// Direct reactions to a reaction
+	require.NotNil(t, query.Reaction)
+	require.Equal(t, 1, *query.Reaction.ReactionStats.Total)
+	require.Equal(t, map[emotion.Emotion]int{
+		emotion.Happy:      0,
+		emotion.Angry:      0,
+		emotion.Excited:    1,
+		emotion.Fearful:    0,
+		emotion.Thoughtful: 0,
+	}, counts(query.Reaction.ReactionStats))
+
+	// Hidden reactions and the reactions to them are omitted
+	// unless the client can moderate
+	debug.Help.OK.HideContent(*angry.ID)
+	debug.Help.OK.HideContent(*excited.ID)
+	reactorClt, _ := ts.Client("2@tst.tst", "testpass")
+	visible := queryStats(reactorClt)
+	require.Equal(t, 2, *visible.Post.ReactionStats.Total)
+	require.Equal(t, 2, *visible.DirectPost.ReactionStats.Total)
+	require.Equal(t, 2, counts(visible.Post.ReactionStats)[emotion.Happy])
+	require.Equal(t, 0, *visible.Reaction.ReactionStats.Total)
+	require.Equal(t, 5, *queryStats(debug).Post.ReactionStats.Total)
+}
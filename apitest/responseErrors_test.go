@@ -0,0 +1,158 @@
+package apitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	thttp "github.com/romshark/dgraph_graphql_go/api/transport/http"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResponseErrors tests the GraphQL error response format
+func TestResponseErrors(t *testing.T) {
+	// post posts a raw graph query accepting the given media type
+	post := func(
+		t *testing.T,
+		ts *setup.TestSetup,
+		accept string,
+		body string,
+	) (status int, contentType string, result map[string]interface{}) {
+		req, err := http.NewRequest(
+			"POST",
+			"http://"+ts.Host()+"/g",
+			strings.NewReader(body),
+		)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", accept)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, resp.Header.Get("Content-Type"), result
+	}
+
+	encodeQuery := func(t *testing.T, query string) string {
+		encoded, err := json.Marshal(map[string]interface{}{"query": query})
+		require.NoError(t, err)
+		return string(encoded)
+	}
+
+	t.Run("partialData", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		own := ts.Debug().Help.OK.CreateUser("own", "1@tst.tst", "testpass")
+		other := ts.Debug().Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		clt, _ := ts.Client("1@tst.tst", "testpass")
+
+		var result struct {
+			Own   *gqlmod.User `json:"own"`
+			Other *gqlmod.User `json:"other"`
+		}
+		err := clt.QueryVar(
+			`query($own: Identifier!, $other: Identifier!) {
+				own: user(id: $own) {
+					id
+					email
+				}
+				other: user(id: $other) {
+					id
+					email
+				}
+			}`,
+			map[string]interface{}{
+				"own":   string(*own.ID),
+				"other": string(*other.ID),
+			},
+			&result,
+		)
+
+		// The inaccessible field is reported
+		require.IsType(t, &graph.ResponseError{}, err)
+		respErr := err.(*graph.ResponseError)
+		require.Equal(t, string(errors.ErrUnauthorized), respErr.Code)
+		require.Equal(t, []interface{}{"other", "email"}, respErr.Path)
+
+		// The accessible fields are resolved nonetheless
+		require.NotNil(t, result.Own)
+		require.Equal(t, *own.ID, *result.Own.ID)
+		require.Equal(t, "1@tst.tst", *result.Own.Email)
+		require.Nil(t, result.Other)
+	})
+
+	t.Run("format", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		status, contentType, result := post(
+			t,
+			ts,
+			"application/graphql-response+json",
+			encodeQuery(t, `{ inexistent }`),
+		)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "application/graphql-response+json", contentType)
+		require.NotContains(t, result, "data")
+		require.Contains(t, result, "errors")
+
+		// Errors are served as an array of error objects
+		encoded, err := json.Marshal(result["errors"])
+		require.NoError(t, err)
+		var respErrs []thttp.GraphResponseError
+		require.NoError(t, json.Unmarshal(encoded, &respErrs))
+		require.Len(t, respErrs, 1)
+		require.True(t, len(respErrs[0].Message) > 0)
+		require.Equal(t, []thttp.GraphErrorLocation{
+			{Line: 1, Column: 3},
+		}, respErrs[0].Locations)
+	})
+
+	t.Run("legacyJSON", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		// Well-formed requests are replied with status 200
+		status, contentType, result := post(
+			t,
+			ts,
+			"application/json",
+			encodeQuery(t, `{ inexistent }`),
+		)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "application/json", contentType)
+		require.NotContains(t, result, "data")
+		require.Contains(t, result, "errors")
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		for _, accept := range []string{
+			"application/json",
+			"application/graphql-response+json",
+		} {
+			status, _, result := post(t, ts, accept, `{"query":`)
+			require.Equal(t, http.StatusBadRequest, status)
+
+			encoded, err := json.Marshal(result["errors"])
+			require.NoError(t, err)
+			var respErrs []thttp.GraphResponseError
+			require.NoError(t, json.Unmarshal(encoded, &respErrs))
+			require.Len(t, respErrs, 1)
+			require.NotNil(t, respErrs[0].Extensions)
+			require.Equal(
+				t,
+				string(errors.ErrInvalidInput),
+				respErrs[0].Extensions.Code,
+			)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestRevertPostAuth tests post reverting authorization
+func TestRevertPostAuth(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		author *gqlmod.User,
+		post *gqlmod.Post,
+	) {
+		ts = setup.New(t, tcx)
+		debug := ts.Debug()
+
+		author = debug.Help.OK.CreateUser(
+			"fooBarowich",
+			"author@tst.tst",
+			"testpass",
+		)
+		post = debug.Help.OK.CreatePost(
+			*author.ID,
+			"example title",
+			"example contents",
+		)
+		newTitle := "new example title"
+		post = debug.Help.OK.EditPost(*post.ID, *author.ID, &newTitle, nil)
+
+		return
+	}
+
+	// Test reverting posts as a guest
+	t.Run("guest (noauth)", func(t *testing.T) {
+		ts, author, post := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.RevertPost(
+			errors.ErrUnauthorized,
+			*post.ID,
+			*author.ID,
+			*post.Revisions[0].ID,
+		)
+	})
+
+	// Test reverting posts of other users
+	t.Run("non-author (noauth)", func(t *testing.T) {
+		ts, _, post := setupTest(t)
+		defer ts.Teardown()
+
+		other := ts.Debug().Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		otherClt, _ := ts.Client("2@tst.tst", "testpass")
+
+		otherClt.Help.ERR.RevertPost(
+			errors.ErrUnauthorized,
+			*post.ID,
+			*other.ID,
+			*post.Revisions[0].ID,
+		)
+	})
+
+	// Test reverting posts on behalf of their author
+	t.Run("non-author on behalf of author (noauth)", func(t *testing.T) {
+		ts, author, post := setupTest(t)
+		defer ts.Teardown()
+
+		ts.Debug().Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		otherClt, _ := ts.Client("2@tst.tst", "testpass")
+
+		otherClt.Help.ERR.RevertPost(
+			errors.ErrUnauthorized,
+			*post.ID,
+			*author.ID,
+			*post.Revisions[0].ID,
+		)
+	})
+
+	// Test probing the revisions of other users' posts
+	t.Run("non-author inexistent revision (noauth)", func(t *testing.T) {
+		ts, _, post := setupTest(t)
+		defer ts.Teardown()
+
+		other := ts.Debug().Help.OK.CreateUser("other", "2@tst.tst", "testpass")
+		otherClt, _ := ts.Client("2@tst.tst", "testpass")
+
+		otherClt.Help.ERR.RevertPost(
+			errors.ErrUnauthorized,
+			*post.ID,
+			*other.ID,
+			store.NewID(), // Inexistent revision
+		)
+	})
+}
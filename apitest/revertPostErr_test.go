@@ -0,0 +1,91 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestRevertPostErr tests all possible post reverting errors
+func TestRevertPostErr(t *testing.T) {
+	testSetup := func(t *testing.T) (
+		ts *setup.TestSetup,
+		debug *setup.Client,
+		author *gqlmod.User,
+		post *gqlmod.Post,
+	) {
+		ts = setup.New(t, tcx)
+		debug = ts.Debug()
+
+		author = debug.Help.OK.CreateUser(
+			"fooBarowich",
+			"foo@bar.buz",
+			"testpass",
+		)
+		post = debug.Help.OK.CreatePost(
+			*author.ID,
+			"valid title",
+			"test contents",
+		)
+		newTitle := "new valid title"
+		post = debug.Help.OK.EditPost(*post.ID, *author.ID, &newTitle, nil)
+		return
+	}
+
+	t.Run("inexistentPost", func(t *testing.T) {
+		ts, debug, author, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.RevertPost(
+			errors.ErrInvalidInput,
+			store.NewID(), // Inexistent post
+			*author.ID,
+			*post.Revisions[0].ID,
+		)
+	})
+
+	t.Run("inexistentEditor", func(t *testing.T) {
+		ts, debug, _, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.RevertPost(
+			errors.ErrInvalidInput,
+			*post.ID,
+			store.NewID(), // Inexistent editor
+			*post.Revisions[0].ID,
+		)
+	})
+
+	t.Run("inexistentRevision", func(t *testing.T) {
+		ts, debug, author, post := testSetup(t)
+		defer ts.Teardown()
+
+		debug.Help.ERR.RevertPost(
+			errors.ErrInvalidInput,
+			*post.ID,
+			*author.ID,
+			store.NewID(), // Inexistent revision
+		)
+	})
+
+	t.Run("revisionOfOtherPost", func(t *testing.T) {
+		ts, debug, author, post := testSetup(t)
+		defer ts.Teardown()
+
+		other := debug.Help.OK.CreatePost(
+			*author.ID,
+			"other title",
+			"other contents",
+		)
+
+		debug.Help.ERR.RevertPost(
+			errors.ErrInvalidInput,
+			*other.ID,
+			*author.ID,
+			*post.Revisions[0].ID, // Revision of another post
+		)
+	})
+}
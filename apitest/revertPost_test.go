@@ -0,0 +1,66 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRevertPost tests reverting posts to previous revisions
+func TestRevertPost(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	// Prepare
+	debug := ts.Debug()
+	author := debug.Help.OK.CreateUser("author", "1@tst.tst", "testpass")
+	authorClt, _ := ts.Client("1@tst.tst", "testpass")
+	post := debug.Help.OK.CreatePost(*author.ID, "test post", "test contents")
+
+	newTitle := "new test post"
+	newContents := "new test contents"
+	edited := authorClt.Help.OK.EditPost(
+		*post.ID,
+		*author.ID,
+		&newTitle,
+		&newContents,
+	)
+	original := edited.Revisions[0]
+
+	// Test revert
+	reverted := authorClt.Help.OK.RevertPost(
+		*post.ID,
+		*author.ID,
+		*original.ID,
+	)
+	require.Equal(t, "test post", *reverted.Title)
+	require.Equal(t, "test contents", *reverted.Contents)
+	require.Len(t, reverted.Revisions, 2)
+	require.Equal(t, newTitle, *reverted.Revisions[1].Title)
+	require.Equal(t, newContents, *reverted.Revisions[1].Contents)
+
+	// Test reverting to the revision of the current values
+	reverted = authorClt.Help.OK.RevertPost(
+		*post.ID,
+		*author.ID,
+		*original.ID,
+	)
+	require.Len(t, reverted.Revisions, 2)
+
+	// Test reverting the revert
+	reverted = authorClt.Help.OK.RevertPost(
+		*post.ID,
+		*author.ID,
+		*reverted.Revisions[1].ID,
+	)
+	require.Equal(t, newTitle, *reverted.Title)
+	require.Equal(t, newContents, *reverted.Contents)
+	require.Len(t, reverted.Revisions, 3)
+
+	// Test reverting on behalf of another editor as a debug client
+	editor := debug.Help.OK.CreateUser("editor", "2@tst.tst", "testpass")
+	reverted = debug.Help.OK.RevertPost(*post.ID, *editor.ID, *original.ID)
+	require.Len(t, reverted.Revisions, 4)
+	require.Equal(t, *editor.ID, *reverted.Revisions[3].Editor.ID)
+}
@@ -0,0 +1,91 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchErr tests all possible search errors
+func TestSearchErr(t *testing.T) {
+	requireInvalidInput := func(t *testing.T, err error) {
+		require.Error(t, err)
+		require.IsType(t, &graph.ResponseError{}, err)
+		require.Equal(
+			t,
+			string(errors.ErrInvalidInput),
+			err.(*graph.ResponseError).Code,
+		)
+	}
+
+	// Test invalid post search parameters
+	t.Run("invalidPostSearch", func(t *testing.T) {
+		invalidParams := map[string]map[string]interface{}{
+			"noWords": {"text": " ,.! "},
+			"tooLong": {"text": randomString(257, nil)},
+			"invalidPageSize": {
+				"text":  "text",
+				"first": 101,
+			},
+			"unknownCursor": {
+				"text":  "text",
+				"after": "Y3Vyc29yOjB4ZmZmZmZm",
+			},
+		}
+
+		for tName, params := range invalidParams {
+			t.Run(tName, func(t *testing.T) {
+				ts := setup.New(t, tcx)
+				defer ts.Teardown()
+
+				var result struct{}
+				requireInvalidInput(t, ts.Debug().QueryVar(
+					`query(
+						$text: String!
+						$first: Int
+						$after: Cursor
+					) {
+						searchPosts(text: $text, first: $first, after: $after) {
+							totalCount
+						}
+					}`,
+					params,
+					&result,
+				))
+			})
+		}
+	})
+
+	// Test invalid user search prefixes
+	t.Run("invalidUserSearch", func(t *testing.T) {
+		invalidPrefixes := map[string]string{
+			"empty":    "",
+			"tooShort": "ab",
+			"blank":    "     ",
+			"tooLong":  randomString(65, nil),
+			"newline":  "ab\nc",
+			"tab":      "a\tbc",
+		}
+
+		for tName, prefix := range invalidPrefixes {
+			t.Run(tName, func(t *testing.T) {
+				ts := setup.New(t, tcx)
+				defer ts.Teardown()
+
+				var result struct{}
+				requireInvalidInput(t, ts.Debug().QueryVar(
+					`query($prefix: String!) {
+						searchUsers(prefix: $prefix) {
+							id
+						}
+					}`,
+					map[string]interface{}{"prefix": prefix},
+					&result,
+				))
+			})
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchPosts tests full-text post search
+func TestSearchPosts(t *testing.T) {
+	type result struct {
+		SearchPosts *gqlmod.PostConnection `json:"searchPosts"`
+	}
+
+	// titles returns the titles of the found posts
+	titles := func(r result) []string {
+		titles := make([]string, len(r.SearchPosts.Edges))
+		for i, edge := range r.SearchPosts.Edges {
+			titles[i] = *edge.Node.Title
+		}
+		return titles
+	}
+
+	search := func(
+		ts *setup.TestSetup,
+		vars map[string]interface{},
+	) (r result) {
+		require.NoError(t, ts.Debug().QueryVar(
+			`query(
+				$text: String!
+				$first: Int
+				$after: Cursor
+			) {
+				searchPosts(text: $text, first: $first, after: $after) {
+					totalCount
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						endCursor
+					}
+					edges {
+						node {
+							title
+						}
+					}
+				}
+			}`,
+			vars,
+			&r,
+		))
+		return
+	}
+
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	debug := ts.Debug()
+	author := debug.Help.OK.CreateUser("fooBarowich", "foo@bar.buz", "testpass")
+	debug.Help.OK.CreatePost(*author.ID, "About gardening", "Tomatoes")
+	debug.Help.OK.CreatePost(*author.ID, "Graph databases", "Dgraph rocks")
+	debug.Help.OK.CreatePost(*author.ID, "Cooking", "Tomatoes and graph")
+	debug.Help.OK.CreatePost(*author.ID, "Dgraph graph tips", "Indexes")
+
+	t.Run("relevance", func(t *testing.T) {
+		r := search(ts, map[string]interface{}{"text": "Graph, Dgraph!"})
+		require.Equal(t, 3, *r.SearchPosts.TotalCount)
+		require.Equal(t, []string{
+			"Dgraph graph tips",
+			"Graph databases",
+			"Cooking",
+		}, titles(r))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		first := search(ts, map[string]interface{}{
+			"text":  "graph dgraph",
+			"first": 2,
+		})
+		require.Equal(t, 3, *first.SearchPosts.TotalCount)
+		require.Equal(t, []string{
+			"Dgraph graph tips",
+			"Graph databases",
+		}, titles(first))
+		require.True(t, *first.SearchPosts.PageInfo.HasNextPage)
+		require.False(t, *first.SearchPosts.PageInfo.HasPreviousPage)
+
+		second := search(ts, map[string]interface{}{
+			"text":  "graph dgraph",
+			"first": 2,
+			"after": *first.SearchPosts.PageInfo.EndCursor,
+		})
+		require.Equal(t, []string{"Cooking"}, titles(second))
+		require.False(t, *second.SearchPosts.PageInfo.HasNextPage)
+		require.True(t, *second.SearchPosts.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("paginateOneByOne", func(t *testing.T) {
+		var found []string
+		vars := map[string]interface{}{"text": "graph tomatoes", "first": 1}
+		for {
+			r := search(ts, vars)
+			require.Equal(t, 4, *r.SearchPosts.TotalCount)
+			found = append(found, titles(r)...)
+			if !*r.SearchPosts.PageInfo.HasNextPage {
+				break
+			}
+			vars["after"] = *r.SearchPosts.PageInfo.EndCursor
+		}
+		require.Equal(t, []string{
+			"Dgraph graph tips",
+			"Graph databases",
+			"Cooking",
+			"About gardening",
+		}, found)
+	})
+
+	t.Run("noMatch", func(t *testing.T) {
+		r := search(ts, map[string]interface{}{"text": "unknown"})
+		require.Equal(t, 0, *r.SearchPosts.TotalCount)
+		require.Len(t, r.SearchPosts.Edges, 0)
+	})
+}
+
+// TestSearchUsers tests user search by display name prefix
+func TestSearchUsers(t *testing.T) {
+	search := func(ts *setup.TestSetup, prefix string) []string {
+		var r struct {
+			SearchUsers []*gqlmod.User `json:"searchUsers"`
+		}
+		require.NoError(t, ts.Debug().QueryVar(
+			`query($prefix: String!) {
+				searchUsers(prefix: $prefix) {
+					displayName
+				}
+			}`,
+			map[string]interface{}{"prefix": prefix},
+			&r,
+		))
+		names := make([]string, len(r.SearchUsers))
+		for i, usr := range r.SearchUsers {
+			names[i] = *usr.DisplayName
+		}
+		return names
+	}
+
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	debug := ts.Debug()
+	debug.Help.OK.CreateUser("alexandra", "1@test.test", "testpass")
+	debug.Help.OK.CreateUser("Alex", "2@test.test", "testpass")
+	debug.Help.OK.CreateUser("alexis", "3@test.test", "testpass")
+	debug.Help.OK.CreateUser("bobby", "4@test.test", "testpass")
+	debug.Help.OK.CreateUser("a.b.c", "5@test.test", "testpass")
+
+	t.Run("prefix", func(t *testing.T) {
+		require.Equal(
+			t,
+			[]string{"Alex", "alexis", "alexandra"},
+			search(ts, "alex"),
+		)
+	})
+
+	t.Run("metaCharacters", func(t *testing.T) {
+		require.Equal(t, []string{"a.b.c"}, search(ts, "a.b"))
+		require.Len(t, search(ts, "a*b"), 0)
+		require.Len(t, search(ts, "al/"), 0)
+	})
+
+	t.Run("noMatch", func(t *testing.T) {
+		require.Len(t, search(ts, "unknown"), 0)
+	})
+}
@@ -0,0 +1,111 @@
+package apitest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/clock"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionExpiry tests session expiration, renewal and reaping
+func TestSessionExpiry(t *testing.T) {
+	// setupTest returns a test setup with a mock clock
+	// which is advanced by the tests instead of waiting
+	setupTest := func(
+		t *testing.T,
+		ttl time.Duration,
+		idle time.Duration,
+	) (*setup.TestSetup, *clock.Mock) {
+		clk := clock.NewMock(time.Now())
+		context := tcx
+		context.Session.AbsoluteTTL = ttl
+		context.Session.IdleTTL = idle
+		context.Session.ReaperInterval = 10 * time.Millisecond
+		context.Clock = clk
+		return setup.New(t, context), clk
+	}
+
+	// signIn must be called after the teardown is deferred
+	// to not leak the server when it fails
+	signIn := func(ts *setup.TestSetup) (
+		usr *gqlmod.User,
+		clt *setup.Client,
+		session *gqlmod.Session,
+	) {
+		usr = ts.Debug().Help.OK.CreateUser("usr", "t1@te.te", "testpass")
+		clt, session = ts.Client("t1@te.te", "testpass")
+		return
+	}
+
+	// Test sessions expiring after their absolute lifetime
+	t.Run("absolute", func(t *testing.T) {
+		ts, clk := setupTest(t, time.Hour, 24*time.Hour)
+		defer ts.Teardown()
+		_, clt, session := signIn(ts)
+
+		ts.Guest().Help.OK.Authenticate(*session.Key)
+		clk.Add(time.Hour)
+
+		// The client is treated as a guest after the session expired
+		ts.Guest().Help.ERR.Authenticate(errors.ErrInvalidInput, *session.Key)
+		clt.Help.ERR.CloseSession(errors.ErrUnauthorized, *session.Key)
+	})
+
+	// Test sessions being renewed on access until they're idle for too long
+	t.Run("idle", func(t *testing.T) {
+		ts, clk := setupTest(t, 24*time.Hour, time.Hour)
+		defer ts.Teardown()
+		_, _, session := signIn(ts)
+
+		for i := 0; i < 4; i++ {
+			clk.Add(40 * time.Minute)
+			renewed := ts.Guest().Help.OK.Authenticate(*session.Key)
+			require.True(t, renewed.LastAccess.After(*session.Creation))
+		}
+
+		clk.Add(time.Hour)
+		ts.Guest().Help.ERR.Authenticate(errors.ErrInvalidInput, *session.Key)
+	})
+
+	// Test expired sessions being deleted in the background
+	t.Run("reaper", func(t *testing.T) {
+		ts, clk := setupTest(t, 24*time.Hour, time.Hour)
+		defer ts.Teardown()
+		usr, _, _ := signIn(ts)
+
+		// sessions returns the number of sessions of the user
+		sessions := func() int {
+			var query struct {
+				User *gqlmod.User `json:"user"`
+			}
+			require.NoError(t, ts.Debug().QueryVar(
+				`query($userId: Identifier!) {
+					user(id: $userId) {
+						sessions {
+							key
+						}
+					}
+				}`,
+				map[string]interface{}{
+					"userId": string(*usr.ID),
+				},
+				&query,
+			))
+			require.NotNil(t, query.User)
+			return len(query.User.Sessions)
+		}
+		require.Equal(t, 1, sessions())
+
+		// Wait for the reaper to run after the session expired
+		clk.Add(2 * time.Hour)
+		deadline := time.Now().Add(5 * time.Second)
+		for sessions() > 0 {
+			require.True(t, time.Now().Before(deadline), "session not reaped")
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetUserRoles tests granting and revoking user roles
+func TestSetUserRoles(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	// Prepare
+	debug := ts.Debug()
+	admin := debug.Help.OK.CreateUser("admin", "1@tst.tst", "testpass")
+	usr := debug.Help.OK.CreateUser("user", "2@tst.tst", "testpass")
+
+	// New users are regular users
+	var result struct {
+		User *gqlmod.User `json:"user"`
+	}
+	require.NoError(t, ts.Guest().QueryVar(
+		`query($id: Identifier!) {
+			user(id: $id) { roles }
+		}`,
+		map[string]interface{}{"id": string(*usr.ID)},
+		&result,
+	))
+	require.Equal(t, []role.Role{role.User}, result.User.Roles)
+
+	debug.Help.OK.SetUserRoles(*admin.ID, role.Admin)
+	adminClt, _ := ts.Client("1@tst.tst", "testpass")
+
+	// The user role is always retained and duplicates are removed
+	adminClt.Help.OK.SetUserRoles(*usr.ID, role.Moderator, role.Moderator)
+	adminClt.Help.OK.SetUserRoles(*usr.ID, role.Admin, role.User)
+	adminClt.Help.OK.SetUserRoles(*usr.ID)
+}
@@ -38,13 +38,30 @@ func (tclt *Client) QueryVar(
 	return tclt.apiClient.QueryVar(query, vars, result)
 }
 
+// QueryPersisted performs a parameterized persisted API query
+func (tclt *Client) QueryPersisted(
+	queryHash string,
+	vars map[string]interface{},
+	result interface{},
+) error {
+	return tclt.apiClient.QueryPersisted(queryHash, vars, result)
+}
+
+// Subscribe starts a parameterized API subscription
+func (tclt *Client) Subscribe(
+	query string,
+	vars map[string]interface{},
+) (trn.Subscription, error) {
+	return tclt.apiClient.Subscribe(query, vars)
+}
+
 // Guest creates a new unauthenticated API client
 func (ts *TestSetup) Guest() *Client {
 	// Initialize client
 	apiClt, err := thttp.NewClient(
 		url.URL{
 			Scheme: "http",
-			Host:   ts.serverTransport.(*thttp.Server).Addr().Host,
+			Host:   ts.Host(),
 		},
 		thttp.ClientConfig{
 			Timeout: time.Second * 10,
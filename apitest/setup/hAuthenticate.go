@@ -21,7 +21,12 @@ func (h Helper) authenticate(
 		) {
 			authenticate(
 				sessionKey: $sessionKey
-			)
+			) {
+				key
+				creation
+				lastAccess
+				expiresAt
+			}
 		}`,
 		map[string]interface{}{
 			"sessionKey": sessionKey,
@@ -34,6 +39,10 @@ func (h Helper) authenticate(
 	}
 
 	require.NotNil(t, result.Authenticate)
+	require.Equal(t, sessionKey, *result.Authenticate.Key)
+	require.True(t, result.Authenticate.ExpiresAt.After(
+		*result.Authenticate.LastAccess,
+	))
 
 	return result.Authenticate
 }
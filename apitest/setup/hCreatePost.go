@@ -39,7 +39,7 @@ func (h Helper) createPost(
 					id
 				}
 				reactions {
-					id
+					totalCount
 				}
 			}
 		}`,
@@ -60,7 +60,7 @@ func (h Helper) createPost(
 	require.Equal(t, title, *result.CreatePost.Title)
 	require.Equal(t, contents, *result.CreatePost.Contents)
 	require.Equal(t, authorID, *result.CreatePost.Author.ID)
-	require.Len(t, result.CreatePost.Reactions, 0)
+	require.Equal(t, 0, *result.CreatePost.Reactions.TotalCount)
 	require.WithinDuration(
 		t,
 		time.Now(),
@@ -29,6 +29,8 @@ func (h Helper) createSession(
 			) {
 				key
 				creation
+				lastAccess
+				expiresAt
 				user {
 					id
 					email
@@ -58,6 +60,14 @@ func (h Helper) createSession(
 		*result.CreateSession.Creation,
 		h.creationTimeTollerance,
 	)
+	require.Equal(
+		t,
+		*result.CreateSession.Creation,
+		*result.CreateSession.LastAccess,
+	)
+	require.True(t, result.CreateSession.ExpiresAt.After(
+		*result.CreateSession.Creation,
+	))
 
 	return result.CreateSession
 }
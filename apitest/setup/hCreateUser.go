@@ -35,7 +35,7 @@ func (h Helper) createUser(
 				displayName
 				creation
 				posts {
-					id
+					totalCount
 				}
 			}
 		}`,
@@ -55,7 +55,7 @@ func (h Helper) createUser(
 	require.Len(t, *result.CreateUser.ID, 32)
 	require.Equal(t, email, *result.CreateUser.Email)
 	require.Equal(t, displayName, *result.CreateUser.DisplayName)
-	require.Len(t, result.CreateUser.Posts, 0)
+	require.Equal(t, 0, *result.CreateUser.Posts.TotalCount)
 	require.WithinDuration(
 		t,
 		time.Now(),
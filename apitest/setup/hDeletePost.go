@@ -0,0 +1,73 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (h Helper) deletePost(
+	expectedErrorCode errors.Code,
+	post store.ID,
+) bool {
+	t := h.c.t
+
+	var result struct {
+		DeletePost bool `json:"deletePost"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$post: Identifier!
+		) {
+			deletePost(
+				post: $post
+			)
+		}`,
+		map[string]interface{}{
+			"post": string(post),
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		return false
+	}
+
+	require.True(t, result.DeletePost)
+
+	// Ensure the post was deleted
+	var after struct {
+		Post *gqlmod.Post `json:"post"`
+	}
+	require.NoError(t, h.ts.Debug().QueryVar(
+		`query($postId: Identifier!) {
+			post(id: $postId) {
+				id
+			}
+		}`,
+		map[string]interface{}{
+			"postId": string(post),
+		},
+		&after,
+	))
+	require.Nil(t, after.Post)
+
+	return result.DeletePost
+}
+
+// DeletePost helps deleting a post and assumes success
+func (ok AssumeSuccess) DeletePost(
+	post store.ID,
+) bool {
+	return ok.h.deletePost("", post)
+}
+
+// DeletePost assumes the given error code to be returned
+func (notOk AssumeFailure) DeletePost(
+	expectedErrorCode errors.Code,
+	post store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.deletePost(expectedErrorCode, post)
+}
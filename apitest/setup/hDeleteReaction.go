@@ -0,0 +1,73 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (h Helper) deleteReaction(
+	expectedErrorCode errors.Code,
+	reaction store.ID,
+) bool {
+	t := h.c.t
+
+	var result struct {
+		DeleteReaction bool `json:"deleteReaction"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$reaction: Identifier!
+		) {
+			deleteReaction(
+				reaction: $reaction
+			)
+		}`,
+		map[string]interface{}{
+			"reaction": string(reaction),
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		return false
+	}
+
+	require.True(t, result.DeleteReaction)
+
+	// Ensure the reaction was deleted
+	var after struct {
+		Reaction *gqlmod.Reaction `json:"reaction"`
+	}
+	require.NoError(t, h.ts.Debug().QueryVar(
+		`query($reactionId: Identifier!) {
+			reaction(id: $reactionId) {
+				id
+			}
+		}`,
+		map[string]interface{}{
+			"reactionId": string(reaction),
+		},
+		&after,
+	))
+	require.Nil(t, after.Reaction)
+
+	return result.DeleteReaction
+}
+
+// DeleteReaction helps deleting a reaction and assumes success
+func (ok AssumeSuccess) DeleteReaction(
+	reaction store.ID,
+) bool {
+	return ok.h.deleteReaction("", reaction)
+}
+
+// DeleteReaction assumes the given error code to be returned
+func (notOk AssumeFailure) DeleteReaction(
+	expectedErrorCode errors.Code,
+	reaction store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.deleteReaction(expectedErrorCode, reaction)
+}
@@ -0,0 +1,73 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (h Helper) deleteUser(
+	expectedErrorCode errors.Code,
+	user store.ID,
+) bool {
+	t := h.c.t
+
+	var result struct {
+		DeleteUser bool `json:"deleteUser"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$user: Identifier!
+		) {
+			deleteUser(
+				user: $user
+			)
+		}`,
+		map[string]interface{}{
+			"user": string(user),
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		return false
+	}
+
+	require.True(t, result.DeleteUser)
+
+	// Ensure the user was deleted
+	var after struct {
+		User *gqlmod.User `json:"user"`
+	}
+	require.NoError(t, h.ts.Debug().QueryVar(
+		`query($userId: Identifier!) {
+			user(id: $userId) {
+				id
+			}
+		}`,
+		map[string]interface{}{
+			"userId": string(user),
+		},
+		&after,
+	))
+	require.Nil(t, after.User)
+
+	return result.DeleteUser
+}
+
+// DeleteUser helps deleting a user and assumes success
+func (ok AssumeSuccess) DeleteUser(
+	user store.ID,
+) bool {
+	return ok.h.deleteUser("", user)
+}
+
+// DeleteUser assumes the given error code to be returned
+func (notOk AssumeFailure) DeleteUser(
+	expectedErrorCode errors.Code,
+	user store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.deleteUser(expectedErrorCode, user)
+}
@@ -0,0 +1,60 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (h Helper) dismissReport(
+	expectedErrorCode errors.Code,
+	reportID store.ID,
+) *gqlmod.Report {
+	t := h.c.t
+
+	oldOpen := h.openReportCount()
+
+	var result struct {
+		DismissReport *gqlmod.Report `json:"dismissReport"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation ($report: Identifier!) {
+			dismissReport(report: $report) {
+				id
+				reason
+				open
+			}
+		}`,
+		map[string]interface{}{
+			"report": string(reportID),
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		require.Equal(t, oldOpen, h.openReportCount())
+		return nil
+	}
+
+	require.NotNil(t, result.DismissReport)
+	require.Equal(t, reportID, *result.DismissReport.ID)
+	require.False(t, *result.DismissReport.Open)
+	require.Equal(t, oldOpen-1, h.openReportCount())
+
+	return result.DismissReport
+}
+
+// DismissReport helps dismiss a report and assumes success
+func (ok AssumeSuccess) DismissReport(reportID store.ID) *gqlmod.Report {
+	return ok.h.dismissReport("", reportID)
+}
+
+// DismissReport assumes the given error code to be returned
+func (notOk AssumeFailure) DismissReport(
+	expectedErrorCode errors.Code,
+	reportID store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.dismissReport(expectedErrorCode, reportID)
+}
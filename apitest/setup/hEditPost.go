@@ -26,9 +26,13 @@ func (h Helper) editPost(
 				title
 				contents
 				creation
+				lastEdited
 				author {
 					id
 				}
+				revisions {
+					id
+				}
 			}
 		}`,
 		map[string]interface{}{
@@ -57,9 +61,19 @@ func (h Helper) editPost(
 				title
 				contents
 				creation
+				lastEdited
 				author {
 					id
 				}
+				revisions {
+					id
+					creation
+					editor {
+						id
+					}
+					title
+					contents
+				}
 			}
 		}`,
 		map[string]interface{}{
@@ -90,6 +104,22 @@ func (h Helper) editPost(
 		}
 		require.Equal(t, *old.Post.Author.ID, *result.EditPost.Author.ID)
 		require.Equal(t, *old.Post.Creation, *result.EditPost.Creation)
+
+		// Ensure the previous values are recorded as a new revision
+		edited := *result.EditPost.Title != *old.Post.Title ||
+			*result.EditPost.Contents != *old.Post.Contents
+		if !edited {
+			require.Len(t, result.EditPost.Revisions, len(old.Post.Revisions))
+			require.Equal(t, old.Post.LastEdited, result.EditPost.LastEdited)
+		} else {
+			require.Len(t, result.EditPost.Revisions, len(old.Post.Revisions)+1)
+			revision := result.EditPost.Revisions[len(old.Post.Revisions)]
+			require.Equal(t, *old.Post.Title, *revision.Title)
+			require.Equal(t, *old.Post.Contents, *revision.Contents)
+			require.Equal(t, editorID, *revision.Editor.ID)
+			require.NotNil(t, result.EditPost.LastEdited)
+			require.Equal(t, *revision.Creation, *result.EditPost.LastEdited)
+		}
 	}
 
 	return result.EditPost
@@ -28,6 +28,9 @@ func (h Helper) editReaction(
 				author {
 					id
 				}
+				revisions {
+					id
+				}
 				subject {
 					__typename
 					... on Post {
@@ -75,6 +78,14 @@ func (h Helper) editReaction(
 				author {
 					id
 				}
+				revisions {
+					id
+					creation
+					editor {
+						id
+					}
+					message
+				}
 			}
 		}`,
 		map[string]interface{}{
@@ -101,6 +112,17 @@ func (h Helper) editReaction(
 			*result.EditReaction.Author.ID,
 		)
 		require.Equal(t, *old.Reaction.Creation, *result.EditReaction.Creation)
+
+		// Ensure the previous message is recorded as a new revision
+		revisions := result.EditReaction.Revisions
+		if newMessage == *old.Reaction.Message {
+			require.Len(t, revisions, len(old.Reaction.Revisions))
+		} else {
+			require.Len(t, revisions, len(old.Reaction.Revisions)+1)
+			revision := revisions[len(old.Reaction.Revisions)]
+			require.Equal(t, *old.Reaction.Message, *revision.Message)
+			require.Equal(t, editorID, *revision.Editor.ID)
+		}
 	}
 
 	return result.EditReaction
@@ -0,0 +1,115 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// followCounts returns the number of users following the followee
+// and the number of users followed by the follower
+func (h Helper) followCounts(
+	followerID store.ID,
+	followeeID store.ID,
+) (followers int, following int) {
+	var result struct {
+		Follower *gqlmod.User `json:"follower"`
+		Followee *gqlmod.User `json:"followee"`
+	}
+	require.NoError(h.c.t, h.ts.Debug().QueryVar(
+		`query(
+			$follower: Identifier!
+			$followee: Identifier!
+		) {
+			follower: user(id: $follower) {
+				following {
+					totalCount
+				}
+			}
+			followee: user(id: $followee) {
+				followers {
+					totalCount
+				}
+			}
+		}`,
+		map[string]interface{}{
+			"follower": string(followerID),
+			"followee": string(followeeID),
+		},
+		&result,
+	))
+	if result.Followee != nil {
+		followers = *result.Followee.Followers.TotalCount
+	}
+	if result.Follower != nil {
+		following = *result.Follower.Following.TotalCount
+	}
+	return
+}
+
+func (h Helper) followUser(
+	expectedErrorCode errors.Code,
+	followerID store.ID,
+	userID store.ID,
+) *gqlmod.User {
+	t := h.c.t
+
+	oldFollowers, oldFollowing := h.followCounts(followerID, userID)
+
+	var result struct {
+		FollowUser *gqlmod.User `json:"followUser"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$follower: Identifier!
+			$user: Identifier!
+		) {
+			followUser(
+				follower: $follower
+				user: $user
+			) {
+				id
+				displayName
+				creation
+			}
+		}`,
+		map[string]interface{}{
+			"follower": string(followerID),
+			"user":     string(userID),
+		},
+		&result,
+	))
+
+	followers, following := h.followCounts(followerID, userID)
+	if expectedErrorCode != "" {
+		require.Equal(t, oldFollowers, followers)
+		require.Equal(t, oldFollowing, following)
+		return nil
+	}
+
+	require.NotNil(t, result.FollowUser)
+	require.Equal(t, userID, *result.FollowUser.ID)
+	require.Equal(t, oldFollowers+1, followers)
+	require.Equal(t, oldFollowing+1, following)
+
+	return result.FollowUser
+}
+
+// FollowUser helps make a user follow another user and assumes success
+func (ok AssumeSuccess) FollowUser(
+	followerID store.ID,
+	userID store.ID,
+) *gqlmod.User {
+	return ok.h.followUser("", followerID, userID)
+}
+
+// FollowUser assumes the given error code to be returned
+func (notOk AssumeFailure) FollowUser(
+	expectedErrorCode errors.Code,
+	followerID store.ID,
+	userID store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.followUser(expectedErrorCode, followerID, userID)
+}
@@ -0,0 +1,92 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// isHidden returns true if the post or reaction is hidden
+func (h Helper) isHidden(subjectID store.ID) bool {
+	var result struct {
+		Post     *gqlmod.Post     `json:"post"`
+		Reaction *gqlmod.Reaction `json:"reaction"`
+	}
+	require.NoError(h.c.t, h.ts.Debug().QueryVar(
+		`query($id: Identifier!) {
+			post(id: $id) {
+				hidden
+			}
+			reaction(id: $id) {
+				hidden
+			}
+		}`,
+		map[string]interface{}{
+			"id": string(subjectID),
+		},
+		&result,
+	))
+	switch {
+	case result.Post != nil:
+		return *result.Post.Hidden
+	case result.Reaction != nil:
+		return *result.Reaction.Hidden
+	}
+	return false
+}
+
+func (h Helper) hideContent(
+	expectedErrorCode errors.Code,
+	subjectID store.ID,
+) {
+	t := h.c.t
+
+	wasHidden := h.isHidden(subjectID)
+
+	var result struct {
+		HideContent map[string]interface{} `json:"hideContent"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation ($subject: Identifier!) {
+			hideContent(subject: $subject) {
+				... on Post {
+					id
+					hidden
+				}
+				... on Reaction {
+					id
+					hidden
+				}
+			}
+		}`,
+		map[string]interface{}{
+			"subject": string(subjectID),
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		require.Equal(t, wasHidden, h.isHidden(subjectID))
+		return
+	}
+
+	require.NotNil(t, result.HideContent)
+	require.Equal(t, string(subjectID), result.HideContent["id"])
+	require.Equal(t, true, result.HideContent["hidden"])
+	require.True(t, h.isHidden(subjectID))
+}
+
+// HideContent helps hide a post or reaction and assumes success
+func (ok AssumeSuccess) HideContent(subjectID store.ID) {
+	ok.h.hideContent("", subjectID)
+}
+
+// HideContent assumes the given error code to be returned
+func (notOk AssumeFailure) HideContent(
+	expectedErrorCode errors.Code,
+	subjectID store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.hideContent(expectedErrorCode, subjectID)
+}
@@ -0,0 +1,101 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// unreadNotificationCount returns the number of unread notifications
+// of the user
+func (h Helper) unreadNotificationCount(user store.ID) int {
+	var result struct {
+		User *gqlmod.User `json:"user"`
+	}
+	require.NoError(h.c.t, h.ts.Debug().QueryVar(
+		`query($user: Identifier!) {
+			user(id: $user) {
+				unreadNotificationCount
+			}
+		}`,
+		map[string]interface{}{
+			"user": string(user),
+		},
+		&result,
+	))
+	if result.User == nil {
+		return 0
+	}
+	return *result.User.UnreadNotificationCount
+}
+
+func (h Helper) markNotificationsRead(
+	expectedErrorCode errors.Code,
+	user store.ID,
+	notifications []store.ID,
+) []store.ID {
+	t := h.c.t
+
+	oldUnread := h.unreadNotificationCount(user)
+
+	vars := map[string]interface{}{
+		"user": string(user),
+	}
+	if notifications != nil {
+		ids := make([]string, len(notifications))
+		for i, id := range notifications {
+			ids[i] = string(id)
+		}
+		vars["notifications"] = ids
+	}
+
+	var result struct {
+		MarkNotificationsRead []store.ID `json:"markNotificationsRead"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$user: Identifier!
+			$notifications: [Identifier!]
+		) {
+			markNotificationsRead(
+				user: $user
+				notifications: $notifications
+			)
+		}`,
+		vars,
+		&result,
+	))
+
+	unread := h.unreadNotificationCount(user)
+	if expectedErrorCode != "" {
+		require.Equal(t, oldUnread, unread)
+		return nil
+	}
+
+	require.Equal(t, oldUnread-len(result.MarkNotificationsRead), unread)
+	if notifications == nil {
+		require.Equal(t, 0, unread)
+	}
+
+	return result.MarkNotificationsRead
+}
+
+// MarkNotificationsRead helps marking notifications as read
+// and assumes success
+func (ok AssumeSuccess) MarkNotificationsRead(
+	user store.ID,
+	notifications []store.ID,
+) []store.ID {
+	return ok.h.markNotificationsRead("", user, notifications)
+}
+
+// MarkNotificationsRead assumes the given error code to be returned
+func (notOk AssumeFailure) MarkNotificationsRead(
+	expectedErrorCode errors.Code,
+	user store.ID,
+	notifications []store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.markNotificationsRead(expectedErrorCode, user, notifications)
+}
@@ -0,0 +1,118 @@
+package setup
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// openReportCount returns the number of reports in the moderation queue
+func (h Helper) openReportCount() int {
+	var result struct {
+		ModerationQueue *gqlmod.ReportConnection `json:"moderationQueue"`
+	}
+	require.NoError(h.c.t, h.ts.Debug().Query(
+		`{
+			moderationQueue(first: 0) {
+				totalCount
+			}
+		}`,
+		&result,
+	))
+	return *result.ModerationQueue.TotalCount
+}
+
+func (h Helper) reportContent(
+	expectedErrorCode errors.Code,
+	reporterID store.ID,
+	subjectID store.ID,
+	reason string,
+) *gqlmod.Report {
+	t := h.c.t
+
+	oldOpen := h.openReportCount()
+
+	var result struct {
+		ReportContent *gqlmod.Report `json:"reportContent"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$reporter: Identifier!
+			$subject: Identifier!
+			$reason: String!
+		) {
+			reportContent(
+				reporter: $reporter
+				subject: $subject
+				reason: $reason
+			) {
+				id
+				creation
+				reporter {
+					id
+				}
+				subject {
+					... on Post {
+						id
+					}
+					... on Reaction {
+						id
+					}
+				}
+				reason
+				open
+			}
+		}`,
+		map[string]interface{}{
+			"reporter": string(reporterID),
+			"subject":  string(subjectID),
+			"reason":   reason,
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		require.Equal(t, oldOpen, h.openReportCount())
+		return nil
+	}
+
+	require.NotNil(t, result.ReportContent)
+	require.Len(t, *result.ReportContent.ID, 32)
+	require.WithinDuration(
+		t,
+		time.Now(),
+		*result.ReportContent.Creation,
+		h.creationTimeTollerance,
+	)
+	require.Equal(t, reporterID, *result.ReportContent.Reporter.ID)
+	subject := result.ReportContent.Subject.(map[string]interface{})
+	require.Equal(t, string(subjectID), subject["id"])
+	require.Equal(t, reason, *result.ReportContent.Reason)
+	require.True(t, *result.ReportContent.Open)
+	require.Equal(t, oldOpen+1, h.openReportCount())
+
+	return result.ReportContent
+}
+
+// ReportContent helps report a post or reaction and assumes success
+func (ok AssumeSuccess) ReportContent(
+	reporterID store.ID,
+	subjectID store.ID,
+	reason string,
+) *gqlmod.Report {
+	return ok.h.reportContent("", reporterID, subjectID, reason)
+}
+
+// ReportContent assumes the given error code to be returned
+func (notOk AssumeFailure) ReportContent(
+	expectedErrorCode errors.Code,
+	reporterID store.ID,
+	subjectID store.ID,
+	reason string,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.reportContent(expectedErrorCode, reporterID, subjectID, reason)
+}
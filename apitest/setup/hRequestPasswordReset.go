@@ -0,0 +1,51 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+func (h Helper) requestPasswordReset(
+	expectedErrorCode errors.Code,
+	email string,
+) bool {
+	t := h.c.t
+
+	var result struct {
+		RequestPasswordReset bool `json:"requestPasswordReset"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$email: String!
+		) {
+			requestPasswordReset(
+				email: $email
+			)
+		}`,
+		map[string]interface{}{
+			"email": email,
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		return false
+	}
+
+	return result.RequestPasswordReset
+}
+
+// RequestPasswordReset helps requesting a password reset and assumes success
+func (ok AssumeSuccess) RequestPasswordReset(
+	email string,
+) bool {
+	return ok.h.requestPasswordReset("", email)
+}
+
+// RequestPasswordReset assumes the given error code to be returned
+func (notOk AssumeFailure) RequestPasswordReset(
+	expectedErrorCode errors.Code,
+	email string,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.requestPasswordReset(expectedErrorCode, email)
+}
@@ -0,0 +1,57 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+func (h Helper) resetPassword(
+	expectedErrorCode errors.Code,
+	token string,
+	newPassword string,
+) bool {
+	t := h.c.t
+
+	var result struct {
+		ResetPassword bool `json:"resetPassword"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$token: String!
+			$newPassword: String!
+		) {
+			resetPassword(
+				token: $token
+				newPassword: $newPassword
+			)
+		}`,
+		map[string]interface{}{
+			"token":       token,
+			"newPassword": newPassword,
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		return false
+	}
+
+	return result.ResetPassword
+}
+
+// ResetPassword helps resetting a password and assumes success
+func (ok AssumeSuccess) ResetPassword(
+	token string,
+	newPassword string,
+) bool {
+	return ok.h.resetPassword("", token, newPassword)
+}
+
+// ResetPassword assumes the given error code to be returned
+func (notOk AssumeFailure) ResetPassword(
+	expectedErrorCode errors.Code,
+	token string,
+	newPassword string,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.resetPassword(expectedErrorCode, token, newPassword)
+}
@@ -0,0 +1,142 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (h Helper) revertPost(
+	expectedErrorCode errors.Code,
+	postID store.ID,
+	editorID store.ID,
+	revisionID store.ID,
+) *gqlmod.Post {
+	t := h.c.t
+
+	var old struct {
+		Post *gqlmod.Post `json:"post"`
+	}
+	require.NoError(t, h.ts.Debug().QueryVar(
+		`query($postId: Identifier!) {
+			post(id: $postId) {
+				id
+				title
+				contents
+				creation
+				author {
+					id
+				}
+				revisions {
+					id
+					title
+					contents
+				}
+			}
+		}`,
+		map[string]interface{}{
+			"postId": string(postID),
+		},
+		&old,
+	))
+
+	var result struct {
+		RevertPost *gqlmod.Post `json:"revertPost"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$post: Identifier!
+			$editor: Identifier!
+			$revision: Identifier!
+		) {
+			revertPost(
+				post: $post
+				editor: $editor
+				revision: $revision
+			) {
+				id
+				title
+				contents
+				creation
+				lastEdited
+				author {
+					id
+				}
+				revisions {
+					id
+					creation
+					editor {
+						id
+					}
+					title
+					contents
+				}
+			}
+		}`,
+		map[string]interface{}{
+			"post":     string(postID),
+			"editor":   string(editorID),
+			"revision": string(revisionID),
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		return nil
+	}
+
+	require.NotNil(t, result.RevertPost)
+	if old.Post != nil {
+		var restored *gqlmod.PostRevision
+		for i := range old.Post.Revisions {
+			if *old.Post.Revisions[i].ID == revisionID {
+				restored = &old.Post.Revisions[i]
+			}
+		}
+		require.NotNil(t, restored)
+
+		reverted := result.RevertPost
+		require.Equal(t, *old.Post.ID, *reverted.ID)
+		require.Equal(t, *restored.Title, *reverted.Title)
+		require.Equal(t, *restored.Contents, *reverted.Contents)
+		require.Equal(t, *old.Post.Author.ID, *reverted.Author.ID)
+		require.Equal(t, *old.Post.Creation, *reverted.Creation)
+
+		// Ensure the replaced values are recorded as a new revision
+		if *restored.Title == *old.Post.Title &&
+			*restored.Contents == *old.Post.Contents {
+			require.Len(t, reverted.Revisions, len(old.Post.Revisions))
+		} else {
+			require.Len(t, reverted.Revisions, len(old.Post.Revisions)+1)
+			revision := reverted.Revisions[len(old.Post.Revisions)]
+			require.Equal(t, *old.Post.Title, *revision.Title)
+			require.Equal(t, *old.Post.Contents, *revision.Contents)
+			require.Equal(t, editorID, *revision.Editor.ID)
+			require.Equal(t, *revision.Creation, *reverted.LastEdited)
+		}
+	}
+
+	return result.RevertPost
+}
+
+// RevertPost helps revert a post to one of its revisions
+// and assumes success
+func (ok AssumeSuccess) RevertPost(
+	postID store.ID,
+	editorID store.ID,
+	revisionID store.ID,
+) *gqlmod.Post {
+	return ok.h.revertPost("", postID, editorID, revisionID)
+}
+
+// RevertPost assumes the given error code to be returned
+func (notOk AssumeFailure) RevertPost(
+	expectedErrorCode errors.Code,
+	postID store.ID,
+	editorID store.ID,
+	revisionID store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.revertPost(expectedErrorCode, postID, editorID, revisionID)
+}
@@ -0,0 +1,99 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// userRoles returns the roles of the user
+func (h Helper) userRoles(user store.ID) []role.Role {
+	var result struct {
+		User *gqlmod.User `json:"user"`
+	}
+	require.NoError(h.c.t, h.ts.Debug().QueryVar(
+		`query($user: Identifier!) {
+			user(id: $user) {
+				roles
+			}
+		}`,
+		map[string]interface{}{
+			"user": string(user),
+		},
+		&result,
+	))
+	if result.User == nil {
+		return nil
+	}
+	return result.User.Roles
+}
+
+func (h Helper) setUserRoles(
+	expectedErrorCode errors.Code,
+	user store.ID,
+	roles []role.Role,
+) *gqlmod.User {
+	t := h.c.t
+
+	if roles == nil {
+		// An empty list of roles must not be passed as null
+		roles = []role.Role{}
+	}
+
+	oldRoles := h.userRoles(user)
+
+	var result struct {
+		SetUserRoles *gqlmod.User `json:"setUserRoles"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$user: Identifier!
+			$roles: [Role!]!
+		) {
+			setUserRoles(
+				user: $user
+				roles: $roles
+			) {
+				id
+				roles
+			}
+		}`,
+		map[string]interface{}{
+			"user":  string(user),
+			"roles": roles,
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		require.Equal(t, oldRoles, h.userRoles(user))
+		return nil
+	}
+
+	require.NotNil(t, result.SetUserRoles)
+	require.Equal(t, user, *result.SetUserRoles.ID)
+	require.Equal(t, role.Normalize(roles), result.SetUserRoles.Roles)
+	require.Equal(t, role.Normalize(roles), h.userRoles(user))
+
+	return result.SetUserRoles
+}
+
+// SetUserRoles helps replace the roles of a user and assumes success
+func (ok AssumeSuccess) SetUserRoles(
+	user store.ID,
+	roles ...role.Role,
+) *gqlmod.User {
+	return ok.h.setUserRoles("", user, roles)
+}
+
+// SetUserRoles assumes the given error code to be returned
+func (notOk AssumeFailure) SetUserRoles(
+	expectedErrorCode errors.Code,
+	user store.ID,
+	roles ...role.Role,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.setUserRoles(expectedErrorCode, user, roles)
+}
@@ -0,0 +1,75 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (h Helper) unfollowUser(
+	expectedErrorCode errors.Code,
+	followerID store.ID,
+	userID store.ID,
+) *gqlmod.User {
+	t := h.c.t
+
+	oldFollowers, oldFollowing := h.followCounts(followerID, userID)
+
+	var result struct {
+		UnfollowUser *gqlmod.User `json:"unfollowUser"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$follower: Identifier!
+			$user: Identifier!
+		) {
+			unfollowUser(
+				follower: $follower
+				user: $user
+			) {
+				id
+				displayName
+				creation
+			}
+		}`,
+		map[string]interface{}{
+			"follower": string(followerID),
+			"user":     string(userID),
+		},
+		&result,
+	))
+
+	followers, following := h.followCounts(followerID, userID)
+	if expectedErrorCode != "" {
+		require.Equal(t, oldFollowers, followers)
+		require.Equal(t, oldFollowing, following)
+		return nil
+	}
+
+	require.NotNil(t, result.UnfollowUser)
+	require.Equal(t, userID, *result.UnfollowUser.ID)
+	require.Equal(t, oldFollowers-1, followers)
+	require.Equal(t, oldFollowing-1, following)
+
+	return result.UnfollowUser
+}
+
+// UnfollowUser helps make a user stop following another user
+// and assumes success
+func (ok AssumeSuccess) UnfollowUser(
+	followerID store.ID,
+	userID store.ID,
+) *gqlmod.User {
+	return ok.h.unfollowUser("", followerID, userID)
+}
+
+// UnfollowUser assumes the given error code to be returned
+func (notOk AssumeFailure) UnfollowUser(
+	expectedErrorCode errors.Code,
+	followerID store.ID,
+	userID store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.unfollowUser(expectedErrorCode, followerID, userID)
+}
@@ -0,0 +1,62 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (h Helper) unhideContent(
+	expectedErrorCode errors.Code,
+	subjectID store.ID,
+) {
+	t := h.c.t
+
+	wasHidden := h.isHidden(subjectID)
+
+	var result struct {
+		UnhideContent map[string]interface{} `json:"unhideContent"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation ($subject: Identifier!) {
+			unhideContent(subject: $subject) {
+				... on Post {
+					id
+					hidden
+				}
+				... on Reaction {
+					id
+					hidden
+				}
+			}
+		}`,
+		map[string]interface{}{
+			"subject": string(subjectID),
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		require.Equal(t, wasHidden, h.isHidden(subjectID))
+		return
+	}
+
+	require.NotNil(t, result.UnhideContent)
+	require.Equal(t, string(subjectID), result.UnhideContent["id"])
+	require.Equal(t, false, result.UnhideContent["hidden"])
+	require.False(t, h.isHidden(subjectID))
+}
+
+// UnhideContent helps unhide a hidden post or reaction and assumes success
+func (ok AssumeSuccess) UnhideContent(subjectID store.ID) {
+	ok.h.unhideContent("", subjectID)
+}
+
+// UnhideContent assumes the given error code to be returned
+func (notOk AssumeFailure) UnhideContent(
+	expectedErrorCode errors.Code,
+	subjectID store.ID,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.unhideContent(expectedErrorCode, subjectID)
+}
@@ -0,0 +1,51 @@
+package setup
+
+import (
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+func (h Helper) verifyEmail(
+	expectedErrorCode errors.Code,
+	token string,
+) bool {
+	t := h.c.t
+
+	var result struct {
+		VerifyEmail bool `json:"verifyEmail"`
+	}
+	checkErr(t, expectedErrorCode, h.c.QueryVar(
+		`mutation (
+			$token: String!
+		) {
+			verifyEmail(
+				token: $token
+			)
+		}`,
+		map[string]interface{}{
+			"token": token,
+		},
+		&result,
+	))
+
+	if expectedErrorCode != "" {
+		return false
+	}
+
+	return result.VerifyEmail
+}
+
+// VerifyEmail helps verifying an email address and assumes success
+func (ok AssumeSuccess) VerifyEmail(
+	token string,
+) bool {
+	return ok.h.verifyEmail("", token)
+}
+
+// VerifyEmail assumes the given error code to be returned
+func (notOk AssumeFailure) VerifyEmail(
+	expectedErrorCode errors.Code,
+	token string,
+) {
+	notOk.checkErrCode(expectedErrorCode)
+	notOk.h.verifyEmail(expectedErrorCode, token)
+}
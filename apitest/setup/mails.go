@@ -0,0 +1,76 @@
+package setup
+
+import (
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	"github.com/stretchr/testify/require"
+)
+
+var mailedTokenPattern = regexp.MustCompile(`(?m)^[A-Za-z0-9_\-]{64}$`)
+
+// mailSubjects maps the token kinds to the subjects of the mails
+// the tokens are sent in
+var mailSubjects = map[token.Kind]string{
+	token.PasswordReset:     "Reset your password",
+	token.EmailVerification: "Verify your email address",
+}
+
+// mailTimeout defines how long to wait for mails delivered in the background
+const mailTimeout = 5 * time.Second
+
+// Mails returns the mails sent to the given recipient in order of delivery
+func (ts *TestSetup) Mails(to string) []mailer.Mail {
+	file, err := os.Open(ts.mailLog.Name())
+	require.NoError(ts.t, err)
+	defer file.Close()
+
+	mails, err := mailer.ReadLog(file)
+	require.NoError(ts.t, err)
+
+	var received []mailer.Mail
+	for _, mail := range mails {
+		if mail.To == to {
+			received = append(received, mail)
+		}
+	}
+	return received
+}
+
+// MailedToken returns the token contained in the last mail of the given kind
+// sent to the given recipient. Mails may be delivered in the background,
+// MailedToken therefore waits for a mail of the kind sent after the one
+// the previously returned token of the kind was read from
+func (ts *TestSetup) MailedToken(to string, kind token.Kind) string {
+	// tokenMails returns the mails of the kind sent to the recipient
+	tokenMails := func() (mails []mailer.Mail) {
+		for _, mail := range ts.Mails(to) {
+			if mail.Subject == mailSubjects[kind] {
+				mails = append(mails, mail)
+			}
+		}
+		return
+	}
+
+	read := ts.readMails[to+"/"+string(kind)]
+	deadline := time.Now().Add(mailTimeout)
+	mails := tokenMails()
+	for len(mails) <= read && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		mails = tokenMails()
+	}
+	require.True(
+		ts.t,
+		len(mails) > read,
+		"no new %s mails sent to %s",
+		kind,
+		to,
+	)
+	ts.readMails[to+"/"+string(kind)] = len(mails)
+	token := mailedTokenPattern.FindString(mails[len(mails)-1].Body)
+	require.NotEmpty(ts.t, token, "no token mailed to %s", to)
+	return token
+}
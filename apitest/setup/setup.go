@@ -3,13 +3,18 @@ package setup
 import (
 	"context"
 	ctx "context"
+	"io/ioutil"
+	"net/http"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/dgraph-io/dgo"
 	dbapi "github.com/dgraph-io/dgo/protos/api"
 	"github.com/romshark/dgraph_graphql_go/api"
+	"github.com/romshark/dgraph_graphql_go/api/clock"
 	"github.com/romshark/dgraph_graphql_go/api/config"
+	"github.com/romshark/dgraph_graphql_go/api/mailer"
 	trn "github.com/romshark/dgraph_graphql_go/api/transport"
 	thttp "github.com/romshark/dgraph_graphql_go/api/transport/http"
 	"github.com/stretchr/testify/require"
@@ -18,9 +23,16 @@ import (
 
 // TestContext represents a test context
 type TestContext struct {
-	Stats   *StatisticsRecorder
-	DBHost  string
-	SrvHost string
+	Stats         *StatisticsRecorder
+	DBDriver      config.DBDriver
+	DBHost        string
+	SrvHost       string
+	Session       config.SessionConfig
+	Token         config.TokenConfig
+	DebugUserMode config.DebugUserMode
+
+	// Clock defaults to the system clock
+	Clock clock.Clock
 }
 
 // TestSetup represents the Dgraph-based server setup of an individual test
@@ -31,6 +43,12 @@ type TestSetup struct {
 	serverTransport trn.Server
 	debugUsername   string
 	debugPassword   string
+	shieldRoles     []config.ShieldClientRole
+	mailLog         *os.File
+
+	// readMails counts the mails per recipient and token kind
+	// the returned mailed tokens were read from
+	readMails map[string]int
 }
 
 // T returns the test reference
@@ -46,14 +64,17 @@ func New(t *testing.T, context TestContext) *TestSetup {
 	debugPassword := "test"
 
 	// Clear database
-	conn, err := grpc.Dial(context.DBHost, grpc.WithInsecure())
-	require.NoError(t, err)
-	db := dgo.NewDgraphClient(dbapi.NewDgraphClient(conn))
-	require.NoError(t, db.Alter(
-		ctx.Background(),
-		&dbapi.Operation{DropAll: true},
-	))
-	require.NoError(t, conn.Close())
+	// (the in-memory database is always empty on startup)
+	if context.DBDriver != config.DBDriverMemory {
+		conn, err := grpc.Dial(context.DBHost, grpc.WithInsecure())
+		require.NoError(t, err)
+		db := dgo.NewDgraphClient(dbapi.NewDgraphClient(conn))
+		require.NoError(t, db.Alter(
+			ctx.Background(),
+			&dbapi.Operation{DropAll: true},
+		))
+		require.NoError(t, conn.Close())
+	}
 
 	serverTransport, err := thttp.NewServer(thttp.ServerConfig{
 		Host:       context.SrvHost,
@@ -61,18 +82,32 @@ func New(t *testing.T, context TestContext) *TestSetup {
 	})
 	require.NoError(t, err)
 
+	// Log the sent mails to a temporary file
+	mailLog, err := ioutil.TempFile("", "apitest_mails_")
+	require.NoError(t, err)
+
+	// Enable the debug user in read-write mode by default
+	debugUserMode := context.DebugUserMode
+	if debugUserMode == config.DebugUserUnset {
+		debugUserMode = config.DebugUserRW
+	}
+
 	serverConfig := &config.ServerConfig{
-		Mode:   config.ModeDebug,
-		DBHost: context.DBHost,
+		Mode:     config.ModeDebug,
+		DBDriver: context.DBDriver,
+		DBHost:   context.DBHost,
 		DebugUser: config.DebugUserConfig{
-			// Enable the debug user in read-write mode
-			Mode:     config.DebugUserRW,
+			Mode:     debugUserMode,
 			Username: debugUsername,
 			Password: debugPassword,
 		},
 		Shield: config.ShieldConfig{
 			WhitelistEnabled: false,
 		},
+		Session: context.Session,
+		Token:   context.Token,
+		Mailer:  mailer.NewLog(mailLog),
+		Clock:   context.Clock,
 		Transport: []trn.Server{
 			serverTransport,
 		},
@@ -89,6 +124,9 @@ func New(t *testing.T, context TestContext) *TestSetup {
 		serverTransport: serverTransport,
 		debugUsername:   debugUsername,
 		debugPassword:   debugPassword,
+		shieldRoles:     serverConfig.Shield.ClientRoles,
+		mailLog:         mailLog,
+		readMails:       make(map[string]int),
 	}
 
 	// Record setup time
@@ -99,6 +137,11 @@ func New(t *testing.T, context TestContext) *TestSetup {
 	return testSetup
 }
 
+// Host returns the host address of the API server
+func (ts *TestSetup) Host() string {
+	return ts.serverTransport.(*thttp.Server).Addr().Host
+}
+
 // Teardown gracefully terminates the test,
 // this method MUST BE DEFERRED until the end of the test!
 func (ts *TestSetup) Teardown() {
@@ -110,6 +153,18 @@ func (ts *TestSetup) Teardown() {
 		ts.t.Errorf("API server shutdown: %s", err)
 	}
 
+	// Remove the mail log
+	if err := ts.mailLog.Close(); err != nil {
+		ts.t.Errorf("mail log closure: %s", err)
+	}
+	if err := os.Remove(ts.mailLog.Name()); err != nil {
+		ts.t.Errorf("mail log removal: %s", err)
+	}
+
+	// Drop pooled keep-alive connections to the terminated server,
+	// otherwise they'd be reused by the clients of the next test
+	http.DefaultTransport.(*http.Transport).CloseIdleConnections()
+
 	// Record teardown time
 	ts.stats.Set(ts.t, func(stat *TestStatistics) {
 		stat.TeardownTime = time.Since(start)
@@ -0,0 +1,46 @@
+package setup
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/config"
+	thttp "github.com/romshark/dgraph_graphql_go/api/transport/http"
+	"github.com/stretchr/testify/require"
+)
+
+// Shield creates a new GraphQL shield administration client
+// signed in as the debug user
+func (ts *TestSetup) Shield() *thttp.ShieldClient {
+	clt := ts.ShieldGuest()
+	require.NoError(ts.t, clt.SignInDebug(ts.debugUsername, ts.debugPassword))
+	return clt
+}
+
+// ShieldGuest creates a new unauthenticated
+// GraphQL shield administration client
+func (ts *TestSetup) ShieldGuest() *thttp.ShieldClient {
+	clt, err := thttp.NewShieldClient(
+		url.URL{
+			Scheme: "http",
+			Host:   ts.Host(),
+		},
+		thttp.ClientConfig{
+			Timeout: time.Second * 10,
+		},
+	)
+	require.NoError(ts.t, err)
+	return clt
+}
+
+// ShieldClientRole returns the identifier of the GraphQL shield client role
+// assigned to the given clients
+func (ts *TestSetup) ShieldClientRole(assignee config.ShieldRoleAssignee) int {
+	for _, clientRole := range ts.shieldRoles {
+		if clientRole.Assignee == assignee {
+			return clientRole.ID
+		}
+	}
+	ts.t.Fatalf("no shield client role assigned to '%s'", assignee)
+	return 0
+}
@@ -0,0 +1,100 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/api/config"
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+	thttp "github.com/romshark/dgraph_graphql_go/api/transport/http"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShieldAdmin tests the GraphQL shield administration endpoints
+func TestShieldAdmin(t *testing.T) {
+	// newEntry returns a whitelist entry for the debug user
+	newEntry := func(ts *setup.TestSetup) thttp.ShieldEntry {
+		return thttp.ShieldEntry{
+			Name:  "users",
+			Query: `query { users { edges { node { id } } } }`,
+			Parameters: map[string]gqlshield.Parameter{
+				"first": gqlshield.Parameter{MaxValueLength: 8},
+			},
+			WhitelistedFor: []int{
+				ts.ShieldClientRole(config.ShieldRoleAssigneeDebug),
+			},
+		}
+	}
+
+	t.Run("manage", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+		shield := ts.Shield()
+		entry := newEntry(ts)
+
+		// Whitelist
+		added, err := shield.WhitelistQueries(entry)
+		require.NoError(t, err)
+		require.Len(t, added, 1)
+		require.Equal(t, entry.Name, added[0].Name)
+		require.Equal(t, entry.Parameters, added[0].Parameters)
+		require.Equal(t, entry.WhitelistedFor, added[0].WhitelistedFor)
+
+		// Expect duplicates to be rejected
+		_, err = shield.WhitelistQueries(entry)
+		require.Error(t, err)
+
+		// List
+		listed, err := shield.ListQueries()
+		require.NoError(t, err)
+		require.Equal(t, added, listed)
+
+		// Update roles
+		roles := []int{
+			ts.ShieldClientRole(config.ShieldRoleAssigneeGuest),
+			ts.ShieldClientRole(config.ShieldRoleAssignee(role.User)),
+		}
+		updated, err := shield.UpdateQueryRoles(entry.Name, roles)
+		require.NoError(t, err)
+		require.Equal(t, added[0].ID, updated.ID)
+		require.Equal(t, roles, updated.WhitelistedFor)
+
+		_, err = shield.UpdateQueryRoles(entry.Name, []int{999})
+		require.Error(t, err)
+
+		// Remove
+		require.NoError(t, shield.RemoveQuery(entry.Name))
+		require.Error(t, shield.RemoveQuery(entry.Name))
+
+		listed, err = shield.ListQueries()
+		require.NoError(t, err)
+		require.Len(t, listed, 0)
+	})
+
+	// Test accessing the endpoints as a guest
+	t.Run("guest (noauth)", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+		shield := ts.ShieldGuest()
+
+		_, err := shield.ListQueries()
+		require.Error(t, err)
+		_, err = shield.WhitelistQueries(newEntry(ts))
+		require.Error(t, err)
+	})
+
+	// Test modifying the whitelist as a read-only debug user
+	t.Run("read-only debug (noauth)", func(t *testing.T) {
+		ctx := tcx
+		ctx.DebugUserMode = config.DebugUserReadOnly
+		ts := setup.New(t, ctx)
+		defer ts.Teardown()
+		shield := ts.Shield()
+
+		_, err := shield.ListQueries()
+		require.NoError(t, err)
+		_, err = shield.WhitelistQueries(newEntry(ts))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,178 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/romshark/dgraph_graphql_go/api/graph"
+	"github.com/romshark/dgraph_graphql_go/api/graph/gqlmod"
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSubscription tests graph subscriptions
+func TestSubscription(t *testing.T) {
+	setupTest := func(t *testing.T) (
+		ts *setup.TestSetup,
+		usr *gqlmod.User,
+		clt *setup.Client,
+	) {
+		ts = setup.New(t, tcx)
+		usr = ts.Debug().Help.OK.CreateUser("usr", "t1@te.te", "testpass")
+		clt, _ = ts.Client("t1@te.te", "testpass")
+		return
+	}
+
+	// Test receiving newly created posts
+	t.Run("postCreated", func(t *testing.T) {
+		ts, usr, clt := setupTest(t)
+		defer ts.Teardown()
+
+		sub, err := ts.Guest().Subscribe(
+			`subscription {
+				postCreated {
+					id
+					title
+					author { id }
+				}
+			}`,
+			nil,
+		)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		post := clt.Help.OK.CreatePost(*usr.ID, "new post", "contents")
+
+		var event struct {
+			PostCreated *gqlmod.Post `json:"postCreated"`
+		}
+		require.NoError(t, sub.Next(&event))
+		require.NotNil(t, event.PostCreated)
+		require.Equal(t, *post.ID, *event.PostCreated.ID)
+		require.Equal(t, "new post", *event.PostCreated.Title)
+		require.Equal(t, *usr.ID, *event.PostCreated.Author.ID)
+	})
+
+	// Test receiving reactions to a particular subject only
+	t.Run("reactionCreated", func(t *testing.T) {
+		ts, usr, clt := setupTest(t)
+		defer ts.Teardown()
+
+		postA := clt.Help.OK.CreatePost(*usr.ID, "post A", "contents")
+		postB := clt.Help.OK.CreatePost(*usr.ID, "post B", "contents")
+
+		sub, err := clt.Subscribe(
+			`subscription($subject: Identifier!) {
+				reactionCreated(subject: $subject) {
+					id
+					message
+				}
+			}`,
+			map[string]interface{}{
+				"subject": string(*postA.ID),
+			},
+		)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		clt.Help.OK.CreateReaction(*usr.ID, *postB.ID, emotion.Happy, "to B")
+		reaction := clt.Help.OK.CreateReaction(
+			*usr.ID,
+			*postA.ID,
+			emotion.Happy,
+			"to A",
+		)
+
+		var event struct {
+			ReactionCreated *gqlmod.Reaction `json:"reactionCreated"`
+		}
+		require.NoError(t, sub.Next(&event))
+		require.NotNil(t, event.ReactionCreated)
+		require.Equal(t, *reaction.ID, *event.ReactionCreated.ID)
+		require.Equal(t, "to A", *event.ReactionCreated.Message)
+	})
+
+	// Test receiving edits of a particular post only
+	t.Run("postEdited", func(t *testing.T) {
+		ts, usr, clt := setupTest(t)
+		defer ts.Teardown()
+
+		postA := clt.Help.OK.CreatePost(*usr.ID, "post A", "contents")
+		postB := clt.Help.OK.CreatePost(*usr.ID, "post B", "contents")
+
+		sub, err := ts.Guest().Subscribe(
+			`subscription($id: Identifier!) {
+				postEdited(id: $id) {
+					id
+					title
+				}
+			}`,
+			map[string]interface{}{
+				"id": string(*postA.ID),
+			},
+		)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		newTitleB := "edited B"
+		clt.Help.OK.EditPost(*postB.ID, *usr.ID, &newTitleB, nil)
+		newTitleA := "edited A"
+		clt.Help.OK.EditPost(*postA.ID, *usr.ID, &newTitleA, nil)
+
+		var event struct {
+			PostEdited *gqlmod.Post `json:"postEdited"`
+		}
+		require.NoError(t, sub.Next(&event))
+		require.NotNil(t, event.PostEdited)
+		require.Equal(t, *postA.ID, *event.PostEdited.ID)
+		require.Equal(t, newTitleA, *event.PostEdited.Title)
+	})
+
+	// Test initializing a connection repeatedly
+	t.Run("repeated init", func(t *testing.T) {
+		ts, _, _ := setupTest(t)
+		defer ts.Teardown()
+
+		conn, _, err := (&websocket.Dialer{
+			Subprotocols: []string{"graphql-ws"},
+		}).Dial("ws://"+ts.Host()+"/ws", nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var msg struct {
+			Type    string `json:"type"`
+			Payload struct {
+				Message string `json:"message"`
+			} `json:"payload"`
+		}
+		initMsg := map[string]string{"type": "connection_init"}
+
+		require.NoError(t, conn.WriteJSON(initMsg))
+		require.NoError(t, conn.ReadJSON(&msg))
+		require.Equal(t, "connection_ack", msg.Type)
+
+		// The second initialization is rejected and the connection closed
+		require.NoError(t, conn.WriteJSON(initMsg))
+		require.NoError(t, conn.ReadJSON(&msg))
+		require.Equal(t, "connection_error", msg.Type)
+		require.Equal(t, "connection already initialized", msg.Payload.Message)
+		require.Error(t, conn.ReadJSON(&msg))
+	})
+
+	// Test subscribing through the query endpoint
+	t.Run("query endpoint", func(t *testing.T) {
+		ts, _, _ := setupTest(t)
+		defer ts.Teardown()
+
+		err := ts.Guest().Query(`subscription { postCreated { id } }`, nil)
+		require.Error(t, err)
+		require.IsType(t, &graph.ResponseError{}, err)
+		require.Equal(
+			t,
+			string(errors.ErrInvalidInput),
+			err.(*graph.ResponseError).Code,
+		)
+	})
+}
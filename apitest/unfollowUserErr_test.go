@@ -0,0 +1,35 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestUnfollowUserErr tests all possible user unfollowing errors
+func TestUnfollowUserErr(t *testing.T) {
+	ts := setup.New(t, tcx)
+	defer ts.Teardown()
+
+	debug := ts.Debug()
+	follower := debug.Help.OK.CreateUser("follower", "1@tst.tst", "testpass")
+	user := debug.Help.OK.CreateUser("followee", "2@tst.tst", "testpass")
+
+	t.Run("notFollowing", func(t *testing.T) {
+		debug.Help.ERR.UnfollowUser(
+			errors.ErrInvalidInput,
+			*follower.ID,
+			*user.ID,
+		)
+	})
+
+	t.Run("inexistentUser", func(t *testing.T) {
+		debug.Help.ERR.UnfollowUser(
+			errors.ErrInvalidInput,
+			*follower.ID,
+			store.NewID(), // Inexistent user
+		)
+	})
+}
@@ -0,0 +1,64 @@
+package apitest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	"github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TestVerifyEmailErr tests email verification errors
+func TestVerifyEmailErr(t *testing.T) {
+	// Test verifying using an unknown token
+	t.Run("invalidToken", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		ts.Guest().Help.ERR.VerifyEmail(errors.ErrInvalidInput, "invalid")
+	})
+
+	// Test verifying using an already used token
+	t.Run("reusedToken", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+		key := ts.MailedToken(email, token.EmailVerification)
+
+		ts.Guest().Help.OK.VerifyEmail(key)
+		ts.Guest().Help.ERR.VerifyEmail(errors.ErrInvalidInput, key)
+	})
+
+	// Test verifying using a token issued for the replaced email
+	t.Run("replacedEmail", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		oldEmail := "1@tst.tst"
+		user := ts.Debug().Help.OK.CreateUser("user", oldEmail, "testpass")
+		userClt, _ := ts.Client(oldEmail, "testpass")
+		key := ts.MailedToken(oldEmail, token.EmailVerification)
+
+		newEmail := "new@tst.tst"
+		userClt.Help.OK.EditUser(*user.ID, *user.ID, &newEmail, nil)
+		ts.Guest().Help.ERR.VerifyEmail(errors.ErrInvalidInput, key)
+	})
+
+	// Test verifying using an expired token
+	t.Run("expiredToken", func(t *testing.T) {
+		context := tcx
+		context.Token.EmailVerificationTTL = 500 * time.Millisecond
+		ts := setup.New(t, context)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+		key := ts.MailedToken(email, token.EmailVerification)
+
+		time.Sleep(600 * time.Millisecond)
+		ts.Guest().Help.ERR.VerifyEmail(errors.ErrInvalidInput, key)
+	})
+}
@@ -0,0 +1,76 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/romshark/dgraph_graphql_go/apitest/setup"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyEmail tests email address verification
+func TestVerifyEmail(t *testing.T) {
+	// emailVerified queries User.emailVerified as the given user
+	emailVerified := func(
+		t *testing.T,
+		clt *setup.Client,
+		userID store.ID,
+	) bool {
+		var result struct {
+			User struct {
+				EmailVerified bool `json:"emailVerified"`
+			} `json:"user"`
+		}
+		require.NoError(t, clt.QueryVar(
+			`query($userID: Identifier!) {
+				user(id: $userID) {
+					emailVerified
+				}
+			}`,
+			map[string]interface{}{
+				"userID": string(userID),
+			},
+			&result,
+		))
+		return result.User.EmailVerified
+	}
+
+	t.Run("verify", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		email := "1@tst.tst"
+		user := ts.Debug().Help.OK.CreateUser("user", email, "testpass")
+		userClt, _ := ts.Client(email, "testpass")
+		require.False(t, emailVerified(t, userClt, *user.ID))
+
+		mails := ts.Mails(email)
+		require.Len(t, mails, 1)
+		require.Equal(t, "Verify your email address", mails[0].Subject)
+
+		key := ts.MailedToken(email, token.EmailVerification)
+		require.True(t, ts.Guest().Help.OK.VerifyEmail(key))
+		require.True(t, emailVerified(t, userClt, *user.ID))
+	})
+
+	// Test changing the email resetting its verification
+	t.Run("emailChange", func(t *testing.T) {
+		ts := setup.New(t, tcx)
+		defer ts.Teardown()
+
+		oldEmail := "1@tst.tst"
+		user := ts.Debug().Help.OK.CreateUser("user", oldEmail, "testpass")
+		userClt, _ := ts.Client(oldEmail, "testpass")
+		oldToken := ts.MailedToken(oldEmail, token.EmailVerification)
+		ts.Guest().Help.OK.VerifyEmail(oldToken)
+
+		newEmail := "new@tst.tst"
+		userClt.Help.OK.EditUser(*user.ID, *user.ID, &newEmail, nil)
+		require.False(t, emailVerified(t, userClt, *user.ID))
+
+		newToken := ts.MailedToken(newEmail, token.EmailVerification)
+		ts.Guest().Help.OK.VerifyEmail(newToken)
+		require.True(t, emailVerified(t, userClt, *user.ID))
+	})
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/dataset"
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+)
+
+var argHost = flag.String("host", "localhost:10180", "database host address")
+
+const usage = `usage: datactl [flags] <command> [arguments]
+
+commands:
+  export [-sessions] [<file>]
+	writes the data set to the given file (stdout if omitted or "-"),
+	sessions are exported only if -sessions is set
+  validate <file>
+	verifies the data set read from the given file ("-" for stdin)
+  import <file>
+	verifies the data set read from the given file
+	and imports it into the database
+
+Data sets contain the password hashes of all users
+and must be handled confidentially.
+
+flags:
+`
+
+func printStats(action string, stats dataset.Stats) {
+	fmt.Fprintf(
+		os.Stderr,
+		"%s %d users, %d follows, %d posts, %d reactions, %d sessions\n",
+		action,
+		stats.Users,
+		stats.Follows,
+		stats.Posts,
+		stats.Reactions,
+		stats.Sessions,
+	)
+}
+
+// openStore connects to the database and migrates its schema
+func openStore() store.Store {
+	str := dgraph.NewStore(
+		*argHost,
+		dgraph.RetryConfig{},
+		func(password, hash string) bool { return false },
+		log.New(ioutil.Discard, "", 0),
+		log.New(os.Stderr, "ERR: ", log.Ldate|log.Ltime),
+	)
+	if err := str.Prepare(); err != nil {
+		log.Fatalf("preparing store: %s", err)
+	}
+	return str
+}
+
+func export(ctx context.Context, args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	sessions := flags.Bool("sessions", false, "export sessions")
+	if err := flags.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	var out io.Writer = os.Stdout
+	if path := flags.Arg(0); path != "" && path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			log.Fatalf("creating file: %s", err)
+		}
+		defer func() {
+			if err := file.Close(); err != nil {
+				log.Fatalf("closing file: %s", err)
+			}
+		}()
+		out = file
+	}
+
+	stats, err := dataset.Export(ctx, openStore(), out, dataset.ExportOptions{
+		Sessions: *sessions,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	printStats("exported", stats)
+}
+
+func validate(path string) {
+	in := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("opening file: %s", err)
+		}
+		defer file.Close()
+		in = file
+	}
+	stats, err := dataset.Validate(in)
+	if err != nil {
+		log.Fatalf("invalid data set: %s", err)
+	}
+	printStats("validated", stats)
+}
+
+func importFile(ctx context.Context, path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("opening file: %s", err)
+	}
+	defer file.Close()
+
+	// Validate the entire data set before importing anything
+	if _, err := dataset.Validate(file); err != nil {
+		log.Fatalf("invalid data set: %s", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		log.Fatalf("rewinding file: %s", err)
+	}
+
+	stats, err := dataset.Import(ctx, openStore(), file)
+	printStats("imported", stats)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	ctx := context.Background()
+
+	switch cmd := flag.Arg(0); cmd {
+	case "export":
+		export(ctx, flag.Args()[1:])
+
+	case "validate":
+		if flag.NArg() != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		validate(flag.Arg(1))
+
+	case "import":
+		if flag.NArg() != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		importFile(ctx, flag.Arg(1))
+
+	default:
+		log.Fatalf("unknown command: %s", cmd)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/dgraph-io/dgo"
+	dbapi "github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	"google.golang.org/grpc"
+)
+
+var argHost = flag.String("host", "localhost:10180", "database host address")
+
+const usage = `usage: migrate [flags] <command>
+
+commands:
+  up
+	applies all pending migrations
+  status
+	prints the database schema version and the pending migrations
+
+flags:
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	conn, err := grpc.Dial(*argHost, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("gRPC dial: %s", err)
+	}
+	defer conn.Close()
+	db := dgo.NewDgraphClient(dbapi.NewDgraphClient(conn))
+	ctx := context.Background()
+
+	switch cmd := flag.Arg(0); cmd {
+	case "up":
+		applied := 0
+		if err := dgraph.MigrateUp(ctx, db, func(m dgraph.Migration) {
+			applied++
+			fmt.Printf("applied %d: %s\n", m.Version, m.Description)
+		}); err != nil {
+			log.Fatal(err)
+		}
+		if applied < 1 {
+			fmt.Println("no pending migrations")
+		}
+
+	case "status":
+		version, err := dgraph.SchemaVersion(ctx, db)
+		if err != nil {
+			log.Fatalf("reading schema version: %s", err)
+		}
+		latest := dgraph.LatestSchemaVersion()
+		fmt.Printf("database version: %d\n", version)
+		fmt.Printf("binary version:   %d\n", latest)
+		if version > latest {
+			fmt.Println("the database is ahead of this binary")
+			os.Exit(1)
+		}
+		for _, m := range dgraph.Migrations() {
+			state := "applied"
+			if m.Version > version {
+				state = "pending"
+			}
+			fmt.Printf("  %d %s: %s\n", m.Version, state, m.Description)
+		}
+
+	default:
+		log.Fatalf("unknown command: %s", cmd)
+	}
+}
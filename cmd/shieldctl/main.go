@@ -0,0 +1,207 @@
+package main
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/gqlshield"
+	thttp "github.com/romshark/dgraph_graphql_go/api/transport/http"
+)
+
+var argHost = flag.String(
+	"host",
+	"https://localhost:16000",
+	"API server address",
+)
+var argUsername = flag.String("username", "debug", "debug user name")
+var argPassword = flag.String("password", "debug", "debug user password")
+var argInsecure = flag.Bool(
+	"insecure",
+	false,
+	"skip the TLS certificate verification",
+)
+
+const usage = `usage: shieldctl [flags] <command> [arguments]
+
+commands:
+  list
+	lists all whitelisted queries
+  add -name <name> -roles <ids> [-param <name>=<definition>]... <query file>
+	whitelists the query read from the given file ("-" for stdin),
+	a parameter is defined by either the max length of a string,
+	a scalar type (Int, Float, Boolean) or a JSON encoded parameter
+  remove <name>
+	removes a query from the whitelist
+  roles <name> <ids>
+	replaces the roles a query is whitelisted for
+
+flags:
+`
+
+// params represents a repeatable query parameter flag
+type params map[string]gqlshield.Parameter
+
+func (p params) String() string {
+	return fmt.Sprintf("%v", map[string]gqlshield.Parameter(p))
+}
+
+func (p params) Set(value string) error {
+	s := strings.SplitN(value, "=", 2)
+	if len(s) != 2 {
+		return fmt.Errorf("invalid parameter: %s", value)
+	}
+	definition := s[1]
+	if maxLen, err := strconv.ParseUint(definition, 10, 32); err == nil {
+		p[s[0]] = gqlshield.Parameter{MaxValueLength: uint32(maxLen)}
+		return nil
+	}
+	if strings.HasPrefix(definition, "{") {
+		var param gqlshield.Parameter
+		if err := json.Unmarshal([]byte(definition), &param); err != nil {
+			return fmt.Errorf("invalid parameter definition: %s", definition)
+		}
+		p[s[0]] = param
+		return nil
+	}
+	p[s[0]] = gqlshield.Parameter{Type: gqlshield.ParameterType(definition)}
+	return nil
+}
+
+// parseRoles parses a comma-separated list of role identifiers
+func parseRoles(value string) ([]int, error) {
+	var roles []int
+	for _, s := range strings.Split(value, ",") {
+		role, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("invalid role ID: %s", s)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// printJSON prints v as indented JSON to stdout
+func printJSON(v interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		log.Fatalf("encoding output: %s", err)
+	}
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	host, err := url.Parse(*argHost)
+	if err != nil {
+		log.Fatalf("invalid host: %s", err)
+	}
+
+	clientConfig := thttp.ClientConfig{
+		Timeout: 30 * time.Second,
+	}
+	if *argInsecure {
+		clientConfig.TLS = &tls.Config{InsecureSkipVerify: true}
+	}
+	clt, err := thttp.NewShieldClient(*host, clientConfig)
+	if err != nil {
+		log.Fatalf("client init: %s", err)
+	}
+	if err := clt.SignInDebug(*argUsername, *argPassword); err != nil {
+		log.Fatalf("debug sign in: %s", err)
+	}
+
+	args := flag.Args()[1:]
+	switch cmd := flag.Arg(0); cmd {
+	case "list":
+		queries, err := clt.ListQueries()
+		if err != nil {
+			log.Fatal(err)
+		}
+		printJSON(queries)
+
+	case "add":
+		flags := flag.NewFlagSet("add", flag.ExitOnError)
+		name := flags.String("name", "", "query name")
+		roles := flags.String("roles", "", "comma-separated role IDs")
+		parameters := params{}
+		flags.Var(parameters, "param", "query parameter (name=definition)")
+		if err := flags.Parse(args); err != nil {
+			log.Fatal(err)
+		}
+		if flags.NArg() != 1 {
+			log.Fatal("missing query file")
+		}
+
+		whitelistedFor, err := parseRoles(*roles)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var query []byte
+		if flags.Arg(0) == "-" {
+			query, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			query, err = ioutil.ReadFile(flags.Arg(0))
+		}
+		if err != nil {
+			log.Fatalf("reading query: %s", err)
+		}
+
+		entry := thttp.ShieldEntry{
+			Name:           *name,
+			Query:          string(query),
+			WhitelistedFor: whitelistedFor,
+		}
+		if len(parameters) > 0 {
+			entry.Parameters = parameters
+		}
+		queries, err := clt.WhitelistQueries(entry)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printJSON(queries)
+
+	case "remove":
+		if len(args) != 1 {
+			log.Fatal("expected exactly one query name")
+		}
+		if err := clt.RemoveQuery(args[0]); err != nil {
+			log.Fatal(err)
+		}
+
+	case "roles":
+		if len(args) != 2 {
+			log.Fatal("expected a query name and a list of role IDs")
+		}
+		whitelistedFor, err := parseRoles(args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		query, err := clt.UpdateQueryRoles(args[0], whitelistedFor)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printJSON(query)
+
+	default:
+		log.Fatalf("unknown command: %s", cmd)
+	}
+}
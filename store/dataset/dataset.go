@@ -0,0 +1,99 @@
+// Package dataset implements the export and import of the data of a store
+// as versioned JSON Lines. A data set starts with a header record
+// followed by one record per line in the order users, follows, posts,
+// reactions and (optionally) sessions. Entities are referenced by their identifiers
+// and must be defined before they're referenced
+package dataset
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+const (
+	// Format identifies data set files
+	Format = "dgraph_graphql_go/dataset"
+
+	// Version defines the version of the data set format
+	// written and read by this package
+	Version = 3
+)
+
+// Header represents the first record of a data set
+type Header struct {
+	Format   string    `json:"format"`
+	Version  int       `json:"version"`
+	Creation time.Time `json:"creation"`
+}
+
+// User represents an exported user,
+// users without roles are imported as regular users
+type User struct {
+	ID            store.ID    `json:"id"`
+	Creation      time.Time   `json:"creation"`
+	Email         string      `json:"email"`
+	EmailVerified bool        `json:"emailVerified,omitempty"`
+	DisplayName   string      `json:"displayName"`
+	PasswordHash  string      `json:"passwordHash"`
+	Roles         []role.Role `json:"roles,omitempty"`
+}
+
+// Follow represents an exported following relation between two users
+type Follow struct {
+	Follower store.ID `json:"follower"`
+	Followee store.ID `json:"followee"`
+}
+
+// Post represents an exported post,
+// hidden posts were hidden by the moderators
+type Post struct {
+	ID       store.ID  `json:"id"`
+	Creation time.Time `json:"creation"`
+	Author   store.ID  `json:"author"`
+	Title    string    `json:"title"`
+	Contents string    `json:"contents"`
+	Hidden   bool      `json:"hidden,omitempty"`
+}
+
+// Reaction represents an exported reaction,
+// its subject is either a post or a reaction
+type Reaction struct {
+	ID       store.ID        `json:"id"`
+	Creation time.Time       `json:"creation"`
+	Author   store.ID        `json:"author"`
+	Subject  store.ID        `json:"subject"`
+	Emotion  emotion.Emotion `json:"emotion"`
+	Message  string          `json:"message"`
+	Hidden   bool            `json:"hidden,omitempty"`
+}
+
+// Session represents an exported session
+type Session struct {
+	Key        string    `json:"key"`
+	Creation   time.Time `json:"creation"`
+	LastAccess time.Time `json:"lastAccess"`
+	User       store.ID  `json:"user"`
+}
+
+// Record represents a single line of a data set,
+// exactly one of its fields is set
+type Record struct {
+	Header   *Header   `json:"header,omitempty"`
+	User     *User     `json:"user,omitempty"`
+	Follow   *Follow   `json:"follow,omitempty"`
+	Post     *Post     `json:"post,omitempty"`
+	Reaction *Reaction `json:"reaction,omitempty"`
+	Session  *Session  `json:"session,omitempty"`
+}
+
+// Stats represents the number of records of a data set
+type Stats struct {
+	Users     int
+	Follows   int
+	Posts     int
+	Reactions int
+	Sessions  int
+}
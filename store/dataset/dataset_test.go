@@ -0,0 +1,367 @@
+package dataset_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/dataset"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) store.Store {
+	str := memory.NewStore(
+		func(password, hash string) bool { return password == hash },
+		log.New(ioutil.Discard, "", 0),
+		log.New(ioutil.Discard, "", 0),
+	)
+	require.NoError(t, str.Prepare())
+	return str
+}
+
+// records returns the lines of the data set excluding the header
+func records(t *testing.T, data []byte) []string {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.True(t, len(lines) > 0)
+	return lines[1:]
+}
+
+// TestExportImport tests whether an imported data set
+// is exported identically
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	src := newStore(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	debugCtx := context.WithValue(
+		ctx,
+		auth.CtxSession,
+		&auth.RequestSession{IsDebug: true},
+	)
+
+	usrA, err := src.CreateUser(ctx, now, "a@test.test", "first", "passA")
+	require.NoError(t, err)
+	usrB, err := src.CreateUser(ctx, now, "b@test.test", "second", "passB")
+	require.NoError(t, err)
+	usrC, err := src.ImportUser(
+		ctx,
+		store.NewID(),
+		now,
+		"c@test.test",
+		"third",
+		"passC",
+		nil,
+		true,
+	)
+	require.NoError(t, err)
+	_, err = src.FollowUser(debugCtx, usrA.ID, usrB.ID)
+	require.NoError(t, err)
+	_, err = src.FollowUser(debugCtx, usrB.ID, usrA.ID)
+	require.NoError(t, err)
+	_, err = src.FollowUser(debugCtx, usrC.ID, usrA.ID)
+	require.NoError(t, err)
+	post, err := src.CreatePost(ctx, now, usrA.ID, "title", "contents")
+	require.NoError(t, err)
+	reaction, err := src.CreateReaction(
+		ctx,
+		now.Add(time.Minute),
+		usrB.ID,
+		post.ID,
+		emotion.Happy,
+		"message",
+	)
+	require.NoError(t, err)
+	reply, err := src.CreateReaction(
+		ctx,
+		now.Add(time.Hour),
+		usrA.ID,
+		reaction.ID,
+		emotion.Thoughtful,
+		"reply",
+	)
+	require.NoError(t, err)
+	hiddenPost, err := src.CreatePost(ctx, now, usrB.ID, "hidden", "contents")
+	require.NoError(t, err)
+	_, err = src.HideContent(debugCtx, hiddenPost.ID)
+	require.NoError(t, err)
+	_, err = src.HideContent(debugCtx, reply.ID)
+	require.NoError(t, err)
+	_, err = src.CreateSession(ctx, "key", now, "a@test.test", "passA")
+	require.NoError(t, err)
+
+	// Export with a small page size to test pagination
+	var exported bytes.Buffer
+	stats, err := dataset.Export(ctx, src, &exported, dataset.ExportOptions{
+		Sessions: true,
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, dataset.Stats{
+		Users:     3,
+		Follows:   3,
+		Posts:     2,
+		Reactions: 2,
+		Sessions:  1,
+	}, stats)
+
+	validated, err := dataset.Validate(bytes.NewReader(exported.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, stats, validated)
+
+	dst := newStore(t)
+	imported, err := dataset.Import(
+		ctx,
+		dst,
+		bytes.NewReader(exported.Bytes()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, stats, imported)
+
+	var reexported bytes.Buffer
+	_, err = dataset.Export(ctx, dst, &reexported, dataset.ExportOptions{
+		Sessions: true,
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		records(t, exported.Bytes()),
+		records(t, reexported.Bytes()),
+	)
+
+	// Moderated content remains hidden
+	var hidden struct {
+		Posts []struct {
+			ID string `json:"Post.id"`
+		} `json:"posts"`
+		Reactions []struct {
+			ID string `json:"Reaction.id"`
+		} `json:"reactions"`
+	}
+	require.NoError(t, dst.Query(
+		ctx,
+		`{
+			posts(func: has(Post.hidden)) { Post.id }
+			reactions(func: has(Reaction.hidden)) { Reaction.id }
+		}`,
+		&hidden,
+	))
+	require.Len(t, hidden.Posts, 1)
+	require.Equal(t, string(hiddenPost.ID), hidden.Posts[0].ID)
+	require.Len(t, hidden.Reactions, 1)
+	require.Equal(t, string(reply.ID), hidden.Reactions[0].ID)
+
+	// Verified emails and following relations are preserved
+	var users struct {
+		Verified []struct {
+			ID string `json:"User.id"`
+		} `json:"verified"`
+		Following []struct {
+			ID        string `json:"User.id"`
+			Following []struct {
+				ID string `json:"User.id"`
+			} `json:"User.following"`
+		} `json:"following"`
+	}
+	require.NoError(t, dst.Query(
+		ctx,
+		`{
+			verified(func: has(User.emailVerified)) { User.id }
+			following(func: eq(User.id, "`+string(usrC.ID)+`")) {
+				User.id
+				User.following { User.id }
+			}
+		}`,
+		&users,
+	))
+	require.Len(t, users.Verified, 1)
+	require.Equal(t, string(usrC.ID), users.Verified[0].ID)
+	require.Len(t, users.Following, 1)
+	require.Len(t, users.Following[0].Following, 1)
+	require.Equal(t, string(usrA.ID), users.Following[0].Following[0].ID)
+
+	// Imported users can sign in with their original password
+	_, err = dst.CreateSession(ctx, "key2", now, "b@test.test", "passB")
+	require.NoError(t, err)
+
+	// Sessions are excluded by default
+	var withoutSessions bytes.Buffer
+	stats, err = dataset.Export(
+		ctx,
+		dst,
+		&withoutSessions,
+		dataset.ExportOptions{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 0, stats.Sessions)
+}
+
+// TestValidateErr tests all possible data set validation errors
+func TestValidateErr(t *testing.T) {
+	const (
+		header = `{"header":{"format":"dgraph_graphql_go/dataset","version":3}}`
+		userA  = `{"user":{"id":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+			`"email":"a@test.test","displayName":"a"}}`
+		userB = `{"user":{"id":"cccccccccccccccccccccccccccccccc",` +
+			`"email":"b@test.test","displayName":"b"}}`
+		followBA = `{"follow":{"follower":"cccccccccccccccccccccccccccccccc",` +
+			`"followee":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`
+		postA = `{"post":{"id":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",` +
+			`"author":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`
+	)
+
+	cases := map[string][]string{
+		"empty":         {},
+		"missingHeader": {userA},
+		"unknownFormat": {`{"header":{"format":"unknown","version":3}}`},
+		"unsupportedVersion": {
+			`{"header":{"format":"dgraph_graphql_go/dataset","version":99}}`,
+		},
+		"duplicateHeader": {header, header},
+		"malformed":       {header, `{"user":`},
+		"noEntity":        {header, `{}`},
+		"multipleEntities": {
+			header,
+			`{"user":{"id":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},` +
+				`"session":{"key":"k","user":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`,
+		},
+		"invalidID": {header, `{"user":{"id":"invalid"}}`},
+		"invalidRole": {
+			header,
+			`{"user":{"id":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+				`"email":"a@test.test","displayName":"a","roles":["owner"]}}`,
+		},
+		"duplicateID": {header, userA, userA},
+		"unknownAuthor": {
+			header,
+			`{"post":{"id":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",` +
+				`"author":"cccccccccccccccccccccccccccccccc"}}`,
+		},
+		"authorNotUser": {
+			header,
+			userA,
+			postA,
+			`{"post":{"id":"cccccccccccccccccccccccccccccccc",` +
+				`"author":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}}`,
+		},
+		"subjectDefinedLater": {
+			header,
+			userA,
+			`{"reaction":{"id":"cccccccccccccccccccccccccccccccc",` +
+				`"author":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+				`"subject":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",` +
+				`"emotion":"happy"}}`,
+			postA,
+		},
+		"invalidEmotion": {
+			header,
+			userA,
+			postA,
+			`{"reaction":{"id":"cccccccccccccccccccccccccccccccc",` +
+				`"author":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+				`"subject":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",` +
+				`"emotion":"bored"}}`,
+		},
+		"unknownFollower": {
+			header,
+			userA,
+			`{"follow":{"follower":"cccccccccccccccccccccccccccccccc",` +
+				`"followee":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`,
+		},
+		"followeeNotUser": {
+			header,
+			userA,
+			postA,
+			`{"follow":{"follower":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+				`"followee":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}}`,
+		},
+		"selfFollow": {
+			header,
+			userA,
+			`{"follow":{"follower":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+				`"followee":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`,
+		},
+		"duplicateFollow": {
+			header,
+			userA,
+			userB,
+			followBA,
+			followBA,
+		},
+		"sessionOfUnknownUser": {
+			header,
+			`{"session":{"key":"k","user":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`,
+		},
+		"duplicateSessionKey": {
+			header,
+			userA,
+			`{"session":{"key":"k","user":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`,
+			`{"session":{"key":"k","user":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}}`,
+		},
+	}
+
+	for name, lines := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := strings.Join(lines, "\n")
+			_, err := dataset.Validate(strings.NewReader(data))
+			require.Error(t, err)
+
+			str := newStore(t)
+			_, err = dataset.Import(
+				context.Background(),
+				str,
+				strings.NewReader(data),
+			)
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestImportRoles tests whether users without roles
+// are imported as regular users
+func TestImportRoles(t *testing.T) {
+	ctx := context.Background()
+	str := newStore(t)
+	data := strings.Join([]string{
+		`{"header":{"format":"dgraph_graphql_go/dataset","version":3}}`,
+		`{"user":{"id":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+			`"email":"a@test.test","displayName":"a"}}`,
+		`{"user":{"id":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",` +
+			`"email":"b@test.test","displayName":"b","roles":["admin"]}}`,
+	}, "\n")
+	_, err := dataset.Import(ctx, str, strings.NewReader(data))
+	require.NoError(t, err)
+
+	var exported bytes.Buffer
+	_, err = dataset.Export(ctx, str, &exported, dataset.ExportOptions{})
+	require.NoError(t, err)
+	lines := records(t, exported.Bytes())
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"roles":["user"]`)
+	require.Contains(t, lines[1], `"roles":["user","admin"]`)
+}
+
+// TestImportConflict tests importing entities already existing in the store
+func TestImportConflict(t *testing.T) {
+	ctx := context.Background()
+	str := newStore(t)
+	usr, err := str.CreateUser(ctx, time.Now(), "a@test.test", "a", "pass")
+	require.NoError(t, err)
+
+	var data bytes.Buffer
+	_, err = dataset.Export(ctx, str, &data, dataset.ExportOptions{})
+	require.NoError(t, err)
+
+	stats, err := dataset.Import(ctx, str, &data)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+	require.Contains(t, err.Error(), string(usr.ID))
+	require.Equal(t, dataset.Stats{}, stats)
+}
@@ -0,0 +1,368 @@
+package dataset
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+)
+
+// defaultPageSize defines the number of entities read per query
+// when no page size is specified
+const defaultPageSize = 500
+
+// ExportOptions represents the export options
+type ExportOptions struct {
+	// Sessions enables the export of sessions
+	Sessions bool
+
+	// PageSize defines the number of entities read per query
+	PageSize int
+}
+
+// exporter streams the entities of a store page by page
+type exporter struct {
+	str      store.Store
+	pageSize int
+	encoder  *json.Encoder
+	stats    Stats
+}
+
+// Export writes the data set of the store to the writer.
+// The entities are read in pages of separate queries,
+// thus a store modified during the export isn't exported consistently
+func Export(
+	ctx context.Context,
+	str store.Store,
+	writer io.Writer,
+	options ExportOptions,
+) (Stats, error) {
+	if options.PageSize < 1 {
+		options.PageSize = defaultPageSize
+	}
+	buf := bufio.NewWriter(writer)
+	exp := &exporter{
+		str:      str,
+		pageSize: options.PageSize,
+		encoder:  json.NewEncoder(buf),
+	}
+
+	if err := exp.encoder.Encode(Record{Header: &Header{
+		Format:   Format,
+		Version:  Version,
+		Creation: time.Now().UTC(),
+	}}); err != nil {
+		return exp.stats, errors.Wrap(err, "writing header")
+	}
+	if err := exp.users(ctx); err != nil {
+		return exp.stats, errors.Wrap(err, "exporting users")
+	}
+	if err := exp.follows(ctx); err != nil {
+		return exp.stats, errors.Wrap(err, "exporting follows")
+	}
+	if err := exp.posts(ctx); err != nil {
+		return exp.stats, errors.Wrap(err, "exporting posts")
+	}
+	if err := exp.reactions(ctx); err != nil {
+		return exp.stats, errors.Wrap(err, "exporting reactions")
+	}
+	if options.Sessions {
+		if err := exp.sessions(ctx); err != nil {
+			return exp.stats, errors.Wrap(err, "exporting sessions")
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return exp.stats, errors.Wrap(err, "flushing")
+	}
+	return exp.stats, nil
+}
+
+// page reads the page of nodes having the given predicate
+// following the node identified by after
+func (exp *exporter) page(
+	ctx context.Context,
+	predicate string,
+	after string,
+	selection string,
+	result interface{},
+) error {
+	afterArg := ""
+	if after != "" {
+		afterArg = ", after: " + after
+	}
+	return exp.str.Query(
+		ctx,
+		fmt.Sprintf(
+			`{ page(func: has(%s), first: %d%s) { %s } }`,
+			predicate,
+			exp.pageSize,
+			afterArg,
+			selection,
+		),
+		result,
+	)
+}
+
+func (exp *exporter) users(ctx context.Context) error {
+	after := ""
+	for {
+		var qr struct {
+			Page []dgraph.User `json:"page"`
+		}
+		if err := exp.page(
+			ctx,
+			"User.id",
+			after,
+			`uid
+			User.id
+			User.creation
+			User.email
+			User.emailVerified
+			User.displayName
+			User.password
+			User.roles`,
+			&qr,
+		); err != nil {
+			return err
+		}
+		for _, usr := range qr.Page {
+			if err := exp.encoder.Encode(Record{User: &User{
+				ID:            usr.ID,
+				Creation:      usr.Creation,
+				Email:         usr.Email,
+				EmailVerified: usr.EmailVerified,
+				DisplayName:   usr.DisplayName,
+				PasswordHash:  usr.Password,
+				Roles:         usr.Roles,
+			}}); err != nil {
+				return err
+			}
+			exp.stats.Users++
+		}
+		if len(qr.Page) < exp.pageSize {
+			return nil
+		}
+		after = qr.Page[len(qr.Page)-1].UID
+	}
+}
+
+// follows exports the following relations of all users.
+// Follows are exported after the users since they may reference
+// users defined later in the users section
+func (exp *exporter) follows(ctx context.Context) error {
+	after := ""
+	for {
+		var qr struct {
+			Page []dgraph.User `json:"page"`
+		}
+		if err := exp.page(
+			ctx,
+			"User.id",
+			after,
+			`uid
+			User.id
+			User.following { User.id }`,
+			&qr,
+		); err != nil {
+			return err
+		}
+		for _, usr := range qr.Page {
+			for _, followee := range usr.Following {
+				if err := exp.encoder.Encode(Record{Follow: &Follow{
+					Follower: usr.ID,
+					Followee: followee.ID,
+				}}); err != nil {
+					return err
+				}
+				exp.stats.Follows++
+			}
+		}
+		if len(qr.Page) < exp.pageSize {
+			return nil
+		}
+		after = qr.Page[len(qr.Page)-1].UID
+	}
+}
+
+func (exp *exporter) posts(ctx context.Context) error {
+	after := ""
+	for {
+		var qr struct {
+			Page []dgraph.Post `json:"page"`
+		}
+		if err := exp.page(
+			ctx,
+			"Post.id",
+			after,
+			`uid
+			Post.id
+			Post.creation
+			Post.title
+			Post.contents
+			Post.hidden
+			Post.author { User.id }`,
+			&qr,
+		); err != nil {
+			return err
+		}
+		for _, post := range qr.Page {
+			if len(post.Author) < 1 {
+				return errors.Errorf("post %s has no author", post.ID)
+			}
+			if err := exp.encoder.Encode(Record{Post: &Post{
+				ID:       post.ID,
+				Creation: post.Creation,
+				Author:   post.Author[0].ID,
+				Title:    post.Title,
+				Contents: post.Contents,
+				Hidden:   post.Hidden,
+			}}); err != nil {
+				return err
+			}
+			exp.stats.Posts++
+		}
+		if len(qr.Page) < exp.pageSize {
+			return nil
+		}
+		after = qr.Page[len(qr.Page)-1].UID
+	}
+}
+
+// reactions exports all reactions. Reactions are deferred until
+// the reaction they're reacting to is exported
+func (exp *exporter) reactions(ctx context.Context) error {
+	exported := make(map[store.ID]bool)
+	pending := make(map[store.ID][]*Reaction)
+
+	// write writes the reaction followed by its pending reactions
+	write := func(reaction *Reaction) error {
+		queue := []*Reaction{reaction}
+		for len(queue) > 0 {
+			next := queue[0]
+			queue = queue[1:]
+			if err := exp.encoder.Encode(Record{Reaction: next}); err != nil {
+				return err
+			}
+			exp.stats.Reactions++
+			exported[next.ID] = true
+			queue = append(queue, pending[next.ID]...)
+			delete(pending, next.ID)
+		}
+		return nil
+	}
+
+	after := ""
+	for {
+		var qr struct {
+			Page []dgraph.Reaction `json:"page"`
+		}
+		if err := exp.page(
+			ctx,
+			"Reaction.id",
+			after,
+			`uid
+			Reaction.id
+			Reaction.creation
+			Reaction.emotion
+			Reaction.message
+			Reaction.hidden
+			Reaction.author { User.id }
+			Reaction.subject { Post.id Reaction.id }`,
+			&qr,
+		); err != nil {
+			return err
+		}
+		for _, reaction := range qr.Page {
+			if len(reaction.Author) < 1 {
+				return errors.Errorf("reaction %s has no author", reaction.ID)
+			}
+			if len(reaction.Subject) < 1 {
+				return errors.Errorf("reaction %s has no subject", reaction.ID)
+			}
+			record := &Reaction{
+				ID:       reaction.ID,
+				Creation: reaction.Creation,
+				Author:   reaction.Author[0].ID,
+				Emotion:  reaction.Emotion,
+				Message:  reaction.Message,
+				Hidden:   reaction.Hidden,
+			}
+			switch v := reaction.Subject[0].V.(type) {
+			case *dgraph.Post:
+				record.Subject = v.ID
+			case *dgraph.Reaction:
+				record.Subject = v.ID
+				if !exported[v.ID] {
+					pending[v.ID] = append(pending[v.ID], record)
+					continue
+				}
+			}
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+		if len(qr.Page) < exp.pageSize {
+			break
+		}
+		after = qr.Page[len(qr.Page)-1].UID
+	}
+
+	if len(pending) > 0 {
+		missing := 0
+		for _, reactions := range pending {
+			missing += len(reactions)
+		}
+		return errors.Errorf(
+			"%d reactions react to reactions which don't exist",
+			missing,
+		)
+	}
+	return nil
+}
+
+func (exp *exporter) sessions(ctx context.Context) error {
+	after := ""
+	for {
+		var qr struct {
+			Page []dgraph.Session `json:"page"`
+		}
+		if err := exp.page(
+			ctx,
+			"Session.key",
+			after,
+			`uid
+			Session.key
+			Session.creation
+			Session.lastAccess
+			Session.user { User.id }`,
+			&qr,
+		); err != nil {
+			return err
+		}
+		for _, sess := range qr.Page {
+			if len(sess.User) < 1 {
+				// Skip sessions of deleted users
+				continue
+			}
+			if err := exp.encoder.Encode(Record{Session: &Session{
+				Key:        sess.Key,
+				Creation:   sess.Creation,
+				LastAccess: sess.LastAccess,
+				User:       sess.User[0].ID,
+			}}); err != nil {
+				return err
+			}
+			exp.stats.Sessions++
+		}
+		if len(qr.Page) < exp.pageSize {
+			return nil
+		}
+		after = qr.Page[len(qr.Page)-1].UID
+	}
+}
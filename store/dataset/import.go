@@ -0,0 +1,330 @@
+package dataset
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// entity represents the kind of an entity
+type entity int
+
+const (
+	entityUser entity = iota + 1
+	entityPost
+	entityReaction
+)
+
+// reader reads and validates the records of a data set line by line
+type reader struct {
+	src     *bufio.Reader
+	line    int
+	header  *Header
+	known   map[store.ID]entity
+	keys    map[string]bool
+	follows map[Follow]bool
+	stats   Stats
+}
+
+func newReader(src io.Reader) *reader {
+	return &reader{
+		src:     bufio.NewReader(src),
+		known:   make(map[store.ID]entity),
+		keys:    make(map[string]bool),
+		follows: make(map[Follow]bool),
+	}
+}
+
+// errorf returns an error referring to the current line
+func (rd *reader) errorf(format string, args ...interface{}) error {
+	return errors.Wrapf(errors.Errorf(format, args...), "line %d", rd.line)
+}
+
+// next returns the next valid record,
+// returns io.EOF when the end of the data set is reached
+func (rd *reader) next() (*Record, error) {
+	var line []byte
+	for len(line) < 1 {
+		var err error
+		line, err = rd.src.ReadBytes('\n')
+		if err == io.EOF && len(line) > 0 {
+			err = nil
+		}
+		if err != nil {
+			if err == io.EOF && rd.header == nil {
+				return nil, errors.New("missing header")
+			}
+			return nil, err
+		}
+		rd.line++
+		line = bytes.TrimSpace(line)
+	}
+
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil, rd.errorf("malformed record: %s", err)
+	}
+	if err := rd.validate(&record); err != nil {
+		return nil, rd.errorf("%s", err)
+	}
+	return &record, nil
+}
+
+// reference returns an error if the identifier doesn't refer
+// to a previously defined entity of one of the given kinds
+func (rd *reader) reference(
+	name string,
+	id store.ID,
+	kinds ...entity,
+) error {
+	kind := rd.known[id]
+	for _, k := range kinds {
+		if kind == k {
+			return nil
+		}
+	}
+	return errors.Errorf("%s %s not defined", name, id)
+}
+
+// define defines a new entity
+func (rd *reader) define(id store.ID, kind entity) error {
+	if err := store.Verify(string(id)); err != nil {
+		return errors.Wrapf(err, "id %s", id)
+	}
+	if rd.known[id] != 0 {
+		return errors.Errorf("duplicate id %s", id)
+	}
+	rd.known[id] = kind
+	return nil
+}
+
+// validate verifies the record and its references
+func (rd *reader) validate(record *Record) error {
+	set := 0
+	for _, isSet := range []bool{
+		record.Header != nil,
+		record.User != nil,
+		record.Follow != nil,
+		record.Post != nil,
+		record.Reaction != nil,
+		record.Session != nil,
+	} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.Errorf("record must define exactly 1 entity, got %d", set)
+	}
+
+	if rd.header == nil {
+		if record.Header == nil {
+			return errors.New("missing header")
+		}
+		if record.Header.Format != Format {
+			return errors.Errorf("unknown format: %q", record.Header.Format)
+		}
+		if record.Header.Version != Version {
+			return errors.Errorf(
+				"unsupported version: %d (supported: %d)",
+				record.Header.Version,
+				Version,
+			)
+		}
+		rd.header = record.Header
+		return nil
+	}
+
+	switch {
+	case record.Header != nil:
+		return errors.New("duplicate header")
+
+	case record.User != nil:
+		for _, r := range record.User.Roles {
+			if err := role.Validate(r); err != nil {
+				return errors.Wrap(err, "role")
+			}
+		}
+		if err := rd.define(record.User.ID, entityUser); err != nil {
+			return err
+		}
+		rd.stats.Users++
+
+	case record.Follow != nil:
+		if err := rd.reference(
+			"follower",
+			record.Follow.Follower,
+			entityUser,
+		); err != nil {
+			return err
+		}
+		if err := rd.reference(
+			"followee",
+			record.Follow.Followee,
+			entityUser,
+		); err != nil {
+			return err
+		}
+		if record.Follow.Follower == record.Follow.Followee {
+			return errors.New("user following itself")
+		}
+		if rd.follows[*record.Follow] {
+			return errors.New("duplicate follow")
+		}
+		rd.follows[*record.Follow] = true
+		rd.stats.Follows++
+
+	case record.Post != nil:
+		if err := rd.reference(
+			"author",
+			record.Post.Author,
+			entityUser,
+		); err != nil {
+			return err
+		}
+		if err := rd.define(record.Post.ID, entityPost); err != nil {
+			return err
+		}
+		rd.stats.Posts++
+
+	case record.Reaction != nil:
+		if err := emotion.Validate(record.Reaction.Emotion); err != nil {
+			return errors.Wrap(err, "emotion")
+		}
+		if err := rd.reference(
+			"author",
+			record.Reaction.Author,
+			entityUser,
+		); err != nil {
+			return err
+		}
+		if err := rd.reference(
+			"subject",
+			record.Reaction.Subject,
+			entityPost,
+			entityReaction,
+		); err != nil {
+			return err
+		}
+		if err := rd.define(record.Reaction.ID, entityReaction); err != nil {
+			return err
+		}
+		rd.stats.Reactions++
+
+	case record.Session != nil:
+		if record.Session.Key == "" {
+			return errors.New("missing session key")
+		}
+		if rd.keys[record.Session.Key] {
+			return errors.New("duplicate session key")
+		}
+		if err := rd.reference(
+			"user",
+			record.Session.User,
+			entityUser,
+		); err != nil {
+			return err
+		}
+		rd.keys[record.Session.Key] = true
+		rd.stats.Sessions++
+	}
+	return nil
+}
+
+// Validate reads the entire data set verifying the header, the records
+// and their referential integrity without importing anything
+func Validate(src io.Reader) (Stats, error) {
+	rd := newReader(src)
+	for {
+		if _, err := rd.next(); err == io.EOF {
+			return rd.stats, nil
+		} else if err != nil {
+			return rd.stats, err
+		}
+	}
+}
+
+// Import imports the data set into the store preserving the identifiers
+// and times of all entities. The import is aborted at the first invalid
+// record, records imported before remain in the store, thus data sets
+// should be validated before they're imported
+func Import(
+	ctx context.Context,
+	str store.ImportStore,
+	src io.Reader,
+) (Stats, error) {
+	rd := newReader(src)
+	var stats Stats
+	for {
+		record, err := rd.next()
+		if err == io.EOF {
+			return stats, nil
+		} else if err != nil {
+			return stats, err
+		}
+
+		switch {
+		case record.User != nil:
+			usr := record.User
+			_, err = str.ImportUser(
+				ctx,
+				usr.ID,
+				usr.Creation,
+				usr.Email,
+				usr.DisplayName,
+				usr.PasswordHash,
+				usr.Roles,
+				usr.EmailVerified,
+			)
+		case record.Follow != nil:
+			_, err = str.ImportFollow(
+				ctx,
+				record.Follow.Follower,
+				record.Follow.Followee,
+			)
+		case record.Post != nil:
+			post := record.Post
+			_, err = str.ImportPost(
+				ctx,
+				post.ID,
+				post.Creation,
+				post.Author,
+				post.Title,
+				post.Contents,
+				post.Hidden,
+			)
+		case record.Reaction != nil:
+			reaction := record.Reaction
+			_, err = str.ImportReaction(
+				ctx,
+				reaction.ID,
+				reaction.Creation,
+				reaction.Author,
+				reaction.Subject,
+				reaction.Emotion,
+				reaction.Message,
+				reaction.Hidden,
+			)
+		case record.Session != nil:
+			sess := record.Session
+			_, err = str.ImportSession(
+				ctx,
+				sess.Key,
+				sess.Creation,
+				sess.LastAccess,
+				sess.User,
+			)
+		}
+		if err != nil {
+			return stats, rd.errorf("import: %s", err)
+		}
+		stats = rd.stats
+	}
+}
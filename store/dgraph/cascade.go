@@ -0,0 +1,152 @@
+package dgraph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// deletableReaction represents a reaction collected for deletion
+type deletableReaction struct {
+	UID       string `json:"uid"`
+	Author    []UID  `json:"Reaction.author"`
+	Reactions []UID  `json:"Reaction.reactions"`
+	Revisions []UID  `json:"Reaction.revisions"`
+	Reports   []UID  `json:"~Report.subject"`
+}
+
+// collectReactions returns the reactions identified by the given node
+// identifiers together with all reactions recursively nested in them
+func collectReactions(
+	ctx context.Context,
+	txn transaction,
+	uids []string,
+) (result []deletableReaction, err error) {
+	visited := make(map[string]struct{}, len(uids))
+	for len(uids) > 0 {
+		var qr struct {
+			Reactions []deletableReaction `json:"reactions"`
+		}
+		if err = txn.Query(
+			ctx,
+			fmt.Sprintf(
+				`{
+					reactions(func: uid(%s)) @filter(has(Reaction.id)) {
+						uid
+						Reaction.author { uid }
+						Reaction.reactions { uid }
+						Reaction.revisions { uid }
+						~Report.subject { uid }
+					}
+				}`,
+				strings.Join(uids, ", "),
+			),
+			&qr,
+		); err != nil {
+			return
+		}
+
+		// Descend into the next level of nested reactions
+		uids = nil
+		for _, reaction := range qr.Reactions {
+			if _, ok := visited[reaction.UID]; ok {
+				continue
+			}
+			visited[reaction.UID] = struct{}{}
+			result = append(result, reaction)
+			for _, nested := range reaction.Reactions {
+				uids = append(uids, nested.NodeID)
+			}
+		}
+	}
+	return
+}
+
+// reactionDeletions returns the deletion mutation objects of the given
+// reactions including their revisions, their reports
+// and the "User.publishedReactions" references
+func reactionDeletions(reactions []deletableReaction) []interface{} {
+	deletions := make([]interface{}, 0, len(reactions)*2)
+	for _, reaction := range reactions {
+		// Delete the "User.publishedReactions" reference
+		for _, author := range reaction.Author {
+			deletions = append(deletions, struct {
+				UID                string `json:"uid"`
+				PublishedReactions []UID  `json:"User.publishedReactions"`
+			}{
+				UID:                author.NodeID,
+				PublishedReactions: []UID{UID{NodeID: reaction.UID}},
+			})
+		}
+
+		// Delete the revisions
+		for _, revision := range reaction.Revisions {
+			deletions = append(deletions, revision)
+		}
+
+		// Delete the reports
+		for _, report := range reaction.Reports {
+			deletions = append(deletions, report)
+		}
+
+		// Delete the actual Reaction node
+		deletions = append(deletions, UID{NodeID: reaction.UID})
+	}
+	return deletions
+}
+
+// postDeletions returns the deletion mutation objects of the given post
+// including its revisions, its reports,
+// the "User.posts" and the global "posts" references
+func postDeletions(post Post) []interface{} {
+	deletions := make(
+		[]interface{},
+		0,
+		len(post.Author)+len(post.RPosts)+len(post.Revisions)+
+			len(post.RReports)+1,
+	)
+
+	// Delete the "User.posts" reference
+	for _, author := range post.Author {
+		deletions = append(deletions, struct {
+			UID   string `json:"uid"`
+			Posts []UID  `json:"User.posts"`
+		}{
+			UID:   author.UID,
+			Posts: []UID{UID{NodeID: post.UID}},
+		})
+	}
+
+	// Delete the global "posts" references
+	for _, ref := range post.RPosts {
+		deletions = append(deletions, ref)
+	}
+
+	// Delete the revisions
+	for _, revision := range post.Revisions {
+		deletions = append(deletions, UID{NodeID: revision.UID})
+	}
+
+	// Delete the reports
+	for _, report := range post.RReports {
+		deletions = append(deletions, report)
+	}
+
+	// Delete the actual Post node
+	return append(deletions, UID{NodeID: post.UID})
+}
+
+// subjectReferenceDeletion returns the deletion mutation object
+// of the reference from the subject (either a post or a reaction)
+// to the given reaction
+func subjectReferenceDeletion(subjectUID, reactionUID string) interface{} {
+	return struct {
+		UID               string `json:"uid"`
+		PostReactions     []UID  `json:"Post.reactions"`
+		ReactionReactions []UID  `json:"Reaction.reactions"`
+	}{
+		UID:               subjectUID,
+		PostReactions:     []UID{UID{NodeID: reactionUID}},
+		ReactionReactions: []UID{UID{NodeID: reactionUID}},
+	}
+}
@@ -0,0 +1,87 @@
+package dgraph
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// followRelation represents the follow relation between two users
+type followRelation struct {
+	followerUID string
+	followee    User
+	following   bool
+}
+
+// findFollowRelation ensures both users exist
+// and returns the relation between them
+func findFollowRelation(
+	ctx context.Context,
+	txn transaction,
+	follower store.ID,
+	followee store.ID,
+) (rel followRelation, err error) {
+	if follower == followee {
+		err = strerr.New(strerr.ErrInvalidInput, "users can't follow themselves")
+		return
+	}
+
+	var qr struct {
+		Follower []User `json:"follower"`
+		Followee []User `json:"followee"`
+	}
+	err = txn.QueryVars(
+		ctx,
+		`query Follow(
+			$followerId: string,
+			$followeeId: string
+		) {
+			follower(func: eq(User.id, $followerId)) {
+				uid
+				User.following @filter(eq(User.id, $followeeId)) { uid }
+			}
+			followee(func: eq(User.id, $followeeId)) {
+				uid
+				User.id
+				User.creation
+				User.email
+				User.displayName
+			}
+		}`,
+		map[string]string{
+			"$followerId": string(follower),
+			"$followeeId": string(followee),
+		},
+		&qr,
+	)
+	if err != nil {
+		return
+	}
+
+	if len(qr.Follower) < 1 {
+		err = strerr.New(strerr.ErrInvalidInput, "follower not found")
+		return
+	}
+	if len(qr.Followee) < 1 {
+		err = strerr.New(strerr.ErrInvalidInput, "followee not found")
+		return
+	}
+
+	rel.followerUID = qr.Follower[0].UID
+	rel.followee = qr.Followee[0]
+	rel.following = len(qr.Follower[0].Following) > 0
+	return
+}
+
+// followMutation returns the mutation object
+// of the "User.following" edge between two users
+func followMutation(followerUID, followeeUID string) interface{} {
+	return struct {
+		UID       string `json:"uid"`
+		Following []UID  `json:"User.following"`
+	}{
+		UID:       followerUID,
+		Following: []UID{UID{NodeID: followeeUID}},
+	}
+}
@@ -0,0 +1,187 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/pkg/errors"
+)
+
+// Migration represents a versioned database migration
+// consisting of a schema alteration and an optional data backfill
+type Migration struct {
+	Version     int
+	Description string
+
+	// schema is applied before the backfill if not empty
+	schema string
+
+	// backfill migrates the existing data if not nil,
+	// it's executed in the same transaction the version is updated in
+	backfill func(ctx context.Context, txn transaction) error
+}
+
+// Migrations returns all migrations ordered by version
+func Migrations() []Migration {
+	list := make([]Migration, len(migrations))
+	copy(list, migrations)
+	return list
+}
+
+// LatestSchemaVersion returns the version of the latest migration
+// known to this binary
+func LatestSchemaVersion() int {
+	if len(migrations) < 1 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// checkSchemaVersion returns an error if the database schema version
+// is ahead of the latest migration known to this binary
+func checkSchemaVersion(version int) error {
+	if latest := LatestSchemaVersion(); version > latest {
+		return errors.Errorf(
+			"database schema version (%d) is ahead of "+
+				"the latest version supported by this binary (%d)",
+			version,
+			latest,
+		)
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the latest migration
+// applied to the database, returns 0 for uninitialized databases
+func SchemaVersion(ctx context.Context, db *dgo.Dgraph) (int, error) {
+	version, _, err := schemaVersion(ctx, &txn{dgTxn: db.NewReadOnlyTxn()})
+	return version, err
+}
+
+// schemaVersion returns the applied schema version
+// and the uid of the node holding it
+func schemaVersion(
+	ctx context.Context,
+	txn transaction,
+) (version int, uid string, err error) {
+	var qr struct {
+		Version []struct {
+			UID     string `json:"uid"`
+			Version int    `json:"Schema.version"`
+		} `json:"version"`
+	}
+	if err = txn.Query(
+		ctx,
+		`{
+			version(func: has(Schema.version)) {
+				uid
+				Schema.version
+			}
+		}`,
+		&qr,
+	); err != nil {
+		return
+	}
+	for _, node := range qr.Version {
+		if node.Version > version {
+			version = node.Version
+			uid = node.UID
+		}
+	}
+	return
+}
+
+// MigrateUp applies all pending migrations in order calling onApplied
+// after each successfully applied migration if it's not nil.
+// Returns an error if the database is ahead of this binary
+func MigrateUp(
+	ctx context.Context,
+	db *dgo.Dgraph,
+	onApplied func(Migration),
+) error {
+	current, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "reading schema version")
+	}
+	if err := checkSchemaVersion(current); err != nil {
+		return err
+	}
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := migration.apply(ctx, db); err != nil {
+			return errors.Wrapf(
+				err,
+				"migration %d (%s)",
+				migration.Version,
+				migration.Description,
+			)
+		}
+		if onApplied != nil {
+			onApplied(migration)
+		}
+	}
+	return nil
+}
+
+// apply alters the schema, backfills the data and records the version
+func (migration Migration) apply(
+	ctx context.Context,
+	db *dgo.Dgraph,
+) (err error) {
+	if migration.schema != "" {
+		if err = db.Alter(ctx, &api.Operation{
+			Schema: migration.schema,
+		}); err != nil {
+			return errors.Wrap(err, "schema alteration")
+		}
+	}
+
+	dgTxn := db.NewTxn()
+	defer func() {
+		if err != nil {
+			if rlbErr := dgTxn.Discard(ctx); rlbErr != nil {
+				err = errors.Wrapf(rlbErr, "rollback after: %s", err)
+			}
+		}
+	}()
+	txn := &txn{dgTxn: dgTxn}
+
+	if migration.backfill != nil {
+		if err = migration.backfill(ctx, txn); err != nil {
+			return errors.Wrap(err, "backfill")
+		}
+	}
+
+	// Record the version
+	_, versionUID, err := schemaVersion(ctx, txn)
+	if err != nil {
+		return
+	}
+	if versionUID == "" {
+		versionUID = "_:version"
+	}
+	versionJSON, err := json.Marshal(struct {
+		UID     string `json:"uid"`
+		Version int    `json:"Schema.version"`
+	}{
+		UID:     versionUID,
+		Version: migration.Version,
+	})
+	if err != nil {
+		return
+	}
+	if _, err = txn.Mutation(ctx, &api.Mutation{
+		SetJson: versionJSON,
+	}); err != nil {
+		return
+	}
+
+	if err = dgTxn.Commit(ctx); err != nil {
+		return errors.Wrap(err, "commit")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package dgraph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrationsOrdered tests whether the migrations are versioned
+// consecutively starting at 1
+func TestMigrationsOrdered(t *testing.T) {
+	require.True(t, len(migrations) > 0)
+	for i, migration := range migrations {
+		require.Equal(t, i+1, migration.Version)
+		require.True(t, len(migration.Description) > 0)
+		require.True(t, migration.schema != "" || migration.backfill != nil)
+	}
+	require.Equal(t, len(migrations), LatestSchemaVersion())
+}
+
+// TestCheckSchemaVersion tests whether databases ahead of the binary
+// are refused
+func TestCheckSchemaVersion(t *testing.T) {
+	require.NoError(t, checkSchemaVersion(0))
+	require.NoError(t, checkSchemaVersion(LatestSchemaVersion()))
+	require.Error(t, checkSchemaVersion(LatestSchemaVersion()+1))
+}
@@ -0,0 +1,183 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+)
+
+// migrations defines the ordered list of all database migrations.
+// Applied migrations must never be changed, a schema or data change
+// must always be appended as a new migration with the next version
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "initial schema",
+		schema: `
+			Schema.version: int .
+
+			users: uid @reverse .
+			posts: uid @reverse .
+			sessions: uid @reverse .
+
+			Session.key: string @index(exact) .
+			Session.creation: dateTime @index(hour) .
+			Session.lastAccess: dateTime @index(hour) .
+			Session.user: uid .
+
+			User.id: string @index(exact) .
+			User.creation: dateTime .
+			User.email: string @index(exact) .
+			User.displayName: string @index(exact) .
+			User.posts: uid .
+			User.sessions: uid .
+			User.password: string .
+			User.publishedReactions: uid .
+
+			Post.id: string @index(exact) .
+			Post.creation: dateTime .
+			Post.title: string .
+			Post.contents: string .
+			Post.author: uid .
+			Post.reactions: uid .
+
+			Reaction.id: string @index(exact) .
+			Reaction.subject: uid .
+			Reaction.creation: dateTime .
+			Reaction.emotion: string .
+			Reaction.message: string .
+			Reaction.author: uid .
+			Reaction.reactions: uid .
+		`,
+	},
+	{
+		Version:     2,
+		Description: "unique user identifiers, emails and display names",
+		schema: `
+			User.id: string @index(exact) @upsert .
+			User.email: string @index(exact) @upsert .
+			User.displayName: string @index(exact) @upsert .
+		`,
+	},
+	{
+		Version:     3,
+		Description: "full-text search indexes",
+		schema: `
+			User.displayName: string @index(exact, trigram) @upsert .
+			Post.title: string @index(fulltext) .
+			Post.contents: string @index(fulltext) .
+		`,
+	},
+	{
+		Version:     4,
+		Description: "post and reaction revisions",
+		schema: `
+			Post.lastEdited: dateTime .
+			Post.revisions: uid .
+			Reaction.revisions: uid .
+
+			PostRevision.id: string @index(exact) .
+			PostRevision.creation: dateTime .
+			PostRevision.editor: uid .
+			PostRevision.title: string .
+			PostRevision.contents: string .
+
+			ReactionRevision.id: string @index(exact) .
+			ReactionRevision.creation: dateTime .
+			ReactionRevision.editor: uid .
+			ReactionRevision.message: string .
+		`,
+	},
+	{
+		Version:     5,
+		Description: "follow graph",
+		schema: `
+			User.following: uid @reverse @count .
+		`,
+	},
+	{
+		Version:     6,
+		Description: "notifications",
+		schema: `
+			User.notifications: uid @count .
+			User.unreadNotifications: uid @count .
+
+			Notification.id: string @index(exact) .
+			Notification.creation: dateTime .
+			Notification.kind: string .
+			Notification.read: bool .
+			Notification.recipient: uid .
+			Notification.actor: uid .
+			Notification.subject: uid .
+		`,
+	},
+	{
+		Version:     7,
+		Description: "user roles and content moderation",
+		schema: `
+			User.roles: [string] .
+
+			Post.hidden: bool .
+			Reaction.hidden: bool .
+
+			Report.id: string @index(exact) .
+			Report.creation: dateTime .
+			Report.reporter: uid .
+			Report.subject: uid @reverse .
+			Report.reason: string .
+			Report.open: bool .
+		`,
+		backfill: backfillUserRoles,
+	},
+	{
+		Version:     8,
+		Description: "password reset and email verification tokens",
+		schema: `
+			User.emailVerified: bool .
+			User.tokens: uid .
+
+			Token.key: string @index(exact) @upsert .
+			Token.kind: string .
+			Token.creation: dateTime .
+			Token.user: uid .
+		`,
+	},
+}
+
+// backfillUserRoles assigns the user role to all existing users
+func backfillUserRoles(ctx context.Context, txn transaction) error {
+	var qr struct {
+		Users []UID `json:"users"`
+	}
+	if err := txn.Query(
+		ctx,
+		`{
+			users(func: has(User.id)) @filter(not has(User.roles)) { uid }
+		}`,
+		&qr,
+	); err != nil {
+		return err
+	}
+	if len(qr.Users) < 1 {
+		return nil
+	}
+
+	mutations := make([]interface{}, len(qr.Users))
+	for i, usr := range qr.Users {
+		mutations[i] = struct {
+			UID   string      `json:"uid"`
+			Roles []role.Role `json:"User.roles"`
+		}{
+			UID:   usr.NodeID,
+			Roles: []role.Role{role.User},
+		}
+	}
+	usersJSON, err := json.Marshal(mutations)
+	if err != nil {
+		return err
+	}
+	_, err = txn.Mutation(ctx, &api.Mutation{SetJson: usersJSON})
+	return err
+}
@@ -0,0 +1,146 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+)
+
+// Notification represents a database model for the Notification entity
+type Notification struct {
+	UID       string            `json:"uid"`
+	ID        store.ID          `json:"Notification.id"`
+	Creation  time.Time         `json:"Notification.creation"`
+	Kind      notification.Kind `json:"Notification.kind"`
+	Read      bool              `json:"Notification.read"`
+	Recipient []User            `json:"Notification.recipient"`
+	Actor     []User            `json:"Notification.actor"`
+	Subject   []UID             `json:"Notification.subject"`
+}
+
+// newNotification represents the mutation object of a new notification
+type newNotification struct {
+	UID       string            `json:"uid"`
+	ID        string            `json:"Notification.id"`
+	Creation  time.Time         `json:"Notification.creation"`
+	Kind      notification.Kind `json:"Notification.kind"`
+	Read      bool              `json:"Notification.read"`
+	Recipient UID               `json:"Notification.recipient"`
+	Actor     UID               `json:"Notification.actor"`
+	Subject   UID               `json:"Notification.subject"`
+}
+
+// findMentioned returns the node identifiers of the existing users
+// mentioned in the text in order of appearance
+func findMentioned(
+	ctx context.Context,
+	txn transaction,
+	text string,
+) (uids []string, err error) {
+	names := store.Mentions(text)
+	if len(names) < 1 {
+		return
+	}
+
+	params := make([]string, len(names))
+	blocks := make([]string, len(names))
+	vars := make(map[string]string, len(names))
+	for i, name := range names {
+		params[i] = fmt.Sprintf("$m%d: string", i)
+		blocks[i] = fmt.Sprintf(
+			"m%d(func: eq(User.displayName, $m%d)) { uid }",
+			i, i,
+		)
+		vars[fmt.Sprintf("$m%d", i)] = name
+	}
+
+	var qr map[string][]UID
+	if err = txn.QueryVars(
+		ctx,
+		fmt.Sprintf(
+			"query Mentioned(%s) { %s }",
+			strings.Join(params, ", "),
+			strings.Join(blocks, "\n"),
+		),
+		vars,
+		&qr,
+	); err != nil {
+		return
+	}
+	for i := range names {
+		for _, usr := range qr[fmt.Sprintf("m%d", i)] {
+			uids = append(uids, usr.NodeID)
+		}
+	}
+	return
+}
+
+// notify notifies the author of the reacted post or reaction
+// (if reactedAuthorUID isn't empty) about the new subject
+// and all users mentioned in the text of the subject.
+// Actors are never notified about their own actions
+// and each user is notified at most once
+func notify(
+	ctx context.Context,
+	txn transaction,
+	creationTime time.Time,
+	actorUID string,
+	subjectUID string,
+	reactedAuthorUID string,
+	text string,
+) error {
+	mentioned, err := findMentioned(ctx, txn, text)
+	if err != nil {
+		return err
+	}
+
+	notified := map[string]struct{}{actorUID: {}}
+	var mutations []interface{}
+	add := func(recipientUID string, kind notification.Kind) {
+		if _, ok := notified[recipientUID]; ok {
+			return
+		}
+		notified[recipientUID] = struct{}{}
+		blank := fmt.Sprintf("_:notification%d", len(mutations))
+		mutations = append(mutations, struct {
+			UID                 string            `json:"uid"`
+			Notifications       []newNotification `json:"User.notifications"`
+			UnreadNotifications []UID             `json:"User.unreadNotifications"`
+		}{
+			UID: recipientUID,
+			Notifications: []newNotification{{
+				UID:       blank,
+				ID:        string(store.NewID()),
+				Creation:  creationTime,
+				Kind:      kind,
+				Recipient: UID{NodeID: recipientUID},
+				Actor:     UID{NodeID: actorUID},
+				Subject:   UID{NodeID: subjectUID},
+			}},
+			UnreadNotifications: []UID{UID{NodeID: blank}},
+		})
+	}
+
+	if reactedAuthorUID != "" {
+		add(reactedAuthorUID, notification.Reaction)
+	}
+	for _, uid := range mentioned {
+		add(uid, notification.Mention)
+	}
+	if len(mutations) < 1 {
+		return nil
+	}
+
+	mutationJSON, err := json.Marshal(mutations)
+	if err != nil {
+		return err
+	}
+	_, err = txn.Mutation(ctx, &api.Mutation{SetJson: mutationJSON})
+	return err
+}
@@ -8,11 +8,16 @@ import (
 
 // Post represents a database model for the Post entity
 type Post struct {
-	UID       string     `json:"uid"`
-	ID        store.ID   `json:"Post.id"`
-	Creation  time.Time  `json:"Post.creation"`
-	Author    []User     `json:"Post.author"`
-	Title     string     `json:"Post.title"`
-	Contents  string     `json:"Post.contents"`
-	Reactions []Reaction `json:"Post.reactions"`
+	UID        string         `json:"uid"`
+	ID         store.ID       `json:"Post.id"`
+	Creation   time.Time      `json:"Post.creation"`
+	LastEdited time.Time      `json:"Post.lastEdited"`
+	Author     []User         `json:"Post.author"`
+	Title      string         `json:"Post.title"`
+	Contents   string         `json:"Post.contents"`
+	Hidden     bool           `json:"Post.hidden"`
+	Reactions  []Reaction     `json:"Post.reactions"`
+	Revisions  []PostRevision `json:"Post.revisions"`
+	RPosts     []UID          `json:"~posts"`
+	RReports   []UID          `json:"~Report.subject"`
 }
@@ -0,0 +1,17 @@
+package dgraph
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// PostRevision represents a database model for the PostRevision entity
+type PostRevision struct {
+	UID      string    `json:"uid"`
+	ID       store.ID  `json:"PostRevision.id"`
+	Creation time.Time `json:"PostRevision.creation"`
+	Editor   []User    `json:"PostRevision.editor"`
+	Title    string    `json:"PostRevision.title"`
+	Contents string    `json:"PostRevision.contents"`
+}
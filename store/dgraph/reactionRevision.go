@@ -0,0 +1,17 @@
+package dgraph
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// ReactionRevision represents a database model
+// for the ReactionRevision entity
+type ReactionRevision struct {
+	UID      string    `json:"uid"`
+	ID       store.ID  `json:"ReactionRevision.id"`
+	Creation time.Time `json:"ReactionRevision.creation"`
+	Editor   []User    `json:"ReactionRevision.editor"`
+	Message  string    `json:"ReactionRevision.message"`
+}
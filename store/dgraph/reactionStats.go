@@ -0,0 +1,16 @@
+package dgraph
+
+import "github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+
+// EmotionGroup represents a group of reactions
+// of a @groupby(Reaction.emotion) aggregation
+type EmotionGroup struct {
+	Emotion emotion.Emotion `json:"Reaction.emotion"`
+	Count   int             `json:"count"`
+}
+
+// ReactionStats represents the result of a count(uid) aggregation
+// of reactions grouped by emotion
+type ReactionStats struct {
+	Groups []EmotionGroup `json:"@groupby"`
+}
@@ -0,0 +1,141 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// Report represents a database model for the Report entity
+type Report struct {
+	UID      string    `json:"uid"`
+	ID       store.ID  `json:"Report.id"`
+	Creation time.Time `json:"Report.creation"`
+	Reporter []User    `json:"Report.reporter"`
+	Subject  []UID     `json:"Report.subject"`
+	Reason   string    `json:"Report.reason"`
+	Open     bool      `json:"Report.open"`
+}
+
+// moderationSubject represents either a post or a reaction
+// together with its open reports
+type moderationSubject struct {
+	UID            string   `json:"uid"`
+	ID             store.ID `json:"-"`
+	PostHidden     bool     `json:"Post.hidden"`
+	ReactionHidden bool     `json:"Reaction.hidden"`
+	OpenReports    []Report `json:"openReports"`
+
+	// isPost is true if the subject is a post, otherwise it's a reaction
+	isPost bool
+}
+
+// hidden returns true if the subject is hidden by the moderators
+func (sub moderationSubject) hidden() bool {
+	return sub.PostHidden || sub.ReactionHidden
+}
+
+// hiddenPredicate returns the predicate marking the subject as hidden
+func (sub moderationSubject) hiddenPredicate() string {
+	if sub.isPost {
+		return "Post.hidden"
+	}
+	return "Reaction.hidden"
+}
+
+// node returns the subject as either a store.Post or a store.Reaction
+func (sub moderationSubject) node() store.AGraphNode {
+	if sub.isPost {
+		return store.Post{
+			GraphNode: store.GraphNode{UID: sub.UID},
+			ID:        sub.ID,
+			Hidden:    sub.hidden(),
+		}
+	}
+	return store.Reaction{
+		GraphNode: store.GraphNode{UID: sub.UID},
+		ID:        sub.ID,
+		Hidden:    sub.hidden(),
+	}
+}
+
+// findModerationSubject returns the post or reaction identified by id
+// including its open reports
+func findModerationSubject(
+	ctx context.Context,
+	txn transaction,
+	id store.ID,
+) (sub moderationSubject, err error) {
+	var qr struct {
+		Post     []moderationSubject `json:"post"`
+		Reaction []moderationSubject `json:"reaction"`
+	}
+	if err = txn.QueryVars(
+		ctx,
+		`query Subject($id: string) {
+			post(func: eq(Post.id, $id)) {
+				uid
+				Post.hidden
+				openReports: ~Report.subject @filter(has(Report.open)) {
+					uid
+					Report.reporter { uid }
+				}
+			}
+			reaction(func: eq(Reaction.id, $id)) {
+				uid
+				Reaction.hidden
+				openReports: ~Report.subject @filter(has(Report.open)) {
+					uid
+					Report.reporter { uid }
+				}
+			}
+		}`,
+		map[string]string{
+			"$id": string(id),
+		},
+		&qr,
+	); err != nil {
+		return
+	}
+
+	switch {
+	case len(qr.Post) > 0:
+		sub = qr.Post[0]
+		sub.isPost = true
+	case len(qr.Reaction) > 0:
+		sub = qr.Reaction[0]
+	default:
+		err = strerr.New(strerr.ErrInvalidInput, "subject not found")
+		return
+	}
+	sub.ID = id
+	return
+}
+
+// closeReports closes the given open reports
+func closeReports(
+	ctx context.Context,
+	txn transaction,
+	reports []Report,
+) error {
+	if len(reports) < 1 {
+		return nil
+	}
+	deletions := make([]interface{}, len(reports))
+	for i, report := range reports {
+		deletions[i] = map[string]interface{}{
+			"uid":         report.UID,
+			"Report.open": nil,
+		}
+	}
+	deleteJSON, err := json.Marshal(deletions)
+	if err != nil {
+		return err
+	}
+	_, err = txn.Mutation(ctx, &api.Mutation{DeleteJson: deleteJSON})
+	return err
+}
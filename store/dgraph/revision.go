@@ -0,0 +1,75 @@
+package dgraph
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// postEdit returns the mutation object of a post edit applying
+// the new values and recording the previous values as a new revision.
+// Unchanged values must be nil
+func postEdit(
+	post Post,
+	editorUID string,
+	editTime time.Time,
+	newTitle *string,
+	newContents *string,
+) interface{} {
+	type revision struct {
+		ID       store.ID  `json:"PostRevision.id"`
+		Creation time.Time `json:"PostRevision.creation"`
+		Editor   UID       `json:"PostRevision.editor"`
+		Title    string    `json:"PostRevision.title"`
+		Contents string    `json:"PostRevision.contents"`
+	}
+	return struct {
+		UID         string    `json:"uid"`
+		NewTitle    *string   `json:"Post.title,omitempty"`
+		NewContents *string   `json:"Post.contents,omitempty"`
+		LastEdited  time.Time `json:"Post.lastEdited"`
+		Revision    revision  `json:"Post.revisions"`
+	}{
+		UID:         post.UID,
+		NewTitle:    newTitle,
+		NewContents: newContents,
+		LastEdited:  editTime,
+		Revision: revision{
+			ID:       store.NewID(),
+			Creation: editTime,
+			Editor:   UID{NodeID: editorUID},
+			Title:    post.Title,
+			Contents: post.Contents,
+		},
+	}
+}
+
+// reactionEdit returns the mutation object of a reaction edit applying
+// the new message and recording the previous message as a new revision
+func reactionEdit(
+	reaction Reaction,
+	editorUID string,
+	editTime time.Time,
+	newMessage string,
+) interface{} {
+	type revision struct {
+		ID       store.ID  `json:"ReactionRevision.id"`
+		Creation time.Time `json:"ReactionRevision.creation"`
+		Editor   UID       `json:"ReactionRevision.editor"`
+		Message  string    `json:"ReactionRevision.message"`
+	}
+	return struct {
+		UID        string   `json:"uid"`
+		NewMessage string   `json:"Reaction.message"`
+		Revision   revision `json:"Reaction.revisions"`
+	}{
+		UID:        reaction.UID,
+		NewMessage: newMessage,
+		Revision: revision{
+			ID:       store.NewID(),
+			Creation: editTime,
+			Editor:   UID{NodeID: editorUID},
+			Message:  reaction.Message,
+		},
+	}
+}
@@ -1,12 +1,60 @@
 package dgraph
 
-import "time"
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+)
 
 // Session represents a database model for the Session entity
 type Session struct {
-	UID       string    `json:"uid"`
-	Key       string    `json:"Session.key"`
-	Creation  time.Time `json:"Session.creation"`
-	User      []User    `json:"Session.user"`
-	RSessions []UID     `json:"~sessions"`
+	UID        string    `json:"uid"`
+	Key        string    `json:"Session.key"`
+	Creation   time.Time `json:"Session.creation"`
+	LastAccess time.Time `json:"Session.lastAccess"`
+	User       []User    `json:"Session.user"`
+	RSessions  []UID     `json:"~sessions"`
+}
+
+// closeSessions deletes the given sessions of the user including
+// the "User.sessions" and the global "sessions" references
+func closeSessions(
+	ctx context.Context,
+	txn transaction,
+	user string,
+	sessions []Session,
+) error {
+	if len(sessions) < 1 {
+		return nil
+	}
+	deletions := make([]interface{}, 0, len(sessions)*2+1)
+	userSessions := make([]UID, len(sessions))
+	for i, sess := range sessions {
+		// Delete the global "sessions" references
+		for _, ref := range sess.RSessions {
+			deletions = append(deletions, ref)
+		}
+
+		// Delete the actual Session nodes
+		deletions = append(deletions, UID{NodeID: sess.UID})
+		userSessions[i] = UID{NodeID: sess.UID}
+	}
+
+	// Delete the "User.sessions" references
+	deletions = append(deletions, struct {
+		UID          string `json:"uid"`
+		UserSessions []UID  `json:"User.sessions"`
+	}{
+		UID:          user,
+		UserSessions: userSessions,
+	})
+
+	deleteJSON, err := json.Marshal(deletions)
+	if err != nil {
+		return err
+	}
+	_, err = txn.Mutation(ctx, &api.Mutation{DeleteJson: deleteJSON})
+	return err
 }
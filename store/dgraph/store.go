@@ -3,6 +3,7 @@ package dgraph
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
@@ -11,11 +12,39 @@ import (
 	"google.golang.org/grpc"
 )
 
+// RetryConfig defines the retry budget of aborted transactions
+type RetryConfig struct {
+	// MaxAttempts defines the maximum number of attempts
+	// to run a transaction
+	MaxAttempts int
+
+	// MinBackoff defines the wait before the first retry,
+	// the wait is doubled after every further retry
+	MinBackoff time.Duration
+
+	// MaxBackoff defines the maximum wait between retries
+	MaxBackoff time.Duration
+}
+
+// setDefaults sets the default retry budget for unset options
+func (conf *RetryConfig) setDefaults() {
+	if conf.MaxAttempts < 1 {
+		conf.MaxAttempts = 5
+	}
+	if conf.MinBackoff <= 0 {
+		conf.MinBackoff = 10 * time.Millisecond
+	}
+	if conf.MaxBackoff < conf.MinBackoff {
+		conf.MaxBackoff = conf.MinBackoff * 32
+	}
+}
+
 // impl represents the service store
 type impl struct {
 	host            string
+	retry           RetryConfig
 	db              *dgo.Dgraph
-	comparePassword func(hash, password string) bool
+	comparePassword func(password, hash string) bool
 	onClose         func()
 	debugLog        *log.Logger
 	errorLog        *log.Logger
@@ -24,12 +53,15 @@ type impl struct {
 // NewStore creates a new disconnected database client instance
 func NewStore(
 	host string,
-	comparePassword func(hash, password string) bool,
+	retry RetryConfig,
+	comparePassword func(password, hash string) bool,
 	debugLog *log.Logger,
 	errorLog *log.Logger,
 ) store.Store {
+	retry.setDefaults()
 	return &impl{
 		host:            host,
+		retry:           retry,
 		db:              nil,
 		comparePassword: comparePassword,
 		debugLog:        debugLog,
@@ -58,12 +90,28 @@ func (str *impl) Prepare() error {
 		str.onClose = nil
 	}
 
-	err = str.setupSchema(context.Background())
-	if err != nil {
-		str.debugLog.Print("database schema setup")
+	// Apply pending migrations refusing to start
+	// if the database is ahead of this binary
+	if err := MigrateUp(
+		context.Background(),
+		str.db,
+		func(migration Migration) {
+			str.debugLog.Printf(
+				"database migration %d (%s) applied",
+				migration.Version,
+				migration.Description,
+			)
+		},
+	); err != nil {
+		str.onClose()
+		return errors.Wrap(err, "database migration")
 	}
+	str.debugLog.Printf(
+		"database schema at version %d",
+		LatestSchemaVersion(),
+	)
 
-	return err
+	return nil
 }
 
 // IsActive returns true if the store is operational, otherwise returns false
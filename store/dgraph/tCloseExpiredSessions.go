@@ -0,0 +1,99 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+)
+
+// CloseExpiredSessions closes all sessions created before createdBefore
+// or last accessed before accessedBefore.
+// Sessions that were never accessed are idle since their creation
+func (str *impl) CloseExpiredSessions(
+	ctx context.Context,
+	createdBefore time.Time,
+	accessedBefore time.Time,
+) (
+	result []string,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Find all expired sessions, their owners and global references
+		var qr struct {
+			Expired []Session `json:"expired"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query ExpiredSessions(
+				$createdBefore: string,
+				$accessedBefore: string
+			) {
+				expired(func: has(Session.key)) @filter(
+					lt(Session.creation, $createdBefore) OR
+					lt(Session.lastAccess, $accessedBefore) OR (
+						NOT has(Session.lastAccess) AND
+						lt(Session.creation, $accessedBefore)
+					)
+				) {
+					uid
+					Session.key
+					Session.user {
+						uid
+					}
+					~sessions {
+						uid
+					}
+				}
+			}`,
+			map[string]string{
+				"$createdBefore":  createdBefore.Format(time.RFC3339Nano),
+				"$accessedBefore": accessedBefore.Format(time.RFC3339Nano),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.Expired) < 1 {
+			return
+		}
+
+		deletions := make([]interface{}, 0, len(qr.Expired)*3)
+		result = make([]string, len(qr.Expired))
+		for i, sess := range qr.Expired {
+			result[i] = sess.Key
+
+			// Delete the global "sessions" references
+			for _, ref := range sess.RSessions {
+				deletions = append(deletions, ref)
+			}
+
+			// Delete the "User.sessions" references
+			for _, owner := range sess.User {
+				deletions = append(deletions, struct {
+					UID          string `json:"uid"`
+					UserSessions []UID  `json:"User.sessions"`
+				}{
+					UID:          owner.UID,
+					UserSessions: []UID{UID{NodeID: sess.UID}},
+				})
+			}
+
+			// Delete the actual Session nodes
+			deletions = append(deletions, UID{NodeID: sess.UID})
+		}
+
+		var deleteJSON []byte
+		deleteJSON, err = json.Marshal(deletions)
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{DeleteJson: deleteJSON})
+		return
+	})
+	return
+}
@@ -6,12 +6,11 @@ import (
 	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
-	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/store"
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
-// CreatePost creates a new post
+// CreatePost creates a new post notifying all mentioned users
 func (str *impl) CreatePost(
 	ctx context.Context,
 	creationTime time.Time,
@@ -22,123 +21,189 @@ func (str *impl) CreatePost(
 	result store.Post,
 	err error,
 ) {
+	return str.createPost(
+		ctx,
+		store.NewID(),
+		creationTime,
+		authorID,
+		title,
+		contents,
+		false,
+		true,
+	)
+}
+
+// ImportPost creates a post preserving the given identifier
+// and moderation state without notifying anyone
+func (str *impl) ImportPost(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	authorID store.ID,
+	title string,
+	contents string,
+	hidden bool,
+) (
+	result store.Post,
+	err error,
+) {
+	return str.createPost(
+		ctx,
+		id,
+		creationTime,
+		authorID,
+		title,
+		contents,
+		hidden,
+		false,
+	)
+}
+
+func (str *impl) createPost(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	authorID store.ID,
+	title string,
+	contents string,
+	hidden bool,
+	sendNotifications bool,
+) (
+	result store.Post,
+	err error,
+) {
+	result.ID = id
 	result.Title = title
 	result.Contents = contents
 	result.Creation = creationTime
+	result.Hidden = hidden
 
-	// Prepare
-	result.ID = store.NewID()
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Ensure author exists
+		var qr struct {
+			ByID   []UID `json:"byId"`
+			Author []UID `json:"author"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User(
+				$id: string,
+				$authorId: string
+			) {
+				byId(func: eq(Post.id, $id)) { uid }
+				author(func: eq(User.id, $authorId)) { uid }
+			}`,
+			map[string]string{
+				"$id":       string(result.ID),
+				"$authorId": string(authorID),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
 
-	// Begin transaction
-	txn, close := str.txn(&err)
-	if err != nil {
-		return
-	}
-	defer close()
+		if len(qr.ByID) > 0 {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"duplicate Post.id: %s",
+				result.ID,
+			)
+			return
+		}
+		if len(qr.Author) < 1 {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"author not found",
+			)
+			return
+		}
 
-	// Ensure author exists
-	var qr struct {
-		ByID   []UID `json:"byId"`
-		Author []UID `json:"author"`
-	}
-	err = txn.QueryVars(
-		ctx,
-		`query User(
-			$id: string,
-			$authorId: string
-		) {
-			byId(func: eq(Post.id, $id)) { uid }
-			author(func: eq(User.id, $authorId)) { uid }
-		}`,
-		map[string]string{
-			"$id":       string(result.ID),
-			"$authorId": string(authorID),
-		},
-		&qr,
-	)
-	if err != nil {
-		return
-	}
+		result.Author = &store.User{
+			GraphNode: store.GraphNode{
+				UID: qr.Author[0].NodeID,
+			},
+		}
 
-	if len(qr.ByID) > 0 {
-		err = errors.Errorf("duplicate Post.id: %s", result.ID)
-		return
-	}
-	if len(qr.Author) < 1 {
-		err = strerr.Newf(
-			strerr.ErrInvalidInput,
-			"author not found",
-		)
-		return
-	}
+		// Create new post
+		var newPostJSON []byte
+		newPostJSON, err = json.Marshal(struct {
+			ID       string    `json:"Post.id"`
+			Author   UID       `json:"Post.author"`
+			Title    string    `json:"Post.title"`
+			Contents string    `json:"Post.contents"`
+			Creation time.Time `json:"Post.creation"`
+			Hidden   bool      `json:"Post.hidden,omitempty"`
+		}{
+			Author:   UID{NodeID: result.Author.UID},
+			ID:       string(result.ID),
+			Title:    title,
+			Contents: contents,
+			Creation: creationTime,
+			Hidden:   hidden,
+		})
+		if err != nil {
+			return
+		}
+		var postCreationMut map[string]string
+		postCreationMut, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newPostJSON,
+		})
+		if err != nil {
+			return
+		}
+		result.UID = postCreationMut["blank-0"]
 
-	result.Author = &store.User{
-		GraphNode: store.GraphNode{
-			UID: qr.Author[0].NodeID,
-		},
-	}
+		// Update author (User.posts -> new post)
+		var updatedAuthorJSON []byte
+		updatedAuthorJSON, err = json.Marshal(struct {
+			UID   string `json:"uid"`
+			Posts UID    `json:"User.posts"`
+		}{
+			UID:   result.Author.UID,
+			Posts: UID{NodeID: result.UID},
+		})
+		if err != nil {
+			return
+		}
 
-	// Create new post
-	var newPostJSON []byte
-	newPostJSON, err = json.Marshal(struct {
-		ID       string    `json:"Post.id"`
-		Author   UID       `json:"Post.author"`
-		Title    string    `json:"Post.title"`
-		Contents string    `json:"Post.contents"`
-		Creation time.Time `json:"Post.creation"`
-	}{
-		Author:   UID{NodeID: result.Author.UID},
-		ID:       string(result.ID),
-		Title:    title,
-		Contents: contents,
-		Creation: creationTime,
-	})
-	if err != nil {
-		return
-	}
-	var postCreationMut map[string]string
-	postCreationMut, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: newPostJSON,
-	})
-	if err != nil {
-		return
-	}
-	result.UID = postCreationMut["blank-0"]
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: updatedAuthorJSON,
+		})
+		if err != nil {
+			return
+		}
 
-	// Update author (User.posts -> new post)
-	var updatedAuthorJSON []byte
-	updatedAuthorJSON, err = json.Marshal(struct {
-		UID   string `json:"uid"`
-		Posts UID    `json:"User.posts"`
-	}{
-		UID:   result.Author.UID,
-		Posts: UID{NodeID: result.UID},
-	})
-	if err != nil {
-		return
-	}
+		// Add the new post to the global Index
+		var newPostsIndexJSON []byte
+		newPostsIndexJSON, err = json.Marshal(struct {
+			UID UID `json:"posts"`
+		}{
+			UID: UID{NodeID: result.UID},
+		})
+		if err != nil {
+			return
+		}
 
-	_, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: updatedAuthorJSON,
-	})
-	if err != nil {
-		return
-	}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newPostsIndexJSON,
+		})
+		if err != nil || !sendNotifications {
+			return
+		}
 
-	// Add the new post to the global Index
-	var newPostsIndexJSON []byte
-	newPostsIndexJSON, err = json.Marshal(struct {
-		UID UID `json:"posts"`
-	}{
-		UID: UID{NodeID: result.UID},
-	})
-	if err != nil {
+		// Notify mentioned users
+		err = notify(
+			ctx,
+			txn,
+			creationTime,
+			result.Author.UID,
+			result.UID,
+			"",
+			contents,
+		)
 		return
-	}
-
-	_, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: newPostsIndexJSON,
 	})
-
 	return
 }
@@ -0,0 +1,133 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CreateToken issues a new token to the user identified by the email
+// replacing the tokens of the same kind issued to the user before
+func (str *impl) CreateToken(
+	ctx context.Context,
+	kind token.Kind,
+	key string,
+	creationTime time.Time,
+	email string,
+) (
+	result store.Token,
+	err error,
+) {
+	if err = token.Validate(kind); err != nil {
+		err = strerr.Wrap(strerr.ErrInvalidInput, err)
+		return
+	}
+
+	result.Key = key
+	result.Kind = kind
+	result.Creation = creationTime
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Find the user and the previously issued tokens
+		var qr struct {
+			User []User `json:"user"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User($email: string) {
+				user(func: eq(User.email, $email)) {
+					uid
+					User.id
+					User.email
+					User.displayName
+					User.tokens {
+						uid
+						Token.kind
+					}
+				}
+			}`,
+			map[string]string{
+				"$email": email,
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.User) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "user not found")
+			return
+		}
+		usr := qr.User[0]
+
+		result.User = &store.User{
+			GraphNode:   store.GraphNode{UID: usr.UID},
+			ID:          usr.ID,
+			Email:       usr.Email,
+			DisplayName: usr.DisplayName,
+		}
+
+		// Delete the replaced tokens
+		var replaced []Token
+		for _, tok := range usr.Tokens {
+			if tok.Kind == kind {
+				replaced = append(replaced, tok)
+			}
+		}
+		if err = deleteTokens(ctx, txn, usr.UID, replaced); err != nil {
+			return
+		}
+
+		// Create new token
+		var newTokenJSON []byte
+		newTokenJSON, err = json.Marshal(struct {
+			Key      string     `json:"Token.key"`
+			Kind     token.Kind `json:"Token.kind"`
+			Creation time.Time  `json:"Token.creation"`
+			User     UID        `json:"Token.user"`
+		}{
+			Key:      key,
+			Kind:     kind,
+			Creation: creationTime,
+			User:     UID{NodeID: usr.UID},
+		})
+		if err != nil {
+			return
+		}
+
+		var tokenCreationMut map[string]string
+		tokenCreationMut, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newTokenJSON,
+		})
+		if err != nil {
+			return
+		}
+		result.UID = tokenCreationMut["blank-0"]
+
+		// Update owner (User.tokens -> new token)
+		var updateOwnerJSON []byte
+		updateOwnerJSON, err = json.Marshal(struct {
+			UID    string `json:"uid"`
+			Tokens UID    `json:"User.tokens"`
+		}{
+			UID:    usr.UID,
+			Tokens: UID{NodeID: result.UID},
+		})
+		if err != nil {
+			return
+		}
+
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: updateOwnerJSON,
+		})
+		return
+	})
+	return
+}
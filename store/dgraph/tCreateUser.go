@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
-	"github.com/pkg/errors"
 	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
@@ -22,118 +22,183 @@ func (str *impl) CreateUser(
 	result store.User,
 	err error,
 ) {
+	return str.createUser(
+		ctx,
+		store.NewID(),
+		creationTime,
+		email,
+		displayName,
+		passwordHash,
+		[]role.Role{role.User},
+		false,
+	)
+}
+
+// ImportUser creates a user preserving the given identifier
+func (str *impl) ImportUser(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	email string,
+	displayName string,
+	passwordHash string,
+	roles []role.Role,
+	emailVerified bool,
+) (
+	result store.User,
+	err error,
+) {
+	for _, r := range roles {
+		if err = role.Validate(r); err != nil {
+			err = strerr.Wrap(strerr.ErrInvalidInput, err)
+			return
+		}
+	}
+	return str.createUser(
+		ctx,
+		id,
+		creationTime,
+		email,
+		displayName,
+		passwordHash,
+		role.Normalize(roles),
+		emailVerified,
+	)
+}
+
+func (str *impl) createUser(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	email string,
+	displayName string,
+	passwordHash string,
+	roles []role.Role,
+	emailVerified bool,
+) (
+	result store.User,
+	err error,
+) {
+	result.ID = id
 	result.Creation = creationTime
 	result.Email = email
 	result.DisplayName = displayName
 	result.Password = passwordHash
+	result.Roles = roles
+	result.EmailVerified = emailVerified
 
-	// Prepare
-	result.ID = store.NewID()
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Ensure no users with a similar email or display name already exist.
+		// The @upsert directive on the indexed predicates makes concurrent
+		// transactions reading and writing the same values conflict
+		var qr struct {
+			ByID []struct {
+				UID string `json:"uid"`
+			} `json:"byId"`
+			ByEmail []struct {
+				UID string `json:"uid"`
+			} `json:"byEmail"`
+			ByDisplayName []struct {
+				UID string `json:"uid"`
+			} `json:"byDisplayName"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User(
+				$id: string,
+				$email: string,
+				$displayName: string
+			) {
+				byId(func: eq(User.id, $id)) { uid }
+				byEmail(func: eq(User.email, $email)) { uid }
+				byDisplayName(func: eq(User.displayName, $displayName)) { uid }
+			}`,
+			map[string]string{
+				"$id":          string(result.ID),
+				"$email":       email,
+				"$displayName": displayName,
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
 
-	// Begin transaction
-	txn, close := str.txn(&err)
-	if err != nil {
-		return
-	}
-	defer close()
+		if len(qr.ByID) > 0 {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"duplicate User.id: %s",
+				result.ID,
+			)
+			return
+		}
+		if len(qr.ByEmail) > 0 {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"%d users with a similar email already exist",
+				len(qr.ByEmail),
+			)
+			return
+		}
+		if len(qr.ByDisplayName) > 0 {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"%d users with a similar displayName already exist",
+				len(qr.ByDisplayName),
+			)
+			return
+		}
 
-	// Ensure no users with a similar email already exist
-	var qr struct {
-		ByID []struct {
-			UID string `json:"uid"`
-		} `json:"byId"`
-		ByEmail []struct {
-			UID string `json:"uid"`
-		} `json:"byEmail"`
-		ByDisplayName []struct {
-			UID string `json:"uid"`
-		} `json:"byDisplayName"`
-	}
-	err = txn.QueryVars(
-		ctx,
-		`query User(
-			$id: string,
-			$email: string,
-			$displayName: string
-		) {
-			byId(func: eq(User.id, $id)) { uid }
-			byEmail(func: eq(User.email, $email)) { uid }
-			byDisplayName(func: eq(User.displayName, $displayName)) { uid }
-		}`,
-		map[string]string{
-			"$email":       email,
-			"$displayName": displayName,
-		},
-		&qr,
-	)
-	if err != nil {
-		return
-	}
+		// Create user account
+		var newUserJSON []byte
+		newUserJSON, err = json.Marshal(struct {
+			ID            string      `json:"User.id"`
+			Email         string      `json:"User.email"`
+			DisplayName   string      `json:"User.displayName"`
+			Creation      time.Time   `json:"User.creation"`
+			Password      string      `json:"User.password"`
+			Roles         []role.Role `json:"User.roles"`
+			EmailVerified bool        `json:"User.emailVerified,omitempty"`
+		}{
+			ID:            string(result.ID),
+			Email:         email,
+			DisplayName:   displayName,
+			Creation:      creationTime,
+			Password:      string(passwordHash),
+			Roles:         roles,
+			EmailVerified: emailVerified,
+		})
+		if err != nil {
+			return
+		}
 
-	if len(qr.ByID) > 0 {
-		err = errors.Errorf("duplicate User.id: %s", result.ID)
-		return
-	}
-	if len(qr.ByEmail) > 0 {
-		err = strerr.Newf(
-			strerr.ErrInvalidInput,
-			"%d users with a similar email already exist",
-			len(qr.ByEmail),
-		)
-		return
-	}
-	if len(qr.ByDisplayName) > 0 {
-		err = strerr.Newf(
-			strerr.ErrInvalidInput,
-			"%d users with a similar displayName already exist",
-			len(qr.ByDisplayName),
-		)
-		return
-	}
+		var userCreationMut map[string]string
+		userCreationMut, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newUserJSON,
+		})
+		if err != nil {
+			return
+		}
+		result.UID = userCreationMut["blank-0"]
 
-	// Create user account
-	var newUserJSON []byte
-	newUserJSON, err = json.Marshal(struct {
-		ID          string    `json:"User.id"`
-		Email       string    `json:"User.email"`
-		DisplayName string    `json:"User.displayName"`
-		Creation    time.Time `json:"User.creation"`
-		Password    string    `json:"User.password"`
-	}{
-		ID:          string(result.ID),
-		Email:       email,
-		DisplayName: displayName,
-		Creation:    creationTime,
-		Password:    string(passwordHash),
-	})
-	if err != nil {
-		return
-	}
+		// Add the new account to the global Index
+		var newUsersIndexJSON []byte
+		newUsersIndexJSON, err = json.Marshal(struct {
+			UID UID `json:"users"`
+		}{
+			UID: UID{NodeID: result.UID},
+		})
+		if err != nil {
+			return
+		}
 
-	var userCreationMut map[string]string
-	userCreationMut, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: newUserJSON,
-	})
-	if err != nil {
-		return
-	}
-	result.UID = userCreationMut["blank-0"]
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newUsersIndexJSON,
+			Set:     nil,
+		})
 
-	// Add the new account to the global Index
-	var newUsersIndexJSON []byte
-	newUsersIndexJSON, err = json.Marshal(struct {
-		UID UID `json:"users"`
-	}{
-		UID: UID{NodeID: result.UID},
-	})
-	if err != nil {
 		return
-	}
-
-	_, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: newUsersIndexJSON,
-		Set:     nil,
 	})
-
 	return
 }
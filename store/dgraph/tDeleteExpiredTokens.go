@@ -0,0 +1,89 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// DeleteExpiredTokens deletes all tokens of the given kind
+// created before createdBefore and returns the number of deleted tokens
+func (str *impl) DeleteExpiredTokens(
+	ctx context.Context,
+	kind token.Kind,
+	createdBefore time.Time,
+) (
+	result int,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Find all expired tokens and their owners
+		var qr struct {
+			Expired []Token `json:"expired"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query ExpiredTokens(
+				$kind: string,
+				$createdBefore: string
+			) {
+				expired(func: has(Token.key)) @filter(
+					eq(Token.kind, $kind) AND
+					lt(Token.creation, $createdBefore)
+				) {
+					uid
+					Token.user {
+						uid
+					}
+				}
+			}`,
+			map[string]string{
+				"$kind":          string(kind),
+				"$createdBefore": createdBefore.Format(time.RFC3339Nano),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.Expired) < 1 {
+			return
+		}
+
+		deletions := make([]interface{}, 0, len(qr.Expired)*2)
+		for _, tok := range qr.Expired {
+			// Delete the "User.tokens" references
+			for _, owner := range tok.User {
+				deletions = append(deletions, struct {
+					UID    string `json:"uid"`
+					Tokens []UID  `json:"User.tokens"`
+				}{
+					UID:    owner.UID,
+					Tokens: []UID{UID{NodeID: tok.UID}},
+				})
+			}
+
+			// Delete the actual Token nodes
+			deletions = append(deletions, UID{NodeID: tok.UID})
+		}
+
+		var deleteJSON []byte
+		deleteJSON, err = json.Marshal(deletions)
+		if err != nil {
+			return
+		}
+		if _, err = txn.Mutation(ctx, &api.Mutation{
+			DeleteJson: deleteJSON,
+		}); err != nil {
+			return
+		}
+		result = len(qr.Expired)
+		return
+	})
+	return
+}
@@ -0,0 +1,99 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DeletePost deletes a post including all of its reactions
+func (str *impl) DeletePost(
+	ctx context.Context,
+	post store.ID,
+) (
+	result store.Post,
+	err error,
+) {
+	result.ID = post
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Find the post, its author, reactions and global references
+		var qr struct {
+			Post []Post `json:"post"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Post(
+				$id: string
+			) {
+				post(func: eq(Post.id, $id)) {
+					uid
+					Post.author {
+						uid
+						User.id
+					}
+					Post.reactions { uid }
+					Post.revisions { uid }
+					~posts { uid }
+					~Report.subject { uid }
+				}
+			}`,
+			map[string]string{
+				"$id": string(post),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.Post) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "post not found")
+			return
+		}
+		pst := qr.Post[0]
+
+		// Check permission
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: pst.Author[0].ID,
+		}); err != nil {
+			return
+		}
+
+		result.UID = pst.UID
+		result.Author = &store.User{
+			GraphNode: store.GraphNode{
+				UID: pst.Author[0].UID,
+			},
+			ID: pst.Author[0].ID,
+		}
+
+		// Find all reactions to the post including nested ones
+		reactionUIDs := make([]string, len(pst.Reactions))
+		for i, reaction := range pst.Reactions {
+			reactionUIDs[i] = reaction.UID
+		}
+		var reactions []deletableReaction
+		reactions, err = collectReactions(ctx, txn, reactionUIDs)
+		if err != nil {
+			return
+		}
+
+		var deleteJSON []byte
+		deleteJSON, err = json.Marshal(append(
+			postDeletions(pst),
+			reactionDeletions(reactions)...,
+		))
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{DeleteJson: deleteJSON})
+		return
+	})
+	return
+}
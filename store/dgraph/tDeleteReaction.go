@@ -0,0 +1,104 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DeleteReaction deletes a reaction including all of its nested reactions
+func (str *impl) DeleteReaction(
+	ctx context.Context,
+	reaction store.ID,
+) (
+	result store.Reaction,
+	err error,
+) {
+	result.ID = reaction
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Find the reaction, its author and subject
+		var qr struct {
+			Reaction []struct {
+				UID     string `json:"uid"`
+				Author  []User `json:"Reaction.author"`
+				Subject []UID  `json:"Reaction.subject"`
+			} `json:"reaction"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Reaction(
+				$id: string
+			) {
+				reaction(func: eq(Reaction.id, $id)) {
+					uid
+					Reaction.author {
+						uid
+						User.id
+					}
+					Reaction.subject { uid }
+				}
+			}`,
+			map[string]string{
+				"$id": string(reaction),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.Reaction) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "reaction not found")
+			return
+		}
+		rct := qr.Reaction[0]
+
+		// Check permission
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: rct.Author[0].ID,
+		}); err != nil {
+			return
+		}
+
+		result.UID = rct.UID
+		result.Author = &store.User{
+			GraphNode: store.GraphNode{
+				UID: rct.Author[0].UID,
+			},
+			ID: rct.Author[0].ID,
+		}
+
+		// Find the reaction including all nested reactions
+		var reactions []deletableReaction
+		reactions, err = collectReactions(ctx, txn, []string{rct.UID})
+		if err != nil {
+			return
+		}
+
+		deletions := reactionDeletions(reactions)
+
+		// Delete the "Post.reactions" or "Reaction.reactions" reference
+		// of the subject
+		for _, subject := range rct.Subject {
+			deletions = append(deletions, subjectReferenceDeletion(
+				subject.NodeID,
+				rct.UID,
+			))
+		}
+
+		var deleteJSON []byte
+		deleteJSON, err = json.Marshal(deletions)
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{DeleteJson: deleteJSON})
+		return
+	})
+	return
+}
@@ -0,0 +1,168 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DeleteUser deletes a user including all of its sessions, posts,
+// published reactions, follow relations, received notifications
+// and issued tokens
+func (str *impl) DeleteUser(
+	ctx context.Context,
+	user store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	result.ID = user
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Check permission
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: user,
+		}); err != nil {
+			return
+		}
+
+		// Find the user and everything owned by it
+		var qr struct {
+			User []struct {
+				UID      string    `json:"uid"`
+				Sessions []Session `json:"User.sessions"`
+				Posts    []Post    `json:"User.posts"`
+				// PublishedReactions references the published reactions
+				PublishedReactions []struct {
+					UID     string `json:"uid"`
+					Subject []UID  `json:"Reaction.subject"`
+				} `json:"User.publishedReactions"`
+				RFollowing    []UID `json:"~User.following"`
+				Notifications []UID `json:"User.notifications"`
+				Tokens        []UID `json:"User.tokens"`
+				RUsers        []UID `json:"~users"`
+			} `json:"user"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User(
+				$id: string
+			) {
+				user(func: eq(User.id, $id)) {
+					uid
+					User.sessions {
+						uid
+						~sessions { uid }
+					}
+					User.posts {
+						uid
+						Post.author { uid }
+						Post.reactions { uid }
+						Post.revisions { uid }
+						~posts { uid }
+						~Report.subject { uid }
+					}
+					User.publishedReactions {
+						uid
+						Reaction.subject { uid }
+					}
+					~User.following { uid }
+					User.notifications { uid }
+					User.tokens { uid }
+					~users { uid }
+				}
+			}`,
+			map[string]string{
+				"$id": string(user),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.User) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "user not found")
+			return
+		}
+		usr := qr.User[0]
+		result.UID = usr.UID
+
+		var deletions []interface{}
+
+		// Delete all sessions including the global "sessions" references
+		for _, sess := range usr.Sessions {
+			for _, ref := range sess.RSessions {
+				deletions = append(deletions, ref)
+			}
+			deletions = append(deletions, UID{NodeID: sess.UID})
+		}
+
+		// Delete all posts
+		var reactionUIDs []string
+		for _, post := range usr.Posts {
+			deletions = append(deletions, postDeletions(post)...)
+			for _, reaction := range post.Reactions {
+				reactionUIDs = append(reactionUIDs, reaction.UID)
+			}
+		}
+
+		// Delete all published reactions including the subject references
+		for _, reaction := range usr.PublishedReactions {
+			reactionUIDs = append(reactionUIDs, reaction.UID)
+			for _, subject := range reaction.Subject {
+				deletions = append(deletions, subjectReferenceDeletion(
+					subject.NodeID,
+					reaction.UID,
+				))
+			}
+		}
+
+		// Delete all reactions to the posts and published reactions
+		// including nested ones
+		var reactions []deletableReaction
+		reactions, err = collectReactions(ctx, txn, reactionUIDs)
+		if err != nil {
+			return
+		}
+		deletions = append(deletions, reactionDeletions(reactions)...)
+
+		// Delete the "User.following" references of the followers
+		for _, follower := range usr.RFollowing {
+			deletions = append(deletions, followMutation(
+				follower.NodeID,
+				usr.UID,
+			))
+		}
+
+		// Delete all received notifications
+		for _, notif := range usr.Notifications {
+			deletions = append(deletions, notif)
+		}
+
+		// Delete all issued tokens
+		for _, tok := range usr.Tokens {
+			deletions = append(deletions, tok)
+		}
+
+		// Delete the global "users" references and the actual User node
+		for _, ref := range usr.RUsers {
+			deletions = append(deletions, ref)
+		}
+		deletions = append(deletions, UID{NodeID: usr.UID})
+
+		var deleteJSON []byte
+		deleteJSON, err = json.Marshal(deletions)
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{DeleteJson: deleteJSON})
+		return
+	})
+	return
+}
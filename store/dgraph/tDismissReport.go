@@ -0,0 +1,93 @@
+package dgraph
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DismissReport closes an open report leaving its subject visible
+func (str *impl) DismissReport(
+	ctx context.Context,
+	report store.ID,
+) (
+	result store.Report,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Check permission
+		if err = auth.Authorize(ctx, auth.HasRole{
+			Role: role.Moderator,
+		}); err != nil {
+			return
+		}
+
+		// Find the report
+		var qr struct {
+			Report []Report `json:"report"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Report($id: string) {
+				report(func: eq(Report.id, $id)) {
+					uid
+					Report.id
+					Report.creation
+					Report.reason
+					Report.open
+					Report.reporter @filter(has(User.id)) {
+						uid
+						User.id
+					}
+					Report.subject { uid }
+				}
+			}`,
+			map[string]string{
+				"$id": string(report),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.Report) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "report not found")
+			return
+		}
+		rep := qr.Report[0]
+		if !rep.Open {
+			err = strerr.New(strerr.ErrInvalidInput, "report already closed")
+			return
+		}
+
+		result = store.Report{
+			GraphNode: store.GraphNode{
+				UID: rep.UID,
+			},
+			ID:       rep.ID,
+			Creation: rep.Creation,
+			Reason:   rep.Reason,
+		}
+		if len(rep.Reporter) > 0 {
+			result.Reporter = &store.User{
+				GraphNode: store.GraphNode{
+					UID: rep.Reporter[0].UID,
+				},
+				ID: rep.Reporter[0].ID,
+			}
+		}
+		if len(rep.Subject) > 0 {
+			result.Subject = store.GraphNode{
+				UID: rep.Subject[0].NodeID,
+			}
+		}
+		err = closeReports(ctx, txn, qr.Report)
+		return
+	})
+	return
+}
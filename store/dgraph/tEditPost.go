@@ -3,6 +3,7 @@ package dgraph
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
@@ -15,6 +16,7 @@ func (str *impl) EditPost(
 	ctx context.Context,
 	post store.ID,
 	editor store.ID,
+	editTime time.Time,
 	newTitle *string,
 	newContents *string,
 ) (
@@ -27,111 +29,118 @@ func (str *impl) EditPost(
 ) {
 	result.ID = post
 
-	// Begin transaction
-	txn, close := str.txn(&err)
-	if err != nil {
-		return
-	}
-	defer close()
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Reset the state of previous attempts
+		newTitle, newContents := newTitle, newContents
+		changes.Title, changes.Contents = false, false
 
-	// Ensure post and editor exist
-	var qr struct {
-		Post   []Post `json:"post"`
-		Editor []User `json:"editor"`
-	}
-	err = txn.QueryVars(
-		ctx,
-		`query User(
-			$id: string,
-			$editorId: string
-		) {
-			post(func: eq(Post.id, $id)) {
-				uid
-				Post.author {
+		// Ensure post and editor exist
+		var qr struct {
+			Post   []Post `json:"post"`
+			Editor []User `json:"editor"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User(
+				$id: string,
+				$editorId: string
+			) {
+				post(func: eq(Post.id, $id)) {
 					uid
-					User.id
+					Post.author {
+						uid
+						User.id
+					}
+					Post.creation
+					Post.lastEdited
+					Post.title
+					Post.contents
 				}
-				Post.creation
-				Post.title
-				Post.contents
-			}
-			editor(func: eq(User.id, $editorId)) { uid }
-		}`,
-		map[string]string{
-			"$id":       string(post),
-			"$editorId": string(editor),
-		},
-		&qr,
-	)
-	if err != nil {
-		return
-	}
+				editor(func: eq(User.id, $editorId)) { uid }
+			}`,
+			map[string]string{
+				"$id":       string(post),
+				"$editorId": string(editor),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
 
-	if len(qr.Post) < 1 {
-		err = strerr.New(strerr.ErrInvalidInput, "post not found")
-		return
-	}
-	if len(qr.Editor) < 1 {
-		err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
-		return
-	}
+		if len(qr.Post) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "post not found")
+			return
+		}
+		if len(qr.Editor) < 1 {
+			err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
+			return
+		}
 
-	// Check permission
-	if err = auth.Authorize(ctx, auth.IsOwner{
-		Owner: store.ID(qr.Post[0].Author[0].ID),
-	}); err != nil {
-		return
-	}
+		// Check permission
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: store.ID(qr.Post[0].Author[0].ID),
+		}); err != nil {
+			return
+		}
 
-	if newTitle != nil {
-		result.Title = *newTitle
-		if qr.Post[0].Title == *newTitle {
-			newTitle = nil
+		if newTitle != nil {
+			result.Title = *newTitle
+			if qr.Post[0].Title == *newTitle {
+				newTitle = nil
+			} else {
+				changes.Title = true
+			}
 		} else {
-			changes.Title = true
+			result.Title = qr.Post[0].Title
 		}
-	} else {
-		result.Title = qr.Post[0].Title
-	}
-	if newContents != nil {
-		result.Contents = *newContents
-		if qr.Post[0].Contents == *newContents {
-			newContents = nil
+		if newContents != nil {
+			result.Contents = *newContents
+			if qr.Post[0].Contents == *newContents {
+				newContents = nil
+			} else {
+				changes.Contents = true
+			}
 		} else {
-			changes.Contents = true
+			result.Contents = qr.Post[0].Contents
+		}
+
+		result.UID = qr.Post[0].UID
+		result.Creation = qr.Post[0].Creation
+		result.Author = &store.User{
+			GraphNode: store.GraphNode{
+				UID: qr.Post[0].Author[0].UID,
+			},
 		}
-	} else {
-		result.Contents = qr.Post[0].Contents
-	}
 
-	result.UID = qr.Post[0].UID
-	result.Creation = qr.Post[0].Creation
-	result.Author = &store.User{
-		GraphNode: store.GraphNode{
-			UID: qr.Post[0].Author[0].UID,
-		},
-	}
+		result.LastEdited = qr.Post[0].LastEdited
+		if !changes.Title && !changes.Contents {
+			return
+		}
+		result.LastEdited = editTime
+
+		// Edit the post recording the previous values as a new revision
+		mutation := postEdit(
+			qr.Post[0],
+			qr.Editor[0].UID,
+			editTime,
+			newTitle,
+			newContents,
+		)
+		var mutatedPostJSON []byte
+		mutatedPostJSON, err = json.Marshal(mutation)
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: mutatedPostJSON,
+		})
+		if err != nil {
+			return
+		}
 
-	// Edit the post
-	var mutatedPostJSON []byte
-	mutatedPostJSON, err = json.Marshal(struct {
-		UID         string  `json:"uid"`
-		NewTitle    *string `json:"Post.title,omitempty"`
-		NewContents *string `json:"Post.contents,omitempty"`
-	}{
-		UID:         result.UID,
-		NewTitle:    newTitle,
-		NewContents: newContents,
-	})
-	if err != nil {
 		return
-	}
-	_, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: mutatedPostJSON,
 	})
-	if err != nil {
-		return
-	}
-
 	return
 }
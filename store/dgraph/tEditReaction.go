@@ -3,6 +3,7 @@ package dgraph
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
@@ -15,6 +16,7 @@ func (str *impl) EditReaction(
 	ctx context.Context,
 	reaction store.ID,
 	editor store.ID,
+	editTime time.Time,
 	newMessage string,
 ) (
 	result store.Reaction,
@@ -25,116 +27,115 @@ func (str *impl) EditReaction(
 ) {
 	result.ID = reaction
 
-	// Begin transaction
-	txn, close := str.txn(&err)
-	if err != nil {
-		return
-	}
-	defer close()
-
-	// Ensure reaction and editor exist
-	var qr struct {
-		Reaction []Reaction `json:"reaction"`
-		Editor   []User     `json:"editor"`
-	}
-	err = txn.QueryVars(
-		ctx,
-		`query Reaction(
-			$id: string,
-			$editorId: string
-		) {
-			reaction(func: eq(Reaction.id, $id)) {
-				uid
-				Reaction.subject {
-					uid
-					Post.id
-					Reaction.id
-				}
-				Reaction.author {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Ensure reaction and editor exist
+		var qr struct {
+			Reaction []Reaction `json:"reaction"`
+			Editor   []User     `json:"editor"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Reaction(
+				$id: string,
+				$editorId: string
+			) {
+				reaction(func: eq(Reaction.id, $id)) {
 					uid
-					User.id
+					Reaction.subject {
+						uid
+						Post.id
+						Reaction.id
+					}
+					Reaction.author {
+						uid
+						User.id
+					}
+					Reaction.creation
+					Reaction.emotion
+					Reaction.message
 				}
-				Reaction.creation
-				Reaction.emotion
-				Reaction.message
-			}
-			editor(func: eq(User.id, $editorId)) { uid }
-		}`,
-		map[string]string{
-			"$id":       string(reaction),
-			"$editorId": string(editor),
-		},
-		&qr,
-	)
-	if err != nil {
-		return
-	}
-
-	if len(qr.Reaction) < 1 {
-		err = strerr.New(strerr.ErrInvalidInput, "reaction not found")
-		return
-	}
-	if len(qr.Editor) < 1 {
-		err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
-		return
-	}
+				editor(func: eq(User.id, $editorId)) { uid }
+			}`,
+			map[string]string{
+				"$id":       string(reaction),
+				"$editorId": string(editor),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
 
-	// Check permission
-	if err = auth.Authorize(ctx, auth.IsOwner{
-		Owner: store.ID(qr.Reaction[0].Author[0].ID),
-	}); err != nil {
-		return
-	}
+		if len(qr.Reaction) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "reaction not found")
+			return
+		}
+		if len(qr.Editor) < 1 {
+			err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
+			return
+		}
 
-	result.Message = newMessage
-	if qr.Reaction[0].Message != newMessage {
-		changes.Message = true
-	}
+		// Check permission
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: store.ID(qr.Reaction[0].Author[0].ID),
+		}); err != nil {
+			return
+		}
 
-	react := qr.Reaction[0]
+		result.Message = newMessage
+		changes.Message = qr.Reaction[0].Message != newMessage
 
-	result.UID = react.UID
-	result.Creation = react.Creation
-	result.Author = &store.User{
-		GraphNode: store.GraphNode{
-			UID: react.Author[0].UID,
-		},
-	}
-	result.Emotion = react.Emotion
+		react := qr.Reaction[0]
 
-	switch subject := react.Subject[0].V.(type) {
-	case *Post:
-		result.Subject = store.Post{
+		result.UID = react.UID
+		result.Creation = react.Creation
+		result.Author = &store.User{
 			GraphNode: store.GraphNode{
-				UID: subject.UID,
+				UID: react.Author[0].UID,
 			},
 		}
-	case *Reaction:
-		result.Subject = store.Reaction{
-			GraphNode: store.GraphNode{
-				UID: subject.UID,
-			},
+		result.Emotion = react.Emotion
+
+		switch subject := react.Subject[0].V.(type) {
+		case *Post:
+			result.Subject = store.Post{
+				GraphNode: store.GraphNode{
+					UID: subject.UID,
+				},
+			}
+		case *Reaction:
+			result.Subject = store.Reaction{
+				GraphNode: store.GraphNode{
+					UID: subject.UID,
+				},
+			}
+		}
+
+		if !changes.Message {
+			return
+		}
+
+		// Edit the reaction recording the previous message as a new revision
+		mutation := reactionEdit(
+			react,
+			qr.Editor[0].UID,
+			editTime,
+			newMessage,
+		)
+		var mutatedReactionJSON []byte
+		mutatedReactionJSON, err = json.Marshal(mutation)
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: mutatedReactionJSON,
+		})
+		if err != nil {
+			return
 		}
-	}
 
-	// Edit the reaction
-	var mutatedReactionJSON []byte
-	mutatedReactionJSON, err = json.Marshal(struct {
-		UID        string `json:"uid"`
-		NewMessage string `json:"Reaction.message"`
-	}{
-		UID:        result.UID,
-		NewMessage: newMessage,
-	})
-	if err != nil {
 		return
-	}
-	_, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: mutatedReactionJSON,
 	})
-	if err != nil {
-		return
-	}
-
 	return
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 )
 
@@ -26,97 +27,156 @@ func (str *impl) EditUser(
 ) {
 	result.ID = user
 
-	// Begin transaction
-	txn, close := str.txn(&err)
-	if err != nil {
-		return
-	}
-	defer close()
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Reset the state of previous attempts
+		newEmail, newPassword := newEmail, newPassword
+		changes.Email, changes.Password = false, false
 
-	// Ensure user and editor exist
-	var qr struct {
-		User   []User `json:"user"`
-		Editor []User `json:"editor"`
-	}
-	err = txn.QueryVars(
-		ctx,
-		`query User(
-			$id: string,
-			$editorId: string
-		) {
-			user(func: eq(User.id, $id)) {
-				uid
-				User.creation
-				User.displayName
-				User.email
-				User.password
-			}
-			editor(func: eq(User.id, $editorId)) { uid }
-		}`,
-		map[string]string{
-			"$id":       string(user),
-			"$editorId": string(editor),
-		},
-		&qr,
-	)
-	if err != nil {
-		return
-	}
+		// Ensure user and editor exist
+		var qr struct {
+			User   []User `json:"user"`
+			Editor []User `json:"editor"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User(
+				$id: string,
+				$editorId: string
+			) {
+				user(func: eq(User.id, $id)) {
+					uid
+					User.creation
+					User.displayName
+					User.email
+					User.emailVerified
+					User.password
+					User.tokens {
+						uid
+						Token.kind
+					}
+				}
+				editor(func: eq(User.id, $editorId)) { uid }
+			}`,
+			map[string]string{
+				"$id":       string(user),
+				"$editorId": string(editor),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
 
-	if len(qr.User) < 1 {
-		err = strerr.New(strerr.ErrInvalidInput, "user profile not found")
-		return
-	}
-	if len(qr.Editor) < 1 {
-		err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
-		return
-	}
+		if len(qr.User) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "user profile not found")
+			return
+		}
+		if len(qr.Editor) < 1 {
+			err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
+			return
+		}
 
-	if newEmail != nil {
-		result.Email = *newEmail
-		if qr.User[0].Email == *newEmail {
-			newEmail = nil
+		if newEmail != nil {
+			result.Email = *newEmail
+			if qr.User[0].Email == *newEmail {
+				newEmail = nil
+			} else {
+				// Ensure no users with a similar email already exist
+				var byEmail struct {
+					Users []UID `json:"byEmail"`
+				}
+				err = txn.QueryVars(
+					ctx,
+					`query User($email: string) {
+						byEmail(func: eq(User.email, $email)) { uid }
+					}`,
+					map[string]string{
+						"$email": *newEmail,
+					},
+					&byEmail,
+				)
+				if err != nil {
+					return
+				}
+				if len(byEmail.Users) > 0 {
+					err = strerr.Newf(
+						strerr.ErrInvalidInput,
+						"%d users with a similar email already exist",
+						len(byEmail.Users),
+					)
+					return
+				}
+				changes.Email = true
+			}
 		} else {
-			changes.Email = true
+			result.Email = qr.User[0].Email
 		}
-	} else {
-		result.Email = qr.User[0].Email
-	}
-	if newPassword != nil {
-		result.Password = *newPassword
-		if qr.User[0].Password == *newPassword {
-			newPassword = nil
+		if newPassword != nil {
+			result.Password = *newPassword
+			if qr.User[0].Password == *newPassword {
+				newPassword = nil
+			} else {
+				changes.Password = true
+			}
 		} else {
-			changes.Password = true
+			result.Password = qr.User[0].Password
 		}
-	} else {
-		result.Password = qr.User[0].Password
-	}
 
-	result.UID = qr.User[0].UID
-	result.Creation = qr.User[0].Creation
-	result.DisplayName = qr.User[0].DisplayName
+		result.UID = qr.User[0].UID
+		result.Creation = qr.User[0].Creation
+		result.DisplayName = qr.User[0].DisplayName
+		result.EmailVerified = qr.User[0].EmailVerified && !changes.Email
+
+		// Edit the user profile
+		var mutatedUserJSON []byte
+		mutatedUserJSON, err = json.Marshal(struct {
+			UID         string  `json:"uid"`
+			NewEmail    *string `json:"User.email,omitempty"`
+			NewPassword *string `json:"User.password,omitempty"`
+		}{
+			UID:         result.UID,
+			NewEmail:    newEmail,
+			NewPassword: newPassword,
+		})
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: mutatedUserJSON,
+		})
+		if err != nil {
+			return
+		}
+
+		if changes.Email {
+			// Reset the verification of the replaced email
+			// and delete the tokens issued for its verification
+			var verifications []Token
+			for _, tok := range qr.User[0].Tokens {
+				if tok.Kind == token.EmailVerification {
+					verifications = append(verifications, tok)
+				}
+			}
+			err = deleteTokens(ctx, txn, result.UID, verifications)
+			if err != nil {
+				return
+			}
+
+			var deleteJSON []byte
+			deleteJSON, err = json.Marshal(map[string]interface{}{
+				"uid":                result.UID,
+				"User.emailVerified": nil,
+			})
+			if err != nil {
+				return
+			}
+			_, err = txn.Mutation(ctx, &api.Mutation{
+				DeleteJson: deleteJSON,
+			})
+		}
 
-	// Edit the user profile
-	var mutatedUserJSON []byte
-	mutatedUserJSON, err = json.Marshal(struct {
-		UID         string  `json:"uid"`
-		NewEmail    *string `json:"User.email,omitempty"`
-		NewPassword *string `json:"User.password,omitempty"`
-	}{
-		UID:         result.UID,
-		NewEmail:    newEmail,
-		NewPassword: newPassword,
-	})
-	if err != nil {
 		return
-	}
-	_, err = txn.Mutation(ctx, &api.Mutation{
-		SetJson: mutatedUserJSON,
 	})
-	if err != nil {
-		return
-	}
-
 	return
 }
@@ -0,0 +1,90 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// FollowUser makes the follower follow the followee
+func (str *impl) FollowUser(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: follower,
+	}); err != nil {
+		return
+	}
+
+	return str.followUser(ctx, follower, followee)
+}
+
+// ImportFollow makes the follower follow the followee.
+// Following relations carry no data to be preserved
+func (str *impl) ImportFollow(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	return str.followUser(ctx, follower, followee)
+}
+
+func (str *impl) followUser(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		var rel followRelation
+		rel, err = findFollowRelation(ctx, txn, follower, followee)
+		if err != nil {
+			return
+		}
+		if rel.following {
+			err = strerr.New(strerr.ErrInvalidInput, "already following")
+			return
+		}
+
+		result = store.User{
+			GraphNode: store.GraphNode{
+				UID: rel.followee.UID,
+			},
+			ID:          rel.followee.ID,
+			Creation:    rel.followee.Creation,
+			Email:       rel.followee.Email,
+			DisplayName: rel.followee.DisplayName,
+		}
+
+		// Create the "User.following" edge
+		var followJSON []byte
+		followJSON, err = json.Marshal(followMutation(
+			rel.followerUID,
+			rel.followee.UID,
+		))
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: followJSON,
+		})
+		return
+	})
+	return
+}
@@ -0,0 +1,66 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// HideContent hides the given post or reaction from public lists
+// and closes all of its open reports
+func (str *impl) HideContent(
+	ctx context.Context,
+	subject store.ID,
+) (
+	result store.AGraphNode,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Check permission
+		if err = auth.Authorize(ctx, auth.HasRole{
+			Role: role.Moderator,
+		}); err != nil {
+			return
+		}
+
+		var sub moderationSubject
+		sub, err = findModerationSubject(ctx, txn, subject)
+		if err != nil {
+			return
+		}
+		if sub.hidden() {
+			err = strerr.New(strerr.ErrInvalidInput, "already hidden")
+			return
+		}
+
+		// Mark the subject hidden
+		var hideJSON []byte
+		hideJSON, err = json.Marshal(map[string]interface{}{
+			"uid":                 sub.UID,
+			sub.hiddenPredicate(): true,
+		})
+		if err != nil {
+			return
+		}
+		if _, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: hideJSON,
+		}); err != nil {
+			return
+		}
+
+		if err = closeReports(ctx, txn, sub.OpenReports); err != nil {
+			return
+		}
+
+		sub.PostHidden, sub.ReactionHidden = sub.isPost, !sub.isPost
+		result = sub.node()
+		return
+	})
+	return
+}
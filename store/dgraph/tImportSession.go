@@ -0,0 +1,140 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// ImportSession creates a session of the given user
+// preserving its key and access time and updates the indexes
+func (str *impl) ImportSession(
+	ctx context.Context,
+	key string,
+	creation time.Time,
+	lastAccess time.Time,
+	userID store.ID,
+) (
+	result store.Session,
+	err error,
+) {
+	result.Key = key
+	result.Creation = creation
+	result.LastAccess = lastAccess
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Ensure the key is unique and the user exists
+		var qr struct {
+			ByKey []UID `json:"byKey"`
+			User  []UID `json:"user"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Session(
+				$key: string,
+				$userId: string
+			) {
+				byKey(func: eq(Session.key, $key)) { uid }
+				user(func: eq(User.id, $userId)) { uid }
+			}`,
+			map[string]string{
+				"$key":    key,
+				"$userId": string(userID),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.ByKey) > 0 {
+			err = strerr.New(strerr.ErrInvalidInput, "duplicate Session.key")
+			return
+		}
+		if len(qr.User) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "user not found")
+			return
+		}
+
+		result.User = &store.User{
+			GraphNode: store.GraphNode{
+				UID: qr.User[0].NodeID,
+			},
+			ID: userID,
+		}
+
+		// Create new session, sessions exported before the last access
+		// was recorded are treated as never accessed
+		var lastAccessValue *time.Time
+		if !lastAccess.IsZero() {
+			lastAccessValue = &lastAccess
+		}
+		var newSessionJSON []byte
+		newSessionJSON, err = json.Marshal(struct {
+			Key        string     `json:"Session.key"`
+			Creation   time.Time  `json:"Session.creation"`
+			LastAccess *time.Time `json:"Session.lastAccess,omitempty"`
+			User       UID        `json:"Session.user"`
+		}{
+			Key:        key,
+			Creation:   creation,
+			LastAccess: lastAccessValue,
+			User:       UID{NodeID: result.User.UID},
+		})
+		if err != nil {
+			return
+		}
+
+		var sessCreationMut map[string]string
+		sessCreationMut, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newSessionJSON,
+		})
+		if err != nil {
+			return
+		}
+		result.UID = sessCreationMut["blank-0"]
+
+		// Update owner (User.sessions -> new session)
+		var updateOwnerJSON []byte
+		updateOwnerJSON, err = json.Marshal(struct {
+			UID      string `json:"uid"`
+			Sessions UID    `json:"User.sessions"`
+		}{
+			UID:      result.User.UID,
+			Sessions: UID{NodeID: result.UID},
+		})
+		if err != nil {
+			return
+		}
+
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: updateOwnerJSON,
+		})
+		if err != nil {
+			return
+		}
+
+		// Add the new session to the global Index
+		var newSessionIndexJSON []byte
+		newSessionIndexJSON, err = json.Marshal(struct {
+			UID UID `json:"sessions"`
+		}{
+			UID: UID{NodeID: result.UID},
+		})
+		if err != nil {
+			return
+		}
+
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newSessionIndexJSON,
+		})
+
+		return
+	})
+	return
+}
@@ -0,0 +1,166 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// MarkNotificationsRead marks the given notifications of the user as read,
+// all unread notifications are marked if notifications is nil.
+// Returns the notifications that weren't read before
+func (str *impl) MarkNotificationsRead(
+	ctx context.Context,
+	user store.ID,
+	notifications []store.ID,
+) (
+	result []store.Notification,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Check permission
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: user,
+		}); err != nil {
+			return
+		}
+
+		// Select either all unread notifications
+		// or the given notifications of the user
+		params := []string{"$id: string"}
+		vars := map[string]string{"$id": string(user)}
+		selection := "User.unreadNotifications"
+		if notifications != nil {
+			if len(notifications) < 1 {
+				return
+			}
+			filters := make([]string, len(notifications))
+			for i, id := range notifications {
+				params = append(params, fmt.Sprintf("$n%d: string", i))
+				filters[i] = fmt.Sprintf("eq(Notification.id, $n%d)", i)
+				vars[fmt.Sprintf("$n%d", i)] = string(id)
+			}
+			selection = fmt.Sprintf(
+				"notifications: User.notifications @filter(%s)",
+				strings.Join(filters, " or "),
+			)
+		}
+
+		var qr struct {
+			User []struct {
+				UID                 string         `json:"uid"`
+				Notifications       []Notification `json:"notifications"`
+				UnreadNotifications []Notification `json:"User.unreadNotifications"`
+			} `json:"user"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			fmt.Sprintf(
+				`query Notifications(%s) {
+					user(func: eq(User.id, $id)) {
+						uid
+						%s {
+							uid
+							Notification.id
+							Notification.creation
+							Notification.kind
+							Notification.read
+						}
+					}
+				}`,
+				strings.Join(params, ", "),
+				selection,
+			),
+			vars,
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.User) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "user not found")
+			return
+		}
+		usr := qr.User[0]
+
+		selected := usr.UnreadNotifications
+		if notifications != nil {
+			selected = usr.Notifications
+			found := make(map[store.ID]struct{}, len(selected))
+			for _, notif := range selected {
+				found[notif.ID] = struct{}{}
+			}
+			for _, id := range notifications {
+				if _, ok := found[id]; !ok {
+					err = strerr.Newf(
+						strerr.ErrInvalidInput,
+						"notification %s not found",
+						id,
+					)
+					return
+				}
+			}
+		}
+
+		var mutations, deletions []interface{}
+		for _, notif := range selected {
+			if notif.Read {
+				continue
+			}
+			result = append(result, store.Notification{
+				GraphNode: store.GraphNode{
+					UID: notif.UID,
+				},
+				ID:       notif.ID,
+				Creation: notif.Creation,
+				Kind:     notif.Kind,
+				Read:     true,
+				Recipient: &store.User{
+					GraphNode: store.GraphNode{
+						UID: usr.UID,
+					},
+					ID: user,
+				},
+			})
+			mutations = append(mutations, struct {
+				UID  string `json:"uid"`
+				Read bool   `json:"Notification.read"`
+			}{
+				UID:  notif.UID,
+				Read: true,
+			})
+			deletions = append(deletions, struct {
+				UID                 string `json:"uid"`
+				UnreadNotifications []UID  `json:"User.unreadNotifications"`
+			}{
+				UID:                 usr.UID,
+				UnreadNotifications: []UID{UID{NodeID: notif.UID}},
+			})
+		}
+		if len(result) < 1 {
+			return
+		}
+
+		var setJSON, deleteJSON []byte
+		if setJSON, err = json.Marshal(mutations); err != nil {
+			return
+		}
+		if deleteJSON, err = json.Marshal(deletions); err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson:    setJSON,
+			DeleteJson: deleteJSON,
+		})
+		return
+	})
+	return
+}
@@ -0,0 +1,117 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// ReportContent files a report asking the moderators
+// to review the given post or reaction
+func (str *impl) ReportContent(
+	ctx context.Context,
+	creationTime time.Time,
+	reporter store.ID,
+	subject store.ID,
+	reason string,
+) (
+	result store.Report,
+	err error,
+) {
+	result.ID = store.NewID()
+	result.Creation = creationTime
+	result.Reason = reason
+	result.Open = true
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Check permission
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: reporter,
+		}); err != nil {
+			return
+		}
+
+		// Find the reporter
+		var qr struct {
+			Reporter []UID `json:"reporter"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Reporter($id: string) {
+				reporter(func: eq(User.id, $id)) { uid }
+			}`,
+			map[string]string{
+				"$id": string(reporter),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+		if len(qr.Reporter) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "reporter not found")
+			return
+		}
+		reporterUID := qr.Reporter[0].NodeID
+
+		// Find the subject and ensure it's not already reported
+		var sub moderationSubject
+		sub, err = findModerationSubject(ctx, txn, subject)
+		if err != nil {
+			return
+		}
+		for _, report := range sub.OpenReports {
+			if len(report.Reporter) > 0 &&
+				report.Reporter[0].UID == reporterUID {
+				err = strerr.New(strerr.ErrInvalidInput, "already reported")
+				return
+			}
+		}
+
+		result.Reporter = &store.User{
+			GraphNode: store.GraphNode{
+				UID: reporterUID,
+			},
+			ID: reporter,
+		}
+		result.Subject = sub.node()
+
+		// Create the report
+		var newReportJSON []byte
+		newReportJSON, err = json.Marshal(struct {
+			ID       string    `json:"Report.id"`
+			Creation time.Time `json:"Report.creation"`
+			Reporter UID       `json:"Report.reporter"`
+			Subject  UID       `json:"Report.subject"`
+			Reason   string    `json:"Report.reason"`
+			Open     bool      `json:"Report.open"`
+		}{
+			ID:       string(result.ID),
+			Creation: creationTime,
+			Reporter: UID{NodeID: reporterUID},
+			Subject:  UID{NodeID: sub.UID},
+			Reason:   reason,
+			Open:     true,
+		})
+		if err != nil {
+			return
+		}
+
+		var reportCreationMut map[string]string
+		reportCreationMut, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: newReportJSON,
+		})
+		if err != nil {
+			return
+		}
+		result.UID = reportCreationMut["blank-0"]
+		return
+	})
+	return
+}
@@ -0,0 +1,66 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// ResetPassword replaces the password of the user the password reset token
+// identified by key was issued to, closes all of the user's sessions
+// and deletes the token.
+// Tokens created before createdAfter are considered expired
+func (str *impl) ResetPassword(
+	ctx context.Context,
+	key string,
+	createdAfter time.Time,
+	newPasswordHash string,
+) (
+	result store.User,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		var owner User
+		owner, err = useToken(ctx, txn, token.PasswordReset, key, createdAfter)
+		if err != nil {
+			return
+		}
+
+		result.UID = owner.UID
+		result.ID = owner.ID
+		result.Creation = owner.Creation
+		result.Email = owner.Email
+		result.EmailVerified = owner.EmailVerified
+		result.DisplayName = owner.DisplayName
+		result.Password = newPasswordHash
+
+		// Replace the password
+		var mutatedUserJSON []byte
+		mutatedUserJSON, err = json.Marshal(struct {
+			UID      string `json:"uid"`
+			Password string `json:"User.password"`
+		}{
+			UID:      owner.UID,
+			Password: newPasswordHash,
+		})
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: mutatedUserJSON,
+		})
+		if err != nil {
+			return
+		}
+
+		// Close all sessions, they may have been stolen
+		err = closeSessions(ctx, txn, owner.UID, owner.Sessions)
+		return
+	})
+	return
+}
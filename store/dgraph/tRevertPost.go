@@ -0,0 +1,136 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// RevertPost restores the title and contents of a post revision
+func (str *impl) RevertPost(
+	ctx context.Context,
+	post store.ID,
+	editor store.ID,
+	revision store.ID,
+	editTime time.Time,
+) (
+	result store.Post,
+	err error,
+) {
+	result.ID = post
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Ensure the post and the editor exist
+		// and the post owns the revision
+		var qr struct {
+			Post   []Post `json:"post"`
+			Editor []User `json:"editor"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Post(
+				$id: string,
+				$editorId: string,
+				$revisionId: string
+			) {
+				post(func: eq(Post.id, $id)) {
+					uid
+					Post.author {
+						uid
+						User.id
+					}
+					Post.creation
+					Post.lastEdited
+					Post.title
+					Post.contents
+					Post.revisions @filter(eq(PostRevision.id, $revisionId)) {
+						uid
+						PostRevision.title
+						PostRevision.contents
+					}
+				}
+				editor(func: eq(User.id, $editorId)) { uid }
+			}`,
+			map[string]string{
+				"$id":         string(post),
+				"$editorId":   string(editor),
+				"$revisionId": string(revision),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.Post) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "post not found")
+			return
+		}
+		if len(qr.Editor) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "editor not found")
+			return
+		}
+		pst := qr.Post[0]
+
+		// Check permission before revealing whether the revision exists
+		if err = auth.Authorize(ctx, auth.IsOwner{
+			Owner: store.ID(pst.Author[0].ID),
+		}); err != nil {
+			return
+		}
+
+		if len(pst.Revisions) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "revision not found")
+			return
+		}
+
+		result.UID = pst.UID
+		result.Creation = pst.Creation
+		result.LastEdited = pst.LastEdited
+		result.Author = &store.User{
+			GraphNode: store.GraphNode{
+				UID: pst.Author[0].UID,
+			},
+			ID: pst.Author[0].ID,
+		}
+		result.Title = pst.Revisions[0].Title
+		result.Contents = pst.Revisions[0].Contents
+
+		var newTitle, newContents *string
+		if pst.Title != result.Title {
+			newTitle = &result.Title
+		}
+		if pst.Contents != result.Contents {
+			newContents = &result.Contents
+		}
+		if newTitle == nil && newContents == nil {
+			return
+		}
+		result.LastEdited = editTime
+
+		// Restore the revision recording the current values
+		// as a new revision edited by the editor
+		var mutatedPostJSON []byte
+		mutatedPostJSON, err = json.Marshal(postEdit(
+			pst,
+			qr.Editor[0].UID,
+			editTime,
+			newTitle,
+			newContents,
+		))
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: mutatedPostJSON,
+		})
+		return
+	})
+	return
+}
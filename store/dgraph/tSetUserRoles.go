@@ -0,0 +1,112 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// SetUserRoles replaces the roles of the user,
+// the user role is always retained
+func (str *impl) SetUserRoles(
+	ctx context.Context,
+	user store.ID,
+	roles []role.Role,
+) (
+	result store.User,
+	err error,
+) {
+	for _, r := range roles {
+		if err = role.Validate(r); err != nil {
+			err = strerr.Wrap(strerr.ErrInvalidInput, err)
+			return
+		}
+	}
+	roles = role.Normalize(roles)
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Check permission
+		if err = auth.Authorize(ctx, auth.HasRole{
+			Role: role.Admin,
+		}); err != nil {
+			return
+		}
+
+		// Find the user
+		var qr struct {
+			User []User `json:"user"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User($id: string) {
+				user(func: eq(User.id, $id)) {
+					uid
+					User.id
+					User.creation
+					User.email
+					User.displayName
+				}
+			}`,
+			map[string]string{
+				"$id": string(user),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.User) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "user not found")
+			return
+		}
+		usr := qr.User[0]
+
+		result = store.User{
+			GraphNode: store.GraphNode{
+				UID: usr.UID,
+			},
+			ID:          usr.ID,
+			Creation:    usr.Creation,
+			Email:       usr.Email,
+			DisplayName: usr.DisplayName,
+			Roles:       roles,
+		}
+
+		// Replace the roles
+		var deleteJSON, setJSON []byte
+		deleteJSON, err = json.Marshal(map[string]interface{}{
+			"uid":        usr.UID,
+			"User.roles": nil,
+		})
+		if err != nil {
+			return
+		}
+		if _, err = txn.Mutation(ctx, &api.Mutation{
+			DeleteJson: deleteJSON,
+		}); err != nil {
+			return
+		}
+		setJSON, err = json.Marshal(struct {
+			UID   string      `json:"uid"`
+			Roles []role.Role `json:"User.roles"`
+		}{
+			UID:   usr.UID,
+			Roles: roles,
+		})
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: setJSON,
+		})
+		return
+	})
+	return
+}
@@ -0,0 +1,65 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TouchSession updates the last access time of the given session
+func (str *impl) TouchSession(
+	ctx context.Context,
+	key string,
+	accessTime time.Time,
+) (
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Find the session
+		var qr struct {
+			Session []UID `json:"session"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query Session(
+				$key: string
+			) {
+				session(func: eq(Session.key, $key)) { uid }
+			}`,
+			map[string]string{
+				"$key": key,
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.Session) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "session not found")
+			return
+		}
+
+		// Update the last access time
+		var touchedSessionJSON []byte
+		touchedSessionJSON, err = json.Marshal(struct {
+			UID        string    `json:"uid"`
+			LastAccess time.Time `json:"Session.lastAccess"`
+		}{
+			UID:        qr.Session[0].NodeID,
+			LastAccess: accessTime,
+		})
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: touchedSessionJSON,
+		})
+		return
+	})
+	return
+}
@@ -0,0 +1,66 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// UnfollowUser makes the follower stop following the followee
+func (str *impl) UnfollowUser(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: follower,
+	}); err != nil {
+		return
+	}
+
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		var rel followRelation
+		rel, err = findFollowRelation(ctx, txn, follower, followee)
+		if err != nil {
+			return
+		}
+		if !rel.following {
+			err = strerr.New(strerr.ErrInvalidInput, "not following")
+			return
+		}
+
+		result = store.User{
+			GraphNode: store.GraphNode{
+				UID: rel.followee.UID,
+			},
+			ID:          rel.followee.ID,
+			Creation:    rel.followee.Creation,
+			Email:       rel.followee.Email,
+			DisplayName: rel.followee.DisplayName,
+		}
+
+		// Delete the "User.following" edge
+		var unfollowJSON []byte
+		unfollowJSON, err = json.Marshal(followMutation(
+			rel.followerUID,
+			rel.followee.UID,
+		))
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			DeleteJson: unfollowJSON,
+		})
+		return
+	})
+	return
+}
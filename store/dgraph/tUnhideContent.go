@@ -0,0 +1,61 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// UnhideContent makes a hidden post or reaction publicly listed again
+func (str *impl) UnhideContent(
+	ctx context.Context,
+	subject store.ID,
+) (
+	result store.AGraphNode,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Check permission
+		if err = auth.Authorize(ctx, auth.HasRole{
+			Role: role.Moderator,
+		}); err != nil {
+			return
+		}
+
+		var sub moderationSubject
+		sub, err = findModerationSubject(ctx, txn, subject)
+		if err != nil {
+			return
+		}
+		if !sub.hidden() {
+			err = strerr.New(strerr.ErrInvalidInput, "not hidden")
+			return
+		}
+
+		// Remove the hidden mark
+		var unhideJSON []byte
+		unhideJSON, err = json.Marshal(map[string]interface{}{
+			"uid":                 sub.UID,
+			sub.hiddenPredicate(): nil,
+		})
+		if err != nil {
+			return
+		}
+		if _, err = txn.Mutation(ctx, &api.Mutation{
+			DeleteJson: unhideJSON,
+		}); err != nil {
+			return
+		}
+
+		sub.PostHidden, sub.ReactionHidden = false, false
+		result = sub.node()
+		return
+	})
+	return
+}
@@ -0,0 +1,78 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// UpgradePasswordHash replaces the password hash of the user
+// unless it was changed since oldPasswordHash was read
+func (str *impl) UpgradePasswordHash(
+	ctx context.Context,
+	user store.ID,
+	oldPasswordHash string,
+	newPasswordHash string,
+) (
+	upgraded bool,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		// Reset the state of previous attempts
+		upgraded = false
+
+		var qr struct {
+			User []User `json:"user"`
+		}
+		err = txn.QueryVars(
+			ctx,
+			`query User($id: string) {
+				user(func: eq(User.id, $id)) {
+					uid
+					User.password
+				}
+			}`,
+			map[string]string{
+				"$id": string(user),
+			},
+			&qr,
+		)
+		if err != nil {
+			return
+		}
+
+		if len(qr.User) < 1 {
+			err = strerr.New(strerr.ErrInvalidInput, "user not found")
+			return
+		}
+		if qr.User[0].Password != oldPasswordHash {
+			return
+		}
+
+		// Replace the password hash
+		var mutatedUserJSON []byte
+		mutatedUserJSON, err = json.Marshal(struct {
+			UID      string `json:"uid"`
+			Password string `json:"User.password"`
+		}{
+			UID:      qr.User[0].UID,
+			Password: newPasswordHash,
+		})
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: mutatedUserJSON,
+		})
+		if err != nil {
+			return
+		}
+		upgraded = true
+		return
+	})
+	return
+}
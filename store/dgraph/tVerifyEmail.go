@@ -0,0 +1,63 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// VerifyEmail marks the email of the user the email verification token
+// identified by key was issued to as verified and deletes the token.
+// Tokens created before createdAfter are considered expired
+func (str *impl) VerifyEmail(
+	ctx context.Context,
+	key string,
+	createdAfter time.Time,
+) (
+	result store.User,
+	err error,
+) {
+	// Run transaction
+	err = str.txn(ctx, func(txn transaction) (err error) {
+		var owner User
+		owner, err = useToken(
+			ctx,
+			txn,
+			token.EmailVerification,
+			key,
+			createdAfter,
+		)
+		if err != nil {
+			return
+		}
+
+		result.UID = owner.UID
+		result.ID = owner.ID
+		result.Creation = owner.Creation
+		result.Email = owner.Email
+		result.EmailVerified = true
+		result.DisplayName = owner.DisplayName
+
+		// Mark the email verified
+		var mutatedUserJSON []byte
+		mutatedUserJSON, err = json.Marshal(struct {
+			UID           string `json:"uid"`
+			EmailVerified bool   `json:"User.emailVerified"`
+		}{
+			UID:           owner.UID,
+			EmailVerified: true,
+		})
+		if err != nil {
+			return
+		}
+		_, err = txn.Mutation(ctx, &api.Mutation{
+			SetJson: mutatedUserJSON,
+		})
+		return
+	})
+	return
+}
@@ -0,0 +1,112 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// Token represents a database model for the Token entity
+type Token struct {
+	UID      string     `json:"uid"`
+	Key      string     `json:"Token.key"`
+	Kind     token.Kind `json:"Token.kind"`
+	Creation time.Time  `json:"Token.creation"`
+	User     []User     `json:"Token.user"`
+}
+
+// useToken finds the token of the given kind identified by key
+// and deletes it. Returns an error if the token doesn't exist
+// or was created before createdAfter
+func useToken(
+	ctx context.Context,
+	txn transaction,
+	kind token.Kind,
+	key string,
+	createdAfter time.Time,
+) (owner User, err error) {
+	var qr struct {
+		Token []Token `json:"token"`
+	}
+	if err = txn.QueryVars(
+		ctx,
+		`query Token($key: string) {
+			token(func: eq(Token.key, $key)) {
+				uid
+				Token.kind
+				Token.creation
+				Token.user {
+					uid
+					User.id
+					User.creation
+					User.email
+					User.emailVerified
+					User.displayName
+					User.sessions {
+						uid
+						~sessions { uid }
+					}
+				}
+			}
+		}`,
+		map[string]string{
+			"$key": key,
+		},
+		&qr,
+	); err != nil {
+		return
+	}
+
+	if len(qr.Token) < 1 ||
+		qr.Token[0].Kind != kind ||
+		len(qr.Token[0].User) < 1 {
+		err = strerr.New(strerr.ErrInvalidInput, "invalid token")
+		return
+	}
+	tok := qr.Token[0]
+	if tok.Creation.Before(createdAfter) {
+		err = strerr.New(strerr.ErrInvalidInput, "token expired")
+		return
+	}
+	owner = tok.User[0]
+
+	err = deleteTokens(ctx, txn, owner.UID, []Token{tok})
+	return
+}
+
+// deleteTokens deletes the given tokens of the user
+// including the "User.tokens" references
+func deleteTokens(
+	ctx context.Context,
+	txn transaction,
+	user string,
+	tokens []Token,
+) error {
+	if len(tokens) < 1 {
+		return nil
+	}
+	deletions := make([]interface{}, 0, len(tokens)*2)
+	for _, tok := range tokens {
+		deletions = append(
+			deletions,
+			struct {
+				UID    string `json:"uid"`
+				Tokens []UID  `json:"User.tokens"`
+			}{
+				UID:    user,
+				Tokens: []UID{UID{NodeID: tok.UID}},
+			},
+			UID{NodeID: tok.UID},
+		)
+	}
+	deleteJSON, err := json.Marshal(deletions)
+	if err != nil {
+		return err
+	}
+	_, err = txn.Mutation(ctx, &api.Mutation{DeleteJson: deleteJSON})
+	return err
+}
@@ -3,9 +3,12 @@ package dgraph
 import (
 	"context"
 	"encoding/json"
+	"math/rand"
+	"time"
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgo/y"
 	"github.com/pkg/errors"
 	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
 	"google.golang.org/grpc/codes"
@@ -91,30 +94,72 @@ func (txn *txn) Mutation(
 	return assigned.Uids, nil
 }
 
-func (str *impl) txn(terr *error) (transaction, func()) {
+// isAborted returns true if the transaction was aborted due to a conflict
+func isAborted(err error) bool {
+	err = errors.Cause(err)
+	return err == y.ErrAborted || status.Code(err) == codes.Aborted
+}
+
+// txn runs fn in a new transaction and commits it if fn succeeds,
+// otherwise rolls it back. Aborted transactions are retried
+// within the retry budget
+func (str *impl) txn(
+	ctx context.Context,
+	fn func(txn transaction) error,
+) error {
 	// Ensure the database is connected
 	if err := str.ensureActive(); err != nil {
-		*terr = err
-		return nil, nil
+		return err
 	}
+	return str.retry.run(ctx, func() error {
+		return str.attemptTxn(ctx, fn)
+	})
+}
 
-	// Create a new transaction and the closure functor
-	dgTxn := str.db.NewTxn()
-	txn := &txn{
-		dgTxn: dgTxn,
+// run calls attempt until it either succeeds, fails with an error
+// other than an abort or the attempts are exhausted.
+// The backoff between the attempts is doubled after every retry
+func (conf RetryConfig) run(ctx context.Context, attempt func() error) error {
+	backoff := conf.MinBackoff
+	for attempts := 1; ; attempts++ {
+		err := attempt()
+		if err == nil || !isAborted(err) {
+			return err
+		}
+		if attempts >= conf.MaxAttempts {
+			return errors.Wrapf(err, "aborted %d times", attempts)
+		}
+
+		// Wait for a random duration between half
+		// and the full backoff before retrying
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return strerr.New(strerr.ErrCanceled, "")
+		}
+		if backoff *= 2; backoff > conf.MaxBackoff {
+			backoff = conf.MaxBackoff
+		}
 	}
-	return txn, func() {
-		ctx := context.Background()
-		if *terr != nil {
-			// Rollback transaction
-			if rlbErr := dgTxn.Discard(ctx); rlbErr != nil {
-				*terr = errors.Wrapf(rlbErr, "rollback after: %s", *terr)
-			}
-		} else {
-			// Commit transaction
-			if commitErr := dgTxn.Commit(ctx); commitErr != nil {
-				*terr = errors.Wrap(commitErr, "commit")
-			}
+}
+
+// attemptTxn makes a single attempt to run the transaction
+func (str *impl) attemptTxn(
+	ctx context.Context,
+	fn func(txn transaction) error,
+) (err error) {
+	dgTxn := str.db.NewTxn()
+	if err = fn(&txn{dgTxn: dgTxn}); err != nil {
+		// Rollback transaction
+		if rlbErr := dgTxn.Discard(context.Background()); rlbErr != nil {
+			err = errors.Wrapf(rlbErr, "rollback after: %s", err)
 		}
+		return
+	}
+	// Commit transaction
+	if err = dgTxn.Commit(context.Background()); err != nil {
+		err = errors.Wrap(err, "commit")
 	}
+	return
 }
@@ -0,0 +1,86 @@
+package dgraph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/y"
+	"github.com/pkg/errors"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestRetry tests the retry loop of aborted transactions
+func TestRetry(t *testing.T) {
+	conf := RetryConfig{
+		MaxAttempts: 4,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+	}
+
+	// abortTimes returns an attempt function that's aborted
+	// the given number of times before it succeeds
+	abortTimes := func(aborts int, attempts *int) func() error {
+		return func() error {
+			*attempts++
+			if *attempts <= aborts {
+				return errors.Wrap(y.ErrAborted, "commit")
+			}
+			return nil
+		}
+	}
+
+	t.Run("retried", func(t *testing.T) {
+		attempts := 0
+		require.NoError(t, conf.run(
+			context.Background(),
+			abortTimes(3, &attempts),
+		))
+		require.Equal(t, 4, attempts)
+	})
+
+	t.Run("abortedStatus", func(t *testing.T) {
+		attempts := 0
+		require.NoError(t, conf.run(context.Background(), func() error {
+			attempts++
+			if attempts < 2 {
+				return errors.Wrap(
+					status.Error(codes.Aborted, "conflict"),
+					"mutation",
+				)
+			}
+			return nil
+		}))
+		require.Equal(t, 2, attempts)
+	})
+
+	t.Run("budgetExhausted", func(t *testing.T) {
+		attempts := 0
+		err := conf.run(context.Background(), abortTimes(10, &attempts))
+		require.Error(t, err)
+		require.True(t, isAborted(err))
+		require.Equal(t, conf.MaxAttempts, attempts)
+	})
+
+	t.Run("otherError", func(t *testing.T) {
+		attempts := 0
+		err := conf.run(context.Background(), func() error {
+			attempts++
+			return errors.New("other")
+		})
+		require.Error(t, err)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		attempts := 0
+		err := conf.run(ctx, abortTimes(10, &attempts))
+		require.Equal(t, string(strerr.ErrCanceled), strerr.ErrorCode(err))
+		require.Equal(t, 1, attempts)
+	})
+}
@@ -4,17 +4,26 @@ import (
 	"time"
 
 	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 )
 
 // User represents the database model for the User entity
 type User struct {
-	UID                string     `json:"uid"`
-	ID                 store.ID   `json:"User.id"`
-	Creation           time.Time  `json:"User.creation"`
-	Email              string     `json:"User.email"`
-	DisplayName        string     `json:"User.displayName"`
-	Password           string     `json:"User.password"`
-	Posts              []Post     `json:"User.posts"`
-	Sessions           []Session  `json:"User.sessions"`
-	PublishedReactions []Reaction `json:"User.publishedReactions"`
+	UID                 string         `json:"uid"`
+	ID                  store.ID       `json:"User.id"`
+	Creation            time.Time      `json:"User.creation"`
+	Email               string         `json:"User.email"`
+	EmailVerified       bool           `json:"User.emailVerified"`
+	DisplayName         string         `json:"User.displayName"`
+	Password            string         `json:"User.password"`
+	Roles               []role.Role    `json:"User.roles"`
+	Posts               []Post         `json:"User.posts"`
+	Sessions            []Session      `json:"User.sessions"`
+	Tokens              []Token        `json:"User.tokens"`
+	PublishedReactions  []Reaction     `json:"User.publishedReactions"`
+	Following           []User         `json:"User.following"`
+	RFollowing          []UID          `json:"~User.following"`
+	Notifications       []Notification `json:"User.notifications"`
+	UnreadNotifications []Notification `json:"User.unreadNotifications"`
+	RUsers              []UID          `json:"~users"`
 }
@@ -22,6 +22,11 @@ const (
 	Thoughtful Emotion = "thoughtful"
 )
 
+// Values returns all emotions in their canonical order
+func Values() []Emotion {
+	return []Emotion{Happy, Angry, Excited, Fearful, Thoughtful}
+}
+
 // Validate returns an error if the value is invalid
 func Validate(v Emotion) error {
 	switch v {
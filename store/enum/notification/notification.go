@@ -0,0 +1,27 @@
+package notification
+
+import "github.com/pkg/errors"
+
+// Kind represents the kind of a notification
+type Kind string
+
+const (
+	// Reaction represents a reaction to a post or reaction
+	// of the notified user
+	Reaction Kind = "reaction"
+
+	// Mention represents a mention of the notified user
+	// in a post or reaction
+	Mention Kind = "mention"
+)
+
+// Validate returns an error if the value is invalid
+func Validate(v Kind) error {
+	switch v {
+	case Reaction:
+		fallthrough
+	case Mention:
+		return nil
+	}
+	return errors.Errorf("invalid value: '%s'", v)
+}
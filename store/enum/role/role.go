@@ -0,0 +1,69 @@
+package role
+
+import "github.com/pkg/errors"
+
+// Role represents a user role
+type Role string
+
+const (
+	// User represents a regular user
+	User Role = "user"
+
+	// Moderator represents a user permitted to moderate content
+	Moderator Role = "moderator"
+
+	// Admin represents a user permitted to manage the roles of other users
+	Admin Role = "admin"
+)
+
+// Values returns all roles ordered by privilege
+func Values() []Role {
+	return []Role{User, Moderator, Admin}
+}
+
+// Validate returns an error if the value is invalid
+func Validate(v Role) error {
+	switch v {
+	case User:
+		fallthrough
+	case Moderator:
+		fallthrough
+	case Admin:
+		return nil
+	}
+	return errors.Errorf("invalid value: '%s'", v)
+}
+
+// level returns the privilege level of the role,
+// returns 0 for invalid roles
+func (r Role) level() int {
+	for i, v := range Values() {
+		if v == r {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Includes returns true if the role grants the privileges of the given role.
+// Admins are moderators and moderators are regular users
+func (r Role) Includes(other Role) bool {
+	lvl := other.level()
+	return lvl > 0 && r.level() >= lvl
+}
+
+// Normalize returns the given roles without duplicates
+// ordered by privilege including the implicit user role,
+// invalid roles are ignored
+func Normalize(roles []Role) []Role {
+	normalized := []Role{User}
+	for _, v := range Values()[1:] {
+		for _, r := range roles {
+			if r == v {
+				normalized = append(normalized, v)
+				break
+			}
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,27 @@
+package token
+
+import "github.com/pkg/errors"
+
+// Kind represents the purpose a token is issued for
+type Kind string
+
+const (
+	// PasswordReset represents a token permitting
+	// to reset the password of the user
+	PasswordReset Kind = "password-reset"
+
+	// EmailVerification represents a token verifying
+	// the user is the owner of the email address
+	EmailVerification Kind = "email-verification"
+)
+
+// Validate returns an error if the value is invalid
+func Validate(v Kind) error {
+	switch v {
+	case PasswordReset:
+		fallthrough
+	case EmailVerification:
+		return nil
+	}
+	return errors.Errorf("invalid value: '%s'", v)
+}
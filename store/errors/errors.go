@@ -19,6 +19,13 @@ const (
 	// ErrWrongCreds is thrown when the API user provides wrong authentication
 	// credentials
 	ErrWrongCreds Code = "WrongCreds"
+
+	// ErrPersistedQueryNotFound is thrown when the API user refers to
+	// a persisted query that doesn't exist
+	ErrPersistedQueryNotFound Code = "PersistedQueryNotFound"
+
+	// ErrInternal is reported to the API user in place of unexpected errors
+	ErrInternal Code = "Internal"
 )
 
 // Error represents a typed store error
@@ -38,6 +45,10 @@ func FilterCode(code Code) string {
 		return string(code)
 	case ErrWrongCreds:
 		return string(code)
+	case ErrPersistedQueryNotFound:
+		return string(code)
+	case ErrInternal:
+		return string(code)
 	}
 	return ""
 }
@@ -0,0 +1,72 @@
+package memory
+
+// deleteReactions deletes the given reactions including all reactions
+// recursively nested in them, their revisions, their reports
+// and the "User.publishedReactions" references
+func (txn *txn) deleteReactions(uids []string) {
+	var deleted []string
+	for len(uids) > 0 {
+		var nested []string
+		for _, uid := range uids {
+			reaction := txn.node(uid)
+			if reaction == nil || !reaction.has("Reaction.id") {
+				// Already deleted
+				continue
+			}
+			nested = append(nested, reaction.edges["Reaction.reactions"]...)
+
+			// Delete the "User.publishedReactions" reference
+			if author := reaction.edge("Reaction.author"); author != "" {
+				if txn.node(author) != nil {
+					txn.mutate(author).unlink("User.publishedReactions", uid)
+				}
+			}
+
+			// Delete the revisions
+			for _, revision := range reaction.edges["Reaction.revisions"] {
+				txn.delete(revision)
+			}
+
+			// Delete the actual Reaction node
+			txn.delete(uid)
+			deleted = append(deleted, uid)
+		}
+		uids = nested
+	}
+	txn.deleteReports(deleted)
+}
+
+// unlinkSubject deletes the reference from the subject
+// (either a post or a reaction) to the given reaction
+func (txn *txn) unlinkSubject(reaction *node) {
+	subject := reaction.edge("Reaction.subject")
+	if subject == "" || txn.node(subject) == nil {
+		return
+	}
+	sub := txn.mutate(subject)
+	sub.unlink("Post.reactions", reaction.uid)
+	sub.unlink("Reaction.reactions", reaction.uid)
+}
+
+// deletePost deletes the given post including its revisions, its reports,
+// all reactions to it and the "User.posts" reference
+func (txn *txn) deletePost(post *node) {
+	txn.deleteReactions(post.edges["Post.reactions"])
+
+	// Delete the "User.posts" reference
+	if author := post.edge("Post.author"); author != "" {
+		if txn.node(author) != nil {
+			txn.mutate(author).unlink("User.posts", post.uid)
+		}
+	}
+
+	// Delete the revisions
+	for _, revision := range post.edges["Post.revisions"] {
+		txn.delete(revision)
+	}
+
+	txn.deleteReports([]string{post.uid})
+
+	// Delete the actual Post node
+	txn.delete(post.uid)
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// findFollowRelation ensures both users exist and returns both users
+// and whether the follower is following the followee
+func (txn *txn) findFollowRelation(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (fwr *node, fwe *node, following bool, err error) {
+	if follower == followee {
+		err = strerr.New(strerr.ErrInvalidInput, "users can't follow themselves")
+		return
+	}
+
+	fwr = txn.findOne("User.id", string(follower))
+	if fwr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "follower not found")
+		return
+	}
+	fwe = txn.findOne("User.id", string(followee))
+	if fwe == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "followee not found")
+		return
+	}
+
+	for _, uid := range fwr.edges["User.following"] {
+		if uid == fwe.uid {
+			following = true
+			break
+		}
+	}
+	return
+}
+
+// userResult returns the store representation of the user node
+func userResult(usr *node) store.User {
+	return store.User{
+		GraphNode: store.GraphNode{
+			UID: usr.uid,
+		},
+		ID:            store.ID(usr.str("User.id")),
+		Creation:      usr.time("User.creation"),
+		Email:         usr.str("User.email"),
+		EmailVerified: usr.has("User.emailVerified"),
+		DisplayName:   usr.str("User.displayName"),
+		Roles:         userRoles(usr),
+	}
+}
+
+// userRoles returns the roles of the user
+func userRoles(usr *node) []role.Role {
+	roles, _ := usr.values["User.roles"].([]role.Role)
+	return roles
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// node represents a graph node holding scalar predicates and edges
+type node struct {
+	uid    string
+	values map[string]interface{}
+	edges  map[string][]string
+}
+
+func newNode(uid string) *node {
+	return &node{
+		uid:    uid,
+		values: make(map[string]interface{}),
+		edges:  make(map[string][]string),
+	}
+}
+
+// clone returns a detached copy of the node
+func (n *node) clone() *node {
+	cp := &node{
+		uid:    n.uid,
+		values: make(map[string]interface{}, len(n.values)),
+		edges:  make(map[string][]string, len(n.edges)),
+	}
+	for pred, val := range n.values {
+		cp.values[pred] = val
+	}
+	for pred, targets := range n.edges {
+		cp.edges[pred] = append([]string(nil), targets...)
+	}
+	return cp
+}
+
+// has returns true if the node has either a value or at least one edge
+// for the given predicate
+func (n *node) has(predicate string) bool {
+	if _, ok := n.values[predicate]; ok {
+		return true
+	}
+	return len(n.edges[predicate]) > 0
+}
+
+// str returns the value of the given predicate as a string
+func (n *node) str(predicate string) string {
+	v, _ := n.values[predicate].(string)
+	return v
+}
+
+// time returns the value of the given predicate as a time
+func (n *node) time(predicate string) time.Time {
+	v, _ := n.values[predicate].(time.Time)
+	return v
+}
+
+// edge returns the first target of the given edge predicate
+func (n *node) edge(predicate string) string {
+	if targets := n.edges[predicate]; len(targets) > 0 {
+		return targets[0]
+	}
+	return ""
+}
+
+// link adds the target to the given edge predicate
+func (n *node) link(predicate, target string) {
+	for _, uid := range n.edges[predicate] {
+		if uid == target {
+			return
+		}
+	}
+	n.edges[predicate] = append(n.edges[predicate], target)
+}
+
+// unlink removes the target from the given edge predicate
+func (n *node) unlink(predicate, target string) {
+	targets := n.edges[predicate]
+	for i, uid := range targets {
+		if uid == target {
+			n.edges[predicate] = append(targets[:i:i], targets[i+1:]...)
+			break
+		}
+	}
+	if len(n.edges[predicate]) < 1 {
+		delete(n.edges, predicate)
+	}
+}
+
+// formatUID formats a numeric node identifier the way Dgraph does
+func formatUID(uid uint64) string {
+	return fmt.Sprintf("0x%x", uid)
+}
+
+// parseUID parses a Dgraph node identifier returning 0 if it's invalid
+func parseUID(uid string) uint64 {
+	if len(uid) < 3 || uid[0] != '0' || (uid[1] != 'x' && uid[1] != 'X') {
+		return 0
+	}
+	v, err := strconv.ParseUint(uid[2:], 16, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
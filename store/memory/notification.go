@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+)
+
+// notify notifies the author of the reacted post or reaction
+// (if reactedAuthor isn't empty) about the new subject
+// and all users mentioned in the text of the subject.
+// Actors are never notified about their own actions
+// and each user is notified at most once
+func (txn *txn) notify(
+	creationTime time.Time,
+	actor string,
+	subject string,
+	reactedAuthor string,
+	text string,
+) {
+	notified := map[string]struct{}{actor: {}}
+	add := func(recipient string, kind notification.Kind) {
+		if _, ok := notified[recipient]; ok {
+			return
+		}
+		notified[recipient] = struct{}{}
+
+		notif := txn.create()
+		notif.values["Notification.id"] = string(store.NewID())
+		notif.values["Notification.creation"] = creationTime
+		notif.values["Notification.kind"] = string(kind)
+		notif.values["Notification.read"] = false
+		notif.link("Notification.recipient", recipient)
+		notif.link("Notification.actor", actor)
+		notif.link("Notification.subject", subject)
+
+		usr := txn.mutate(recipient)
+		usr.link("User.notifications", notif.uid)
+		usr.link("User.unreadNotifications", notif.uid)
+	}
+
+	if reactedAuthor != "" && txn.node(reactedAuthor) != nil {
+		add(reactedAuthor, notification.Reaction)
+	}
+	for _, name := range store.Mentions(text) {
+		if usr := txn.findOne("User.displayName", name); usr != nil {
+			add(usr.uid, notification.Mention)
+		}
+	}
+}
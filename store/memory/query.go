@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+func (str *impl) Query(
+	ctx context.Context,
+	query string,
+	result interface{},
+) error {
+	return str.QueryVars(ctx, query, nil, result)
+}
+
+func (str *impl) QueryVars(
+	ctx context.Context,
+	query string,
+	vars map[string]string,
+	result interface{},
+) error {
+	if err := ctx.Err(); err != nil {
+		return strerr.New(strerr.ErrCanceled, "")
+	}
+
+	parsed, err := parseDQL(query, vars)
+	if err != nil {
+		return errors.Wrap(err, "query")
+	}
+
+	str.lock.RLock()
+	res, err := executor{view: readView{str}}.exec(parsed)
+	if err != nil {
+		str.lock.RUnlock()
+		return errors.Wrap(err, "query")
+	}
+	// Marshal while the store is still locked
+	// since the result references stored values
+	resJSON, err := json.Marshal(res)
+	str.lock.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "query result marshal")
+	}
+
+	if err := json.Unmarshal(resJSON, result); err != nil {
+		return errors.Wrap(err, "db query result unmarshal")
+	}
+
+	return nil
+}
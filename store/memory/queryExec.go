@@ -0,0 +1,507 @@
+package memory
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// executor executes parsed queries against a view of the graph
+type executor struct {
+	view view
+
+	// vars holds the uids of the nodes assigned to uid variables
+	vars map[string][]string
+}
+
+// exec executes the query and returns the result in the shape
+// Dgraph would return it in
+func (ex executor) exec(query *dqlQuery) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(query.blocks))
+	ex.vars = make(map[string][]string)
+
+	// Blocks are executed in order, variables must thus be defined
+	// before they're used
+	for _, block := range query.blocks {
+		nodes, err := ex.root(block.fn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "block %s", block.name)
+		}
+		if nodes, err = ex.filter(nodes, block.filter); err != nil {
+			return nil, errors.Wrapf(err, "block %s", block.name)
+		}
+		if nodes, err = ex.paginate(nodes, block.args); err != nil {
+			return nil, errors.Wrapf(err, "block %s", block.name)
+		}
+		ex.define(block.varName, nodes)
+		if block.name == "var" {
+			ex.selectNodes(nodes, block.selection)
+			continue
+		}
+		if block.groupBy != "" {
+			result[block.name] = ex.groupBy(nodes, block.groupBy, block.selection)
+			continue
+		}
+		result[block.name] = ex.selectNodes(nodes, block.selection)
+	}
+	return result, nil
+}
+
+// root returns all nodes matched by the root function
+func (ex executor) root(fn *dqlFunc) ([]*node, error) {
+	if fn.name == "uid" {
+		var nodes []*node
+		for _, uid := range ex.uids(fn.args) {
+			if n := ex.view.node(uid); n != nil {
+				nodes = append(nodes, n)
+			}
+		}
+		sortNodes(nodes)
+		return dedup(nodes), nil
+	}
+	return ex.filter(ex.view.all(), &dqlFilter{fn: fn})
+}
+
+// define assigns the uids of the given nodes to the uid variable,
+// the uids are added to the variable if it's already defined
+func (ex executor) define(varName string, nodes []*node) {
+	if varName == "" {
+		return
+	}
+	for _, n := range nodes {
+		ex.vars[varName] = append(ex.vars[varName], n.uid)
+	}
+}
+
+// uids resolves the uid variables among the given uid function arguments
+func (ex executor) uids(args []string) []string {
+	uids := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "0x") {
+			uids = append(uids, arg)
+			continue
+		}
+		uids = append(uids, ex.vars[arg]...)
+	}
+	return uids
+}
+
+// dedup removes duplicates from a sorted list of nodes
+func dedup(nodes []*node) []*node {
+	result := nodes[:0]
+	for i, n := range nodes {
+		if i > 0 && nodes[i-1].uid == n.uid {
+			continue
+		}
+		result = append(result, n)
+	}
+	return result
+}
+
+// filter returns all nodes matching the filter expression
+func (ex executor) filter(nodes []*node, filter *dqlFilter) ([]*node, error) {
+	if filter == nil {
+		return nodes, nil
+	}
+	var result []*node
+	for _, n := range nodes {
+		match, err := ex.match(n, filter)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (ex executor) match(n *node, filter *dqlFilter) (bool, error) {
+	switch filter.op {
+	case "and", "or":
+		for _, child := range filter.children {
+			match, err := ex.match(n, child)
+			if err != nil {
+				return false, err
+			}
+			if filter.op == "and" && !match {
+				return false, nil
+			}
+			if filter.op == "or" && match {
+				return true, nil
+			}
+		}
+		return filter.op == "and", nil
+	case "not":
+		match, err := ex.match(n, filter.children[0])
+		return !match, err
+	}
+	return ex.matchFunc(n, filter.fn)
+}
+
+func (ex executor) matchFunc(n *node, fn *dqlFunc) (bool, error) {
+	switch fn.name {
+	case "uid":
+		for _, uid := range ex.uids(fn.args) {
+			if uid == n.uid {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "has":
+		if len(fn.args) != 1 {
+			return false, errors.New("has expects exactly 1 argument")
+		}
+		return n.has(fn.args[0]), nil
+	case "uid_in":
+		if len(fn.args) < 2 {
+			return false, errors.New("uid_in expects at least 2 arguments")
+		}
+		for _, target := range ex.targets(n, fn.args[0]) {
+			for _, uid := range fn.args[1:] {
+				if target == uid {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case "anyoftext", "alloftext":
+		if len(fn.args) != 2 {
+			return false, errors.Errorf("%s expects 2 arguments", fn.name)
+		}
+		tokens := make(map[string]bool)
+		for _, token := range textTokens(n.str(fn.args[0])) {
+			tokens[token] = true
+		}
+		terms := textTokens(fn.args[1])
+		if len(terms) < 1 {
+			return false, nil
+		}
+		for _, term := range terms {
+			if tokens[term] && fn.name == "anyoftext" {
+				return true, nil
+			}
+			if !tokens[term] && fn.name == "alloftext" {
+				return false, nil
+			}
+		}
+		return fn.name == "alloftext", nil
+	case "regexp":
+		if len(fn.args) != 2 {
+			return false, errors.New("regexp expects 2 arguments")
+		}
+		expr, err := compileRegexp(fn.args[1])
+		if err != nil {
+			return false, err
+		}
+		return n.has(fn.args[0]) && expr.MatchString(n.str(fn.args[0])), nil
+	case "eq", "ge", "gt", "le", "lt":
+		if len(fn.args) < 2 {
+			return false, errors.Errorf(
+				"%s expects at least 2 arguments",
+				fn.name,
+			)
+		}
+		val, ok := n.values[fn.args[0]]
+		if !ok {
+			return false, nil
+		}
+		for _, arg := range fn.args[1:] {
+			if compareOp(fn.name, compare(val, arg)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, errors.Errorf("unsupported function %s", fn.name)
+}
+
+func compareOp(op string, cmp int) bool {
+	switch op {
+	case "eq":
+		return cmp == 0
+	case "ge":
+		return cmp >= 0
+	case "gt":
+		return cmp > 0
+	case "le":
+		return cmp <= 0
+	case "lt":
+		return cmp < 0
+	}
+	return false
+}
+
+// compare compares a stored value to a query argument
+func compare(val interface{}, arg string) int {
+	switch val := val.(type) {
+	case time.Time:
+		if t, err := time.Parse(time.RFC3339Nano, arg); err == nil {
+			switch {
+			case val.Before(t):
+				return -1
+			case val.After(t):
+				return 1
+			}
+			return 0
+		}
+	case int:
+		if i, err := strconv.Atoi(arg); err == nil {
+			return val - i
+		}
+	case bool:
+		if b, err := strconv.ParseBool(arg); err == nil && b == val {
+			return 0
+		}
+		return -1
+	}
+	return strings.Compare(fmt.Sprint(val), arg)
+}
+
+// less returns true if value a is ordered before value b
+func less(a, b interface{}) bool {
+	switch a := a.(type) {
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Before(b)
+		}
+	case int:
+		if b, ok := b.(int); ok {
+			return a < b
+		}
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// textTokens splits the text into lower-case words.
+// Unlike Dgraph's full-text index it neither stems words
+// nor removes stop words
+func textTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// compileRegexp compiles a regular expression literal such as /^abc/i
+func compileRegexp(literal string) (*regexp.Regexp, error) {
+	end := strings.LastIndex(literal, "/")
+	if !strings.HasPrefix(literal, "/") || end < 1 {
+		return nil, errors.Errorf("invalid regular expression: %s", literal)
+	}
+	expr, flags := literal[1:end], literal[end+1:]
+	switch flags {
+	case "":
+	case "i":
+		expr = "(?i)" + expr
+	default:
+		return nil, errors.Errorf(
+			"unsupported regular expression flags: %s",
+			flags,
+		)
+	}
+	return regexp.Compile(strings.Replace(expr, `\/`, "/", -1))
+}
+
+// paginate orders and paginates the nodes according to the arguments
+func (ex executor) paginate(
+	nodes []*node,
+	args map[string]string,
+) ([]*node, error) {
+	if pred, ok := args["orderasc"]; ok {
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return less(nodes[i].values[pred], nodes[j].values[pred])
+		})
+	} else if pred, ok := args["orderdesc"]; ok {
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return less(nodes[j].values[pred], nodes[i].values[pred])
+		})
+	}
+
+	if after, ok := args["after"]; ok {
+		afterUID := parseUID(after)
+		var result []*node
+		for _, n := range nodes {
+			if parseUID(n.uid) > afterUID {
+				result = append(result, n)
+			}
+		}
+		nodes = result
+	}
+
+	if offset, ok := args["offset"]; ok {
+		o, err := strconv.Atoi(offset)
+		if err != nil || o < 0 {
+			return nil, errors.Errorf("invalid offset: %s", offset)
+		}
+		if o > len(nodes) {
+			o = len(nodes)
+		}
+		nodes = nodes[o:]
+	}
+
+	if first, ok := args["first"]; ok {
+		f, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, errors.Errorf("invalid first: %s", first)
+		}
+		switch {
+		case f >= 0 && f < len(nodes):
+			nodes = nodes[:f]
+		case f < 0 && -f < len(nodes):
+			// Negative values select the last nodes
+			nodes = nodes[len(nodes)+f:]
+		}
+	}
+	return nodes, nil
+}
+
+// targets returns the targets of the given (possibly reverse) edge
+func (ex executor) targets(n *node, predicate string) []string {
+	if !strings.HasPrefix(predicate, "~") {
+		return n.edges[predicate]
+	}
+	predicate = predicate[1:]
+	var sources []string
+	for _, src := range ex.view.all() {
+		for _, target := range src.edges[predicate] {
+			if target == n.uid {
+				sources = append(sources, src.uid)
+				break
+			}
+		}
+	}
+	return sources
+}
+
+// edge returns the paginated and filtered target nodes of the field's edge
+func (ex executor) edge(n *node, field *dqlField) ([]*node, error) {
+	var nodes []*node
+	for _, uid := range ex.targets(n, field.predicate) {
+		if target := ex.view.node(uid); target != nil {
+			nodes = append(nodes, target)
+		}
+	}
+	sortNodes(nodes)
+	nodes, err := ex.filter(nodes, field.filter)
+	if err != nil {
+		return nil, err
+	}
+	return ex.paginate(nodes, field.args)
+}
+
+// selectNodes returns the selection of each node omitting empty objects
+func (ex executor) selectNodes(
+	nodes []*node,
+	selection []*dqlField,
+) []interface{} {
+	result := []interface{}{}
+
+	// Aggregate count(uid) selections
+	for _, field := range selection {
+		if field.count && field.predicate == "uid" {
+			result = append(result, map[string]interface{}{
+				field.key(): len(nodes),
+			})
+		}
+	}
+	if len(result) > 0 {
+		return result
+	}
+
+	for _, n := range nodes {
+		if obj := ex.selectNode(n, selection); len(obj) > 0 {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// selectNode returns the selection of the given node
+func (ex executor) selectNode(
+	n *node,
+	selection []*dqlField,
+) map[string]interface{} {
+	obj := make(map[string]interface{}, len(selection))
+	for _, field := range selection {
+		switch {
+		case field.predicate == "uid":
+			obj[field.key()] = n.uid
+		case field.count:
+			targets, err := ex.edge(n, field)
+			if err != nil {
+				continue
+			}
+			obj[field.key()] = len(targets)
+		case field.selection != nil && field.groupBy != "":
+			targets, err := ex.edge(n, field)
+			if err != nil {
+				continue
+			}
+			if groups := ex.groupBy(
+				targets,
+				field.groupBy,
+				field.selection,
+			); len(groups) > 0 {
+				obj[field.key()] = groups
+			}
+		case field.selection != nil:
+			targets, err := ex.edge(n, field)
+			if err != nil {
+				continue
+			}
+			ex.define(field.varName, targets)
+			if list := ex.selectNodes(targets, field.selection); len(list) > 0 {
+				obj[field.key()] = list
+			}
+		default:
+			if val, ok := n.values[field.predicate]; ok {
+				obj[field.key()] = val
+			}
+		}
+	}
+	return obj
+}
+
+// groupBy groups the nodes by the value of the given predicate
+// and returns the count(uid) aggregation of each group
+func (ex executor) groupBy(
+	nodes []*node,
+	predicate string,
+	selection []*dqlField,
+) []interface{} {
+	counts := make(map[interface{}]int)
+	var values []interface{}
+	for _, n := range nodes {
+		val, ok := n.values[predicate]
+		if !ok {
+			continue
+		}
+		if _, known := counts[val]; !known {
+			values = append(values, val)
+		}
+		counts[val]++
+	}
+	if len(values) < 1 {
+		return []interface{}{}
+	}
+
+	groups := make([]interface{}, len(values))
+	for i, val := range values {
+		group := map[string]interface{}{predicate: val}
+		for _, field := range selection {
+			if field.count && field.predicate == "uid" {
+				group[field.key()] = counts[val]
+			}
+		}
+		groups[i] = group
+	}
+	return []interface{}{
+		map[string]interface{}{"@groupby": groups},
+	}
+}
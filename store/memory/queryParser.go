@@ -0,0 +1,618 @@
+package memory
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// The in-memory store understands the subset of the Dgraph query language
+// (GraphQL+-) used by the API. Queries are parsed into the following tree
+// which is then executed against a view of the graph
+
+// dqlQuery represents a parsed query
+type dqlQuery struct {
+	blocks []*dqlBlock
+}
+
+// dqlBlock represents a root query block,
+// blocks named var only define variables and aren't part of the result
+type dqlBlock struct {
+	name      string
+	varName   string
+	fn        *dqlFunc
+	args      map[string]string
+	filter    *dqlFilter
+	groupBy   string
+	selection []*dqlField
+}
+
+// dqlFunc represents a function such as eq(User.id, "x")
+type dqlFunc struct {
+	name string
+	args []string
+}
+
+// dqlFilter represents a filter expression tree
+type dqlFilter struct {
+	// op is either "and", "or", "not" or empty for function leafs
+	op       string
+	fn       *dqlFunc
+	children []*dqlFilter
+}
+
+// dqlField represents a selected predicate
+type dqlField struct {
+	alias     string
+	varName   string
+	predicate string
+	count     bool
+	args      map[string]string
+	filter    *dqlFilter
+	groupBy   string
+	selection []*dqlField
+}
+
+// key returns the key the field is represented by in the result
+func (f *dqlField) key() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	if f.count {
+		if f.predicate == "uid" {
+			return "count"
+		}
+		return "count(" + f.predicate + ")"
+	}
+	return f.predicate
+}
+
+type dqlTokenType int
+
+const (
+	dqlTkEOF dqlTokenType = iota
+	dqlTkName
+	dqlTkVar
+	dqlTkString
+	dqlTkRegexp
+	dqlTkPunct
+)
+
+type dqlToken struct {
+	tp  dqlTokenType
+	val string
+}
+
+func (tk dqlToken) String() string {
+	if tk.tp == dqlTkEOF {
+		return "EOF"
+	}
+	return fmt.Sprintf("'%s'", tk.val)
+}
+
+func isDQLNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// lexDQL splits the query source into tokens
+func lexDQL(src string) ([]dqlToken, error) {
+	var tokens []dqlToken
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#':
+			// Skip comments
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '"':
+			var str strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				str.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, errors.New("unclosed string")
+			}
+			i++
+			tokens = append(tokens, dqlToken{dqlTkString, str.String()})
+		case r == '/':
+			// Regular expression literals such as /^abc/i
+			// are kept as is including escape sequences and flags
+			start := i
+			i++
+			for ; i < len(runes) && runes[i] != '/'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return nil, errors.New("unclosed regular expression")
+			}
+			i++
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, dqlToken{
+				dqlTkRegexp,
+				string(runes[start:i]),
+			})
+		case r == '$' || isDQLNameRune(r) ||
+			// Negative numbers
+			r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			start := i
+			i++
+			for i < len(runes) && isDQLNameRune(runes[i]) {
+				i++
+			}
+			tp := dqlTkName
+			if r == '$' {
+				tp = dqlTkVar
+			}
+			tokens = append(tokens, dqlToken{tp, string(runes[start:i])})
+		case strings.ContainsRune("{}():,@[]=~!", r):
+			i++
+			tokens = append(tokens, dqlToken{dqlTkPunct, string(r)})
+		default:
+			return nil, errors.Errorf("unexpected character '%c'", r)
+		}
+	}
+	return tokens, nil
+}
+
+type dqlParser struct {
+	tokens []dqlToken
+	pos    int
+	vars   map[string]string
+}
+
+// parseDQL parses the query substituting the given variables
+func parseDQL(src string, vars map[string]string) (*dqlQuery, error) {
+	tokens, err := lexDQL(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "lexing")
+	}
+	varsCopy := make(map[string]string, len(vars))
+	for name, val := range vars {
+		varsCopy[name] = val
+	}
+	parser := &dqlParser{tokens: tokens, vars: varsCopy}
+	query, err := parser.query()
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing")
+	}
+	return query, nil
+}
+
+func (p *dqlParser) peek() dqlToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return dqlToken{tp: dqlTkEOF}
+}
+
+func (p *dqlParser) peekAt(offset int) dqlToken {
+	if p.pos+offset < len(p.tokens) {
+		return p.tokens[p.pos+offset]
+	}
+	return dqlToken{tp: dqlTkEOF}
+}
+
+func (p *dqlParser) next() dqlToken {
+	tk := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tk
+}
+
+func (p *dqlParser) isPunct(punct string) bool {
+	tk := p.peek()
+	return tk.tp == dqlTkPunct && tk.val == punct
+}
+
+func (p *dqlParser) expectPunct(punct string) error {
+	if tk := p.next(); tk.tp != dqlTkPunct || tk.val != punct {
+		return errors.Errorf("expected '%s', got %s", punct, tk)
+	}
+	return nil
+}
+
+func (p *dqlParser) expectName() (string, error) {
+	tk := p.next()
+	if tk.tp != dqlTkName {
+		return "", errors.Errorf("expected name, got %s", tk)
+	}
+	return tk.val, nil
+}
+
+// skipCommas skips optional separating commas
+func (p *dqlParser) skipCommas() {
+	for p.isPunct(",") {
+		p.next()
+	}
+}
+
+func (p *dqlParser) query() (*dqlQuery, error) {
+	if tk := p.peek(); tk.tp == dqlTkName && tk.val == "query" {
+		p.next()
+		// Optional query name
+		if p.peek().tp == dqlTkName {
+			p.next()
+		}
+		if p.isPunct("(") {
+			if err := p.varDefinitions(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	query := &dqlQuery{}
+	for !p.isPunct("}") {
+		if p.peek().tp == dqlTkEOF {
+			return nil, errors.New("unexpected EOF")
+		}
+		block, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		query.blocks = append(query.blocks, block)
+	}
+	p.next()
+	if tk := p.peek(); tk.tp != dqlTkEOF {
+		return nil, errors.Errorf("unexpected %s after query", tk)
+	}
+	return query, nil
+}
+
+func (p *dqlParser) varDefinitions() error {
+	if err := p.expectPunct("("); err != nil {
+		return err
+	}
+	for !p.isPunct(")") {
+		tk := p.next()
+		if tk.tp != dqlTkVar {
+			return errors.Errorf("expected variable, got %s", tk)
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return err
+		}
+		if _, err := p.expectName(); err != nil {
+			return err
+		}
+		if p.isPunct("!") {
+			p.next()
+		}
+		if p.isPunct("=") {
+			p.next()
+			def := p.next()
+			if _, provided := p.vars[tk.val]; !provided {
+				p.vars[tk.val] = def.val
+			}
+		}
+		p.skipCommas()
+	}
+	p.next()
+	return nil
+}
+
+// value reads a single value resolving variables
+func (p *dqlParser) value() (string, error) {
+	tk := p.next()
+	switch tk.tp {
+	case dqlTkVar:
+		val, defined := p.vars[tk.val]
+		if !defined {
+			return "", errors.Errorf("undefined variable %s", tk.val)
+		}
+		return val, nil
+	case dqlTkName, dqlTkString, dqlTkRegexp:
+		return tk.val, nil
+	}
+	return "", errors.Errorf("expected value, got %s", tk)
+}
+
+// args reads a parenthesized list of key-value arguments
+func (p *dqlParser) args(fn **dqlFunc) (map[string]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	args := make(map[string]string)
+	for !p.isPunct(")") {
+		key, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		if key == "func" {
+			if fn == nil {
+				return nil, errors.New("unexpected function argument")
+			}
+			if *fn, err = p.function(); err != nil {
+				return nil, err
+			}
+		} else {
+			if args[key], err = p.value(); err != nil {
+				return nil, err
+			}
+		}
+		p.skipCommas()
+	}
+	p.next()
+	return args, nil
+}
+
+func (p *dqlParser) function() (*dqlFunc, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	fn := &dqlFunc{name: name}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	for !p.isPunct(")") {
+		switch {
+		case p.isPunct("["):
+			// Flatten value lists
+			p.next()
+			for !p.isPunct("]") {
+				val, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				fn.args = append(fn.args, val)
+				p.skipCommas()
+			}
+			p.next()
+		case p.isPunct("~"):
+			p.next()
+			pred, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			fn.args = append(fn.args, "~"+pred)
+		default:
+			val, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			fn.args = append(fn.args, val)
+		}
+		p.skipCommas()
+	}
+	p.next()
+	return fn, nil
+}
+
+// directives reads all directives and returns the filter
+// and the grouping predicate if any
+func (p *dqlParser) directives() (
+	filter *dqlFilter,
+	groupBy string,
+	err error,
+) {
+	for p.isPunct("@") {
+		p.next()
+		var name string
+		if name, err = p.expectName(); err != nil {
+			return
+		}
+		if err = p.expectPunct("("); err != nil {
+			return
+		}
+		switch name {
+		case "filter":
+			if filter, err = p.filterOr(); err != nil {
+				return
+			}
+		case "groupby":
+			if groupBy, err = p.expectName(); err != nil {
+				return
+			}
+		default:
+			err = errors.Errorf("unsupported directive @%s", name)
+			return
+		}
+		if err = p.expectPunct(")"); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (p *dqlParser) isKeyword(keyword string) bool {
+	tk := p.peek()
+	return tk.tp == dqlTkName && strings.ToLower(tk.val) == keyword
+}
+
+func (p *dqlParser) filterOr() (*dqlFilter, error) {
+	left, err := p.filterAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.filterAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &dqlFilter{op: "or", children: []*dqlFilter{left, right}}
+	}
+	return left, nil
+}
+
+func (p *dqlParser) filterAnd() (*dqlFilter, error) {
+	left, err := p.filterUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.filterUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &dqlFilter{op: "and", children: []*dqlFilter{left, right}}
+	}
+	return left, nil
+}
+
+func (p *dqlParser) filterUnary() (*dqlFilter, error) {
+	switch {
+	case p.isKeyword("not"):
+		p.next()
+		operand, err := p.filterUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &dqlFilter{op: "not", children: []*dqlFilter{operand}}, nil
+	case p.isPunct("("):
+		p.next()
+		expr, err := p.filterOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	fn, err := p.function()
+	if err != nil {
+		return nil, err
+	}
+	return &dqlFilter{fn: fn}, nil
+}
+
+// varDefinition reads the optional uid variable definition ("name as")
+func (p *dqlParser) varDefinition() string {
+	if tk := p.peekAt(1); p.peek().tp == dqlTkName &&
+		tk.tp == dqlTkName && tk.val == "as" {
+		name := p.next().val
+		p.next()
+		return name
+	}
+	return ""
+}
+
+func (p *dqlParser) block() (*dqlBlock, error) {
+	varName := p.varDefinition()
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	block := &dqlBlock{name: name, varName: varName}
+	if block.args, err = p.args(&block.fn); err != nil {
+		return nil, err
+	}
+	if block.fn == nil {
+		return nil, errors.Errorf("block %s has no root function", name)
+	}
+	if block.filter, block.groupBy, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if block.name == "var" && !p.isPunct("{") {
+		// Variable blocks such as v as var(func: ...) select no predicates
+		return block, nil
+	}
+	if block.selection, err = p.selection(); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (p *dqlParser) selection() ([]*dqlField, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var fields []*dqlField
+	for !p.isPunct("}") {
+		if p.peek().tp == dqlTkEOF {
+			return nil, errors.New("unexpected EOF")
+		}
+		field, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		p.skipCommas()
+	}
+	p.next()
+	return fields, nil
+}
+
+// predicate reads a (possibly reverse) predicate name
+func (p *dqlParser) predicate() (string, error) {
+	if p.isPunct("~") {
+		p.next()
+		name, err := p.expectName()
+		return "~" + name, err
+	}
+	return p.expectName()
+}
+
+func (p *dqlParser) field() (*dqlField, error) {
+	field := &dqlField{varName: p.varDefinition()}
+
+	// Alias
+	if tk := p.peekAt(1); p.peek().tp == dqlTkName &&
+		tk.tp == dqlTkPunct && tk.val == ":" {
+		field.alias = p.next().val
+		p.next()
+	}
+
+	var err error
+	if p.isKeyword("count") && p.peekAt(1).val == "(" {
+		// count(predicate)
+		p.next()
+		p.next()
+		field.count = true
+		if field.predicate, err = p.predicate(); err != nil {
+			return nil, err
+		}
+		if p.isPunct("(") {
+			if field.args, err = p.args(nil); err != nil {
+				return nil, err
+			}
+		}
+		if field.filter, _, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return field, nil
+	}
+
+	if field.predicate, err = p.predicate(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("(") {
+		if field.args, err = p.args(nil); err != nil {
+			return nil, err
+		}
+	}
+	if field.filter, field.groupBy, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("{") {
+		if field.selection, err = p.selection(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// findModerationSubject returns the post or reaction identified by id
+// and the predicate marking it hidden
+func (txn *txn) findModerationSubject(
+	id store.ID,
+) (subject *node, hiddenPredicate string, err error) {
+	if subject = txn.findOne("Post.id", string(id)); subject != nil {
+		return subject, "Post.hidden", nil
+	}
+	if subject = txn.findOne("Reaction.id", string(id)); subject != nil {
+		return subject, "Reaction.hidden", nil
+	}
+	err = strerr.New(strerr.ErrInvalidInput, "subject not found")
+	return
+}
+
+// moderationResult returns the subject as either a store.Post
+// or a store.Reaction
+func moderationResult(subject *node) store.AGraphNode {
+	if subject.has("Post.id") {
+		return store.Post{
+			GraphNode: store.GraphNode{UID: subject.uid},
+			ID:        store.ID(subject.str("Post.id")),
+			Hidden:    subject.has("Post.hidden"),
+		}
+	}
+	return store.Reaction{
+		GraphNode: store.GraphNode{UID: subject.uid},
+		ID:        store.ID(subject.str("Reaction.id")),
+		Hidden:    subject.has("Reaction.hidden"),
+	}
+}
+
+// openReports returns the open reports of the given subject
+func (txn *txn) openReports(subject string) []*node {
+	var reports []*node
+	for _, n := range txn.all() {
+		if n.has("Report.open") && n.edge("Report.subject") == subject {
+			reports = append(reports, n)
+		}
+	}
+	return reports
+}
+
+// closeReports closes the given open reports
+func (txn *txn) closeReports(reports []*node) {
+	for _, report := range reports {
+		delete(txn.mutate(report.uid).values, "Report.open")
+	}
+}
+
+// deleteReports deletes all reports of the given subjects
+func (txn *txn) deleteReports(subjects []string) {
+	if len(subjects) < 1 {
+		return
+	}
+	deleted := make(map[string]struct{}, len(subjects))
+	for _, uid := range subjects {
+		deleted[uid] = struct{}{}
+	}
+	for _, n := range txn.all() {
+		if !n.has("Report.id") {
+			continue
+		}
+		if _, ok := deleted[n.edge("Report.subject")]; ok {
+			txn.delete(n.uid)
+		}
+	}
+}
+
+// reportResult returns the store representation of the report
+func (txn *txn) reportResult(report *node) store.Report {
+	result := store.Report{
+		GraphNode: store.GraphNode{
+			UID: report.uid,
+		},
+		ID:       store.ID(report.str("Report.id")),
+		Creation: report.time("Report.creation"),
+		Reason:   report.str("Report.reason"),
+		Open:     report.has("Report.open"),
+	}
+	if reporter := txn.node(report.edge("Report.reporter")); reporter != nil {
+		result.Reporter = &store.User{
+			GraphNode: store.GraphNode{
+				UID: reporter.uid,
+			},
+			ID: store.ID(reporter.str("User.id")),
+		}
+	}
+	if subject := txn.node(report.edge("Report.subject")); subject != nil {
+		result.Subject = moderationResult(subject)
+	}
+	return result
+}
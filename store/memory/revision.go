@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// createPostRevision records the current title and contents
+// of the given writable post as a new revision
+func (txn *txn) createPostRevision(
+	post *node,
+	editor string,
+	editTime time.Time,
+) {
+	revision := txn.create()
+	revision.values["PostRevision.id"] = string(store.NewID())
+	revision.values["PostRevision.creation"] = editTime
+	revision.values["PostRevision.title"] = post.str("Post.title")
+	revision.values["PostRevision.contents"] = post.str("Post.contents")
+	revision.link("PostRevision.editor", editor)
+
+	post.values["Post.lastEdited"] = editTime
+	post.link("Post.revisions", revision.uid)
+}
+
+// createReactionRevision records the current message
+// of the given writable reaction as a new revision
+func (txn *txn) createReactionRevision(
+	reaction *node,
+	editor string,
+	editTime time.Time,
+) {
+	revision := txn.create()
+	revision.values["ReactionRevision.id"] = string(store.NewID())
+	revision.values["ReactionRevision.creation"] = editTime
+	revision.values["ReactionRevision.message"] = reaction.str(
+		"Reaction.message",
+	)
+	revision.link("ReactionRevision.editor", editor)
+
+	reaction.link("Reaction.revisions", revision.uid)
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"log"
+	"sync"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+)
+
+// impl represents the in-memory service store
+type impl struct {
+	lock            *sync.RWMutex
+	nodes           map[string]*node
+	lastUID         uint64
+	comparePassword func(password, hash string) bool
+	debugLog        *log.Logger
+	errorLog        *log.Logger
+}
+
+// NewStore creates a new empty in-memory store instance.
+// The in-memory store is intended for tests and local development only,
+// all data is lost when the process terminates
+func NewStore(
+	comparePassword func(password, hash string) bool,
+	debugLog *log.Logger,
+	errorLog *log.Logger,
+) store.Store {
+	return &impl{
+		lock:            &sync.RWMutex{},
+		nodes:           make(map[string]*node),
+		comparePassword: comparePassword,
+		debugLog:        debugLog,
+		errorLog:        errorLog,
+	}
+}
+
+// Prepare prepares the store for use
+func (str *impl) Prepare() error {
+	str.debugLog.Print("in-memory database prepared")
+	return nil
+}
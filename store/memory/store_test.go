@@ -0,0 +1,568 @@
+package memory_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/dgraph"
+	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+	"github.com/romshark/dgraph_graphql_go/store/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) store.Store {
+	str := memory.NewStore(
+		func(password, hash string) bool { return password == hash },
+		log.New(ioutil.Discard, "", 0),
+		log.New(ioutil.Discard, "", 0),
+	)
+	require.NoError(t, str.Prepare())
+	return str
+}
+
+// TestQuery tests querying the in-memory store
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	str := newStore(t)
+	timeNow := time.Now().Truncate(time.Second)
+
+	usr, err := str.CreateUser(ctx, timeNow, "t@t.t", "usr", "pass")
+	require.NoError(t, err)
+	post1, err := str.CreatePost(ctx, timeNow, usr.ID, "title1", "contents")
+	require.NoError(t, err)
+	post2, err := str.CreatePost(ctx, timeNow, usr.ID, "title2", "contents")
+	require.NoError(t, err)
+	reaction, err := str.CreateReaction(
+		ctx,
+		timeNow,
+		usr.ID,
+		post2.ID,
+		emotion.Happy,
+		"message",
+	)
+	require.NoError(t, err)
+
+	t.Run("eq", func(t *testing.T) {
+		var qr struct {
+			User []dgraph.User `json:"user"`
+		}
+		require.NoError(t, str.QueryVars(
+			ctx,
+			`query User($id: string) {
+				user(func: eq(User.id, $id)) {
+					uid
+					User.id
+					User.creation
+					User.displayName
+					User.posts { uid Post.title }
+				}
+			}`,
+			map[string]string{"$id": string(usr.ID)},
+			&qr,
+		))
+		require.Len(t, qr.User, 1)
+		require.Equal(t, usr.UID, qr.User[0].UID)
+		require.Equal(t, usr.ID, qr.User[0].ID)
+		require.Equal(t, "usr", qr.User[0].DisplayName)
+		require.Equal(t, timeNow.Unix(), qr.User[0].Creation.Unix())
+		require.Len(t, qr.User[0].Posts, 2)
+		require.Equal(t, "title1", qr.User[0].Posts[0].Title)
+		require.Equal(t, "title2", qr.User[0].Posts[1].Title)
+	})
+
+	t.Run("has", func(t *testing.T) {
+		var qr struct {
+			Posts []dgraph.Post `json:"posts"`
+		}
+		require.NoError(t, str.Query(
+			ctx,
+			`{ posts(func: has(Post.id)) { uid Post.id } }`,
+			&qr,
+		))
+		require.Len(t, qr.Posts, 2)
+		require.Equal(t, post1.ID, qr.Posts[0].ID)
+		require.Equal(t, post2.ID, qr.Posts[1].ID)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		var qr struct {
+			Posts []dgraph.Post `json:"posts"`
+		}
+		require.NoError(t, str.Query(
+			ctx,
+			`{ posts(func: has(Post.id), first: 1, offset: 1) { Post.id } }`,
+			&qr,
+		))
+		require.Len(t, qr.Posts, 1)
+		require.Equal(t, post2.ID, qr.Posts[0].ID)
+	})
+
+	t.Run("uidVariables", func(t *testing.T) {
+		var qr struct {
+			Root   []dgraph.Post `json:"root"`
+			Nested []struct {
+				Posts []dgraph.Post `json:"User.posts"`
+			} `json:"nested"`
+		}
+		require.NoError(t, str.QueryVars(
+			ctx,
+			`query Posts($user: string, $post: string) {
+				rootVar as var(func: has(Post.id), after: $post)
+				var(func: uid($user)) {
+					nestedVar as User.posts (first: -1) { uid }
+				}
+				root(func: has(Post.id)) @filter(not uid(rootVar)) {
+					Post.id
+				}
+				nested(func: uid($user)) {
+					User.posts @filter(uid(nestedVar)) { Post.id }
+				}
+			}`,
+			map[string]string{"$user": usr.UID, "$post": post1.UID},
+			&qr,
+		))
+		require.Len(t, qr.Root, 1)
+		require.Equal(t, post1.ID, qr.Root[0].ID)
+		require.Len(t, qr.Nested, 1)
+		require.Len(t, qr.Nested[0].Posts, 1)
+		require.Equal(t, post2.ID, qr.Nested[0].Posts[0].ID)
+	})
+
+	t.Run("uidUnionSubject", func(t *testing.T) {
+		var qr struct {
+			Reaction []dgraph.Reaction `json:"reaction"`
+		}
+		require.NoError(t, str.QueryVars(
+			ctx,
+			`query Reaction($nodeId: string) {
+				reaction(func: uid($nodeId)) {
+					Reaction.subject {
+						uid
+						Post.id
+						Reaction.id
+					}
+				}
+			}`,
+			map[string]string{"$nodeId": reaction.UID},
+			&qr,
+		))
+		require.Len(t, qr.Reaction, 1)
+		require.Len(t, qr.Reaction[0].Subject, 1)
+		require.IsType(t, &dgraph.Post{}, qr.Reaction[0].Subject[0].V)
+		require.Equal(t, post2.ID, qr.Reaction[0].Subject[0].V.(*dgraph.Post).ID)
+	})
+
+	t.Run("reverseAndCount", func(t *testing.T) {
+		var qr struct {
+			Post []struct {
+				Author []struct {
+					UID string `json:"uid"`
+				} `json:"~User.posts"`
+				Reactions int `json:"count(Post.reactions)"`
+			} `json:"post"`
+		}
+		require.NoError(t, str.QueryVars(
+			ctx,
+			`query Post($id: string) {
+				post(func: eq(Post.id, $id)) {
+					~User.posts { uid }
+					count(Post.reactions)
+				}
+			}`,
+			map[string]string{"$id": string(post2.ID)},
+			&qr,
+		))
+		require.Len(t, qr.Post, 1)
+		require.Len(t, qr.Post[0].Author, 1)
+		require.Equal(t, usr.UID, qr.Post[0].Author[0].UID)
+		require.Equal(t, 1, qr.Post[0].Reactions)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		var qr struct {
+			Posts []dgraph.Post `json:"posts"`
+		}
+		require.NoError(t, str.QueryVars(
+			ctx,
+			`query Posts($title: string) {
+				posts(func: has(Post.id)) @filter(
+					not eq(Post.title, $title) and has(Post.reactions)
+				) { Post.id }
+			}`,
+			map[string]string{"$title": "title1"},
+			&qr,
+		))
+		require.Len(t, qr.Posts, 1)
+		require.Equal(t, post2.ID, qr.Posts[0].ID)
+	})
+
+	t.Run("fullText", func(t *testing.T) {
+		var qr struct {
+			Any []dgraph.Post `json:"any"`
+			All []dgraph.Post `json:"all"`
+		}
+		require.NoError(t, str.QueryVars(
+			ctx,
+			`query Posts($text: string) {
+				any(func: anyoftext(Post.title, $text)) { Post.id }
+				all(func: alloftext(Post.title, $text)) { Post.id }
+			}`,
+			map[string]string{"$text": "TITLE2, unknown"},
+			&qr,
+		))
+		require.Len(t, qr.Any, 1)
+		require.Equal(t, post2.ID, qr.Any[0].ID)
+		require.Len(t, qr.All, 0)
+	})
+
+	t.Run("regexp", func(t *testing.T) {
+		var qr struct {
+			Users []dgraph.User `json:"users"`
+			None  []dgraph.User `json:"none"`
+		}
+		require.NoError(t, str.Query(
+			ctx,
+			`{
+				users(func: regexp(User.displayName, /^US/i)) { User.id }
+				none(func: regexp(User.displayName, /^\/us/)) { User.id }
+			}`,
+			&qr,
+		))
+		require.Len(t, qr.Users, 1)
+		require.Equal(t, usr.ID, qr.Users[0].ID)
+		require.Len(t, qr.None, 0)
+	})
+
+	t.Run("groupBy", func(t *testing.T) {
+		var qr struct {
+			Posts []struct {
+				Reactions []dgraph.ReactionStats `json:"Post.reactions"`
+			} `json:"posts"`
+			Root []dgraph.ReactionStats `json:"root"`
+		}
+		require.NoError(t, str.QueryVars(
+			ctx,
+			`query Stats($id: string) {
+				posts(func: eq(Post.id, $id)) {
+					Post.reactions @groupby(Reaction.emotion) { count(uid) }
+				}
+				root(func: has(Reaction.id)) @groupby(Reaction.emotion) {
+					count(uid)
+				}
+			}`,
+			map[string]string{"$id": string(post2.ID)},
+			&qr,
+		))
+		expected := []dgraph.EmotionGroup{{Emotion: emotion.Happy, Count: 1}}
+		require.Len(t, qr.Posts, 1)
+		require.Len(t, qr.Posts[0].Reactions, 1)
+		require.Equal(t, expected, qr.Posts[0].Reactions[0].Groups)
+		require.Len(t, qr.Root, 1)
+		require.Equal(t, expected, qr.Root[0].Groups)
+	})
+}
+
+// TestTransactionRollback tests whether failed transactions are discarded
+func TestTransactionRollback(t *testing.T) {
+	ctx := context.Background()
+	str := newStore(t)
+
+	_, err := str.CreateUser(ctx, time.Now(), "t@t.t", "usr", "pass")
+	require.NoError(t, err)
+
+	_, err = str.CreateUser(ctx, time.Now(), "t@t.t", "other", "pass")
+	require.Error(t, err)
+	require.Equal(t, string(strerr.ErrInvalidInput), strerr.ErrorCode(err))
+
+	var qr struct {
+		Users []dgraph.User `json:"users"`
+	}
+	require.NoError(t, str.Query(
+		ctx,
+		`{ users(func: has(User.id)) { uid } }`,
+		&qr,
+	))
+	require.Len(t, qr.Users, 1)
+}
+
+// TestDeletePostRevisions tests whether the revisions of a post
+// and its reactions are deleted together with the post
+func TestDeletePostRevisions(t *testing.T) {
+	ctx := context.WithValue(
+		context.Background(),
+		auth.CtxSession,
+		&auth.RequestSession{
+			IsDebug:   true,
+			DebugMode: auth.DebugModeReadWrite,
+		},
+	)
+	str := newStore(t)
+	timeNow := time.Now()
+
+	usr, err := str.CreateUser(ctx, timeNow, "t@t.t", "usr", "pass")
+	require.NoError(t, err)
+	post, err := str.CreatePost(ctx, timeNow, usr.ID, "title", "contents")
+	require.NoError(t, err)
+	reaction, err := str.CreateReaction(
+		ctx,
+		timeNow,
+		usr.ID,
+		post.ID,
+		emotion.Happy,
+		"message",
+	)
+	require.NoError(t, err)
+
+	newTitle := "new title"
+	_, _, err = str.EditPost(ctx, post.ID, usr.ID, timeNow, &newTitle, nil)
+	require.NoError(t, err)
+	_, _, err = str.EditReaction(ctx, reaction.ID, usr.ID, timeNow, "new")
+	require.NoError(t, err)
+
+	var qr struct {
+		PostRevisions     []dgraph.PostRevision     `json:"postRevisions"`
+		ReactionRevisions []dgraph.ReactionRevision `json:"reactionRevisions"`
+	}
+	query := `{
+		postRevisions(func: has(PostRevision.id)) { uid }
+		reactionRevisions(func: has(ReactionRevision.id)) { uid }
+	}`
+	require.NoError(t, str.Query(ctx, query, &qr))
+	require.Len(t, qr.PostRevisions, 1)
+	require.Len(t, qr.ReactionRevisions, 1)
+
+	_, err = str.DeletePost(ctx, post.ID)
+	require.NoError(t, err)
+
+	qr.PostRevisions, qr.ReactionRevisions = nil, nil
+	require.NoError(t, str.Query(ctx, query, &qr))
+	require.Len(t, qr.PostRevisions, 0)
+	require.Len(t, qr.ReactionRevisions, 0)
+}
+
+// TestDeleteUserNotifications tests whether the notifications received
+// by a user are deleted together with the user
+// while the notifications caused by the user are preserved
+func TestDeleteUserNotifications(t *testing.T) {
+	ctx := context.WithValue(
+		context.Background(),
+		auth.CtxSession,
+		&auth.RequestSession{
+			IsDebug:   true,
+			DebugMode: auth.DebugModeReadWrite,
+		},
+	)
+	str := newStore(t)
+	timeNow := time.Now()
+
+	author, err := str.CreateUser(ctx, timeNow, "a@t.t", "author", "pass")
+	require.NoError(t, err)
+	reactor, err := str.CreateUser(ctx, timeNow, "r@t.t", "reactor", "pass")
+	require.NoError(t, err)
+	post, err := str.CreatePost(ctx, timeNow, author.ID, "title", "@reactor")
+	require.NoError(t, err)
+	_, err = str.CreateReaction(
+		ctx,
+		timeNow,
+		reactor.ID,
+		post.ID,
+		emotion.Happy,
+		"message",
+	)
+	require.NoError(t, err)
+
+	var qr struct {
+		Notifications []dgraph.Notification `json:"notifications"`
+	}
+	query := `{
+		notifications(func: has(Notification.id)) {
+			uid
+			Notification.kind
+		}
+	}`
+	require.NoError(t, str.Query(ctx, query, &qr))
+	require.Len(t, qr.Notifications, 2)
+
+	_, err = str.DeleteUser(ctx, author.ID)
+	require.NoError(t, err)
+
+	qr.Notifications = nil
+	require.NoError(t, str.Query(ctx, query, &qr))
+	require.Len(t, qr.Notifications, 1)
+	require.Equal(t, notification.Mention, qr.Notifications[0].Kind)
+}
+
+// TestCloseNeverAccessedSessions tests whether sessions
+// that were never accessed are closed when idle since their creation
+func TestCloseNeverAccessedSessions(t *testing.T) {
+	ctx := context.WithValue(
+		context.Background(),
+		auth.CtxSession,
+		&auth.RequestSession{
+			IsDebug:   true,
+			DebugMode: auth.DebugModeReadWrite,
+		},
+	)
+	str := newStore(t)
+	timeNow := time.Now()
+
+	usr, err := str.CreateUser(ctx, timeNow, "a@t.t", "user", "pass")
+	require.NoError(t, err)
+	_, err = str.ImportSession(
+		ctx,
+		"idle",
+		timeNow.Add(-time.Hour),
+		time.Time{},
+		usr.ID,
+	)
+	require.NoError(t, err)
+	_, err = str.ImportSession(ctx, "fresh", timeNow, time.Time{}, usr.ID)
+	require.NoError(t, err)
+
+	closed, err := str.CloseExpiredSessions(
+		ctx,
+		timeNow.Add(-24*time.Hour),
+		timeNow.Add(-time.Minute),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"idle"}, closed)
+}
+
+// TestDeleteExpiredTokens tests whether only the expired tokens
+// of the given kind are deleted
+func TestDeleteExpiredTokens(t *testing.T) {
+	ctx := context.WithValue(
+		context.Background(),
+		auth.CtxSession,
+		&auth.RequestSession{
+			IsDebug:   true,
+			DebugMode: auth.DebugModeReadWrite,
+		},
+	)
+	str := newStore(t)
+	timeNow := time.Now()
+
+	_, err := str.CreateUser(ctx, timeNow, "a@t.t", "user", "pass")
+	require.NoError(t, err)
+	_, err = str.CreateToken(
+		ctx,
+		token.PasswordReset,
+		"expired",
+		timeNow.Add(-time.Hour),
+		"a@t.t",
+	)
+	require.NoError(t, err)
+	_, err = str.CreateToken(
+		ctx,
+		token.EmailVerification,
+		"other kind",
+		timeNow.Add(-time.Hour),
+		"a@t.t",
+	)
+	require.NoError(t, err)
+
+	deleted, err := str.DeleteExpiredTokens(
+		ctx,
+		token.PasswordReset,
+		timeNow.Add(-time.Minute),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+
+	var qr struct {
+		Tokens []struct {
+			Key string `json:"Token.key"`
+		} `json:"tokens"`
+		Users []struct {
+			Tokens []struct {
+				UID string `json:"uid"`
+			} `json:"User.tokens"`
+		} `json:"users"`
+	}
+	require.NoError(t, str.Query(ctx, `{
+		tokens(func: has(Token.key)) {
+			Token.key
+		}
+		users(func: has(User.id)) {
+			User.tokens {
+				uid
+			}
+		}
+	}`, &qr))
+	require.Len(t, qr.Tokens, 1)
+	require.Equal(t, "other kind", qr.Tokens[0].Key)
+	require.Len(t, qr.Users, 1)
+	require.Len(t, qr.Users[0].Tokens, 1)
+}
+
+// TestDeleteReportedContent tests whether the reports of deleted posts
+// and reactions are deleted while hiding closes the open reports
+func TestDeleteReportedContent(t *testing.T) {
+	ctx := context.WithValue(
+		context.Background(),
+		auth.CtxSession,
+		&auth.RequestSession{
+			IsDebug:   true,
+			DebugMode: auth.DebugModeReadWrite,
+		},
+	)
+	str := newStore(t)
+	timeNow := time.Now()
+
+	author, err := str.CreateUser(ctx, timeNow, "a@t.t", "author", "pass")
+	require.NoError(t, err)
+	reporter, err := str.CreateUser(ctx, timeNow, "r@t.t", "reporter", "pass")
+	require.NoError(t, err)
+	post, err := str.CreatePost(ctx, timeNow, author.ID, "title", "contents")
+	require.NoError(t, err)
+	reaction, err := str.CreateReaction(
+		ctx,
+		timeNow,
+		author.ID,
+		post.ID,
+		emotion.Angry,
+		"message",
+	)
+	require.NoError(t, err)
+
+	_, err = str.ReportContent(ctx, timeNow, reporter.ID, post.ID, "spam")
+	require.NoError(t, err)
+	_, err = str.ReportContent(ctx, timeNow, reporter.ID, reaction.ID, "rude")
+	require.NoError(t, err)
+
+	var qr struct {
+		Reports []dgraph.Report `json:"reports"`
+	}
+	query := `{
+		reports(func: has(Report.id)) {
+			uid
+			Report.reason
+			Report.open
+		}
+	}`
+	require.NoError(t, str.Query(ctx, query, &qr))
+	require.Len(t, qr.Reports, 2)
+
+	// Hiding the reaction closes its report
+	_, err = str.HideContent(ctx, reaction.ID)
+	require.NoError(t, err)
+	qr.Reports = nil
+	require.NoError(t, str.Query(ctx, query, &qr))
+	require.Len(t, qr.Reports, 2)
+	require.True(t, qr.Reports[0].Open)
+	require.False(t, qr.Reports[1].Open)
+
+	_, err = str.DeletePost(ctx, post.ID)
+	require.NoError(t, err)
+	qr.Reports = nil
+	require.NoError(t, str.Query(ctx, query, &qr))
+	require.Len(t, qr.Reports, 0)
+}
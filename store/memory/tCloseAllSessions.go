@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CloseAllSessions closes all sessions of the given user
+func (str *impl) CloseAllSessions(
+	ctx context.Context,
+	user store.ID,
+) (
+	result []string,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Find the user
+	usr := txn.findOne("User.id", string(user))
+	if usr == nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"user not found",
+		)
+		return
+	}
+
+	result = txn.closeSessions(txn.mutate(usr.uid))
+	return
+}
+
+// closeSessions deletes all sessions of the user
+// including the "User.sessions" references
+// returning the keys of the closed sessions
+func (txn *txn) closeSessions(usr *node) []string {
+	sessions := usr.edges["User.sessions"]
+	keys := make([]string, 0, len(sessions))
+	for _, uid := range sessions {
+		if sess := txn.node(uid); sess != nil {
+			keys = append(keys, sess.str("Session.key"))
+			txn.delete(uid)
+		}
+	}
+	delete(usr.edges, "User.sessions")
+	return keys
+}
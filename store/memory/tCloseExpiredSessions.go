@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"time"
+)
+
+// CloseExpiredSessions closes all sessions created before createdBefore
+// or last accessed before accessedBefore.
+// Sessions that were never accessed are idle since their creation
+func (str *impl) CloseExpiredSessions(
+	ctx context.Context,
+	createdBefore time.Time,
+	accessedBefore time.Time,
+) (
+	result []string,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	for _, sess := range txn.all() {
+		if !sess.has("Session.key") {
+			continue
+		}
+		creation := sess.time("Session.creation")
+		lastAccess := sess.time("Session.lastAccess")
+		if lastAccess.Before(creation) {
+			lastAccess = creation
+		}
+		if !creation.Before(createdBefore) &&
+			!lastAccess.Before(accessedBefore) {
+			continue
+		}
+		result = append(result, sess.str("Session.key"))
+
+		// Delete the "User.sessions" reference and the actual Session node
+		if owner := sess.edge("Session.user"); owner != "" {
+			txn.mutate(owner).unlink("User.sessions", sess.uid)
+		}
+		txn.delete(sess.uid)
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CloseSession closes the given session
+func (str *impl) CloseSession(
+	ctx context.Context,
+	key string,
+) (
+	result bool,
+	err error,
+) {
+	result = true
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Find the session and its owner
+	sess := txn.findOne("Session.key", key)
+	if sess == nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"session not found",
+		)
+		return
+	}
+	owner := txn.node(sess.edge("Session.user"))
+
+	// Authorize client
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(owner.str("User.id")),
+	}); err != nil {
+		return
+	}
+
+	// Delete the "User.sessions" reference and the actual Session node
+	txn.mutate(owner.uid).unlink("User.sessions", sess.uid)
+	txn.delete(sess.uid)
+	return
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CreatePost creates a new post notifying all mentioned users
+func (str *impl) CreatePost(
+	ctx context.Context,
+	creationTime time.Time,
+	authorID store.ID,
+	title string,
+	contents string,
+) (
+	result store.Post,
+	err error,
+) {
+	return str.createPost(
+		ctx,
+		store.NewID(),
+		creationTime,
+		authorID,
+		title,
+		contents,
+		false,
+		true,
+	)
+}
+
+// ImportPost creates a post preserving the given identifier
+// and moderation state without notifying anyone
+func (str *impl) ImportPost(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	authorID store.ID,
+	title string,
+	contents string,
+	hidden bool,
+) (
+	result store.Post,
+	err error,
+) {
+	return str.createPost(
+		ctx,
+		id,
+		creationTime,
+		authorID,
+		title,
+		contents,
+		hidden,
+		false,
+	)
+}
+
+func (str *impl) createPost(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	authorID store.ID,
+	title string,
+	contents string,
+	hidden bool,
+	sendNotifications bool,
+) (
+	result store.Post,
+	err error,
+) {
+	result.ID = id
+	result.Title = title
+	result.Contents = contents
+	result.Creation = creationTime
+	result.Hidden = hidden
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure author exists
+	if txn.findOne("Post.id", string(result.ID)) != nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"duplicate Post.id: %s",
+			result.ID,
+		)
+		return
+	}
+	author := txn.findOne("User.id", string(authorID))
+	if author == nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"author not found",
+		)
+		return
+	}
+
+	result.Author = &store.User{
+		GraphNode: store.GraphNode{
+			UID: author.uid,
+		},
+	}
+
+	// Create new post
+	post := txn.create()
+	post.values["Post.id"] = string(result.ID)
+	post.values["Post.title"] = title
+	post.values["Post.contents"] = contents
+	post.values["Post.creation"] = creationTime
+	post.link("Post.author", author.uid)
+	if hidden {
+		post.values["Post.hidden"] = true
+	}
+	result.UID = post.uid
+
+	// Update author (User.posts -> new post)
+	txn.mutate(author.uid).link("User.posts", post.uid)
+
+	// Notify mentioned users
+	if sendNotifications {
+		txn.notify(creationTime, author.uid, post.uid, "", contents)
+	}
+
+	return
+}
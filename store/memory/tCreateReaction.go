@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	emo "github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CreateReaction creates a new reaction notifying the author
+// of the subject and all mentioned users
+func (str *impl) CreateReaction(
+	ctx context.Context,
+	creationTime time.Time,
+	authorID store.ID,
+	subjectID store.ID,
+	emotion emo.Emotion,
+	message string,
+) (
+	result store.Reaction,
+	err error,
+) {
+	return str.createReaction(
+		ctx,
+		store.NewID(),
+		creationTime,
+		authorID,
+		subjectID,
+		emotion,
+		message,
+		false,
+		true,
+	)
+}
+
+// ImportReaction creates a reaction preserving the given identifier
+// and moderation state without notifying anyone
+func (str *impl) ImportReaction(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	authorID store.ID,
+	subjectID store.ID,
+	emotion emo.Emotion,
+	message string,
+	hidden bool,
+) (
+	result store.Reaction,
+	err error,
+) {
+	return str.createReaction(
+		ctx,
+		id,
+		creationTime,
+		authorID,
+		subjectID,
+		emotion,
+		message,
+		hidden,
+		false,
+	)
+}
+
+func (str *impl) createReaction(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	authorID store.ID,
+	subjectID store.ID,
+	emotion emo.Emotion,
+	message string,
+	hidden bool,
+	sendNotifications bool,
+) (
+	result store.Reaction,
+	err error,
+) {
+	result.ID = id
+	result.Creation = creationTime
+	result.Hidden = hidden
+	result.Emotion = emotion
+	result.Message = message
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure author and subject exist
+	if txn.findOne("Reaction.id", string(result.ID)) != nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"duplicate Reaction.id: %s",
+			result.ID,
+		)
+		return
+	}
+	author := txn.findOne("User.id", string(authorID))
+	if author == nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"author not found",
+		)
+		return
+	}
+
+	// subjectEdge is the edge of the subject referencing its reactions
+	// and authorEdge is the edge of the subject referencing its author
+	var subjectEdge, authorEdge string
+	var subject *node
+	if subject = txn.findOne("Post.id", string(subjectID)); subject != nil {
+		subjectEdge = "Post.reactions"
+		authorEdge = "Post.author"
+		result.Subject = store.Post{
+			GraphNode: store.GraphNode{
+				UID: subject.uid,
+			},
+		}
+	} else if subject = txn.findOne(
+		"Reaction.id",
+		string(subjectID),
+	); subject != nil {
+		subjectEdge = "Reaction.reactions"
+		authorEdge = "Reaction.author"
+		result.Subject = store.Reaction{
+			GraphNode: store.GraphNode{
+				UID: subject.uid,
+			},
+		}
+	} else {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"subject not found",
+		)
+		return
+	}
+
+	result.Author = &store.User{
+		GraphNode: store.GraphNode{
+			UID: author.uid,
+		},
+	}
+
+	// Create new reaction
+	reaction := txn.create()
+	reaction.values["Reaction.id"] = string(result.ID)
+	reaction.values["Reaction.emotion"] = string(emotion)
+	reaction.values["Reaction.message"] = message
+	reaction.values["Reaction.creation"] = creationTime
+	reaction.link("Reaction.author", author.uid)
+	reaction.link("Reaction.subject", subject.uid)
+	if hidden {
+		reaction.values["Reaction.hidden"] = true
+	}
+	result.UID = reaction.uid
+
+	// Update author (User.publishedReactions -> new reaction)
+	txn.mutate(author.uid).link("User.publishedReactions", reaction.uid)
+
+	// Update subject
+	txn.mutate(subject.uid).link(subjectEdge, reaction.uid)
+
+	// Notify the author of the subject and mentioned users
+	if sendNotifications {
+		txn.notify(
+			creationTime,
+			author.uid,
+			reaction.uid,
+			subject.edge(authorEdge),
+			message,
+		)
+	}
+
+	return
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CreateSession creates a new session
+func (str *impl) CreateSession(
+	ctx context.Context,
+	key string,
+	creation time.Time,
+	email string,
+	password string,
+) (
+	result store.Session,
+	err error,
+) {
+	result.Key = key
+	result.Creation = creation
+	result.LastAccess = creation
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure the user exists and the password is correct
+	usr := txn.findOne("User.email", email)
+	if usr == nil || !str.comparePassword(
+		password,
+		usr.str("User.password"),
+	) {
+		err = strerr.New(strerr.ErrWrongCreds, "wrong credentials")
+		return
+	}
+
+	result.User = &store.User{
+		GraphNode: store.GraphNode{
+			UID: usr.uid,
+		},
+		ID:       store.ID(usr.str("User.id")),
+		Password: usr.str("User.password"),
+		Roles:    userRoles(usr),
+	}
+
+	// Create new session
+	sess := txn.create()
+	sess.values["Session.key"] = key
+	sess.values["Session.creation"] = creation
+	sess.values["Session.lastAccess"] = creation
+	sess.link("Session.user", usr.uid)
+	result.UID = sess.uid
+
+	// Update owner (User.sessions -> new session)
+	txn.mutate(usr.uid).link("User.sessions", sess.uid)
+
+	return
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CreateToken issues a new token to the user identified by the email
+// replacing the tokens of the same kind issued to the user before
+func (str *impl) CreateToken(
+	ctx context.Context,
+	kind token.Kind,
+	key string,
+	creationTime time.Time,
+	email string,
+) (
+	result store.Token,
+	err error,
+) {
+	if err = token.Validate(kind); err != nil {
+		err = strerr.Wrap(strerr.ErrInvalidInput, err)
+		return
+	}
+
+	result.Key = key
+	result.Kind = kind
+	result.Creation = creationTime
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	usr := txn.findOne("User.email", email)
+	if usr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "user not found")
+		return
+	}
+	owner := userResult(usr)
+	result.User = &owner
+
+	// Delete the replaced tokens
+	txn.deleteTokens(usr, kind)
+
+	// Create new token
+	tok := txn.create()
+	tok.values["Token.key"] = key
+	tok.values["Token.kind"] = string(kind)
+	tok.values["Token.creation"] = creationTime
+	tok.link("Token.user", usr.uid)
+	result.UID = tok.uid
+
+	// Update owner (User.tokens -> new token)
+	txn.mutate(usr.uid).link("User.tokens", tok.uid)
+
+	return
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// CreateUser creates a new user account
+func (str *impl) CreateUser(
+	ctx context.Context,
+	creationTime time.Time,
+	email string,
+	displayName string,
+	passwordHash string,
+) (
+	result store.User,
+	err error,
+) {
+	return str.createUser(
+		ctx,
+		store.NewID(),
+		creationTime,
+		email,
+		displayName,
+		passwordHash,
+		[]role.Role{role.User},
+		false,
+	)
+}
+
+// ImportUser creates a user preserving the given identifier
+func (str *impl) ImportUser(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	email string,
+	displayName string,
+	passwordHash string,
+	roles []role.Role,
+	emailVerified bool,
+) (
+	result store.User,
+	err error,
+) {
+	for _, r := range roles {
+		if err = role.Validate(r); err != nil {
+			err = strerr.Wrap(strerr.ErrInvalidInput, err)
+			return
+		}
+	}
+	return str.createUser(
+		ctx,
+		id,
+		creationTime,
+		email,
+		displayName,
+		passwordHash,
+		role.Normalize(roles),
+		emailVerified,
+	)
+}
+
+func (str *impl) createUser(
+	ctx context.Context,
+	id store.ID,
+	creationTime time.Time,
+	email string,
+	displayName string,
+	passwordHash string,
+	roles []role.Role,
+	emailVerified bool,
+) (
+	result store.User,
+	err error,
+) {
+	result.ID = id
+	result.Creation = creationTime
+	result.Email = email
+	result.DisplayName = displayName
+	result.Password = passwordHash
+	result.Roles = roles
+	result.EmailVerified = emailVerified
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure no users with a similar email already exist
+	if txn.findOne("User.id", string(result.ID)) != nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"duplicate User.id: %s",
+			result.ID,
+		)
+		return
+	}
+	if byEmail := txn.find("User.email", email); len(byEmail) > 0 {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"%d users with a similar email already exist",
+			len(byEmail),
+		)
+		return
+	}
+	if byName := txn.find("User.displayName", displayName); len(byName) > 0 {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"%d users with a similar displayName already exist",
+			len(byName),
+		)
+		return
+	}
+
+	// Create user account
+	usr := txn.create()
+	usr.values["User.id"] = string(result.ID)
+	usr.values["User.email"] = email
+	usr.values["User.displayName"] = displayName
+	usr.values["User.creation"] = creationTime
+	usr.values["User.password"] = passwordHash
+	usr.values["User.roles"] = roles
+	if emailVerified {
+		usr.values["User.emailVerified"] = true
+	}
+	result.UID = usr.uid
+
+	return
+}
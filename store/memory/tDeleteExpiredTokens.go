@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// DeleteExpiredTokens deletes all tokens of the given kind
+// created before createdBefore and returns the number of deleted tokens
+func (str *impl) DeleteExpiredTokens(
+	ctx context.Context,
+	kind token.Kind,
+	createdBefore time.Time,
+) (
+	result int,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	for _, tok := range txn.all() {
+		if !tok.has("Token.key") ||
+			token.Kind(tok.str("Token.kind")) != kind ||
+			!tok.time("Token.creation").Before(createdBefore) {
+			continue
+		}
+		result++
+
+		// Delete the "User.tokens" reference and the actual Token node
+		if owner := tok.edge("Token.user"); owner != "" {
+			txn.mutate(owner).unlink("User.tokens", tok.uid)
+		}
+		txn.delete(tok.uid)
+	}
+	return
+}
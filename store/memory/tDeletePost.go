@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DeletePost deletes a post including all of its reactions
+func (str *impl) DeletePost(
+	ctx context.Context,
+	post store.ID,
+) (
+	result store.Post,
+	err error,
+) {
+	result.ID = post
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Find the post and its author
+	pst := txn.findOne("Post.id", string(post))
+	if pst == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "post not found")
+		return
+	}
+	author := txn.node(pst.edge("Post.author"))
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(author.str("User.id")),
+	}); err != nil {
+		return
+	}
+
+	result.UID = pst.uid
+	result.Author = &store.User{
+		GraphNode: store.GraphNode{
+			UID: author.uid,
+		},
+		ID: store.ID(author.str("User.id")),
+	}
+
+	txn.deletePost(pst)
+	return
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DeleteReaction deletes a reaction including all of its nested reactions
+func (str *impl) DeleteReaction(
+	ctx context.Context,
+	reaction store.ID,
+) (
+	result store.Reaction,
+	err error,
+) {
+	result.ID = reaction
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Find the reaction and its author
+	rct := txn.findOne("Reaction.id", string(reaction))
+	if rct == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "reaction not found")
+		return
+	}
+	author := txn.node(rct.edge("Reaction.author"))
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(author.str("User.id")),
+	}); err != nil {
+		return
+	}
+
+	result.UID = rct.uid
+	result.Author = &store.User{
+		GraphNode: store.GraphNode{
+			UID: author.uid,
+		},
+		ID: store.ID(author.str("User.id")),
+	}
+
+	txn.unlinkSubject(rct)
+	txn.deleteReactions([]string{rct.uid})
+	return
+}
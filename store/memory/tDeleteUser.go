@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DeleteUser deletes a user including all of its sessions, posts,
+// published reactions, follow relations, received notifications
+// and issued tokens
+func (str *impl) DeleteUser(
+	ctx context.Context,
+	user store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	result.ID = user
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: user,
+	}); err != nil {
+		return
+	}
+
+	// Find the user
+	usr := txn.findOne("User.id", string(user))
+	if usr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "user not found")
+		return
+	}
+	result.UID = usr.uid
+
+	// Delete all sessions
+	for _, uid := range usr.edges["User.sessions"] {
+		txn.delete(uid)
+	}
+
+	// Delete all posts
+	for _, uid := range usr.edges["User.posts"] {
+		if post := txn.node(uid); post != nil {
+			txn.deletePost(post)
+		}
+	}
+
+	// Delete all published reactions
+	published := txn.node(usr.uid).edges["User.publishedReactions"]
+	for _, uid := range published {
+		if reaction := txn.node(uid); reaction != nil {
+			txn.unlinkSubject(reaction)
+		}
+	}
+	txn.deleteReactions(published)
+
+	// Delete the "User.following" references of the followers
+	for _, follower := range txn.all() {
+		for _, uid := range follower.edges["User.following"] {
+			if uid == usr.uid {
+				txn.mutate(follower.uid).unlink("User.following", usr.uid)
+				break
+			}
+		}
+	}
+
+	// Delete all received notifications
+	for _, uid := range usr.edges["User.notifications"] {
+		txn.delete(uid)
+	}
+
+	// Delete all issued tokens
+	for _, uid := range usr.edges["User.tokens"] {
+		txn.delete(uid)
+	}
+
+	// Delete the actual User node
+	txn.delete(usr.uid)
+	return
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// DismissReport closes an open report leaving its subject visible
+func (str *impl) DismissReport(
+	ctx context.Context,
+	report store.ID,
+) (
+	result store.Report,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.HasRole{
+		Role: role.Moderator,
+	}); err != nil {
+		return
+	}
+
+	rep := txn.findOne("Report.id", string(report))
+	if rep == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "report not found")
+		return
+	}
+	if !rep.has("Report.open") {
+		err = strerr.New(strerr.ErrInvalidInput, "report already closed")
+		return
+	}
+
+	txn.closeReports([]*node{rep})
+
+	result = txn.reportResult(txn.node(rep.uid))
+	return
+}
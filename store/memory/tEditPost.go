@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// EditPost edits an existing post
+func (str *impl) EditPost(
+	ctx context.Context,
+	post store.ID,
+	editor store.ID,
+	editTime time.Time,
+	newTitle *string,
+	newContents *string,
+) (
+	result store.Post,
+	changes struct {
+		Title    bool
+		Contents bool
+	},
+	err error,
+) {
+	result.ID = post
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure post and editor exist
+	pst := txn.findOne("Post.id", string(post))
+	if pst == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "post not found")
+		return
+	}
+	edt := txn.findOne("User.id", string(editor))
+	if edt == nil {
+		err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
+		return
+	}
+	author := txn.node(pst.edge("Post.author"))
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(author.str("User.id")),
+	}); err != nil {
+		return
+	}
+
+	result.Title = pst.str("Post.title")
+	result.Contents = pst.str("Post.contents")
+	if newTitle != nil && *newTitle != result.Title {
+		result.Title = *newTitle
+		changes.Title = true
+	}
+	if newContents != nil && *newContents != result.Contents {
+		result.Contents = *newContents
+		changes.Contents = true
+	}
+
+	// Edit the post recording the previous values as a new revision
+	if changes.Title || changes.Contents {
+		pst = txn.mutate(pst.uid)
+		txn.createPostRevision(pst, edt.uid, editTime)
+		pst.values["Post.title"] = result.Title
+		pst.values["Post.contents"] = result.Contents
+	}
+
+	result.UID = pst.uid
+	result.Creation = pst.time("Post.creation")
+	result.LastEdited = pst.time("Post.lastEdited")
+	result.Author = &store.User{
+		GraphNode: store.GraphNode{
+			UID: author.uid,
+		},
+	}
+
+	return
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	emo "github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// EditReaction edits an existing reaction
+func (str *impl) EditReaction(
+	ctx context.Context,
+	reaction store.ID,
+	editor store.ID,
+	editTime time.Time,
+	newMessage string,
+) (
+	result store.Reaction,
+	changes struct {
+		Message bool
+	},
+	err error,
+) {
+	result.ID = reaction
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure reaction and editor exist
+	react := txn.findOne("Reaction.id", string(reaction))
+	if react == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "reaction not found")
+		return
+	}
+	edt := txn.findOne("User.id", string(editor))
+	if edt == nil {
+		err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
+		return
+	}
+	author := txn.node(react.edge("Reaction.author"))
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(author.str("User.id")),
+	}); err != nil {
+		return
+	}
+
+	result.Message = newMessage
+	if react.str("Reaction.message") != newMessage {
+		changes.Message = true
+	}
+
+	result.UID = react.uid
+	result.Creation = react.time("Reaction.creation")
+	result.Author = &store.User{
+		GraphNode: store.GraphNode{
+			UID: author.uid,
+		},
+	}
+	result.Emotion = emo.Emotion(react.str("Reaction.emotion"))
+
+	subject := txn.node(react.edge("Reaction.subject"))
+	if subject.has("Post.id") {
+		result.Subject = store.Post{
+			GraphNode: store.GraphNode{
+				UID: subject.uid,
+			},
+		}
+	} else {
+		result.Subject = store.Reaction{
+			GraphNode: store.GraphNode{
+				UID: subject.uid,
+			},
+		}
+	}
+
+	// Edit the reaction recording the previous message as a new revision
+	if changes.Message {
+		react = txn.mutate(react.uid)
+		txn.createReactionRevision(react, edt.uid, editTime)
+		react.values["Reaction.message"] = newMessage
+	}
+
+	return
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// EditUser edits an existing user profile
+func (str *impl) EditUser(
+	ctx context.Context,
+	user store.ID,
+	editor store.ID,
+	newEmail *string,
+	newPassword *string,
+) (
+	result store.User,
+	changes struct {
+		Email    bool
+		Password bool
+	},
+	err error,
+) {
+	result.ID = user
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure user and editor exist
+	usr := txn.findOne("User.id", string(user))
+	if usr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "user profile not found")
+		return
+	}
+	if txn.findOne("User.id", string(editor)) == nil {
+		err = strerr.Newf(strerr.ErrInvalidInput, "editor not found")
+		return
+	}
+
+	result.Email = usr.str("User.email")
+	result.Password = usr.str("User.password")
+
+	// Ensure no users with a similar email already exist
+	if newEmail != nil && *newEmail != result.Email {
+		if byEmail := txn.find("User.email", *newEmail); len(byEmail) > 0 {
+			err = strerr.Newf(
+				strerr.ErrInvalidInput,
+				"%d users with a similar email already exist",
+				len(byEmail),
+			)
+			return
+		}
+	}
+
+	usr = txn.mutate(usr.uid)
+	if newEmail != nil && *newEmail != result.Email {
+		result.Email = *newEmail
+		changes.Email = true
+		usr.values["User.email"] = *newEmail
+
+		// Reset the verification of the replaced email
+		// and delete the tokens issued for its verification
+		delete(usr.values, "User.emailVerified")
+		txn.deleteTokens(usr, token.EmailVerification)
+	}
+	if newPassword != nil && *newPassword != result.Password {
+		result.Password = *newPassword
+		changes.Password = true
+		usr.values["User.password"] = *newPassword
+	}
+
+	result.UID = usr.uid
+	result.Creation = usr.time("User.creation")
+	result.DisplayName = usr.str("User.displayName")
+	result.EmailVerified = usr.has("User.emailVerified")
+
+	return
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// FollowUser makes the follower follow the followee
+func (str *impl) FollowUser(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: follower,
+	}); err != nil {
+		return
+	}
+
+	return str.followUser(ctx, follower, followee)
+}
+
+// ImportFollow makes the follower follow the followee.
+// Following relations carry no data to be preserved
+func (str *impl) ImportFollow(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	return str.followUser(ctx, follower, followee)
+}
+
+func (str *impl) followUser(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	fwr, fwe, following, err := txn.findFollowRelation(ctx, follower, followee)
+	if err != nil {
+		return
+	}
+	if following {
+		err = strerr.New(strerr.ErrInvalidInput, "already following")
+		return
+	}
+
+	// Create the "User.following" edge
+	txn.mutate(fwr.uid).link("User.following", fwe.uid)
+
+	result = userResult(fwe)
+	return
+}
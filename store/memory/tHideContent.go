@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// HideContent hides the given post or reaction from public lists
+// and closes all of its open reports
+func (str *impl) HideContent(
+	ctx context.Context,
+	subject store.ID,
+) (
+	result store.AGraphNode,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.HasRole{
+		Role: role.Moderator,
+	}); err != nil {
+		return
+	}
+
+	sub, hiddenPredicate, err := txn.findModerationSubject(subject)
+	if err != nil {
+		return
+	}
+	if sub.has(hiddenPredicate) {
+		err = strerr.New(strerr.ErrInvalidInput, "already hidden")
+		return
+	}
+
+	sub = txn.mutate(sub.uid)
+	sub.values[hiddenPredicate] = true
+	txn.closeReports(txn.openReports(sub.uid))
+
+	result = moderationResult(sub)
+	return
+}
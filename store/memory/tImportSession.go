@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// ImportSession creates a session of the given user
+// preserving its key and access time
+func (str *impl) ImportSession(
+	ctx context.Context,
+	key string,
+	creation time.Time,
+	lastAccess time.Time,
+	userID store.ID,
+) (
+	result store.Session,
+	err error,
+) {
+	result.Key = key
+	result.Creation = creation
+	result.LastAccess = lastAccess
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure the key is unique and the user exists
+	if txn.findOne("Session.key", key) != nil {
+		err = strerr.New(strerr.ErrInvalidInput, "duplicate Session.key")
+		return
+	}
+	usr := txn.findOne("User.id", string(userID))
+	if usr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "user not found")
+		return
+	}
+
+	result.User = &store.User{
+		GraphNode: store.GraphNode{
+			UID: usr.uid,
+		},
+		ID: userID,
+	}
+
+	// Create new session
+	sess := txn.create()
+	sess.values["Session.key"] = key
+	sess.values["Session.creation"] = creation
+	if !lastAccess.IsZero() {
+		// Sessions exported before the last access was recorded
+		// are treated as never accessed
+		sess.values["Session.lastAccess"] = lastAccess
+	}
+	sess.link("Session.user", usr.uid)
+	result.UID = sess.uid
+
+	// Update owner (User.sessions -> new session)
+	txn.mutate(usr.uid).link("User.sessions", sess.uid)
+
+	return
+}
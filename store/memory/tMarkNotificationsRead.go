@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// MarkNotificationsRead marks the given notifications of the user as read,
+// all unread notifications are marked if notifications is nil.
+// Returns the notifications that weren't read before
+func (str *impl) MarkNotificationsRead(
+	ctx context.Context,
+	user store.ID,
+	notifications []store.ID,
+) (
+	result []store.Notification,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: user,
+	}); err != nil {
+		return
+	}
+
+	usr := txn.findOne("User.id", string(user))
+	if usr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "user not found")
+		return
+	}
+
+	// Select either all unread notifications
+	// or the given notifications of the user
+	selected := usr.edges["User.unreadNotifications"]
+	if notifications != nil {
+		selected = nil
+		for _, id := range notifications {
+			notif := txn.findOne("Notification.id", string(id))
+			if notif == nil ||
+				notif.edge("Notification.recipient") != usr.uid {
+				err = strerr.Newf(
+					strerr.ErrInvalidInput,
+					"notification %s not found",
+					id,
+				)
+				return
+			}
+			selected = append(selected, notif.uid)
+		}
+	}
+
+	for _, uid := range selected {
+		notif := txn.node(uid)
+		if notif == nil {
+			continue
+		}
+		if read, _ := notif.values["Notification.read"].(bool); read {
+			continue
+		}
+		notif = txn.mutate(uid)
+		notif.values["Notification.read"] = true
+		txn.mutate(usr.uid).unlink("User.unreadNotifications", uid)
+
+		result = append(result, store.Notification{
+			GraphNode: store.GraphNode{
+				UID: uid,
+			},
+			ID:       store.ID(notif.str("Notification.id")),
+			Creation: notif.time("Notification.creation"),
+			Kind:     notification.Kind(notif.str("Notification.kind")),
+			Read:     true,
+			Recipient: &store.User{
+				GraphNode: store.GraphNode{
+					UID: usr.uid,
+				},
+				ID: user,
+			},
+		})
+	}
+	return
+}
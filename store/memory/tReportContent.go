@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// ReportContent files a report asking the moderators
+// to review the given post or reaction
+func (str *impl) ReportContent(
+	ctx context.Context,
+	creationTime time.Time,
+	reporter store.ID,
+	subject store.ID,
+	reason string,
+) (
+	result store.Report,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: reporter,
+	}); err != nil {
+		return
+	}
+
+	rep := txn.findOne("User.id", string(reporter))
+	if rep == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "reporter not found")
+		return
+	}
+
+	// Find the subject and ensure it's not already reported
+	sub, _, err := txn.findModerationSubject(subject)
+	if err != nil {
+		return
+	}
+	for _, report := range txn.openReports(sub.uid) {
+		if report.edge("Report.reporter") == rep.uid {
+			err = strerr.New(strerr.ErrInvalidInput, "already reported")
+			return
+		}
+	}
+
+	// Create the report
+	report := txn.create()
+	report.values["Report.id"] = string(store.NewID())
+	report.values["Report.creation"] = creationTime
+	report.values["Report.reason"] = reason
+	report.values["Report.open"] = true
+	report.link("Report.reporter", rep.uid)
+	report.link("Report.subject", sub.uid)
+
+	result = txn.reportResult(report)
+	return
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// ResetPassword replaces the password of the user the password reset token
+// identified by key was issued to, closes all of the user's sessions
+// and deletes the token.
+// Tokens created before createdAfter are considered expired
+func (str *impl) ResetPassword(
+	ctx context.Context,
+	key string,
+	createdAfter time.Time,
+	newPasswordHash string,
+) (
+	result store.User,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	owner, err := txn.useToken(token.PasswordReset, key, createdAfter)
+	if err != nil {
+		return
+	}
+
+	usr := txn.mutate(owner.uid)
+	usr.values["User.password"] = newPasswordHash
+
+	// Close all sessions, they may have been stolen
+	txn.closeSessions(usr)
+
+	result = userResult(usr)
+	result.Password = newPasswordHash
+	return
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// RevertPost restores the title and contents of a post revision
+func (str *impl) RevertPost(
+	ctx context.Context,
+	post store.ID,
+	editor store.ID,
+	revision store.ID,
+	editTime time.Time,
+) (
+	result store.Post,
+	err error,
+) {
+	result.ID = post
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Ensure the post and the editor exist
+	pst := txn.findOne("Post.id", string(post))
+	if pst == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "post not found")
+		return
+	}
+	edt := txn.findOne("User.id", string(editor))
+	if edt == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "editor not found")
+		return
+	}
+	author := txn.node(pst.edge("Post.author"))
+
+	// Check permission before revealing whether the revision exists
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: store.ID(author.str("User.id")),
+	}); err != nil {
+		return
+	}
+
+	// Ensure the post owns the revision
+	var rev *node
+	for _, uid := range pst.edges["Post.revisions"] {
+		if n := txn.node(uid); n != nil &&
+			n.str("PostRevision.id") == string(revision) {
+			rev = n
+			break
+		}
+	}
+	if rev == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "revision not found")
+		return
+	}
+
+	result.Title = rev.str("PostRevision.title")
+	result.Contents = rev.str("PostRevision.contents")
+
+	// Restore the revision recording the current values
+	// as a new revision edited by the editor
+	if result.Title != pst.str("Post.title") ||
+		result.Contents != pst.str("Post.contents") {
+		pst = txn.mutate(pst.uid)
+		txn.createPostRevision(pst, edt.uid, editTime)
+		pst.values["Post.title"] = result.Title
+		pst.values["Post.contents"] = result.Contents
+	}
+
+	result.UID = pst.uid
+	result.Creation = pst.time("Post.creation")
+	result.LastEdited = pst.time("Post.lastEdited")
+	result.Author = &store.User{
+		GraphNode: store.GraphNode{
+			UID: author.uid,
+		},
+		ID: store.ID(author.str("User.id")),
+	}
+
+	return
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// SetUserRoles replaces the roles of the user,
+// the user role is always retained
+func (str *impl) SetUserRoles(
+	ctx context.Context,
+	user store.ID,
+	roles []role.Role,
+) (
+	result store.User,
+	err error,
+) {
+	for _, r := range roles {
+		if err = role.Validate(r); err != nil {
+			err = strerr.Wrap(strerr.ErrInvalidInput, err)
+			return
+		}
+	}
+	roles = role.Normalize(roles)
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.HasRole{
+		Role: role.Admin,
+	}); err != nil {
+		return
+	}
+
+	usr := txn.findOne("User.id", string(user))
+	if usr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "user not found")
+		return
+	}
+
+	usr = txn.mutate(usr.uid)
+	usr.values["User.roles"] = roles
+
+	result = userResult(usr)
+	return
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// TouchSession updates the last access time of the given session
+func (str *impl) TouchSession(
+	ctx context.Context,
+	key string,
+	accessTime time.Time,
+) (
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Find the session
+	sess := txn.findOne("Session.key", key)
+	if sess == nil {
+		err = strerr.Newf(
+			strerr.ErrInvalidInput,
+			"session not found",
+		)
+		return
+	}
+
+	// Update the last access time
+	txn.mutate(sess.uid).values["Session.lastAccess"] = accessTime
+	return
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// UnfollowUser makes the follower stop following the followee
+func (str *impl) UnfollowUser(
+	ctx context.Context,
+	follower store.ID,
+	followee store.ID,
+) (
+	result store.User,
+	err error,
+) {
+	// Check permission
+	if err = auth.Authorize(ctx, auth.IsOwner{
+		Owner: follower,
+	}); err != nil {
+		return
+	}
+
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	fwr, fwe, following, err := txn.findFollowRelation(ctx, follower, followee)
+	if err != nil {
+		return
+	}
+	if !following {
+		err = strerr.New(strerr.ErrInvalidInput, "not following")
+		return
+	}
+
+	// Delete the "User.following" edge
+	txn.mutate(fwr.uid).unlink("User.following", fwe.uid)
+
+	result = userResult(fwe)
+	return
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/api/graph/auth"
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// UnhideContent makes a hidden post or reaction publicly listed again
+func (str *impl) UnhideContent(
+	ctx context.Context,
+	subject store.ID,
+) (
+	result store.AGraphNode,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	// Check permission
+	if err = auth.Authorize(ctx, auth.HasRole{
+		Role: role.Moderator,
+	}); err != nil {
+		return
+	}
+
+	sub, hiddenPredicate, err := txn.findModerationSubject(subject)
+	if err != nil {
+		return
+	}
+	if !sub.has(hiddenPredicate) {
+		err = strerr.New(strerr.ErrInvalidInput, "not hidden")
+		return
+	}
+
+	sub = txn.mutate(sub.uid)
+	delete(sub.values, hiddenPredicate)
+
+	result = moderationResult(sub)
+	return
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// UpgradePasswordHash replaces the password hash of the user
+// unless it was changed since oldPasswordHash was read
+func (str *impl) UpgradePasswordHash(
+	ctx context.Context,
+	user store.ID,
+	oldPasswordHash string,
+	newPasswordHash string,
+) (
+	upgraded bool,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	usr := txn.findOne("User.id", string(user))
+	if usr == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "user not found")
+		return
+	}
+	if usr.str("User.password") != oldPasswordHash {
+		return
+	}
+
+	txn.mutate(usr.uid).values["User.password"] = newPasswordHash
+	upgraded = true
+	return
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// VerifyEmail marks the email of the user the email verification token
+// identified by key was issued to as verified and deletes the token.
+// Tokens created before createdAfter are considered expired
+func (str *impl) VerifyEmail(
+	ctx context.Context,
+	key string,
+	createdAfter time.Time,
+) (
+	result store.User,
+	err error,
+) {
+	// Begin transaction
+	txn, close := str.txn(ctx, &err)
+	if err != nil {
+		return
+	}
+	defer close()
+
+	owner, err := txn.useToken(token.EmailVerification, key, createdAfter)
+	if err != nil {
+		return
+	}
+
+	usr := txn.mutate(owner.uid)
+	usr.values["User.emailVerified"] = true
+
+	result = userResult(usr)
+	return
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// useToken finds the token of the given kind identified by key
+// and deletes it returning its owner. Returns an error if the token
+// doesn't exist or was created before createdAfter
+func (txn *txn) useToken(
+	kind token.Kind,
+	key string,
+	createdAfter time.Time,
+) (owner *node, err error) {
+	tok := txn.findOne("Token.key", key)
+	if tok == nil || token.Kind(tok.str("Token.kind")) != kind {
+		err = strerr.New(strerr.ErrInvalidInput, "invalid token")
+		return
+	}
+	if owner = txn.node(tok.edge("Token.user")); owner == nil {
+		err = strerr.New(strerr.ErrInvalidInput, "invalid token")
+		return
+	}
+	if tok.time("Token.creation").Before(createdAfter) {
+		err = strerr.New(strerr.ErrInvalidInput, "token expired")
+		return
+	}
+
+	txn.mutate(owner.uid).unlink("User.tokens", tok.uid)
+	txn.delete(tok.uid)
+	return
+}
+
+// deleteTokens deletes the tokens of the given kind issued to the user
+func (txn *txn) deleteTokens(owner *node, kind token.Kind) {
+	for _, uid := range owner.edges["User.tokens"] {
+		tok := txn.node(uid)
+		if tok == nil || token.Kind(tok.str("Token.kind")) != kind {
+			continue
+		}
+		txn.mutate(owner.uid).unlink("User.tokens", uid)
+		txn.delete(uid)
+	}
+}
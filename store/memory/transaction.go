@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	strerr "github.com/romshark/dgraph_graphql_go/store/errors"
+)
+
+// view represents a consistent read-only view of the graph
+type view interface {
+	// node returns the node identified by uid or nil if there's none
+	node(uid string) *node
+
+	// all returns all nodes ordered by uid
+	all() []*node
+}
+
+// sortNodes orders the nodes by uid ascending like Dgraph does
+func sortNodes(nodes []*node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return parseUID(nodes[i].uid) < parseUID(nodes[j].uid)
+	})
+}
+
+// find returns all nodes of the view having the given predicate value
+func find(v view, predicate string, value interface{}) []*node {
+	var result []*node
+	for _, n := range v.all() {
+		if val, ok := n.values[predicate]; ok && val == value {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// readView represents a view on the committed state.
+// The store must be read-locked while the view is in use
+type readView struct {
+	str *impl
+}
+
+func (v readView) node(uid string) *node {
+	return v.str.nodes[uid]
+}
+
+func (v readView) all() []*node {
+	nodes := make([]*node, 0, len(v.str.nodes))
+	for _, n := range v.str.nodes {
+		nodes = append(nodes, n)
+	}
+	sortNodes(nodes)
+	return nodes
+}
+
+// txn represents an exclusive read-write transaction.
+// Modified nodes are copied on write and only applied to the committed state
+// when the transaction is committed
+type txn struct {
+	str    *impl
+	writes map[string]*node
+}
+
+func (txn *txn) node(uid string) *node {
+	if n, written := txn.writes[uid]; written {
+		return n
+	}
+	return txn.str.nodes[uid]
+}
+
+func (txn *txn) all() []*node {
+	nodes := make([]*node, 0, len(txn.str.nodes)+len(txn.writes))
+	for uid, n := range txn.str.nodes {
+		if _, written := txn.writes[uid]; !written {
+			nodes = append(nodes, n)
+		}
+	}
+	for _, n := range txn.writes {
+		if n != nil {
+			nodes = append(nodes, n)
+		}
+	}
+	sortNodes(nodes)
+	return nodes
+}
+
+// find returns all nodes having the given predicate value
+func (txn *txn) find(predicate string, value interface{}) []*node {
+	return find(txn, predicate, value)
+}
+
+// findOne returns the first node having the given predicate value
+// or nil if there's none
+func (txn *txn) findOne(predicate string, value interface{}) *node {
+	if nodes := txn.find(predicate, value); len(nodes) > 0 {
+		return nodes[0]
+	}
+	return nil
+}
+
+// create creates a new node
+func (txn *txn) create() *node {
+	txn.str.lastUID++
+	n := newNode(formatUID(txn.str.lastUID))
+	txn.writes[n.uid] = n
+	return n
+}
+
+// mutate returns a writable copy of the node identified by uid
+// or nil if there's none
+func (txn *txn) mutate(uid string) *node {
+	if n, written := txn.writes[uid]; written {
+		return n
+	}
+	n, exists := txn.str.nodes[uid]
+	if !exists {
+		return nil
+	}
+	cp := n.clone()
+	txn.writes[uid] = cp
+	return cp
+}
+
+// delete deletes the node identified by uid
+func (txn *txn) delete(uid string) {
+	txn.writes[uid] = nil
+}
+
+func (txn *txn) commit() {
+	for uid, n := range txn.writes {
+		if n == nil {
+			delete(txn.str.nodes, uid)
+			continue
+		}
+		txn.str.nodes[uid] = n
+	}
+}
+
+// txn begins a new exclusive transaction. The returned closure must be
+// deferred, it commits the transaction if *terr is nil when it's called
+// and discards it otherwise
+func (str *impl) txn(ctx context.Context, terr *error) (*txn, func()) {
+	if err := ctx.Err(); err != nil {
+		*terr = strerr.New(strerr.ErrCanceled, "")
+		return nil, nil
+	}
+
+	str.lock.Lock()
+	lastUID := str.lastUID
+	txn := &txn{
+		str:    str,
+		writes: make(map[string]*node),
+	}
+	return txn, func() {
+		defer str.lock.Unlock()
+		if *terr == nil && ctx.Err() != nil {
+			*terr = strerr.New(strerr.ErrCanceled, "")
+		}
+		if *terr != nil {
+			// Rollback transaction
+			str.lastUID = lastUID
+			return
+		}
+		// Commit transaction
+		txn.commit()
+	}
+}
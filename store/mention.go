@@ -0,0 +1,27 @@
+package store
+
+import "regexp"
+
+// MaxMentions defines the maximum number of distinct users
+// mentioned in a single text, further mentions are ignored
+const MaxMentions = 32
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.-]*\w)`)
+
+// Mentions returns the distinct display names mentioned
+// in the text (such as "@alice") in order of appearance
+func Mentions(text string) []string {
+	var names []string
+	known := make(map[string]struct{})
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		if _, ok := known[match[1]]; ok {
+			continue
+		}
+		known[match[1]] = struct{}{}
+		names = append(names, match[1])
+		if len(names) >= MaxMentions {
+			break
+		}
+	}
+	return names
+}
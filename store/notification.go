@@ -0,0 +1,21 @@
+package store
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/notification"
+)
+
+// Notification represents a Notification entity
+// informing its recipient about a reaction or a mention
+type Notification struct {
+	GraphNode
+
+	ID        ID
+	Creation  time.Time
+	Kind      notification.Kind
+	Read      bool
+	Recipient *User
+	Actor     *User
+	Subject   AGraphNode
+}
@@ -6,10 +6,13 @@ import "time"
 type Post struct {
 	GraphNode
 
-	ID        ID
-	Creation  time.Time
-	Author    *User
-	Title     string
-	Contents  string
-	Reactions []Reaction
+	ID         ID
+	Creation   time.Time
+	LastEdited time.Time
+	Author     *User
+	Title      string
+	Contents   string
+	Hidden     bool
+	Reactions  []Reaction
+	Revisions  []PostRevision
 }
@@ -0,0 +1,15 @@
+package store
+
+import "time"
+
+// PostRevision represents an immutable PostRevision entity
+// holding the values of a post before it was edited
+type PostRevision struct {
+	GraphNode
+
+	ID       ID
+	Creation time.Time
+	Editor   *User
+	Title    string
+	Contents string
+}
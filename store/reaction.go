@@ -16,5 +16,7 @@ type Reaction struct {
 	Author    *User
 	Message   string
 	Emotion   emotion.Emotion
+	Hidden    bool
 	Reactions []Reaction
+	Revisions []ReactionRevision
 }
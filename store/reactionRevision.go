@@ -0,0 +1,14 @@
+package store
+
+import "time"
+
+// ReactionRevision represents an immutable ReactionRevision entity
+// holding the values of a reaction before it was edited
+type ReactionRevision struct {
+	GraphNode
+
+	ID       ID
+	Creation time.Time
+	Editor   *User
+	Message  string
+}
@@ -0,0 +1,16 @@
+package store
+
+import "time"
+
+// Report represents a Report entity filed by a user
+// asking the moderators to review a post or a reaction
+type Report struct {
+	GraphNode
+
+	ID       ID
+	Creation time.Time
+	Reporter *User
+	Subject  AGraphNode
+	Reason   string
+	Open     bool
+}
@@ -6,7 +6,8 @@ import "time"
 type Session struct {
 	GraphNode
 
-	Key      string
-	Creation time.Time
-	User     *User
+	Key        string
+	Creation   time.Time
+	LastAccess time.Time
+	User       *User
 }
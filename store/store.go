@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/romshark/dgraph_graphql_go/store/enum/emotion"
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
 )
 
 // MutableStore interfaces a transactional store
@@ -36,6 +38,72 @@ type MutableStore interface {
 		err error,
 	)
 
+	TouchSession(
+		ctx context.Context,
+		key string,
+		accessTime time.Time,
+	) (
+		err error,
+	)
+
+	CloseExpiredSessions(
+		ctx context.Context,
+		createdBefore time.Time,
+		accessedBefore time.Time,
+	) (
+		result []string,
+		err error,
+	)
+
+	CreateToken(
+		ctx context.Context,
+		kind token.Kind,
+		key string,
+		creationTime time.Time,
+		email string,
+	) (
+		result Token,
+		err error,
+	)
+
+	DeleteExpiredTokens(
+		ctx context.Context,
+		kind token.Kind,
+		createdBefore time.Time,
+	) (
+		result int,
+		err error,
+	)
+
+	ResetPassword(
+		ctx context.Context,
+		key string,
+		createdAfter time.Time,
+		newPasswordHash string,
+	) (
+		result User,
+		err error,
+	)
+
+	VerifyEmail(
+		ctx context.Context,
+		key string,
+		createdAfter time.Time,
+	) (
+		result User,
+		err error,
+	)
+
+	UpgradePasswordHash(
+		ctx context.Context,
+		user ID,
+		oldPasswordHash string,
+		newPasswordHash string,
+	) (
+		upgraded bool,
+		err error,
+	)
+
 	CreatePost(
 		ctx context.Context,
 		creationTime time.Time,
@@ -74,6 +142,7 @@ type MutableStore interface {
 		ctx context.Context,
 		post ID,
 		editor ID,
+		editTime time.Time,
 		newTitle *string,
 		newContents *string,
 	) (
@@ -104,6 +173,7 @@ type MutableStore interface {
 		ctx context.Context,
 		reaction ID,
 		editor ID,
+		editTime time.Time,
 		newMessage string,
 	) (
 		result Reaction,
@@ -112,6 +182,177 @@ type MutableStore interface {
 		},
 		err error,
 	)
+
+	RevertPost(
+		ctx context.Context,
+		post ID,
+		editor ID,
+		revision ID,
+		editTime time.Time,
+	) (
+		result Post,
+		err error,
+	)
+
+	FollowUser(
+		ctx context.Context,
+		follower ID,
+		followee ID,
+	) (
+		result User,
+		err error,
+	)
+
+	UnfollowUser(
+		ctx context.Context,
+		follower ID,
+		followee ID,
+	) (
+		result User,
+		err error,
+	)
+
+	MarkNotificationsRead(
+		ctx context.Context,
+		user ID,
+		notifications []ID,
+	) (
+		result []Notification,
+		err error,
+	)
+
+	SetUserRoles(
+		ctx context.Context,
+		user ID,
+		roles []role.Role,
+	) (
+		result User,
+		err error,
+	)
+
+	ReportContent(
+		ctx context.Context,
+		creationTime time.Time,
+		reporter ID,
+		subject ID,
+		reason string,
+	) (
+		result Report,
+		err error,
+	)
+
+	DismissReport(
+		ctx context.Context,
+		report ID,
+	) (
+		result Report,
+		err error,
+	)
+
+	HideContent(
+		ctx context.Context,
+		subject ID,
+	) (
+		result AGraphNode,
+		err error,
+	)
+
+	UnhideContent(
+		ctx context.Context,
+		subject ID,
+	) (
+		result AGraphNode,
+		err error,
+	)
+
+	DeletePost(
+		ctx context.Context,
+		post ID,
+	) (
+		result Post,
+		err error,
+	)
+
+	DeleteReaction(
+		ctx context.Context,
+		reaction ID,
+	) (
+		result Reaction,
+		err error,
+	)
+
+	DeleteUser(
+		ctx context.Context,
+		user ID,
+	) (
+		result User,
+		err error,
+	)
+}
+
+// ImportStore interfaces the creation of entities
+// preserving their identifiers, creation and access times
+type ImportStore interface {
+	ImportUser(
+		ctx context.Context,
+		id ID,
+		creationTime time.Time,
+		email string,
+		displayName string,
+		passwordHash string,
+		roles []role.Role,
+		emailVerified bool,
+	) (
+		result User,
+		err error,
+	)
+
+	ImportFollow(
+		ctx context.Context,
+		follower ID,
+		followee ID,
+	) (
+		result User,
+		err error,
+	)
+
+	ImportPost(
+		ctx context.Context,
+		id ID,
+		creationTime time.Time,
+		author ID,
+		title string,
+		contents string,
+		hidden bool,
+	) (
+		result Post,
+		err error,
+	)
+
+	ImportReaction(
+		ctx context.Context,
+		id ID,
+		creationTime time.Time,
+		author ID,
+		subject ID,
+		emotion emotion.Emotion,
+		message string,
+		hidden bool,
+	) (
+		result Reaction,
+		err error,
+	)
+
+	ImportSession(
+		ctx context.Context,
+		key string,
+		creationTime time.Time,
+		lastAccess time.Time,
+		user ID,
+	) (
+		result Session,
+		err error,
+	)
 }
 
 // Store interfaces a store implementation
@@ -119,6 +360,7 @@ type Store interface {
 	Prepare() error
 
 	MutableStore
+	ImportStore
 
 	Query(
 		ctx context.Context,
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/token"
+)
+
+// Token represents a single-use Token entity issued to a user
+type Token struct {
+	GraphNode
+
+	Key      string
+	Kind     token.Kind
+	Creation time.Time
+	User     *User
+}
@@ -2,6 +2,8 @@ package store
 
 import (
 	"time"
+
+	"github.com/romshark/dgraph_graphql_go/store/enum/role"
 )
 
 // User represents a User entity
@@ -11,9 +13,13 @@ type User struct {
 	ID                 ID
 	Creation           time.Time
 	Email              string
+	EmailVerified      bool
 	DisplayName        string
 	Password           string
+	Roles              []role.Role
 	Posts              []Post
 	Sessions           []Session
 	PublishedReactions []Reaction
+	Following          []User
+	Notifications      []Notification
 }